@@ -0,0 +1,80 @@
+package cite
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/person"
+)
+
+// bibtexSpecial escapes the characters TeX gives a meaning to.
+var bibtexSpecial = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`$`, `\$`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`^`, `\^{}`,
+	`~`, `\~{}`,
+)
+
+// bibtexRoles are the name fields of an entry, translator and illustrator
+// being those of biblatex.
+var bibtexRoles = []struct{ field, role string }{
+	{"author", models.ContributorAuthor},
+	{"editor", models.ContributorEditor},
+	{"translator", models.ContributorTranslator},
+	{"illustrator", models.ContributorIllustrator},
+}
+
+func writeBibtex(w io.Writer, books []*models.Book) error {
+	bw := bufio.NewWriter(w)
+	for i, k := range Keys(books) {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		book := books[i]
+		bw.WriteString("@book{" + k + ",\n")
+		for _, r := range bibtexRoles {
+			if names := contributors(book, r.role); len(names) > 0 {
+				list := make([]string, 0, len(names))
+				for _, name := range names {
+					list = append(list, bibtexName(name))
+				}
+				bibtexField(bw, r.field, strings.Join(list, " and "))
+			}
+		}
+		// The title is braced twice so that styles keep its case.
+		bibtexField(bw, "title", "{"+bibtexSpecial.Replace(book.Title)+"}")
+		if book.Isbn != "" {
+			bibtexField(bw, "isbn", bibtexSpecial.Replace(book.Isbn))
+		}
+		bw.WriteString("}\n")
+	}
+	return bw.Flush()
+}
+
+func bibtexField(w *bufio.Writer, name, value string) {
+	w.WriteString("  " + name + " = {" + value + "},\n")
+}
+
+// bibtexName returns a name as "Surname, Forenames". A name holding the
+// word "and", which separates the names of a field, is braced as a whole.
+func bibtexName(name string) string {
+	family, given := person.SplitName(name)
+	if given != "" {
+		name = family + ", " + given
+	}
+	name = bibtexSpecial.Replace(name)
+	for _, word := range strings.Fields(name) {
+		if strings.EqualFold(word, "and") {
+			return "{" + name + "}"
+		}
+	}
+	return name
+}
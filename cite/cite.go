@@ -0,0 +1,148 @@
+// Package cite formats the citations of books as BibTeX, RIS and CSL-JSON.
+//
+// Every citation has a key, made of the surname and the birth year of the
+// first author and the first word of the title, such as herbert1920dune. A
+// book has the same key whenever it is cited, unless other books cited with
+// it have the same key: keys are only unique within one list of citations.
+package cite
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/person"
+	"golang.org/x/text/unicode/norm"
+)
+
+// The formats of the citations.
+const (
+	FormatBibtex  = "bibtex"
+	FormatRis     = "ris"
+	FormatCslJson = "csl-json"
+)
+
+var ErrUnknownFormat = errors.New("unknown citation format")
+
+// articles are skipped for the title word of a key.
+var articles = map[string]bool{"a": true, "an": true, "the": true}
+
+// Write writes the citations of the books in format, in order.
+func Write(w io.Writer, format string, books []*models.Book) error {
+	switch format {
+	case FormatBibtex:
+		return writeBibtex(w, books)
+	case FormatRis:
+		return writeRis(w, books)
+	case FormatCslJson:
+		return writeCslJson(w, books)
+	}
+	return ErrUnknownFormat
+}
+
+// Keys returns the citation keys of the books. Books sharing a key are told
+// apart by a letter given in the order of their ids, herbert1920dunea and
+// herbert1920duneb, so that the order of the list does not change them.
+func Keys(books []*models.Book) []string {
+	keys := make([]string, len(books))
+	shared := map[string][]int{}
+	for i, b := range books {
+		keys[i] = key(b)
+		shared[keys[i]] = append(shared[keys[i]], i)
+	}
+	for k, indexes := range shared {
+		if len(indexes) < 2 {
+			continue
+		}
+		sort.SliceStable(indexes, func(i, j int) bool {
+			return bytes.Compare(books[indexes[i]].Id[:], books[indexes[j]].Id[:]) < 0
+		})
+		for n, i := range indexes {
+			keys[i] = k + suffix(n)
+		}
+	}
+	return keys
+}
+
+func key(b *models.Book) string {
+	var k strings.Builder
+	if c := firstAuthor(b); c != nil {
+		family, _ := person.SplitName(c.Author.Name)
+		k.WriteString(fold(family))
+		if !c.Author.Birthdate.IsZero() {
+			k.WriteString(strconv.Itoa(c.Author.Birthdate.Year()))
+		}
+	}
+	if k.Len() == 0 {
+		k.WriteString("anon")
+	}
+	for _, word := range strings.Fields(b.Title) {
+		if word = fold(word); word != "" && !articles[word] {
+			k.WriteString(word)
+			break
+		}
+	}
+	return k.String()
+}
+
+// suffix returns the letters telling apart the nth book sharing a key: a to
+// z, then aa, ab and so on.
+func suffix(n int) string {
+	var s string
+	for n++; n > 0; n /= 26 {
+		n--
+		s = string(rune('a'+n%26)) + s
+	}
+	return s
+}
+
+// fold returns the lower case ASCII letters and digits of s, without their
+// accents.
+func fold(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		r = unicode.ToLower(r)
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// firstAuthor returns the first author of the book, or its first contributor
+// when it has no author. Contributors without a name are left out of the
+// citations.
+func firstAuthor(b *models.Book) *models.BookContributor {
+	var first *models.BookContributor
+	for i, c := range b.Contributors {
+		if strings.TrimSpace(c.Author.Name) == "" {
+			continue
+		}
+		if c.Role == models.ContributorAuthor || c.Role == "" {
+			return &b.Contributors[i]
+		}
+		if first == nil {
+			first = &b.Contributors[i]
+		}
+	}
+	return first
+}
+
+// contributors returns the names of the contributors of the book in role.
+func contributors(b *models.Book, role string) []string {
+	var names []string
+	for _, c := range b.Contributors {
+		if strings.TrimSpace(c.Author.Name) == "" {
+			continue
+		}
+		if c.Role == role || (c.Role == "" && role == models.ContributorAuthor) {
+			names = append(names, c.Author.Name)
+		}
+	}
+	return names
+}
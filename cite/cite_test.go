@@ -0,0 +1,168 @@
+package cite_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/cite"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func contributor(role, name string, born int) models.BookContributor {
+	c := models.BookContributor{Role: role, Author: models.Author{Name: name}}
+	if born != 0 {
+		c.Author.Birthdate = time.Date(born, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return c
+}
+
+func dune() *models.Book {
+	return &models.Book{
+		Title: "Dune",
+		Isbn:  "9780441013593",
+		Contributors: []models.BookContributor{
+			contributor(models.ContributorAuthor, "Frank Herbert", 1920),
+			contributor(models.ContributorEditor, "Sterling Lanier", 0),
+			contributor(models.ContributorAuthor, "Brian Herbert", 1947),
+		},
+	}
+}
+
+func TestKeys(t *testing.T) {
+	t.Run("success - it should key the books by author, birth year and title", func(t *testing.T) {
+		books := []*models.Book{
+			dune(),
+			{Title: "L'Assommoir", Contributors: []models.BookContributor{contributor(models.ContributorAuthor, "Émile Zola", 1840)}},
+			{Title: "The Left Hand of Darkness", Contributors: []models.BookContributor{contributor("", "Ursula K. Le Guin", 1929)}},
+			{Title: "The Odyssey", Contributors: []models.BookContributor{contributor(models.ContributorTranslator, "Robert Fagles", 0)}},
+			{Title: "Beowulf", Contributors: []models.BookContributor{contributor(models.ContributorAuthor, "", 0)}},
+		}
+		assert.Equal(t, []string{"herbert1920dune", "zola1840lassommoir", "leguin1929left", "faglesodyssey", "anonbeowulf"}, cite.Keys(books))
+	})
+
+	t.Run("success - it should tell apart the books sharing a key", func(t *testing.T) {
+		books := []*models.Book{dune(), {Title: "Emma"}, dune(), dune()}
+		assert.Equal(t, []string{"herbert1920dunea", "anonemma", "herbert1920duneb", "herbert1920dunec"}, cite.Keys(books))
+
+		many := make([]*models.Book, 28)
+		for i := range many {
+			many[i] = &models.Book{Title: "Emma"}
+		}
+		keys := cite.Keys(many)
+		assert.Equal(t, "anonemmaz", keys[25])
+		assert.Equal(t, "anonemmaaa", keys[26])
+		assert.Equal(t, "anonemmaab", keys[27])
+	})
+
+	t.Run("success - it should give a book the same key alone and in a list", func(t *testing.T) {
+		book := dune()
+		book.Id = uuid.New()
+		emma := &models.Book{Id: uuid.New(), Title: "Emma"}
+
+		alone := cite.Keys([]*models.Book{book})
+		assert.Equal(t, alone, cite.Keys([]*models.Book{emma, book})[1:])
+	})
+
+	t.Run("success - it should tell apart the books sharing a key by their ids, whatever their order", func(t *testing.T) {
+		first, second := dune(), dune()
+		first.Id = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		second.Id = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+		assert.Equal(t, []string{"herbert1920dunea", "herbert1920duneb"}, cite.Keys([]*models.Book{first, second}))
+		assert.Equal(t, []string{"herbert1920duneb", "herbert1920dunea"}, cite.Keys([]*models.Book{second, first}))
+	})
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("success - it should write BibTeX entries", func(t *testing.T) {
+		books := []*models.Book{
+			dune(),
+			{
+				Title: `Profits & Losses: 100% {of} #1_things ~ $\`,
+				Contributors: []models.BookContributor{
+					contributor(models.ContributorAuthor, "Procter and Gamble", 0),
+					contributor(models.ContributorIllustrator, "Homer", 0),
+				},
+			},
+		}
+		var out bytes.Buffer
+		assert.NoError(t, cite.Write(&out, cite.FormatBibtex, books))
+
+		assert.Equal(t, "@book{herbert1920dune,\n"+
+			"  author = {Herbert, Frank and Herbert, Brian},\n"+
+			"  editor = {Lanier, Sterling},\n"+
+			"  title = {{Dune}},\n"+
+			"  isbn = {9780441013593},\n"+
+			"}\n"+
+			"\n"+
+			"@book{procterandgambleprofits,\n"+
+			"  author = {{Procter and Gamble}},\n"+
+			"  illustrator = {Homer},\n"+
+			`  title = {{Profits \& Losses: 100\% \{of\} \#1\_things \~{} \$\textbackslash{}}},`+"\n"+
+			"}\n", out.String())
+	})
+
+	t.Run("success - it should write RIS records", func(t *testing.T) {
+		book := dune()
+		book.Title = "Dune\nor the desert planet"
+		book.Contributors = append(book.Contributors, contributor(models.ContributorTranslator, "Michel Demuth", 0))
+		var out bytes.Buffer
+		assert.NoError(t, cite.Write(&out, cite.FormatRis, []*models.Book{book, {Title: "Beowulf", Contributors: []models.BookContributor{contributor(models.ContributorAuthor, " ", 0)}}}))
+
+		assert.Equal(t, []string{
+			"TY  - BOOK",
+			"ID  - herbert1920dune",
+			"AU  - Herbert, Frank",
+			"AU  - Herbert, Brian",
+			"ED  - Lanier, Sterling",
+			"A4  - Demuth, Michel",
+			"TI  - Dune or the desert planet",
+			"SN  - 9780441013593",
+			"ER  -",
+			"",
+			"TY  - BOOK",
+			"ID  - anonbeowulf",
+			"TI  - Beowulf",
+			"ER  -",
+			"",
+		}, strings.Split(strings.ReplaceAll(out.String(), " \r\n", "\r\n"), "\r\n"))
+		assert.True(t, strings.HasSuffix(out.String(), "ER  - \r\n"))
+	})
+
+	t.Run("success - it should write CSL-JSON items", func(t *testing.T) {
+		book := dune()
+		book.Contributors = append(book.Contributors, contributor(models.ContributorIllustrator, "Moebius", 0))
+		var out bytes.Buffer
+		assert.NoError(t, cite.Write(&out, cite.FormatCslJson, []*models.Book{book}))
+
+		var items []map[string]interface{}
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &items))
+		assert.Equal(t, []map[string]interface{}{{
+			"id":    "herbert1920dune",
+			"type":  "book",
+			"title": "Dune",
+			"ISBN":  "9780441013593",
+			"author": []interface{}{
+				map[string]interface{}{"family": "Herbert", "given": "Frank"},
+				map[string]interface{}{"family": "Herbert", "given": "Brian"},
+			},
+			"editor":      []interface{}{map[string]interface{}{"family": "Lanier", "given": "Sterling"}},
+			"illustrator": []interface{}{map[string]interface{}{"literal": "Moebius"}},
+		}}, items)
+	})
+
+	t.Run("success - it should write an empty CSL-JSON array", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, cite.Write(&out, cite.FormatCslJson, nil))
+		assert.Equal(t, "[]\n", out.String())
+	})
+
+	t.Run("error - it should reject an unknown format", func(t *testing.T) {
+		assert.ErrorIs(t, cite.Write(&bytes.Buffer{}, "endnote", nil), cite.ErrUnknownFormat)
+	})
+}
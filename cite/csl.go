@@ -0,0 +1,63 @@
+package cite
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/person"
+)
+
+type cslItem struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	Title       string    `json:"title"`
+	ISBN        string    `json:"ISBN,omitempty"`
+	Author      []cslName `json:"author,omitempty"`
+	Editor      []cslName `json:"editor,omitempty"`
+	Translator  []cslName `json:"translator,omitempty"`
+	Illustrator []cslName `json:"illustrator,omitempty"`
+}
+
+// cslName is a name split in its parts, or a single word kept literal, as in
+// "Homer".
+type cslName struct {
+	Family  string `json:"family,omitempty"`
+	Given   string `json:"given,omitempty"`
+	Literal string `json:"literal,omitempty"`
+}
+
+// writeCslJson writes the citations as a CSL-JSON array.
+func writeCslJson(w io.Writer, books []*models.Book) error {
+	items := make([]cslItem, 0, len(books))
+	for i, k := range Keys(books) {
+		book := books[i]
+		items = append(items, cslItem{
+			ID:          k,
+			Type:        "book",
+			Title:       book.Title,
+			ISBN:        book.Isbn,
+			Author:      cslNames(book, models.ContributorAuthor),
+			Editor:      cslNames(book, models.ContributorEditor),
+			Translator:  cslNames(book, models.ContributorTranslator),
+			Illustrator: cslNames(book, models.ContributorIllustrator),
+		})
+	}
+	e := json.NewEncoder(w)
+	e.SetEscapeHTML(false)
+	e.SetIndent("", "  ")
+	return e.Encode(items)
+}
+
+func cslNames(b *models.Book, role string) []cslName {
+	var names []cslName
+	for _, name := range contributors(b, role) {
+		family, given := person.SplitName(name)
+		if given == "" {
+			names = append(names, cslName{Literal: family})
+		} else {
+			names = append(names, cslName{Family: family, Given: given})
+		}
+	}
+	return names
+}
@@ -0,0 +1,54 @@
+package cite
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/person"
+)
+
+// risTags are the name tags of a record, the translators and illustrators
+// being subsidiary authors.
+var risTags = []struct{ tag, role string }{
+	{"AU", models.ContributorAuthor},
+	{"ED", models.ContributorEditor},
+	{"A4", models.ContributorTranslator},
+	{"A4", models.ContributorIllustrator},
+}
+
+// risLine keeps a value on its line.
+var risLine = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// writeRis writes RIS records, whose lines end with CRLF.
+func writeRis(w io.Writer, books []*models.Book) error {
+	bw := bufio.NewWriter(w)
+	for i, k := range Keys(books) {
+		if i > 0 {
+			bw.WriteString("\r\n")
+		}
+		book := books[i]
+		risField(bw, "TY", "BOOK")
+		risField(bw, "ID", k)
+		for _, t := range risTags {
+			for _, name := range contributors(book, t.role) {
+				family, given := person.SplitName(name)
+				if given != "" {
+					name = family + ", " + given
+				}
+				risField(bw, t.tag, name)
+			}
+		}
+		risField(bw, "TI", book.Title)
+		if book.Isbn != "" {
+			risField(bw, "SN", book.Isbn)
+		}
+		risField(bw, "ER", "")
+	}
+	return bw.Flush()
+}
+
+func risField(w *bufio.Writer, tag, value string) {
+	w.WriteString(tag + "  - " + strings.TrimSpace(risLine.Replace(value)) + "\r\n")
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/config"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/gorm"
+)
+
+const usage = `Usage: admin <command> [arguments]
+
+Commands:
+  keys list                     list the jwt signing keys
+  keys generate                 add a new signing key, previous keys stay valid
+  keys rotate                   add a new signing key and retire the previous ones
+  keys retire <kid>             retire a key, it is still accepted during the grace window
+  users list                    list the users and their roles
+  users set-role <user> <role>  change the role of a user (admin, librarian, member, read-only)
+  check [-repair] [-delete]     report rows referencing missing books, authors or users; -repair
+                                removes dangling contributors and credits books left without one
+                                to an "Unknown author", or deletes them with -delete
+`
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch flag.Arg(0) {
+	case "keys":
+		err = keys(flag.Args()[1:])
+	case "users":
+		err = users(flag.Args()[1:])
+	case "check":
+		err = check(flag.Args()[1:])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func keys(args []string) error {
+	if len(args) < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	path := config.GetJwtKeysFile()
+	if args[0] == "list" {
+		keys, err := config.ReadJwtKeyFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "KID\tCREATED\tRETIRED")
+		for _, k := range keys {
+			retired := "-"
+			if k.RetiredAt != nil {
+				retired = k.RetiredAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", k.Id, k.CreatedAt.Format(time.RFC3339), retired)
+		}
+		return w.Flush()
+	}
+
+	// The other commands change the file under its lock, as running
+	// servers may rotate the keys at the same time.
+	var update func(keys []*config.JwtKey) ([]*config.JwtKey, error)
+	switch args[0] {
+	case "generate":
+		update = func(keys []*config.JwtKey) ([]*config.JwtKey, error) {
+			key, err := config.NewJwtKey()
+			if err != nil {
+				return nil, err
+			}
+			return append([]*config.JwtKey{key}, keys...), nil
+		}
+	case "rotate":
+		update = config.RotateJwtKeys
+	case "retire":
+		if len(args) != 2 {
+			return fmt.Errorf("keys retire expects a kid")
+		}
+		update = func(keys []*config.JwtKey) ([]*config.JwtKey, error) {
+			return keys, config.RetireJwtKey(keys, args[1])
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	keys, err := config.UpdateJwtKeyFile(path, update)
+	if err != nil {
+		return err
+	}
+	if args[0] == "retire" {
+		fmt.Println("retired key", args[1])
+	} else {
+		fmt.Println("generated key", keys[0].Id)
+	}
+	return nil
+}
+
+func users(args []string) error {
+	if len(args) < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	db, err := config.ConnectGorm()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	repo := gorm.NewUserRepo(db)
+
+	switch args[0] {
+	case "list":
+		users, err := repo.GetUsers(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tUSERNAME\tROLE")
+		for _, u := range users {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", u.Id, u.Username, u.Role)
+		}
+		return w.Flush()
+	case "set-role":
+		if len(args) != 3 {
+			return fmt.Errorf("users set-role expects a username and a role")
+		}
+		if !slices.Contains(common.Roles, args[2]) {
+			return fmt.Errorf("unknown role %q", args[2])
+		}
+		user, err := repo.GetUserByUsername(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		if err := repo.UpdateUserRole(ctx, user.Id, args[2]); err != nil {
+			return err
+		}
+		if err := gorm.NewSessionRepo(db).RevokeUserSessions(ctx, user.Id); err != nil {
+			return err
+		}
+		fmt.Printf("%s is now %s\n", user.Username, args[2])
+		return nil
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	return nil
+}
+
+func check(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	fs.Usage = flag.Usage
+	repair := fs.Bool("repair", false, "repair the orphaned rows")
+	remove := fs.Bool("delete", false, "delete orphaned books instead of crediting them to a placeholder author")
+	fs.Parse(args)
+
+	db, err := config.ConnectGorm()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	repo := gorm.NewConsistencyRepo(db)
+
+	var report *repository.ConsistencyReport
+	if *repair {
+		mode := repository.RepairPlaceholder
+		if *remove {
+			mode = repository.RepairDelete
+		}
+		report, err = repo.RepairConsistency(ctx, mode)
+	} else {
+		report, err = repo.CheckConsistency(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	if report.Empty() {
+		fmt.Println("no inconsistencies found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PROBLEM\tID\tDETAIL")
+	for _, c := range report.DanglingContributors {
+		fmt.Fprintf(w, "dangling contributor\t%s\t%s %s, the book or the author is missing\n", c.BookId, c.Role, c.AuthorId)
+	}
+	for _, b := range report.OrphanedBooks {
+		fmt.Fprintf(w, "orphaned book\t%s\t%q has no author\n", b.Id, b.Title)
+	}
+	for _, b := range report.UnownedBooks {
+		fmt.Fprintf(w, "unowned book\t%s\tmissing user %s\n", b.Id, b.UserId)
+	}
+	for _, a := range report.UnownedAuthors {
+		fmt.Fprintf(w, "unowned author\t%s\tmissing user %s\n", a.Id, a.UserId)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if *repair {
+		fmt.Println("repaired dangling contributors and orphaned books, unowned rows need to be fixed by hand")
+		return nil
+	}
+	fmt.Println("run with -repair to fix dangling contributors and orphaned books")
+	os.Exit(1)
+	return nil
+}
@@ -1,15 +1,39 @@
 package main
 
 import (
+	"context"
+
 	"github.com/gin-gonic/gin"
 	"github.com/storyofhis/books-management/config"
 	"github.com/storyofhis/books-management/httpserver"
 	author_controller "github.com/storyofhis/books-management/httpserver/controller/author"
 	book_controller "github.com/storyofhis/books-management/httpserver/controller/book"
+	catalog_controller "github.com/storyofhis/books-management/httpserver/controller/catalog"
+	circulation_controller "github.com/storyofhis/books-management/httpserver/controller/circulation"
+	citation_controller "github.com/storyofhis/books-management/httpserver/controller/citation"
+	export_controller "github.com/storyofhis/books-management/httpserver/controller/export"
+	importer_controller "github.com/storyofhis/books-management/httpserver/controller/importer"
+	inventory_controller "github.com/storyofhis/books-management/httpserver/controller/inventory"
+	ledger_controller "github.com/storyofhis/books-management/httpserver/controller/ledger"
+	review_controller "github.com/storyofhis/books-management/httpserver/controller/review"
+	search_controller "github.com/storyofhis/books-management/httpserver/controller/search"
+	shelf_controller "github.com/storyofhis/books-management/httpserver/controller/shelf"
+	trash_controller "github.com/storyofhis/books-management/httpserver/controller/trash"
 	user_controller "github.com/storyofhis/books-management/httpserver/controller/user"
 	"github.com/storyofhis/books-management/httpserver/repository/gorm"
 	"github.com/storyofhis/books-management/httpserver/service/author"
 	"github.com/storyofhis/books-management/httpserver/service/book"
+	"github.com/storyofhis/books-management/httpserver/service/catalog"
+	"github.com/storyofhis/books-management/httpserver/service/circulation"
+	"github.com/storyofhis/books-management/httpserver/service/citation"
+	"github.com/storyofhis/books-management/httpserver/service/export"
+	"github.com/storyofhis/books-management/httpserver/service/importer"
+	"github.com/storyofhis/books-management/httpserver/service/inventory"
+	"github.com/storyofhis/books-management/httpserver/service/ledger"
+	"github.com/storyofhis/books-management/httpserver/service/review"
+	"github.com/storyofhis/books-management/httpserver/service/search"
+	"github.com/storyofhis/books-management/httpserver/service/shelf"
+	"github.com/storyofhis/books-management/httpserver/service/trash"
 	"github.com/storyofhis/books-management/httpserver/service/user"
 )
 
@@ -19,21 +43,77 @@ func main() {
 		panic(err)
 	}
 
+	err = config.LoadJwtKeys()
+	if err != nil {
+		panic(err)
+	}
+	go config.StartJwtKeyRotation(context.Background())
+
 	router := gin.Default()
-	config.GenerateJwtSignature()
 
 	userRepo := gorm.NewUserRepo(db)
-	userSvc := user.NewUserSvc(userRepo)
+	sessionRepo := gorm.NewSessionRepo(db)
+	userSvc := user.NewUserSvc(userRepo, sessionRepo)
 	userControl := user_controller.NewUserController(userSvc)
 
+	transactor := gorm.NewTransactor(db)
+	historyRepo := gorm.NewHistoryRepo(db)
+
 	authorRepo := gorm.NewAuthorRepo(db)
-	authorSvc := author.NewAuthorSvc(authorRepo)
+	authorSvc := author.NewAuthorSvc(authorRepo, historyRepo, transactor)
 	authorControl := author_controller.NewAuthorController(authorSvc)
 
 	bookRepo := gorm.NewBookRepo(db)
-	bookSvc := book.NewBookSvc(bookRepo)
+	copyRepo := gorm.NewCopyRepo(db)
+	bookSvc := book.NewBookSvc(bookRepo, authorRepo, copyRepo, historyRepo, transactor)
 	bookControl := book_controller.NewBookController(bookSvc)
 
-	app := httpserver.NewRouter(router, *userControl, *authorControl, *bookControl)
+	holdRepo := gorm.NewHoldRepo(db)
+	copySvc := inventory.NewCopySvc(copyRepo, bookRepo, holdRepo, transactor)
+	copyControl := inventory_controller.NewCopyController(copySvc)
+
+	loanRepo := gorm.NewLoanRepo(db)
+	ledgerRepo := gorm.NewLedgerRepo(db)
+	loanSvc := circulation.NewLoanSvc(loanRepo, copyRepo, userRepo, holdRepo, ledgerRepo, transactor)
+	loanControl := circulation_controller.NewLoanController(loanSvc)
+
+	holdSvc := circulation.NewHoldSvc(holdRepo, bookRepo, userRepo, transactor)
+	holdControl := circulation_controller.NewHoldController(holdSvc)
+	go circulation.StartHoldExpiry(context.Background(), holdRepo)
+
+	ledgerSvc := ledger.NewLedgerSvc(ledgerRepo, userRepo, transactor)
+	ledgerControl := ledger_controller.NewLedgerController(ledgerSvc)
+	go ledger.StartFineAccrual(context.Background(), loanRepo, ledgerRepo)
+
+	reviewRepo := gorm.NewReviewRepo(db)
+	reviewSvc := review.NewReviewSvc(reviewRepo, bookRepo)
+	reviewControl := review_controller.NewReviewController(reviewSvc)
+
+	shelfRepo := gorm.NewShelfRepo(db)
+	shelfSvc := shelf.NewShelfSvc(shelfRepo, bookRepo, userRepo)
+	shelfControl := shelf_controller.NewShelfController(shelfSvc)
+
+	importSvc := importer.NewImportSvc(bookRepo, authorRepo, historyRepo, transactor)
+	importControl := importer_controller.NewImportController(importSvc)
+
+	exportSvc := export.NewExportSvc(bookRepo, authorRepo)
+	exportControl := export_controller.NewExportController(exportSvc)
+
+	searchRepo := gorm.NewSearchRepo(db)
+	searchSvc := search.NewSearchSvc(searchRepo)
+	searchControl := search_controller.NewSearchController(searchSvc)
+
+	catalogSvc := catalog.NewCatalogSvc(bookRepo, authorRepo, searchRepo)
+	catalogControl := catalog_controller.NewCatalogController(catalogSvc)
+
+	citationSvc := citation.NewCitationSvc(bookRepo, shelfRepo)
+	citationControl := citation_controller.NewCitationController(citationSvc)
+
+	trashRepo := gorm.NewTrashRepo(db)
+	trashSvc := trash.NewTrashSvc(trashRepo)
+	trashControl := trash_controller.NewTrashController(trashSvc)
+	go trash.StartPurge(context.Background(), trashRepo)
+
+	app := httpserver.NewRouter(router, userSvc, *userControl, *authorControl, *bookControl, *copyControl, *loanControl, *holdControl, *ledgerControl, *reviewControl, *shelfControl, *importControl, *exportControl, *searchControl, *catalogControl, *citationControl, *trashControl)
 	app.Start(":" + "8080")
 }
@@ -11,17 +11,68 @@ import (
 var (
 	ErrTokenInvalid  = errors.New("token invalid")
 	ErrTokenInactive = errors.New("token inactive")
+	ErrTokenRevoked  = errors.New("token revoked")
+
+	ErrInvalidCredentials = errors.New("invalid username or password")
+)
+
+const (
+	RoleAdmin     = "admin"
+	RoleLibrarian = "librarian"
+	RoleMember    = "member"
+	RoleReadOnly  = "read-only"
 )
 
+var Roles = []string{RoleAdmin, RoleLibrarian, RoleMember, RoleReadOnly}
+
 type CustomClaims struct {
-	Id   uuid.UUID `json:"id"`
-	Role string    `json:"role"`
+	Id        uuid.UUID `json:"id"`
+	Role      string    `json:"role"`
+	SessionId uuid.UUID `json:"sid"`
 	jwt.StandardClaims
 }
 
+// HasRole reports whether the claims carry one of roles.
+func (c *CustomClaims) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if c.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// CanManage reports whether the claims allow changing a record owned by
+// ownerId. Admins may change any record.
+func (c *CustomClaims) CanManage(ownerId uuid.UUID) bool {
+	return c.Id == ownerId || c.HasRole(RoleAdmin)
+}
+
+// IsStaff reports whether the claims belong to an admin or a librarian, who
+// manage the copies and loans of every user.
+func (c *CustomClaims) IsStaff() bool {
+	return c.HasRole(RoleAdmin, RoleLibrarian)
+}
+
+// SignToken signs claims with the current signing key and records its id in
+// the kid header.
+func SignToken(claims *CustomClaims) (string, error) {
+	key, err := config.GetJwtSigningKey()
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = key.Id
+	return token.SignedString(key.Secret)
+}
+
 func ValidateToken(tokenString string) (*CustomClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return config.GetJwtSignature(), nil
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrTokenInvalid
+		}
+		kid, _ := token.Header["kid"].(string)
+		return config.GetJwtVerificationKey(kid)
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultLoanPeriod  = 14 * 24 * time.Hour
+	defaultMaxRenewals = 2
+
+	defaultHoldPickupWindow   = 72 * time.Hour
+	defaultHoldExpiryInterval = time.Hour
+
+	defaultFineDailyRate       = 25
+	defaultFineCap             = 1000
+	defaultFineBalanceLimit    = 500
+	defaultFineAccrualInterval = time.Hour
+)
+
+// GetLoanPeriod returns how long a copy is lent for, and how much a renewal
+// extends the loan by.
+func GetLoanPeriod() time.Duration {
+	return durationFromEnv("LOAN_PERIOD", defaultLoanPeriod)
+}
+
+// GetMaxRenewals returns how many times a loan can be renewed. Zero disables
+// renewals.
+func GetMaxRenewals() int {
+	return intFromEnv("MAX_RENEWALS", defaultMaxRenewals)
+}
+
+// GetHoldPickupWindow returns how long a copy assigned to a hold is kept for
+// its member before the hold expires.
+func GetHoldPickupWindow() time.Duration {
+	return durationFromEnv("HOLD_PICKUP_WINDOW", defaultHoldPickupWindow)
+}
+
+// GetHoldExpiryInterval returns how often holds not picked up are expired.
+func GetHoldExpiryInterval() time.Duration {
+	return durationFromEnv("HOLD_EXPIRY_INTERVAL", defaultHoldExpiryInterval)
+}
+
+// GetFineDailyRate returns the late fee charged for every started day a loan
+// is overdue, in minor units.
+func GetFineDailyRate() int64 {
+	return int64(intFromEnv("FINE_DAILY_RATE", defaultFineDailyRate))
+}
+
+// GetFineCap returns the highest fine charged for a loan, zero meaning no
+// cap.
+func GetFineCap() int64 {
+	return int64(intFromEnv("FINE_CAP", defaultFineCap))
+}
+
+// GetFineBalanceLimit returns the balance above which a member cannot
+// borrow any more.
+func GetFineBalanceLimit() int64 {
+	return int64(intFromEnv("FINE_BALANCE_LIMIT", defaultFineBalanceLimit))
+}
+
+// GetFineAccrualInterval returns how often the fines of overdue loans are
+// brought up to date.
+func GetFineAccrualInterval() time.Duration {
+	return durationFromEnv("FINE_ACCRUAL_INTERVAL", defaultFineAccrualInterval)
+}
+
+func intFromEnv(name string, fallback int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Printf("Invalid %s %q, using %d", name, value, fallback)
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+// GetRequireIfMatch reports whether updates and deletes of books and authors
+// must carry an If-Match header (REQUIRE_IF_MATCH). It is off by default, in
+// which case requests without the header overwrite the record.
+func GetRequireIfMatch() bool {
+	value := os.Getenv("REQUIRE_IF_MATCH")
+	if value == "" {
+		return false
+	}
+	required, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid REQUIRE_IF_MATCH %q, using false", value)
+		return false
+	}
+	return required
+}
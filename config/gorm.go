@@ -1,24 +1,195 @@
 package config
 
 import (
+	"errors"
 	"log"
+	"strings"
 
 	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/isbn"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
 func ConnectGorm() (*gorm.DB, error) {
-	db, err := gorm.Open(sqlite.Open("gorm.db"), &gorm.Config{})
+	db, err := gorm.Open(sqlite.Open("gorm.db?_foreign_keys=on"), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatalf("Failed to connect database : %v", err)
 		return nil, err
 	}
 
-	err = db.AutoMigrate(&models.Author{}, &models.Book{}, &models.User{})
+	// SQLite only allows toggling foreign keys outside of a transaction and
+	// per connection, so the migrations run on a single connection with
+	// them turned off, as rebuilding a table requires.
+	err = db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("PRAGMA foreign_keys = OFF").Error; err != nil {
+			return err
+		}
+		defer conn.Exec("PRAGMA foreign_keys = ON")
+		// The connection shares one statement between chained calls, a new
+		// session starts every query from scratch.
+		return migrate(conn.Session(&gorm.Session{NewDB: true}))
+	})
 	if err != nil {
-		log.Fatalf("Failed to migrate database : %v", err)
 		return nil, err
 	}
+
+	var violations int64
+	err = db.Raw("SELECT COUNT(*) FROM pragma_foreign_key_check").Scan(&violations).Error
+	if err != nil {
+		log.Fatalf("Failed to check foreign keys : %v", err)
+		return nil, err
+	}
+	if violations > 0 {
+		log.Printf("Found %d rows referencing missing records, run `go run ./cmd/admin check` for details", violations)
+	}
 	return db, err
 }
+
+func migrate(db *gorm.DB) error {
+	err := migrateContributors(db)
+	if err != nil {
+		log.Fatalf("Failed to migrate book contributors : %v", err)
+		return err
+	}
+
+	err = db.AutoMigrate(&models.Author{}, &models.Book{}, &models.User{}, &models.Session{}, &models.RefreshToken{}, &models.BookContributor{}, &models.HistoryEntry{}, &models.Copy{}, &models.Loan{}, &models.Hold{}, &models.LedgerEntry{}, &models.Review{}, &models.Shelf{}, &models.ShelfItem{})
+	if err != nil {
+		log.Fatalf("Failed to migrate database : %v", err)
+		return err
+	}
+
+	err = migrateAuthorNameKeys(db)
+	if err != nil {
+		log.Fatalf("Failed to fill the name keys of authors : %v", err)
+		return err
+	}
+
+	err = normalizeIsbns(db)
+	if err != nil {
+		log.Fatalf("Failed to normalize isbns : %v", err)
+		return err
+	}
+
+	err = migrateIsbnIndex(db)
+	if err != nil {
+		log.Fatalf("Failed to create the isbn index : %v", err)
+		return err
+	}
+
+	err = migrateSearch(db)
+	if err != nil {
+		log.Fatalf("Failed to migrate search index, make sure to build with -tags sqlite_fts5 : %v", err)
+		return err
+	}
+	return nil
+}
+
+// migrateContributors moves the single author of books created before books
+// had contributors into book_contributors and drops books.author_id. It runs
+// before AutoMigrate, which recreates the indexes lost when the column is
+// dropped.
+func migrateContributors(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.Book{}, "author_id") {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := dropSearchTriggers(tx); err != nil {
+			return err
+		}
+		if err := tx.AutoMigrate(&models.BookContributor{}); err != nil {
+			return err
+		}
+		err := tx.Exec(`INSERT INTO book_contributors (book_id, author_id, role, position)
+			SELECT id, author_id, ?, 0 FROM books WHERE author_id IS NOT NULL AND author_id <> ''
+			ON CONFLICT DO NOTHING`, models.ContributorAuthor).Error
+		if err != nil {
+			return err
+		}
+		if tx.Migrator().HasConstraint(&models.Book{}, "fk_books_author") {
+			if err := tx.Migrator().DropConstraint(&models.Book{}, "fk_books_author"); err != nil {
+				return err
+			}
+		}
+		return tx.Migrator().DropColumn(&models.Book{}, "author_id")
+	})
+}
+
+// migrateAuthorNameKeys fills the name key of the authors created before
+// authors had one.
+func migrateAuthorNameKeys(db *gorm.DB) error {
+	var authors []models.Author
+	err := db.Unscoped().Select("id", "name").Where("name_key IS NULL OR name_key = ''").Where("name <> ''").Find(&authors).Error
+	if err != nil {
+		return err
+	}
+	for _, author := range authors {
+		err := db.Unscoped().Model(&models.Author{}).Where("id = ?", author.Id).UpdateColumn("name_key", strings.ToLower(author.Name)).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeIsbns rewrites valid ISBNs stored before normalization as
+// unhyphenated ISBN-13. Invalid ISBNs are left untouched and logged.
+func normalizeIsbns(db *gorm.DB) error {
+	var books []models.Book
+	err := db.Select("id", "isbn").Where("isbn <> ''").Find(&books).Error
+	if err != nil {
+		return err
+	}
+	for _, book := range books {
+		code, err := isbn.Normalize(book.Isbn)
+		if err != nil {
+			log.Printf("Book %s has an invalid isbn %q", book.Id, book.Isbn)
+			continue
+		}
+		if code == book.Isbn {
+			continue
+		}
+		err = db.Model(&models.Book{}).Where("id = ?", book.Id).UpdateColumn("isbn", code).Error
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			log.Printf("Book %s has the isbn %q of another book as %q", book.Id, code, book.Isbn)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateIsbnIndex makes the isbn of books that are not in the trash unique.
+// Books sharing an isbn from before the index keep it on the oldest of them,
+// it is cleared on the others and logged so that it can be fixed by hand.
+func migrateIsbnIndex(db *gorm.DB) error {
+	if db.Migrator().HasIndex(&models.Book{}, "idx_books_isbn_unique") {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		var books []models.Book
+		err := tx.Select("id", "isbn").
+			Where(`isbn IN (SELECT isbn FROM books WHERE deleted_at IS NULL AND isbn <> ''
+				GROUP BY isbn HAVING COUNT(*) > 1)`).
+			Order("isbn, created_at, id").
+			Find(&books).Error
+		if err != nil {
+			return err
+		}
+		var keeper models.Book
+		for _, book := range books {
+			if book.Isbn != keeper.Isbn {
+				keeper = book
+				continue
+			}
+			log.Printf("Cleared the isbn %q of book %s, book %s has it", book.Isbn, book.Id, keeper.Id)
+			err := tx.Model(&models.Book{}).Where("id = ?", book.Id).UpdateColumn("isbn", "").Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Exec(`CREATE UNIQUE INDEX idx_books_isbn_unique ON books (isbn) WHERE deleted_at IS NULL AND isbn <> ''`).Error
+	})
+}
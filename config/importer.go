@@ -0,0 +1,16 @@
+package config
+
+const (
+	defaultImportMaxRows  = 10000
+	defaultImportMaxBytes = 10 << 20
+)
+
+// GetImportMaxRows returns how many rows an import may have.
+func GetImportMaxRows() int {
+	return intFromEnv("IMPORT_MAX_ROWS", defaultImportMaxRows)
+}
+
+// GetImportMaxBytes returns how large an uploaded import may be.
+func GetImportMaxBytes() int64 {
+	return int64(intFromEnv("IMPORT_MAX_BYTES", defaultImportMaxBytes))
+}
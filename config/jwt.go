@@ -1,25 +1,419 @@
 package config
 
 import (
-	"math/rand"
-	"strconv"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 )
 
 var (
-	jwtSignature []byte
-	expiredTime  = 1000
+	ErrNoJwtKey      = errors.New("no active jwt signing key")
+	ErrUnknownJwtKey = errors.New("unknown jwt key")
+	ErrJwtKeyRetired = errors.New("jwt key retired")
+	ErrLastJwtKey    = errors.New("cannot retire the only active jwt key, rotate it instead")
 )
 
-func GenerateJwtSignature() {
-	rand.Seed(time.Now().UTC().UnixNano())
-	jwtSignature = []byte(strconv.FormatUint(rand.Uint64(), 10))
+const (
+	defaultJwtKeysFile    = "jwt_keys.json"
+	defaultJwtKeyRotation = 30 * 24 * time.Hour
+	jwtKeyReloadInterval  = time.Minute
+)
+
+var (
+	jwtKeysMu     sync.RWMutex
+	jwtKeys       []*JwtKey
+	jwtKeysFile   string
+	jwtKeysStatic bool
+	// jwtKeysModTime is the modification time of the key file when an
+	// unknown kid last reloaded it.
+	jwtKeysModTime time.Time
+	expiredTime    = 15
+	// refreshExpiredTime is the refresh token lifetime in minutes.
+	refreshExpiredTime = 60 * 24 * 30
+)
+
+// JwtKey is an HMAC secret used to sign access tokens. Keys are identified by
+// the kid header of the token they signed.
+type JwtKey struct {
+	Id        string     `json:"kid"`
+	Secret    []byte     `json:"secret"`
+	CreatedAt time.Time  `json:"created_at"`
+	RetiredAt *time.Time `json:"retired_at,omitempty"`
+}
+
+type jwtKeyFile struct {
+	Keys []*JwtKey `json:"keys"`
+}
+
+func NewJwtKey() (*JwtKey, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return &JwtKey{
+		Id:        hex.EncodeToString(id),
+		Secret:    secret,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// LoadJwtKeys loads the signing keys from the JWT_KEYS environment variable
+// or, when it is not set, from the key file (JWT_KEYS_FILE, jwt_keys.json by
+// default). A missing key file is created with a fresh key.
+func LoadJwtKeys() error {
+	if env := os.Getenv("JWT_KEYS"); env != "" {
+		keys, err := parseJwtKeysEnv(env)
+		if err != nil {
+			return err
+		}
+		jwtKeysMu.Lock()
+		jwtKeys = keys
+		jwtKeysStatic = true
+		jwtKeysMu.Unlock()
+		return nil
+	}
+
+	path := GetJwtKeysFile()
+	keys, err := ReadJwtKeyFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		// Replicas starting together create the file once.
+		keys, err = UpdateJwtKeyFile(path, func(keys []*JwtKey) ([]*JwtKey, error) {
+			if len(keys) > 0 {
+				return keys, nil
+			}
+			key, err := NewJwtKey()
+			if err != nil {
+				return nil, err
+			}
+			return []*JwtKey{key}, nil
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	jwtKeysMu.Lock()
+	jwtKeys = keys
+	jwtKeysFile = path
+	jwtKeysStatic = false
+	jwtKeysMu.Unlock()
+	return nil
+}
+
+// parseJwtKeysEnv parses a comma separated list of kid:base64secret pairs.
+// The first pair is used for signing.
+func parseJwtKeysEnv(env string) ([]*JwtKey, error) {
+	var keys []*JwtKey
+	for _, pair := range strings.Split(env, ",") {
+		kid, secret, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || kid == "" || secret == "" {
+			return nil, fmt.Errorf("invalid JWT_KEYS entry %q, expected kid:base64secret", pair)
+		}
+		raw, err := base64.StdEncoding.DecodeString(secret)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT_KEYS secret for %q: %w", kid, err)
+		}
+		keys = append(keys, &JwtKey{Id: kid, Secret: raw})
+	}
+	return keys, nil
+}
+
+func ReadJwtKeyFile(path string) ([]*JwtKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file jwtKeyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid jwt key file %s: %w", path, err)
+	}
+	return file.Keys, nil
+}
+
+// WriteJwtKeyFile atomically replaces the key file so that other replicas
+// never read a partially written file.
+func WriteJwtKeyFile(path string, keys []*JwtKey) error {
+	data, err := json.MarshalIndent(jwtKeyFile{Keys: keys}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".jwt_keys-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// UpdateJwtKeyFile replaces the keys of the key file with those returned by
+// update, holding a lock on the file so that replicas and the admin command
+// changing it together do not overwrite each other's keys. update is given
+// the keys read under the lock, none when the file does not exist yet.
+func UpdateJwtKeyFile(path string, update func(keys []*JwtKey) ([]*JwtKey, error)) ([]*JwtKey, error) {
+	unlock, err := lockJwtKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	keys, err := ReadJwtKeyFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	keys, err = update(keys)
+	if err != nil {
+		return nil, err
+	}
+	return keys, WriteJwtKeyFile(path, keys)
+}
+
+// SetJwtKeys replaces the in-memory key set.
+func SetJwtKeys(keys []*JwtKey) {
+	jwtKeysMu.Lock()
+	defer jwtKeysMu.Unlock()
+	jwtKeys = keys
+}
+
+func GetJwtKeys() []*JwtKey {
+	jwtKeysMu.RLock()
+	defer jwtKeysMu.RUnlock()
+	return append([]*JwtKey(nil), jwtKeys...)
+}
+
+// GetJwtSigningKey returns the first key that has not been retired.
+func GetJwtSigningKey() (*JwtKey, error) {
+	jwtKeysMu.RLock()
+	defer jwtKeysMu.RUnlock()
+	for _, key := range jwtKeys {
+		if key.RetiredAt == nil {
+			return key, nil
+		}
+	}
+	return nil, ErrNoJwtKey
+}
+
+// GetJwtVerificationKey returns the secret for kid. Retired keys are still
+// accepted during the grace window so tokens signed before a rotation keep
+// working until they expire. An unknown kid reloads the key file first, as
+// it may have been added by another replica since the last reload.
+func GetJwtVerificationKey(kid string) ([]byte, error) {
+	secret, err := findJwtKey(kid)
+	if err == ErrUnknownJwtKey && reloadJwtKeys() {
+		secret, err = findJwtKey(kid)
+	}
+	return secret, err
+}
+
+func findJwtKey(kid string) ([]byte, error) {
+	jwtKeysMu.RLock()
+	defer jwtKeysMu.RUnlock()
+	for _, key := range jwtKeys {
+		if key.Id != kid {
+			continue
+		}
+		if key.RetiredAt != nil && time.Since(*key.RetiredAt) > GetJwtKeyGrace() {
+			return nil, ErrJwtKeyRetired
+		}
+		return key.Secret, nil
+	}
+	return nil, ErrUnknownJwtKey
+}
+
+// reloadJwtKeys reloads the key file when it changed since the last reload,
+// and reports whether it did. Tokens with made up kids only cost a stat.
+func reloadJwtKeys() bool {
+	jwtKeysMu.Lock()
+	path := jwtKeysFile
+	if jwtKeysStatic || path == "" {
+		jwtKeysMu.Unlock()
+		return false
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.ModTime().Equal(jwtKeysModTime) {
+		jwtKeysMu.Unlock()
+		return false
+	}
+	jwtKeysModTime = info.ModTime()
+	jwtKeysMu.Unlock()
+
+	keys, err := ReadJwtKeyFile(path)
+	if err != nil {
+		log.Printf("Failed to reload jwt keys : %v", err)
+		return false
+	}
+	SetJwtKeys(keys)
+	return true
+}
+
+// RotateJwtKeys adds a new signing key to keys, retires the previous ones and
+// drops keys whose grace window has passed.
+func RotateJwtKeys(keys []*JwtKey) ([]*JwtKey, error) {
+	key, err := NewJwtKey()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	rotated := []*JwtKey{key}
+	for _, k := range keys {
+		if k.RetiredAt == nil {
+			k.RetiredAt = &now
+		}
+		if now.Sub(*k.RetiredAt) <= GetJwtKeyGrace() {
+			rotated = append(rotated, k)
+		}
+	}
+	return rotated, nil
+}
+
+// RetireJwtKey marks the key identified by kid as retired. The last active
+// key cannot be retired, as there would be no key left to sign with.
+func RetireJwtKey(keys []*JwtKey, kid string) error {
+	active := 0
+	for _, k := range keys {
+		if k.RetiredAt == nil {
+			active++
+		}
+	}
+	for _, k := range keys {
+		if k.Id == kid {
+			if k.RetiredAt == nil {
+				if active == 1 {
+					return ErrLastJwtKey
+				}
+				now := time.Now().UTC()
+				k.RetiredAt = &now
+			}
+			return nil
+		}
+	}
+	return ErrUnknownJwtKey
+}
+
+// StartJwtKeyRotation periodically reloads the key file, picking up keys
+// written by other replicas or the admin command, and rotates the signing key
+// once it is older than the rotation interval. The replica that rotates does
+// so under the lock of the key file, so only one of them adds a key. Keys
+// loaded from the environment are never rotated.
+func StartJwtKeyRotation(ctx context.Context) {
+	jwtKeysMu.RLock()
+	path, static := jwtKeysFile, jwtKeysStatic
+	jwtKeysMu.RUnlock()
+	if static || path == "" {
+		return
+	}
+
+	ticker := time.NewTicker(jwtKeyReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := rotateJwtKeyFile(path); err != nil {
+				log.Printf("Failed to rotate jwt keys : %v", err)
+			}
+		}
+	}
+}
+
+func rotateJwtKeyFile(path string) error {
+	keys, err := ReadJwtKeyFile(path)
+	if err != nil {
+		return err
+	}
+	if rotationDue(keys) {
+		// Another replica may have rotated since the file was read, which
+		// the keys read again under the lock tell.
+		keys, err = UpdateJwtKeyFile(path, func(keys []*JwtKey) ([]*JwtKey, error) {
+			if !rotationDue(keys) {
+				return keys, nil
+			}
+			return RotateJwtKeys(keys)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	SetJwtKeys(keys)
+	return nil
+}
+
+// rotationDue reports whether the signing key of keys is older than the
+// rotation interval, or there is none.
+func rotationDue(keys []*JwtKey) bool {
+	interval := GetJwtKeyRotation()
+	if interval <= 0 {
+		return false
+	}
+	for _, k := range keys {
+		if k.RetiredAt == nil {
+			return time.Since(k.CreatedAt) > interval
+		}
+	}
+	return true
+}
+
+func GetJwtKeysFile() string {
+	if path := os.Getenv("JWT_KEYS_FILE"); path != "" {
+		return path
+	}
+	return defaultJwtKeysFile
+}
+
+// GetJwtKeyRotation returns how long a signing key is used before it is
+// rotated. Zero disables scheduled rotation.
+func GetJwtKeyRotation() time.Duration {
+	return durationFromEnv("JWT_KEY_ROTATION", defaultJwtKeyRotation)
+}
+
+// GetJwtKeyGrace returns how long a retired key is still accepted. It
+// defaults to the token lifetime.
+func GetJwtKeyGrace() time.Duration {
+	return durationFromEnv("JWT_KEY_GRACE", time.Minute*time.Duration(GetJwtExpiredTime()))
 }
 
 func GetJwtExpiredTime() int {
 	return expiredTime
 }
 
-var GetJwtSignature = func() []byte {
-	return jwtSignature
+func GetRefreshExpiredTime() int {
+	return refreshExpiredTime
+}
+
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid %s %q, using %s", name, value, fallback)
+		return fallback
+	}
+	return d
 }
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package config
+
+// lockJwtKeyFile does not lock anything where file locks are not available.
+// Replicas sharing a key file there should leave rotation to one of them,
+// with JWT_KEY_ROTATION=0 on the others.
+func lockJwtKeyFile(path string) (func(), error) {
+	return func() {}, nil
+}
@@ -0,0 +1,146 @@
+package config_test
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/storyofhis/books-management/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadJwtKeys(t *testing.T) {
+	t.Run("success - it should create the key file on first load and reuse it", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keys.json")
+		t.Setenv("JWT_KEYS_FILE", path)
+
+		assert.NoError(t, config.LoadJwtKeys())
+		first, err := config.GetJwtSigningKey()
+		assert.NoError(t, err)
+
+		assert.NoError(t, config.LoadJwtKeys())
+		second, err := config.GetJwtSigningKey()
+		assert.NoError(t, err)
+		assert.Equal(t, first.Id, second.Id)
+		assert.Equal(t, first.Secret, second.Secret)
+	})
+
+	t.Run("success - it should load keys from the environment", func(t *testing.T) {
+		secret := base64.StdEncoding.EncodeToString([]byte("secret"))
+		t.Setenv("JWT_KEYS", "new:"+secret+",old:"+secret)
+
+		assert.NoError(t, config.LoadJwtKeys())
+		key, err := config.GetJwtSigningKey()
+		assert.NoError(t, err)
+		assert.Equal(t, "new", key.Id)
+
+		_, err = config.GetJwtVerificationKey("old")
+		assert.NoError(t, err)
+	})
+
+	t.Run("success - it should accept a key another replica just added to the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keys.json")
+		t.Setenv("JWT_KEYS_FILE", path)
+		assert.NoError(t, config.LoadJwtKeys())
+
+		keys, err := config.UpdateJwtKeyFile(path, config.RotateJwtKeys)
+		assert.NoError(t, err)
+		_, err = config.GetJwtVerificationKey(keys[0].Id)
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - it should reject malformed environment keys", func(t *testing.T) {
+		t.Setenv("JWT_KEYS", "missing-secret")
+		assert.Error(t, config.LoadJwtKeys())
+	})
+}
+
+func TestRotateJwtKeys(t *testing.T) {
+	t.Run("success - it should keep accepting the previous key during the grace window", func(t *testing.T) {
+		t.Setenv("JWT_KEY_GRACE", "1h")
+		old, err := config.NewJwtKey()
+		assert.NoError(t, err)
+
+		keys, err := config.RotateJwtKeys([]*config.JwtKey{old})
+		assert.NoError(t, err)
+		config.SetJwtKeys(keys)
+
+		signing, err := config.GetJwtSigningKey()
+		assert.NoError(t, err)
+		assert.NotEqual(t, old.Id, signing.Id)
+
+		_, err = config.GetJwtVerificationKey(old.Id)
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - it should reject keys retired before the grace window", func(t *testing.T) {
+		t.Setenv("JWT_KEY_GRACE", "1h")
+		retiredAt := time.Now().Add(-2 * time.Hour)
+		old, err := config.NewJwtKey()
+		assert.NoError(t, err)
+		old.RetiredAt = &retiredAt
+		config.SetJwtKeys([]*config.JwtKey{old})
+
+		_, err = config.GetJwtVerificationKey(old.Id)
+		assert.ErrorIs(t, err, config.ErrJwtKeyRetired)
+
+		keys, err := config.RotateJwtKeys([]*config.JwtKey{old})
+		assert.NoError(t, err)
+		assert.Len(t, keys, 1)
+	})
+
+	t.Run("error - it should reject unknown keys", func(t *testing.T) {
+		config.SetJwtKeys(nil)
+		_, err := config.GetJwtVerificationKey("unknown")
+		assert.ErrorIs(t, err, config.ErrUnknownJwtKey)
+		_, err = config.GetJwtSigningKey()
+		assert.ErrorIs(t, err, config.ErrNoJwtKey)
+	})
+}
+
+func TestUpdateJwtKeyFile(t *testing.T) {
+	t.Run("success - it should keep the keys of concurrent updates", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keys.json")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := config.UpdateJwtKeyFile(path, func(keys []*config.JwtKey) ([]*config.JwtKey, error) {
+					key, err := config.NewJwtKey()
+					return append(keys, key), err
+				})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		keys, err := config.ReadJwtKeyFile(path)
+		assert.NoError(t, err)
+		assert.Len(t, keys, 10)
+	})
+}
+
+func TestRetireJwtKey(t *testing.T) {
+	t.Run("success - it should retire a key while another one signs", func(t *testing.T) {
+		first, _ := config.NewJwtKey()
+		second, _ := config.NewJwtKey()
+
+		assert.NoError(t, config.RetireJwtKey([]*config.JwtKey{first, second}, second.Id))
+		assert.NotNil(t, second.RetiredAt)
+		assert.Nil(t, first.RetiredAt)
+	})
+
+	t.Run("error - it should not retire the only active key", func(t *testing.T) {
+		retiredAt := time.Now()
+		old, _ := config.NewJwtKey()
+		old.RetiredAt = &retiredAt
+		key, _ := config.NewJwtKey()
+
+		assert.ErrorIs(t, config.RetireJwtKey([]*config.JwtKey{key, old}, key.Id), config.ErrLastJwtKey)
+		assert.Nil(t, key.RetiredAt)
+	})
+}
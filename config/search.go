@@ -0,0 +1,146 @@
+package config
+
+import (
+	"gorm.io/gorm"
+)
+
+// searchIndexVersion must be bumped whenever the statements below change so
+// that existing databases rebuild their search index on the next start.
+const searchIndexVersion = "4"
+
+// searchTriggers are the triggers that keep the full-text index in sync.
+var searchTriggers = []string{
+	"books_fts_insert", "books_fts_update", "books_fts_delete",
+	"contributors_fts_insert", "contributors_fts_delete",
+	"authors_fts_insert", "authors_fts_update", "authors_fts_delete",
+}
+
+// bookAuthors selects the names of the contributors of a book, in order.
+func bookAuthors(bookId string) string {
+	return `COALESCE((SELECT group_concat(name, ', ') FROM (
+		SELECT authors.name FROM book_contributors
+		JOIN authors ON authors.id = book_contributors.author_id
+		WHERE book_contributors.book_id = ` + bookId + ` AND authors.deleted_at IS NULL
+		GROUP BY authors.id ORDER BY MIN(book_contributors.position))), '')`
+}
+
+// searchRowid selects the rowid a book or author is indexed under.
+func searchRowid(id string) string {
+	return `(SELECT seq FROM search_ids WHERE id = ` + id + `)`
+}
+
+// The full-text index is kept in FTS5 tables. Books and authors are keyed by
+// UUID, and their implicit rowids are renumbered by VACUUM, so every indexed
+// row gets a number in search_ids instead: an INTEGER PRIMARY KEY keeps its
+// values. Triggers keep the index in sync with every write, so the
+// repositories do not need to know about it. Rows in the trash are left out of
+// the index and added back when they are restored.
+var searchSchema = []string{
+	`CREATE VIRTUAL TABLE IF NOT EXISTS books_fts USING fts5(title, authors, isbn, tokenize = 'unicode61 remove_diacritics 2')`,
+	`CREATE VIRTUAL TABLE IF NOT EXISTS authors_fts USING fts5(name, tokenize = 'unicode61 remove_diacritics 2')`,
+	`CREATE TABLE IF NOT EXISTS search_ids (seq INTEGER PRIMARY KEY, id TEXT NOT NULL UNIQUE)`,
+	`CREATE TABLE IF NOT EXISTS search_meta (key TEXT PRIMARY KEY, value TEXT)`,
+
+	`CREATE TRIGGER books_fts_insert AFTER INSERT ON books WHEN new.deleted_at IS NULL BEGIN
+		INSERT OR IGNORE INTO search_ids (id) VALUES (new.id);
+		INSERT INTO books_fts (rowid, title, authors, isbn)
+		VALUES (` + searchRowid("new.id") + `, new.title, ` + bookAuthors("new.id") + `, new.isbn);
+	END`,
+	`CREATE TRIGGER books_fts_update AFTER UPDATE ON books BEGIN
+		DELETE FROM books_fts WHERE rowid = ` + searchRowid("old.id") + `;
+		INSERT OR IGNORE INTO search_ids (id) SELECT new.id WHERE new.deleted_at IS NULL;
+		INSERT INTO books_fts (rowid, title, authors, isbn)
+		SELECT ` + searchRowid("new.id") + `, new.title, ` + bookAuthors("new.id") + `, new.isbn WHERE new.deleted_at IS NULL;
+	END`,
+	`CREATE TRIGGER books_fts_delete AFTER DELETE ON books BEGIN
+		DELETE FROM books_fts WHERE rowid = ` + searchRowid("old.id") + `;
+		DELETE FROM search_ids WHERE id = old.id;
+	END`,
+
+	`CREATE TRIGGER contributors_fts_insert AFTER INSERT ON book_contributors BEGIN
+		UPDATE books_fts SET authors = ` + bookAuthors("new.book_id") + `
+		WHERE rowid = ` + searchRowid("new.book_id") + `;
+	END`,
+	`CREATE TRIGGER contributors_fts_delete AFTER DELETE ON book_contributors BEGIN
+		UPDATE books_fts SET authors = ` + bookAuthors("old.book_id") + `
+		WHERE rowid = ` + searchRowid("old.book_id") + `;
+	END`,
+
+	`CREATE TRIGGER authors_fts_insert AFTER INSERT ON authors WHEN new.deleted_at IS NULL BEGIN
+		INSERT OR IGNORE INTO search_ids (id) VALUES (new.id);
+		INSERT INTO authors_fts (rowid, name) VALUES (` + searchRowid("new.id") + `, new.name);
+	END`,
+	`CREATE TRIGGER authors_fts_update AFTER UPDATE ON authors BEGIN
+		DELETE FROM authors_fts WHERE rowid = ` + searchRowid("old.id") + `;
+		INSERT OR IGNORE INTO search_ids (id) SELECT new.id WHERE new.deleted_at IS NULL;
+		INSERT INTO authors_fts (rowid, name) SELECT ` + searchRowid("new.id") + `, new.name WHERE new.deleted_at IS NULL;
+		UPDATE books_fts SET authors = ` + bookAuthors("(SELECT id FROM search_ids WHERE seq = books_fts.rowid)") + `
+		WHERE rowid IN (SELECT search_ids.seq FROM search_ids JOIN book_contributors ON book_contributors.book_id = search_ids.id
+			WHERE book_contributors.author_id = new.id);
+	END`,
+	`CREATE TRIGGER authors_fts_delete AFTER DELETE ON authors BEGIN
+		DELETE FROM authors_fts WHERE rowid = ` + searchRowid("old.id") + `;
+		DELETE FROM search_ids WHERE id = old.id;
+		UPDATE books_fts SET authors = ` + bookAuthors("(SELECT id FROM search_ids WHERE seq = books_fts.rowid)") + `
+		WHERE rowid IN (SELECT search_ids.seq FROM search_ids JOIN book_contributors ON book_contributors.book_id = search_ids.id
+			WHERE book_contributors.author_id = old.id);
+	END`,
+}
+
+var searchRebuild = []string{
+	`DELETE FROM books_fts`,
+	`DELETE FROM authors_fts`,
+	`DELETE FROM search_ids`,
+	`INSERT INTO search_ids (id) SELECT id FROM books WHERE deleted_at IS NULL`,
+	`INSERT INTO search_ids (id) SELECT id FROM authors WHERE deleted_at IS NULL`,
+	`INSERT INTO books_fts (rowid, title, authors, isbn)
+	SELECT search_ids.seq, books.title, ` + bookAuthors("books.id") + `, books.isbn
+	FROM books JOIN search_ids ON search_ids.id = books.id WHERE books.deleted_at IS NULL`,
+	`INSERT INTO authors_fts (rowid, name)
+	SELECT search_ids.seq, authors.name
+	FROM authors JOIN search_ids ON search_ids.id = authors.id WHERE authors.deleted_at IS NULL`,
+	`INSERT INTO search_meta (key, value) VALUES ('version', '` + searchIndexVersion + `')
+	ON CONFLICT (key) DO UPDATE SET value = excluded.value`,
+}
+
+// dropSearchTriggers removes the triggers of the full-text index, which
+// migrations altering the indexed tables need out of the way. migrateSearch
+// recreates them.
+func dropSearchTriggers(tx *gorm.DB) error {
+	for _, name := range searchTriggers {
+		if err := tx.Exec(`DROP TRIGGER IF EXISTS ` + name).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateSearch creates the full-text index and rebuilds it when it was
+// created by another version of the schema.
+func migrateSearch(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := dropSearchTriggers(tx); err != nil {
+			return err
+		}
+		for _, stmt := range searchSchema {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+
+		var version string
+		err := tx.Raw(`SELECT value FROM search_meta WHERE key = 'version'`).Scan(&version).Error
+		if err != nil {
+			return err
+		}
+		if version == searchIndexVersion {
+			return nil
+		}
+		for _, stmt := range searchRebuild {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+const (
+	defaultTrashRetention     = 30 * 24 * time.Hour
+	defaultTrashPurgeInterval = time.Hour
+)
+
+// GetTrashRetention returns how long deleted books and authors stay in the
+// trash before they are purged. Zero keeps them forever.
+func GetTrashRetention() time.Duration {
+	return durationFromEnv("TRASH_RETENTION", defaultTrashRetention)
+}
+
+// GetTrashPurgeInterval returns how often the trash is purged. Zero disables
+// the purge.
+func GetTrashPurgeInterval() time.Duration {
+	return durationFromEnv("TRASH_PURGE_INTERVAL", defaultTrashPurgeInterval)
+}
@@ -47,6 +47,6 @@ require (
 	github.com/jinzhu/now v1.1.5 // indirect
 	github.com/mattn/go-sqlite3 v1.14.22 // indirect
 	golang.org/x/crypto v0.27.0
-	golang.org/x/text v0.18.0 // indirect
+	golang.org/x/text v0.18.0
 	gorm.io/gorm v1.25.12
 )
@@ -1,6 +1,7 @@
 package author_controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -10,6 +11,7 @@ import (
 	"github.com/storyofhis/books-management/httpserver/controller/params"
 	"github.com/storyofhis/books-management/httpserver/controller/views"
 	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/jsonpatch"
 )
 
 type AuthorController struct {
@@ -54,7 +56,22 @@ func (control *AuthorController) CreateAuthor(ctx *gin.Context) {
 }
 
 func (control *AuthorController) GetAuthors(ctx *gin.Context) {
-	reponse := control.svc.GetAuthors(ctx)
+	var req params.ListAuthors
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	err := validator.New().Struct(&req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	reponse := control.svc.GetAuthors(ctx, &req)
 	views.WriteJsonResponse(ctx, reponse)
 }
 
@@ -74,6 +91,12 @@ func (control *AuthorController) GetAuthorById(ctx *gin.Context) {
 		return
 	}
 
+	if author, ok := authorResponse.Payload.(views.Author); ok {
+		if views.NotModified(ctx, author.Version) {
+			return
+		}
+		views.SetETag(ctx, author.Version)
+	}
 	views.WriteJsonResponse(ctx, authorResponse)
 }
 
@@ -119,16 +142,123 @@ func (control *AuthorController) UpdateAuthor(ctx *gin.Context) {
 	}
 
 	userData := claims.(*common.CustomClaims)
-	if userData.Id != authorDetails.UserId {
+	if !userData.CanManage(authorDetails.UserId) {
 		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
 			"error": "You do not have permission to update this author",
 		})
 		return
 	}
-	response := control.svc.UpdateAuthor(ctx, &req, authorId)
+	version, ok := views.IfMatch(ctx, authorDetails.Version)
+	if !ok {
+		return
+	}
+
+	response := control.svc.UpdateAuthor(ctx, &req, authorId, version, userData.Id)
+	if updated, ok := response.Payload.(views.UpdateAuthor); ok {
+		views.SetETag(ctx, updated.Version)
+	}
 	views.WriteJsonResponse(ctx, response)
 }
 
+// PatchAuthor applies a merge patch or a json patch to the author and saves
+// the result like UpdateAuthor.
+func (control *AuthorController) PatchAuthor(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	authorId, err := uuid.Parse(idParam)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid author ID format",
+		})
+		return
+	}
+	if ct := ctx.ContentType(); ct != params.MergePatch && ct != params.JsonPatch {
+		ctx.Header("Accept-Patch", params.AcceptPatch)
+		ctx.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{
+			"error": params.ErrUnsupportedPatch.Error(),
+		})
+		return
+	}
+	patch, err := ctx.GetRawData()
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	authorResponse := control.svc.GetAuthorById(ctx, authorId)
+	if authorResponse.Status != http.StatusOK {
+		views.WriteJsonResponse(ctx, authorResponse)
+		return
+	}
+
+	authorDetails, ok := authorResponse.Payload.(views.Author)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": "Unable to process author details",
+		})
+		return
+	}
+
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return
+	}
+
+	userData := claims.(*common.CustomClaims)
+	if !userData.CanManage(authorDetails.UserId) {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "You do not have permission to update this author",
+		})
+		return
+	}
+	if _, ok := views.IfMatch(ctx, authorDetails.Version); !ok {
+		return
+	}
+
+	current := map[string]interface{}{
+		"name":      authorDetails.Name,
+		"birthdate": authorDetails.Birthdate,
+	}
+	var req params.UpdateAuthors
+	if err := params.Patch(ctx.ContentType(), current, patch, &req); err != nil {
+		ctx.AbortWithStatusJSON(patchStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err := validator.New().Struct(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// The patch was applied to the version read above, a change made since
+	// must not be overwritten.
+	response := control.svc.UpdateAuthor(ctx, &req, authorId, authorDetails.Version, userData.Id)
+	if updated, ok := response.Payload.(views.UpdateAuthor); ok {
+		views.SetETag(ctx, updated.Version)
+	}
+	views.WriteJsonResponse(ctx, response)
+}
+
+// patchStatus returns the status of a patch that cannot be applied.
+func patchStatus(err error) int {
+	switch {
+	case errors.Is(err, params.ErrUnsupportedPatch):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		return http.StatusConflict
+	case errors.Is(err, jsonpatch.ErrPathNotFound):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
 func (control *AuthorController) DeleteAuthor(ctx *gin.Context) {
 	idParam := ctx.Param("id")
 	authorId, err := uuid.Parse(idParam)
@@ -163,13 +293,136 @@ func (control *AuthorController) DeleteAuthor(ctx *gin.Context) {
 
 	userData := claims.(*common.CustomClaims)
 
-	if userData.Id != authorDetails.UserId {
+	if !userData.CanManage(authorDetails.UserId) {
 		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
 			"error": "You do not have permission to update this author",
 		})
 		return
 	}
 
-	reponse := control.svc.DeleteAuthor(ctx, authorId)
+	var req params.DeleteAuthor
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	err = validator.New().Struct(&req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	version, ok := views.IfMatch(ctx, authorDetails.Version)
+	if !ok {
+		return
+	}
+
+	reponse := control.svc.DeleteAuthor(ctx, authorId, &req, version, userData)
 	views.WriteJsonResponse(ctx, reponse)
 }
+
+func (control *AuthorController) RestoreAuthor(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	authorId, err := uuid.Parse(idParam)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid author ID format",
+		})
+		return
+	}
+
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return
+	}
+
+	response := control.svc.RestoreAuthor(ctx, authorId, claims.(*common.CustomClaims))
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *AuthorController) GetAuthorHistory(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	authorId, err := uuid.Parse(idParam)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid author ID format",
+		})
+		return
+	}
+
+	response := control.svc.GetAuthorHistory(ctx, authorId)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *AuthorController) RevertAuthor(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	authorId, err := uuid.Parse(idParam)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid author ID format",
+		})
+		return
+	}
+
+	var req params.Revert
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	err = validator.New().Struct(&req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	authorResponse := control.svc.GetAuthorById(ctx, authorId)
+	if authorResponse.Status != http.StatusOK {
+		views.WriteJsonResponse(ctx, authorResponse)
+		return
+	}
+
+	authorDetails, ok := authorResponse.Payload.(views.Author)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": "Unable to process author details",
+		})
+		return
+	}
+
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return
+	}
+
+	userData := claims.(*common.CustomClaims)
+	if !userData.CanManage(authorDetails.UserId) {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "You do not have permission to update this author",
+		})
+		return
+	}
+
+	version, ok := views.IfMatch(ctx, authorDetails.Version)
+	if !ok {
+		return
+	}
+
+	response := control.svc.RevertAuthor(ctx, authorId, &req, version, userData.Id)
+	if updated, ok := response.Payload.(views.UpdateAuthor); ok {
+		views.SetETag(ctx, updated.Version)
+	}
+	views.WriteJsonResponse(ctx, response)
+}
@@ -143,7 +143,7 @@ func TestGetAuthors_Success(t *testing.T) {
 		},
 	}
 	response := views.SuccessResponse(http.StatusOK, views.M_OK, expectedAuthors)
-	mockAuthorSvc.On("GetAuthors", mock.Anything).Return(response)
+	mockAuthorSvc.On("GetAuthors", mock.Anything, mock.AnythingOfType("*params.ListAuthors")).Return(response)
 
 	req, _ := http.NewRequest(http.MethodGet, "/authors", nil)
 	rec := httptest.NewRecorder()
@@ -170,7 +170,7 @@ func TestGetAuthors_EmptyResponse(t *testing.T) {
 	})
 
 	response := views.SuccessResponse(http.StatusOK, views.M_OK, []views.Author{})
-	moockAuthorSvc.On("GetAuthors", mock.Anything).Return(response)
+	moockAuthorSvc.On("GetAuthors", mock.Anything, mock.AnythingOfType("*params.ListAuthors")).Return(response)
 	req, _ := http.NewRequest(http.MethodGet, "/authors", nil)
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
@@ -455,7 +455,7 @@ func TestDeleteAuthor_Success(t *testing.T) {
 
 	mockAuthorSvc.On("GetAuthorById", mock.Anything, authorId).Return(authorResponse)
 	deleteResponse := views.SuccessResponse(http.StatusOK, views.M_OK, nil)
-	mockAuthorSvc.On("DeleteAuthor", mock.Anything, authorId).Return(deleteResponse)
+	mockAuthorSvc.On("DeleteAuthor", mock.Anything, authorId, mock.Anything, 0, mock.Anything).Return(deleteResponse)
 	req, _ := http.NewRequest(http.MethodDelete, "/authors/"+authorId.String(), nil)
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
@@ -556,3 +556,108 @@ func TestDeleteAuthor_Forbidden(t *testing.T) {
 	assert.JSONEq(t, `{"error":"You do not have permission to update this author"}`, rec.Body.String())
 	mockAuthorSvc.AssertNotCalled(t, "DeleteAuthor")
 }
+
+// servePatchAuthor sends a PATCH /authors/:id request as userId.
+func servePatchAuthor(svc *mocks.MockAuthorSvc, userId, authorId uuid.UUID, contentType, ifMatch, body string) *httptest.ResponseRecorder {
+	controller := author_controller.NewAuthorController(svc)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.PATCH("/authors/:id", func(ctx *gin.Context) {
+		ctx.Set("userData", &common.CustomClaims{Id: userId})
+		controller.PatchAuthor(ctx)
+	})
+
+	req, _ := http.NewRequest(http.MethodPatch, "/authors/"+authorId.String(), bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", contentType)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+var herbertBirthdate = time.Date(1920, time.October, 8, 0, 0, 0, 0, time.UTC)
+
+func patchedAuthor(userId, authorId uuid.UUID) views.Author {
+	return views.Author{Id: authorId, UserId: userId, Name: "Frank Herbert", Birthdate: herbertBirthdate, Version: 2}
+}
+
+func TestPatchAuthor_MergePatch(t *testing.T) {
+	mockAuthorSvc := new(mocks.MockAuthorSvc)
+	userId, authorId := uuid.New(), uuid.New()
+	mockAuthorSvc.On("GetAuthorById", mock.Anything, authorId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, patchedAuthor(userId, authorId)))
+	mockAuthorSvc.On("UpdateAuthor", mock.Anything, &params.UpdateAuthors{Name: "Frank Patrick Herbert", Birthdate: herbertBirthdate}, authorId, 2, userId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, views.UpdateAuthor{Id: authorId, Version: 3}))
+
+	rec := servePatchAuthor(mockAuthorSvc, userId, authorId, params.MergePatch, `"2"`, `{"name":"Frank Patrick Herbert"}`)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	mockAuthorSvc.AssertExpectations(t)
+}
+
+func TestPatchAuthor_JsonPatch(t *testing.T) {
+	mockAuthorSvc := new(mocks.MockAuthorSvc)
+	userId, authorId := uuid.New(), uuid.New()
+	birthdate := time.Date(1920, time.October, 18, 0, 0, 0, 0, time.UTC)
+	mockAuthorSvc.On("GetAuthorById", mock.Anything, authorId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, patchedAuthor(userId, authorId)))
+	mockAuthorSvc.On("UpdateAuthor", mock.Anything, &params.UpdateAuthors{Name: "Frank Herbert", Birthdate: birthdate}, authorId, 2, userId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, views.UpdateAuthor{Id: authorId, Version: 3}))
+
+	body := `[{"op":"test","path":"/name","value":"Frank Herbert"},{"op":"replace","path":"/birthdate","value":"1920-10-18T00:00:00Z"}]`
+	rec := servePatchAuthor(mockAuthorSvc, userId, authorId, params.JsonPatch, "", body)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockAuthorSvc.AssertExpectations(t)
+}
+
+func TestPatchAuthor_UnsupportedContentType(t *testing.T) {
+	mockAuthorSvc := new(mocks.MockAuthorSvc)
+	rec := servePatchAuthor(mockAuthorSvc, uuid.New(), uuid.New(), "text/plain", "", `{"name":"Frank Patrick Herbert"}`)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	assert.Equal(t, params.AcceptPatch, rec.Header().Get("Accept-Patch"))
+	mockAuthorSvc.AssertNotCalled(t, "GetAuthorById", mock.Anything, mock.Anything)
+}
+
+func TestPatchAuthor_FailedTest(t *testing.T) {
+	mockAuthorSvc := new(mocks.MockAuthorSvc)
+	userId, authorId := uuid.New(), uuid.New()
+	mockAuthorSvc.On("GetAuthorById", mock.Anything, authorId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, patchedAuthor(userId, authorId)))
+
+	body := `[{"op":"test","path":"/name","value":"Brian Herbert"},{"op":"replace","path":"/name","value":"Frank Patrick Herbert"}]`
+	rec := servePatchAuthor(mockAuthorSvc, userId, authorId, params.JsonPatch, "", body)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockAuthorSvc.AssertNotCalled(t, "UpdateAuthor", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPatchAuthor_UnknownField(t *testing.T) {
+	mockAuthorSvc := new(mocks.MockAuthorSvc)
+	userId, authorId := uuid.New(), uuid.New()
+	mockAuthorSvc.On("GetAuthorById", mock.Anything, authorId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, patchedAuthor(userId, authorId)))
+
+	rec := servePatchAuthor(mockAuthorSvc, userId, authorId, params.MergePatch, "", `{"nickname":"Frank"}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "nickname")
+	mockAuthorSvc.AssertNotCalled(t, "UpdateAuthor", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPatchAuthor_IfMatchStaleVersion(t *testing.T) {
+	mockAuthorSvc := new(mocks.MockAuthorSvc)
+	userId, authorId := uuid.New(), uuid.New()
+	mockAuthorSvc.On("GetAuthorById", mock.Anything, authorId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, patchedAuthor(userId, authorId)))
+
+	rec := servePatchAuthor(mockAuthorSvc, userId, authorId, params.MergePatch, `"1"`, `{"name":"Frank Patrick Herbert"}`)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+	mockAuthorSvc.AssertNotCalled(t, "UpdateAuthor", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -1,7 +1,9 @@
 package book_controller
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,15 +13,23 @@ import (
 	"github.com/storyofhis/books-management/httpserver/controller/params"
 	"github.com/storyofhis/books-management/httpserver/controller/views"
 	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/isbn"
+	"github.com/storyofhis/books-management/jsonpatch"
 )
 
 type BookController struct {
-	svc service.BookSvc
+	svc      service.BookSvc
+	validate *validator.Validate
 }
 
 func NewBookController(svc service.BookSvc) *BookController {
+	validate := validator.New()
+	if err := isbn.RegisterValidation(validate); err != nil {
+		panic(err)
+	}
 	return &BookController{
-		svc: svc,
+		svc:      svc,
+		validate: validate,
 	}
 }
 
@@ -42,7 +52,7 @@ func (control *BookController) CreateBook(ctx *gin.Context) {
 
 	userData := claims.(*common.CustomClaims)
 	userId := userData.Id
-	err := validator.New().Struct(&req)
+	err := control.validate.Struct(&req)
 	if err != nil {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -55,7 +65,22 @@ func (control *BookController) CreateBook(ctx *gin.Context) {
 }
 
 func (control *BookController) GetBooks(ctx *gin.Context) {
-	reponse := control.svc.GetBooks(ctx)
+	var req params.ListBooks
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	err := control.validate.Struct(&req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	reponse := control.svc.GetBooks(ctx, &req)
 	views.WriteJsonResponse(ctx, reponse)
 }
 
@@ -75,6 +100,12 @@ func (control *BookController) GetBookById(ctx *gin.Context) {
 		return
 	}
 
+	if book, ok := bookResponse.Payload.(views.Book); ok {
+		if views.NotModified(ctx, book.Version, bookState(book.Availability, book.Rating)...) {
+			return
+		}
+		views.SetETag(ctx, book.Version, bookState(book.Availability, book.Rating)...)
+	}
 	views.WriteJsonResponse(ctx, bookResponse)
 }
 
@@ -120,16 +151,140 @@ func (control *BookController) UpdateBook(ctx *gin.Context) {
 	}
 
 	userData := claims.(*common.CustomClaims)
-	if userData.Id != bookDetails.UserId {
+	if !userData.CanManage(bookDetails.UserId) {
 		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
 			"error": "You do not have permission to update this book",
 		})
 		return
 	}
-	response := control.svc.UpdateBook(ctx, &req, bookId)
+	if err := control.validate.Struct(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	version, ok := views.IfMatch(ctx, bookDetails.Version)
+	if !ok {
+		return
+	}
+
+	response := control.svc.UpdateBook(ctx, &req, bookId, version, userData.Id)
+	if updated, ok := response.Payload.(views.UpdateBook); ok {
+		views.SetETag(ctx, updated.Version, bookState(updated.Availability, updated.Rating)...)
+	}
 	views.WriteJsonResponse(ctx, response)
 }
 
+// PatchBook applies a merge patch or a json patch to the book and saves the
+// result like UpdateBook.
+func (control *BookController) PatchBook(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	bookId, err := uuid.Parse(idParam)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid book ID format",
+		})
+		return
+	}
+	if ct := ctx.ContentType(); ct != params.MergePatch && ct != params.JsonPatch {
+		ctx.Header("Accept-Patch", params.AcceptPatch)
+		ctx.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{
+			"error": params.ErrUnsupportedPatch.Error(),
+		})
+		return
+	}
+	patch, err := ctx.GetRawData()
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	bookResponse := control.svc.GetBookById(ctx, bookId)
+	if bookResponse.Status != http.StatusOK {
+		views.WriteJsonResponse(ctx, bookResponse)
+		return
+	}
+
+	bookDetails, ok := bookResponse.Payload.(views.Book)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": "Unable to process book details",
+		})
+		return
+	}
+
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return
+	}
+
+	userData := claims.(*common.CustomClaims)
+	if !userData.CanManage(bookDetails.UserId) {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "You do not have permission to update this book",
+		})
+		return
+	}
+	if _, ok := views.IfMatch(ctx, bookDetails.Version); !ok {
+		return
+	}
+
+	current := params.UpdateBook{
+		Title:        bookDetails.Title,
+		Isbn:         bookDetails.Isbn,
+		Contributors: make([]params.Contributor, 0, len(bookDetails.Contributors)),
+	}
+	for _, c := range bookDetails.Contributors {
+		current.Contributors = append(current.Contributors, params.Contributor{AuthorId: c.AuthorId, Role: c.Role})
+	}
+	var req params.UpdateBook
+	if err := params.Patch(ctx.ContentType(), &current, patch, &req); err != nil {
+		ctx.AbortWithStatusJSON(patchStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err := control.validate.Struct(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// The patch was applied to the version read above, a change made since
+	// must not be overwritten.
+	response := control.svc.UpdateBook(ctx, &req, bookId, bookDetails.Version, userData.Id)
+	if updated, ok := response.Payload.(views.UpdateBook); ok {
+		views.SetETag(ctx, updated.Version, bookState(updated.Availability, updated.Rating)...)
+	}
+	views.WriteJsonResponse(ctx, response)
+}
+
+// bookState returns the state the entity tag of a book carries besides its
+// version. The availability and rating change without the version of the
+// book changing, so they are only compared by If-None-Match.
+func bookState(availability views.Availability, rating views.Rating) []int64 {
+	return []int64{availability.Total, availability.Available, rating.Count, int64(math.Round(rating.Average * 100))}
+}
+
+// patchStatus returns the status of a patch that cannot be applied.
+func patchStatus(err error) int {
+	switch {
+	case errors.Is(err, params.ErrUnsupportedPatch):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		return http.StatusConflict
+	case errors.Is(err, jsonpatch.ErrPathNotFound):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
 func (control *BookController) DeleteBook(ctx *gin.Context) {
 	idParam := ctx.Param("id")
 	bookId, err := uuid.Parse(idParam)
@@ -164,13 +319,120 @@ func (control *BookController) DeleteBook(ctx *gin.Context) {
 
 	userData := claims.(*common.CustomClaims)
 
-	if userData.Id != bookDetails.UserId {
+	if !userData.CanManage(bookDetails.UserId) {
 		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
 			"error": "You do not have permission to update this author",
 		})
 		return
 	}
 
-	response := control.svc.DeleteBook(ctx, bookId)
+	version, ok := views.IfMatch(ctx, bookDetails.Version)
+	if !ok {
+		return
+	}
+
+	response := control.svc.DeleteBook(ctx, bookId, version, userData.Id)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *BookController) RestoreBook(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	bookId, err := uuid.Parse(idParam)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid book ID format",
+		})
+		return
+	}
+
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return
+	}
+
+	response := control.svc.RestoreBook(ctx, bookId, claims.(*common.CustomClaims))
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *BookController) GetBookHistory(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	bookId, err := uuid.Parse(idParam)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid book ID format",
+		})
+		return
+	}
+
+	response := control.svc.GetBookHistory(ctx, bookId)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *BookController) RevertBook(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	bookId, err := uuid.Parse(idParam)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid book ID format",
+		})
+		return
+	}
+
+	var req params.Revert
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err := control.validate.Struct(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	bookResponse := control.svc.GetBookById(ctx, bookId)
+	if bookResponse.Status != http.StatusOK {
+		views.WriteJsonResponse(ctx, bookResponse)
+		return
+	}
+
+	bookDetails, ok := bookResponse.Payload.(views.Book)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": "Unable to process book details",
+		})
+		return
+	}
+
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return
+	}
+
+	userData := claims.(*common.CustomClaims)
+	if !userData.CanManage(bookDetails.UserId) {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "You do not have permission to update this book",
+		})
+		return
+	}
+
+	version, ok := views.IfMatch(ctx, bookDetails.Version)
+	if !ok {
+		return
+	}
+
+	response := control.svc.RevertBook(ctx, bookId, &req, version, userData.Id)
+	if updated, ok := response.Payload.(views.UpdateBook); ok {
+		views.SetETag(ctx, updated.Version, bookState(updated.Availability, updated.Rating)...)
+	}
 	views.WriteJsonResponse(ctx, response)
 }
@@ -35,16 +35,15 @@ func TestCreateBook_Success(t *testing.T) {
 	})
 
 	payload := params.CreateBook{
-		Title:    "Test Book",
-		Isbn:     "123-456",
-		AuthorId: uuid.New(),
+		Title:        "Test Book",
+		Isbn:         "978-0-306-40615-7",
+		Contributors: []params.Contributor{{AuthorId: uuid.New()}},
 	}
 	body, _ := json.Marshal(payload)
 
 	response := views.SuccessResponse(http.StatusCreated, views.M_CREATED, views.Book{
 		Id:        uuid.New(),
 		UserId:    uuid.New(),
-		AuthorId:  payload.AuthorId,
 		Title:     payload.Title,
 		Isbn:      payload.Isbn,
 		CreatedAt: time.Now(),
@@ -76,8 +75,35 @@ func TestCreateBook_InvalidInput(t *testing.T) {
 	})
 
 	payload := params.CreateBook{
-		Isbn:     "123-456",
-		AuthorId: uuid.New(),
+		Isbn:         "123-456",
+		Contributors: []params.Contributor{{AuthorId: uuid.New()}},
+	}
+	body, _ := json.Marshal(payload)
+	req, _ := http.NewRequest(http.MethodPost, "/books", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockBookSvc.AssertNotCalled(t, "CreateBook")
+}
+
+func TestCreateBook_InvalidIsbn(t *testing.T) {
+	mockBookSvc := new(mocks.MockBookSvc)
+	controller := book_controller.NewBookController(mockBookSvc)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/books", func(ctx *gin.Context) {
+		claims := &common.CustomClaims{Id: uuid.New()}
+		ctx.Set("userData", claims)
+		controller.CreateBook(ctx)
+	})
+
+	payload := params.CreateBook{
+		Title:        "Test Book",
+		Isbn:         "978-0-306-40615-8",
+		Contributors: []params.Contributor{{AuthorId: uuid.New()}},
 	}
 	body, _ := json.Marshal(payload)
 	req, _ := http.NewRequest(http.MethodPost, "/books", bytes.NewBuffer(body))
@@ -98,9 +124,9 @@ func TestCreateBook_NoToken(t *testing.T) {
 	router.POST("/books", controller.CreateBook)
 
 	payload := params.CreateBook{
-		Title:    "Test Book",
-		Isbn:     "123-456",
-		AuthorId: uuid.New(),
+		Title:        "Test Book",
+		Isbn:         "123-456",
+		Contributors: []params.Contributor{{AuthorId: uuid.New()}},
 	}
 	body, _ := json.Marshal(payload)
 	req, _ := http.NewRequest(http.MethodPost, "/books", bytes.NewBuffer(body))
@@ -128,7 +154,7 @@ func TestGetBooks_Success(t *testing.T) {
 		{Id: uuid.New(), Title: "Book 2", Isbn: "789-012"},
 	}
 	response := views.SuccessResponse(http.StatusOK, views.M_OK, expectedBooks)
-	mockBookSvc.On("GetBooks", mock.Anything).Return(response)
+	mockBookSvc.On("GetBooks", mock.Anything, mock.AnythingOfType("*params.ListBooks")).Return(response)
 	req, _ := http.NewRequest(http.MethodGet, "/books", nil)
 
 	rec := httptest.NewRecorder()
@@ -152,7 +178,7 @@ func TestGetBooks_EmptyResponse(t *testing.T) {
 	})
 
 	response := views.SuccessResponse(http.StatusOK, views.M_OK, []views.Book{})
-	mockBookSvc.On("GetBooks", mock.Anything).Return(response)
+	mockBookSvc.On("GetBooks", mock.Anything, mock.AnythingOfType("*params.ListBooks")).Return(response)
 	req, _ := http.NewRequest(http.MethodGet, "/books", nil)
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
@@ -176,10 +202,9 @@ func TestGetBookById_Success(t *testing.T) {
 
 	bookId := uuid.New()
 	expectedBook := views.Book{
-		Id:       bookId,
-		Title:    "Test Book",
-		Isbn:     "123-456",
-		AuthorId: uuid.New(),
+		Id:    bookId,
+		Title: "Test Book",
+		Isbn:  "123-456",
 	}
 	response := views.SuccessResponse(http.StatusOK, views.M_OK, expectedBook)
 	mockBookSvc.On("GetBookById", mock.Anything, bookId).Return(response)
@@ -249,7 +274,7 @@ func TestUpdateBook_Success(t *testing.T) {
 	bookId := uuid.New()
 	updatePayload := params.UpdateBook{
 		Title: "Updated Book",
-		Isbn:  "456-789",
+		Isbn:  "978-3-16-148410-0",
 	}
 	body, _ := json.Marshal(updatePayload)
 	existingBook := views.Book{
@@ -261,7 +286,7 @@ func TestUpdateBook_Success(t *testing.T) {
 	bookResponse := views.SuccessResponse(http.StatusOK, views.M_OK, existingBook)
 	mockBookSvc.On("GetBookById", mock.Anything, bookId).Return(bookResponse)
 	updateResponse := views.SuccessResponse(http.StatusOK, views.M_OK, existingBook)
-	mockBookSvc.On("UpdateBook", mock.Anything, mock.AnythingOfType("*params.UpdateBook"), bookId).
+	mockBookSvc.On("UpdateBook", mock.Anything, mock.AnythingOfType("*params.UpdateBook"), bookId, 0, mock.Anything).
 		Return(updateResponse)
 
 	req, _ := http.NewRequest(http.MethodPut, "/books/"+bookId.String(), bytes.NewBuffer(body))
@@ -330,7 +355,7 @@ func TestUpdateBook_NotFound(t *testing.T) {
 
 	updatePayload := params.UpdateBook{
 		Title: "Updated Book",
-		Isbn:  "456-789",
+		Isbn:  "978-3-16-148410-0",
 	}
 	body, _ := json.Marshal(updatePayload)
 
@@ -354,7 +379,7 @@ func TestUpdateBook_Unauthorized(t *testing.T) {
 	bookId := uuid.New()
 	updatePayload := params.UpdateBook{
 		Title: "Updated Book",
-		Isbn:  "456-789",
+		Isbn:  "978-3-16-148410-0",
 	}
 	body, _ := json.Marshal(updatePayload)
 	req, _ := http.NewRequest(http.MethodPut, "/books/"+bookId.String(), bytes.NewBuffer(body))
@@ -383,7 +408,7 @@ func TestUpdateBook_Forbidden(t *testing.T) {
 
 	updatePayload := params.UpdateBook{
 		Title: "Updated Book",
-		Isbn:  "456-789",
+		Isbn:  "978-3-16-148410-0",
 	}
 	body, _ := json.Marshal(updatePayload)
 
@@ -404,6 +429,75 @@ func TestUpdateBook_Forbidden(t *testing.T) {
 	mockBookSvc.AssertExpectations(t)
 }
 
+func TestUpdateBook_IfMatchIgnoresAvailability(t *testing.T) {
+	mockBookSvc := new(mocks.MockBookSvc)
+	controller := book_controller.NewBookController(mockBookSvc)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	userId := uuid.New()
+	router.PUT("/books/:id", func(ctx *gin.Context) {
+		ctx.Set("userData", &common.CustomClaims{Id: userId})
+		controller.UpdateBook(ctx)
+	})
+
+	bookId := uuid.New()
+	body, _ := json.Marshal(params.UpdateBook{
+		Title:        "Dune",
+		Isbn:         "9780441013593",
+		Contributors: []params.Contributor{{AuthorId: uuid.New()}},
+	})
+	// A copy was lent since the tag "3-2-2-0-0" was read.
+	existingBook := views.Book{
+		Id:           bookId,
+		UserId:       userId,
+		Version:      3,
+		Availability: views.Availability{Total: 2, Available: 1},
+	}
+	mockBookSvc.On("GetBookById", mock.Anything, bookId).Return(views.SuccessResponse(http.StatusOK, views.M_OK, existingBook))
+	mockBookSvc.On("UpdateBook", mock.Anything, mock.Anything, bookId, 3, userId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, views.UpdateBook{Id: bookId, Version: 4}))
+
+	req, _ := http.NewRequest(http.MethodPut, "/books/"+bookId.String(), bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"3-2-2-0-0"`)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockBookSvc.AssertExpectations(t)
+}
+
+func TestUpdateBook_IfMatchStaleVersion(t *testing.T) {
+	mockBookSvc := new(mocks.MockBookSvc)
+	controller := book_controller.NewBookController(mockBookSvc)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	userId := uuid.New()
+	router.PUT("/books/:id", func(ctx *gin.Context) {
+		ctx.Set("userData", &common.CustomClaims{Id: userId})
+		controller.UpdateBook(ctx)
+	})
+
+	bookId := uuid.New()
+	body, _ := json.Marshal(params.UpdateBook{
+		Title:        "Dune",
+		Isbn:         "9780441013593",
+		Contributors: []params.Contributor{{AuthorId: uuid.New()}},
+	})
+	existingBook := views.Book{Id: bookId, UserId: userId, Version: 4}
+	mockBookSvc.On("GetBookById", mock.Anything, bookId).Return(views.SuccessResponse(http.StatusOK, views.M_OK, existingBook))
+
+	req, _ := http.NewRequest(http.MethodPut, "/books/"+bookId.String(), bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"3-2-2-0-0"`)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+	mockBookSvc.AssertNotCalled(t, "UpdateBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestDeleteBook_Success(t *testing.T) {
 	mockBookSvc := new(mocks.MockBookSvc)
 	controller := book_controller.NewBookController(mockBookSvc)
@@ -425,7 +519,7 @@ func TestDeleteBook_Success(t *testing.T) {
 
 	mockBookSvc.On("GetBookById", mock.Anything, bookId).Return(bookResponse)
 	deleteResponse := views.SuccessResponse(http.StatusOK, views.M_OK, nil)
-	mockBookSvc.On("DeleteBook", mock.Anything, bookId).Return(deleteResponse)
+	mockBookSvc.On("DeleteBook", mock.Anything, bookId, 0, mock.Anything).Return(deleteResponse)
 	req, _ := http.NewRequest(http.MethodDelete, "/books/"+bookId.String(), nil)
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
@@ -526,3 +620,125 @@ func TestDeleteBook_Forbidden(t *testing.T) {
 	assert.JSONEq(t, `{"error":"You do not have permission to update this author"}`, rec.Body.String())
 	mockBookSvc.AssertNotCalled(t, "DeleteBook")
 }
+
+// servePatchBook sends a PATCH /books/:id request as userId.
+func servePatchBook(svc *mocks.MockBookSvc, userId, bookId uuid.UUID, contentType, ifMatch, body string) *httptest.ResponseRecorder {
+	controller := book_controller.NewBookController(svc)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.PATCH("/books/:id", func(ctx *gin.Context) {
+		ctx.Set("userData", &common.CustomClaims{Id: userId})
+		controller.PatchBook(ctx)
+	})
+
+	req, _ := http.NewRequest(http.MethodPatch, "/books/"+bookId.String(), bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", contentType)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func patchedBook(userId, bookId, authorId uuid.UUID) views.Book {
+	return views.Book{
+		Id:           bookId,
+		UserId:       userId,
+		Title:        "Dune",
+		Isbn:         "9780441013593",
+		Contributors: []views.Contributor{{AuthorId: authorId, Name: "Frank Herbert", Role: "author"}},
+		Version:      3,
+	}
+}
+
+func TestPatchBook_MergePatch(t *testing.T) {
+	mockBookSvc := new(mocks.MockBookSvc)
+	userId, bookId, authorId := uuid.New(), uuid.New(), uuid.New()
+	mockBookSvc.On("GetBookById", mock.Anything, bookId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, patchedBook(userId, bookId, authorId)))
+	mockBookSvc.On("UpdateBook", mock.Anything, &params.UpdateBook{
+		Title:        "Dune Messiah",
+		Isbn:         "9780441013593",
+		Contributors: []params.Contributor{{AuthorId: authorId, Role: "author"}},
+	}, bookId, 3, userId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, views.UpdateBook{Id: bookId, Version: 4}))
+
+	rec := servePatchBook(mockBookSvc, userId, bookId, params.MergePatch, `"3"`, `{"title":"Dune Messiah"}`)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"4-0-0-0-0"`, rec.Header().Get("ETag"))
+	mockBookSvc.AssertExpectations(t)
+}
+
+func TestPatchBook_JsonPatch(t *testing.T) {
+	mockBookSvc := new(mocks.MockBookSvc)
+	userId, bookId, authorId := uuid.New(), uuid.New(), uuid.New()
+	editorId := uuid.New()
+	mockBookSvc.On("GetBookById", mock.Anything, bookId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, patchedBook(userId, bookId, authorId)))
+	mockBookSvc.On("UpdateBook", mock.Anything, &params.UpdateBook{
+		Title: "Dune",
+		Isbn:  "9780441013593",
+		Contributors: []params.Contributor{
+			{AuthorId: authorId, Role: "author"},
+			{AuthorId: editorId, Role: "editor"},
+		},
+	}, bookId, 3, userId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, views.UpdateBook{Id: bookId, Version: 4}))
+
+	body := `[{"op":"test","path":"/title","value":"Dune"},` +
+		`{"op":"add","path":"/contributors/-","value":{"author_id":"` + editorId.String() + `","role":"editor"}}]`
+	rec := servePatchBook(mockBookSvc, userId, bookId, params.JsonPatch, "", body)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockBookSvc.AssertExpectations(t)
+}
+
+func TestPatchBook_UnsupportedContentType(t *testing.T) {
+	mockBookSvc := new(mocks.MockBookSvc)
+	rec := servePatchBook(mockBookSvc, uuid.New(), uuid.New(), "application/json", "", `{"title":"Dune Messiah"}`)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	assert.Equal(t, params.AcceptPatch, rec.Header().Get("Accept-Patch"))
+	mockBookSvc.AssertNotCalled(t, "GetBookById", mock.Anything, mock.Anything)
+}
+
+func TestPatchBook_FailedTest(t *testing.T) {
+	mockBookSvc := new(mocks.MockBookSvc)
+	userId, bookId := uuid.New(), uuid.New()
+	mockBookSvc.On("GetBookById", mock.Anything, bookId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, patchedBook(userId, bookId, uuid.New())))
+
+	body := `[{"op":"test","path":"/title","value":"Children of Dune"},{"op":"replace","path":"/title","value":"Dune Messiah"}]`
+	rec := servePatchBook(mockBookSvc, userId, bookId, params.JsonPatch, "", body)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockBookSvc.AssertNotCalled(t, "UpdateBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPatchBook_UnknownField(t *testing.T) {
+	mockBookSvc := new(mocks.MockBookSvc)
+	userId, bookId := uuid.New(), uuid.New()
+	mockBookSvc.On("GetBookById", mock.Anything, bookId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, patchedBook(userId, bookId, uuid.New())))
+
+	rec := servePatchBook(mockBookSvc, userId, bookId, params.MergePatch, "", `{"titel":"Dune Messiah"}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "titel")
+	mockBookSvc.AssertNotCalled(t, "UpdateBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPatchBook_IfMatchStaleVersion(t *testing.T) {
+	mockBookSvc := new(mocks.MockBookSvc)
+	userId, bookId := uuid.New(), uuid.New()
+	mockBookSvc.On("GetBookById", mock.Anything, bookId).
+		Return(views.SuccessResponse(http.StatusOK, views.M_OK, patchedBook(userId, bookId, uuid.New())))
+
+	rec := servePatchBook(mockBookSvc, userId, bookId, params.MergePatch, `"2-1-1-0-0"`, `{"title":"Dune Messiah"}`)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	mockBookSvc.AssertNotCalled(t, "UpdateBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,183 @@
+package catalog_controller
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/catalog"
+	"github.com/storyofhis/books-management/opds"
+)
+
+// The roots of the catalog in each version of OPDS.
+const (
+	rootV1 = "/opds"
+	rootV2 = "/opds/v2"
+)
+
+// CatalogController serves the OPDS catalog, as OPDS 2.0 under /opds/v2 and
+// as OPDS 1.2 under /opds.
+type CatalogController struct {
+	svc      service.CatalogSvc
+	validate *validator.Validate
+}
+
+func NewCatalogController(svc service.CatalogSvc) *CatalogController {
+	return &CatalogController{
+		svc:      svc,
+		validate: validator.New(),
+	}
+}
+
+func (control *CatalogController) GetRoot(ctx *gin.Context) {
+	writeFeed(ctx, control.svc.GetRoot(ctx))
+}
+
+func (control *CatalogController) GetNewest(ctx *gin.Context) {
+	var req params.CatalogPage
+	if !control.bind(ctx, &req) {
+		return
+	}
+	writeFeed(ctx, control.svc.GetNewest(ctx, &req))
+}
+
+func (control *CatalogController) GetAuthors(ctx *gin.Context) {
+	var req params.CatalogPage
+	if !control.bind(ctx, &req) {
+		return
+	}
+	writeFeed(ctx, control.svc.GetAuthors(ctx, &req))
+}
+
+func (control *CatalogController) GetAuthorBooks(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "invalid author id",
+		})
+		return
+	}
+	var req params.CatalogPage
+	if !control.bind(ctx, &req) {
+		return
+	}
+	writeFeed(ctx, control.svc.GetAuthorBooks(ctx, id, &req))
+}
+
+func (control *CatalogController) Search(ctx *gin.Context) {
+	var req params.CatalogSearch
+	if !control.bind(ctx, &req) {
+		return
+	}
+	writeFeed(ctx, control.svc.Search(ctx, &req))
+}
+
+// GetOpenSearch serves the OpenSearch description OPDS 1.2 clients search
+// the catalog with.
+func (control *CatalogController) GetOpenSearch(ctx *gin.Context) {
+	var buf bytes.Buffer
+	if err := opds.WriteOpenSearch(&buf, root(ctx), catalog.Title, "Search the books of the catalog"); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	ctx.Data(http.StatusOK, opds.TypeOpenSearch, buf.Bytes())
+}
+
+// GetAuthentication serves the authentication document of the catalog, which
+// tells readers to sign in with the username and password of their account.
+func (control *CatalogController) GetAuthentication(ctx *gin.Context) {
+	writeAuthentication(ctx, http.StatusOK)
+}
+
+// Unauthorized answers a request without valid credentials with the
+// authentication document, which readers expect to come with a 401.
+func (control *CatalogController) Unauthorized(ctx *gin.Context) {
+	ctx.Header("WWW-Authenticate", `Basic realm="`+catalog.Title+`", charset="UTF-8"`)
+	writeAuthentication(ctx, http.StatusUnauthorized)
+	ctx.Abort()
+}
+
+func writeAuthentication(ctx *gin.Context, status int) {
+	var buf bytes.Buffer
+	if err := opds.WriteAuthentication(&buf, root(ctx), catalog.Title, "Sign in with the username and password of your account"); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	ctx.Data(status, opds.TypeAuthentication, buf.Bytes())
+}
+
+func (control *CatalogController) bind(ctx *gin.Context, req interface{}) bool {
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return false
+	}
+	if err := control.validate.Struct(req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// writeFeed writes the feed of a response in the version of the route, and
+// errors as JSON.
+func writeFeed(ctx *gin.Context, res *views.Response) {
+	feed, ok := res.Payload.(*opds.Feed)
+	if !ok {
+		views.WriteJsonResponse(ctx, res)
+		return
+	}
+
+	var (
+		buf         bytes.Buffer
+		err         error
+		contentType string
+	)
+	if strings.HasPrefix(ctx.FullPath(), rootV2) {
+		contentType = opds.TypeJSON
+		err = opds.WriteJSON(&buf, root(ctx), feed)
+	} else {
+		contentType = opds.TypeAcquisition
+		if feed.Kind == opds.KindNavigation {
+			contentType = opds.TypeNavigation
+		}
+		err = opds.WriteAtom(&buf, root(ctx), feed)
+	}
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	ctx.Data(res.Status, contentType, buf.Bytes())
+}
+
+// root returns the URL of the root of the catalog the request was made to,
+// which the links of the feeds are resolved against.
+func root(ctx *gin.Context) string {
+	scheme := "http"
+	if ctx.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := ctx.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	path := rootV1
+	if strings.HasPrefix(ctx.FullPath(), rootV2) {
+		path = rootV2
+	}
+	return scheme + "://" + ctx.Request.Host + path
+}
@@ -0,0 +1,88 @@
+package circulation_controller
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/service"
+)
+
+type HoldController struct {
+	svc service.HoldSvc
+}
+
+func NewHoldController(svc service.HoldSvc) *HoldController {
+	return &HoldController{
+		svc: svc,
+	}
+}
+
+// PlaceHold places a hold on the book. The body is optional, staff send a
+// user_id to place the hold for a member.
+func (control *HoldController) PlaceHold(ctx *gin.Context) {
+	bookId, ok := bookParam(ctx)
+	if !ok {
+		return
+	}
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	var req params.PlaceHold
+	if err := ctx.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := control.svc.PlaceHold(ctx, bookId, &req, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *HoldController) GetBookHolds(ctx *gin.Context) {
+	bookId, ok := bookParam(ctx)
+	if !ok {
+		return
+	}
+
+	response := control.svc.GetBookHolds(ctx, bookId)
+	views.WriteJsonResponse(ctx, response)
+}
+
+// GetUserHolds lists the open holds of a user, "me" being the caller, with
+// the position of the waiting ones in their queue.
+func (control *HoldController) GetUserHolds(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	userId, ok := userParam(ctx, userData)
+	if !ok {
+		return
+	}
+
+	response := control.svc.GetUserHolds(ctx, userId)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *HoldController) CancelHold(ctx *gin.Context) {
+	holdId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid hold ID format",
+		})
+		return
+	}
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+
+	response := control.svc.CancelHold(ctx, holdId, userData)
+	views.WriteJsonResponse(ctx, response)
+}
@@ -0,0 +1,199 @@
+package circulation_controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/service"
+)
+
+type LoanController struct {
+	svc      service.LoanSvc
+	validate *validator.Validate
+}
+
+func NewLoanController(svc service.LoanSvc) *LoanController {
+	return &LoanController{
+		svc:      svc,
+		validate: validator.New(),
+	}
+}
+
+func (control *LoanController) Checkout(ctx *gin.Context) {
+	var req params.Checkout
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err := control.validate.Struct(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := control.svc.Checkout(ctx, &req)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *LoanController) GetLoan(ctx *gin.Context) {
+	loanId, ok := loanParam(ctx)
+	if !ok {
+		return
+	}
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+
+	response := control.svc.GetLoan(ctx, loanId, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *LoanController) ReturnLoan(ctx *gin.Context) {
+	loanId, ok := loanParam(ctx)
+	if !ok {
+		return
+	}
+
+	response := control.svc.ReturnLoan(ctx, loanId)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *LoanController) RenewLoan(ctx *gin.Context) {
+	loanId, ok := loanParam(ctx)
+	if !ok {
+		return
+	}
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+
+	response := control.svc.RenewLoan(ctx, loanId, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+// GetUserLoans lists the loans of a user, "me" being the caller. Members only
+// see their own loans.
+func (control *LoanController) GetUserLoans(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	userId, ok := userParam(ctx, userData)
+	if !ok {
+		return
+	}
+	query, ok := control.listQuery(ctx)
+	if !ok {
+		return
+	}
+
+	response := control.svc.GetUserLoans(ctx, userId, query)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *LoanController) GetBookLoans(ctx *gin.Context) {
+	bookId, ok := bookParam(ctx)
+	if !ok {
+		return
+	}
+	query, ok := control.listQuery(ctx)
+	if !ok {
+		return
+	}
+
+	response := control.svc.GetBookLoans(ctx, bookId, query)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *LoanController) GetOverdueLoans(ctx *gin.Context) {
+	query, ok := control.listQuery(ctx)
+	if !ok {
+		return
+	}
+
+	response := control.svc.GetOverdueLoans(ctx, query)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *LoanController) listQuery(ctx *gin.Context) (*params.ListLoans, bool) {
+	var req params.ListLoans
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return nil, false
+	}
+	if err := control.validate.Struct(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return nil, false
+	}
+	return &req, true
+}
+
+func loanParam(ctx *gin.Context) (uuid.UUID, bool) {
+	loanId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid loan ID format",
+		})
+		return uuid.Nil, false
+	}
+	return loanId, true
+}
+
+func bookParam(ctx *gin.Context) (uuid.UUID, bool) {
+	bookId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid book ID format",
+		})
+		return uuid.Nil, false
+	}
+	return bookId, true
+}
+
+// userParam returns the user of the path, where "me" stands for the caller.
+// Only staff may read the records of other users.
+func userParam(ctx *gin.Context, userData *common.CustomClaims) (uuid.UUID, bool) {
+	idParam := ctx.Param("id")
+	if idParam == "me" {
+		return userData.Id, true
+	}
+	userId, err := uuid.Parse(idParam)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID format",
+		})
+		return uuid.Nil, false
+	}
+	if userId != userData.Id && !userData.IsStaff() {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "You do not have permission to perform this action",
+		})
+		return uuid.Nil, false
+	}
+	return userId, true
+}
+
+func claims(ctx *gin.Context) (*common.CustomClaims, bool) {
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return nil, false
+	}
+	return claims.(*common.CustomClaims), true
+}
@@ -0,0 +1,110 @@
+package citation_controller
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/cite"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+)
+
+var contentTypes = map[string]string{
+	cite.FormatBibtex:  "application/x-bibtex; charset=utf-8",
+	cite.FormatRis:     "application/x-research-info-systems",
+	cite.FormatCslJson: "application/vnd.citationstyles.csl+json",
+}
+
+type CitationController struct {
+	svc      service.CitationSvc
+	validate *validator.Validate
+}
+
+func NewCitationController(svc service.CitationSvc) *CitationController {
+	return &CitationController{
+		svc:      svc,
+		validate: validator.New(),
+	}
+}
+
+func (control *CitationController) GetCitation(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "invalid book id",
+		})
+		return
+	}
+	var req params.Citation
+	if !control.bind(ctx, &req) {
+		return
+	}
+	writeCitations(ctx, req.Format, control.svc.GetCitation(ctx, id))
+}
+
+func (control *CitationController) GetCitations(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	var req params.Citations
+	if !control.bind(ctx, &req) {
+		return
+	}
+	writeCitations(ctx, req.Format, control.svc.GetCitations(ctx, &req, userData))
+}
+
+func (control *CitationController) bind(ctx *gin.Context, req interface{}) bool {
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return false
+	}
+	if err := control.validate.Struct(req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// writeCitations writes the books of a response as citations in format,
+// bibtex by default, and errors as JSON.
+func writeCitations(ctx *gin.Context, format string, res *views.Response) {
+	books, ok := res.Payload.([]*models.Book)
+	if !ok {
+		views.WriteJsonResponse(ctx, res)
+		return
+	}
+	if format == "" {
+		format = cite.FormatBibtex
+	}
+
+	var buf bytes.Buffer
+	if err := cite.Write(&buf, format, books); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	ctx.Data(res.Status, contentTypes[format], buf.Bytes())
+}
+
+func claims(ctx *gin.Context) (*common.CustomClaims, bool) {
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return nil, false
+	}
+	return claims.(*common.CustomClaims), true
+}
@@ -0,0 +1,122 @@
+package export_controller
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/export"
+)
+
+var contentTypes = map[string]string{
+	export.FormatCsv:     "text/csv; charset=utf-8",
+	export.FormatNdjson:  "application/x-ndjson",
+	export.FormatXlsx:    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	export.FormatMarc:    "application/marc",
+	export.FormatMarcxml: "application/marcxml+xml",
+}
+
+// extensions are the file extensions of the formats not named after theirs.
+var extensions = map[string]string{
+	export.FormatMarc:    "mrc",
+	export.FormatMarcxml: "xml",
+}
+
+type ExportController struct {
+	svc      service.ExportSvc
+	validate *validator.Validate
+}
+
+func NewExportController(svc service.ExportSvc) *ExportController {
+	return &ExportController{
+		svc:      svc,
+		validate: validator.New(),
+	}
+}
+
+func (control *ExportController) ExportBooks(ctx *gin.Context) {
+	var req params.ExportBooks
+	if !control.bind(ctx, &req) {
+		return
+	}
+	if req.Format == "" {
+		req.Format = export.FormatCsv
+	}
+
+	file := &download{ctx: ctx, name: "books", format: req.Format}
+	response := control.svc.ExportBooks(ctx, &req, file)
+	file.finish(response)
+}
+
+func (control *ExportController) ExportAuthors(ctx *gin.Context) {
+	var req params.ExportAuthors
+	if !control.bind(ctx, &req) {
+		return
+	}
+	if req.Format == "" {
+		req.Format = export.FormatCsv
+	}
+
+	file := &download{ctx: ctx, name: "authors", format: req.Format}
+	response := control.svc.ExportAuthors(ctx, &req, file)
+	file.finish(response)
+}
+
+func (control *ExportController) bind(ctx *gin.Context, req interface{}) bool {
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return false
+	}
+	if err := control.validate.Struct(req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// download is the response body of an export. The headers of the file are
+// only written with its first bytes, so that an error response can still be
+// sent as JSON until then.
+type download struct {
+	ctx     *gin.Context
+	name    string
+	format  string
+	started bool
+}
+
+func (d *download) Write(p []byte) (int, error) {
+	if !d.started {
+		d.started = true
+		extension := d.format
+		if ext, ok := extensions[d.format]; ok {
+			extension = ext
+		}
+		filename := fmt.Sprintf("%s-%s.%s", d.name, time.Now().Format("20060102"), extension)
+		d.ctx.Header("Content-Type", contentTypes[d.format])
+		d.ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		d.ctx.Status(http.StatusOK)
+	}
+	return d.ctx.Writer.Write(p)
+}
+
+// finish sends the error response of a failed export. When part of the file
+// was already sent it can only be cut short.
+func (d *download) finish(response *views.Response) {
+	if response == nil {
+		return
+	}
+	if d.started {
+		d.ctx.Abort()
+		return
+	}
+	views.WriteJsonResponse(d.ctx, response)
+}
@@ -0,0 +1,145 @@
+package importer_controller
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/config"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/service"
+)
+
+// formats are the import formats by content type and by file extension.
+var formats = map[string]string{
+	"text/csv":                "csv",
+	"application/json":        "json",
+	"application/x-ndjson":    "ndjson",
+	"application/ndjson":      "ndjson",
+	"application/marc":        "marc",
+	"application/marcxml+xml": "marcxml",
+	"application/xml":         "marcxml",
+	"text/xml":                "marcxml",
+	".csv":                    "csv",
+	".json":                   "json",
+	".ndjson":                 "ndjson",
+	".jsonl":                  "ndjson",
+	".mrc":                    "marc",
+	".marc":                   "marc",
+	".xml":                    "marcxml",
+}
+
+type ImportController struct {
+	svc      service.ImportSvc
+	validate *validator.Validate
+}
+
+func NewImportController(svc service.ImportSvc) *ImportController {
+	return &ImportController{
+		svc:      svc,
+		validate: validator.New(),
+	}
+}
+
+// ImportBooks imports the books of the uploaded file, sent as the "file" of
+// a multipart form or as the body.
+func (control *ImportController) ImportBooks(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	file, req, ok := control.upload(ctx)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	response := control.svc.ImportBooks(ctx, file, req, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *ImportController) ImportAuthors(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	file, req, ok := control.upload(ctx)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	response := control.svc.ImportAuthors(ctx, file, req, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+// upload returns the uploaded file with the import options. The format is
+// taken from the query, else from the content type or the name of the file.
+func (control *ImportController) upload(ctx *gin.Context) (io.ReadCloser, *params.Import, bool) {
+	var req params.Import
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return nil, nil, false
+	}
+	if err := control.validate.Struct(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return nil, nil, false
+	}
+	req.Mapping = ctx.QueryMap("map")
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, config.GetImportMaxBytes())
+	var file io.ReadCloser = ctx.Request.Body
+	contentType := ctx.ContentType()
+	if contentType == gin.MIMEMultipartPOSTForm {
+		upload, err := ctx.FormFile("file")
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return nil, nil, false
+		}
+		file, err = upload.Open()
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return nil, nil, false
+		}
+		contentType, _, _ = mime.ParseMediaType(upload.Header.Get("Content-Type"))
+		if req.Format == "" {
+			req.Format = formats[strings.ToLower(path.Ext(upload.Filename))]
+		}
+	}
+	if req.Format == "" {
+		req.Format = formats[contentType]
+	}
+	if req.Format == "" {
+		file.Close()
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Unknown import format, set format to csv, json, ndjson, marc or marcxml",
+		})
+		return nil, nil, false
+	}
+	return file, &req, true
+}
+
+func claims(ctx *gin.Context) (*common.CustomClaims, bool) {
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return nil, false
+	}
+	return claims.(*common.CustomClaims), true
+}
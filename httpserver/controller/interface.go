@@ -5,6 +5,11 @@ import "github.com/gin-gonic/gin"
 type UserController interface {
 	Register(ctx *gin.Context)
 	Login(ctx *gin.Context)
+	Refresh(ctx *gin.Context)
+	Logout(ctx *gin.Context)
+	LogoutAll(ctx *gin.Context)
+	GetUsers(ctx *gin.Context)
+	UpdateRole(ctx *gin.Context)
 }
 
 type AuthorController interface {
@@ -22,3 +27,7 @@ type BookController interface {
 	UpdateBook(ctx *gin.Context)
 	DeleteBook(ctx *gin.Context)
 }
+
+type SearchController interface {
+	Search(ctx *gin.Context)
+}
@@ -0,0 +1,133 @@
+package inventory_controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/service"
+)
+
+type CopyController struct {
+	svc      service.CopySvc
+	validate *validator.Validate
+}
+
+func NewCopyController(svc service.CopySvc) *CopyController {
+	return &CopyController{
+		svc:      svc,
+		validate: validator.New(),
+	}
+}
+
+func (control *CopyController) CreateCopy(ctx *gin.Context) {
+	bookId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid book ID format",
+		})
+		return
+	}
+
+	var req params.CreateCopy
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err := control.validate.Struct(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := control.svc.CreateCopy(ctx, bookId, &req)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *CopyController) GetCopies(ctx *gin.Context) {
+	bookId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid book ID format",
+		})
+		return
+	}
+
+	response := control.svc.GetCopies(ctx, bookId)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *CopyController) GetCopy(ctx *gin.Context) {
+	bookId, copyId, ok := copyIds(ctx)
+	if !ok {
+		return
+	}
+
+	response := control.svc.GetCopy(ctx, bookId, copyId)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *CopyController) UpdateCopy(ctx *gin.Context) {
+	bookId, copyId, ok := copyIds(ctx)
+	if !ok {
+		return
+	}
+
+	var req params.UpdateCopy
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err := control.validate.Struct(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := control.svc.UpdateCopy(ctx, bookId, copyId, &req)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *CopyController) DeleteCopy(ctx *gin.Context) {
+	bookId, copyId, ok := copyIds(ctx)
+	if !ok {
+		return
+	}
+
+	response := control.svc.DeleteCopy(ctx, bookId, copyId)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *CopyController) GetCopyByBarcode(ctx *gin.Context) {
+	response := control.svc.GetCopyByBarcode(ctx, ctx.Param("code"))
+	views.WriteJsonResponse(ctx, response)
+}
+
+// copyIds parses the book and copy ids of the path, it writes a 400 response
+// when one of them is malformed.
+func copyIds(ctx *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	bookId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid book ID format",
+		})
+		return uuid.Nil, uuid.Nil, false
+	}
+	copyId, err := uuid.Parse(ctx.Param("copyId"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid copy ID format",
+		})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return bookId, copyId, true
+}
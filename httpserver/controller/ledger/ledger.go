@@ -0,0 +1,151 @@
+package ledger_controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/service"
+)
+
+type LedgerController struct {
+	svc      service.LedgerSvc
+	validate *validator.Validate
+}
+
+func NewLedgerController(svc service.LedgerSvc) *LedgerController {
+	return &LedgerController{
+		svc:      svc,
+		validate: validator.New(),
+	}
+}
+
+// GetBalance returns what a user owes, "me" being the caller. Members only
+// see their own account.
+func (control *LedgerController) GetBalance(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	userId, ok := userParam(ctx, userData)
+	if !ok {
+		return
+	}
+
+	response := control.svc.GetBalance(ctx, userId)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *LedgerController) GetTransactions(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	userId, ok := userParam(ctx, userData)
+	if !ok {
+		return
+	}
+	var req params.ListTransactions
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err := control.validate.Struct(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := control.svc.GetTransactions(ctx, userId, &req)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *LedgerController) RecordPayment(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	userId, req, ok := control.credit(ctx, userData)
+	if !ok {
+		return
+	}
+
+	response := control.svc.RecordPayment(ctx, userId, req, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *LedgerController) RecordWaiver(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	userId, req, ok := control.credit(ctx, userData)
+	if !ok {
+		return
+	}
+
+	response := control.svc.RecordWaiver(ctx, userId, req, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *LedgerController) credit(ctx *gin.Context, userData *common.CustomClaims) (uuid.UUID, *params.Credit, bool) {
+	userId, ok := userParam(ctx, userData)
+	if !ok {
+		return uuid.Nil, nil, false
+	}
+	var req params.Credit
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return uuid.Nil, nil, false
+	}
+	if err := control.validate.Struct(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return uuid.Nil, nil, false
+	}
+	return userId, &req, true
+}
+
+// userParam returns the user of the path, where "me" stands for the caller.
+// Only staff may see the accounts of other users.
+func userParam(ctx *gin.Context, userData *common.CustomClaims) (uuid.UUID, bool) {
+	idParam := ctx.Param("id")
+	if idParam == "me" {
+		return userData.Id, true
+	}
+	userId, err := uuid.Parse(idParam)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID format",
+		})
+		return uuid.Nil, false
+	}
+	if userId != userData.Id && !userData.IsStaff() {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "You do not have permission to perform this action",
+		})
+		return uuid.Nil, false
+	}
+	return userId, true
+}
+
+func claims(ctx *gin.Context) (*common.CustomClaims, bool) {
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return nil, false
+	}
+	return claims.(*common.CustomClaims), true
+}
@@ -0,0 +1,68 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package controller
+
+import (
+	gin "github.com/gin-gonic/gin"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSearchController is an autogenerated mock type for the SearchController type
+type MockSearchController struct {
+	mock.Mock
+}
+
+type MockSearchController_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSearchController) EXPECT() *MockSearchController_Expecter {
+	return &MockSearchController_Expecter{mock: &_m.Mock}
+}
+
+// Search provides a mock function with given fields: ctx
+func (_m *MockSearchController) Search(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockSearchController_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockSearchController_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockSearchController_Expecter) Search(ctx interface{}) *MockSearchController_Search_Call {
+	return &MockSearchController_Search_Call{Call: _e.mock.On("Search", ctx)}
+}
+
+func (_c *MockSearchController_Search_Call) Run(run func(ctx *gin.Context)) *MockSearchController_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockSearchController_Search_Call) Return() *MockSearchController_Search_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSearchController_Search_Call) RunAndReturn(run func(*gin.Context)) *MockSearchController_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSearchController creates a new instance of MockSearchController. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSearchController(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSearchController {
+	mock := &MockSearchController{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -20,6 +20,39 @@ func (_m *MockUserController) EXPECT() *MockUserController_Expecter {
 	return &MockUserController_Expecter{mock: &_m.Mock}
 }
 
+// GetUsers provides a mock function with given fields: ctx
+func (_m *MockUserController) GetUsers(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockUserController_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
+type MockUserController_GetUsers_Call struct {
+	*mock.Call
+}
+
+// GetUsers is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockUserController_Expecter) GetUsers(ctx interface{}) *MockUserController_GetUsers_Call {
+	return &MockUserController_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx)}
+}
+
+func (_c *MockUserController_GetUsers_Call) Run(run func(ctx *gin.Context)) *MockUserController_GetUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockUserController_GetUsers_Call) Return() *MockUserController_GetUsers_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockUserController_GetUsers_Call) RunAndReturn(run func(*gin.Context)) *MockUserController_GetUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Login provides a mock function with given fields: ctx
 func (_m *MockUserController) Login(ctx *gin.Context) {
 	_m.Called(ctx)
@@ -53,6 +86,105 @@ func (_c *MockUserController_Login_Call) RunAndReturn(run func(*gin.Context)) *M
 	return _c
 }
 
+// Logout provides a mock function with given fields: ctx
+func (_m *MockUserController) Logout(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockUserController_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockUserController_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockUserController_Expecter) Logout(ctx interface{}) *MockUserController_Logout_Call {
+	return &MockUserController_Logout_Call{Call: _e.mock.On("Logout", ctx)}
+}
+
+func (_c *MockUserController_Logout_Call) Run(run func(ctx *gin.Context)) *MockUserController_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockUserController_Logout_Call) Return() *MockUserController_Logout_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockUserController_Logout_Call) RunAndReturn(run func(*gin.Context)) *MockUserController_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogoutAll provides a mock function with given fields: ctx
+func (_m *MockUserController) LogoutAll(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockUserController_LogoutAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogoutAll'
+type MockUserController_LogoutAll_Call struct {
+	*mock.Call
+}
+
+// LogoutAll is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockUserController_Expecter) LogoutAll(ctx interface{}) *MockUserController_LogoutAll_Call {
+	return &MockUserController_LogoutAll_Call{Call: _e.mock.On("LogoutAll", ctx)}
+}
+
+func (_c *MockUserController_LogoutAll_Call) Run(run func(ctx *gin.Context)) *MockUserController_LogoutAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockUserController_LogoutAll_Call) Return() *MockUserController_LogoutAll_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockUserController_LogoutAll_Call) RunAndReturn(run func(*gin.Context)) *MockUserController_LogoutAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with given fields: ctx
+func (_m *MockUserController) Refresh(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockUserController_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type MockUserController_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockUserController_Expecter) Refresh(ctx interface{}) *MockUserController_Refresh_Call {
+	return &MockUserController_Refresh_Call{Call: _e.mock.On("Refresh", ctx)}
+}
+
+func (_c *MockUserController_Refresh_Call) Run(run func(ctx *gin.Context)) *MockUserController_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockUserController_Refresh_Call) Return() *MockUserController_Refresh_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockUserController_Refresh_Call) RunAndReturn(run func(*gin.Context)) *MockUserController_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Register provides a mock function with given fields: ctx
 func (_m *MockUserController) Register(ctx *gin.Context) {
 	_m.Called(ctx)
@@ -86,6 +218,39 @@ func (_c *MockUserController_Register_Call) RunAndReturn(run func(*gin.Context))
 	return _c
 }
 
+// UpdateRole provides a mock function with given fields: ctx
+func (_m *MockUserController) UpdateRole(ctx *gin.Context) {
+	_m.Called(ctx)
+}
+
+// MockUserController_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type MockUserController_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - ctx *gin.Context
+func (_e *MockUserController_Expecter) UpdateRole(ctx interface{}) *MockUserController_UpdateRole_Call {
+	return &MockUserController_UpdateRole_Call{Call: _e.mock.On("UpdateRole", ctx)}
+}
+
+func (_c *MockUserController_UpdateRole_Call) Run(run func(ctx *gin.Context)) *MockUserController_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gin.Context))
+	})
+	return _c
+}
+
+func (_c *MockUserController_UpdateRole_Call) Return() *MockUserController_UpdateRole_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockUserController_UpdateRole_Call) RunAndReturn(run func(*gin.Context)) *MockUserController_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockUserController creates a new instance of MockUserController. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUserController(t interface {
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
 	"github.com/storyofhis/books-management/httpserver/controller/params"
 	"github.com/storyofhis/books-management/httpserver/controller/views"
 	"github.com/stretchr/testify/mock"
@@ -14,8 +15,8 @@ type MockAuthorSvc struct {
 }
 
 // DeleteAuthor implements service.AuthorSvc.
-func (m *MockAuthorSvc) DeleteAuthor(ctx context.Context, id uuid.UUID) *views.Response {
-	args := m.Called(ctx, id)
+func (m *MockAuthorSvc) DeleteAuthor(ctx context.Context, id uuid.UUID, query *params.DeleteAuthor, version int, user *common.CustomClaims) *views.Response {
+	args := m.Called(ctx, id, query, version, user)
 	return args.Get(0).(*views.Response)
 }
 
@@ -26,14 +27,14 @@ func (m *MockAuthorSvc) GetAuthorById(ctx context.Context, id uuid.UUID) *views.
 }
 
 // GetAuthors implements service.AuthorSvc.
-func (m *MockAuthorSvc) GetAuthors(ctx context.Context) *views.Response {
-	args := m.Called(ctx)
+func (m *MockAuthorSvc) GetAuthors(ctx context.Context, query *params.ListAuthors) *views.Response {
+	args := m.Called(ctx, query)
 	return args.Get(0).(*views.Response)
 }
 
 // UpdateAuthor implements service.AuthorSvc.
-func (m *MockAuthorSvc) UpdateAuthor(ctx context.Context, author *params.UpdateAuthors, id uuid.UUID) *views.Response {
-	args := m.Called(ctx, author, id)
+func (m *MockAuthorSvc) UpdateAuthor(ctx context.Context, author *params.UpdateAuthors, id uuid.UUID, version int, actorId uuid.UUID) *views.Response {
+	args := m.Called(ctx, author, id, version, actorId)
 	return args.Get(0).(*views.Response)
 }
 
@@ -41,3 +42,21 @@ func (m *MockAuthorSvc) CreateAuthor(ctx context.Context, authorParams *params.C
 	args := m.Called(ctx, authorParams, userId)
 	return args.Get(0).(*views.Response)
 }
+
+// RestoreAuthor implements service.AuthorSvc.
+func (m *MockAuthorSvc) RestoreAuthor(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	args := m.Called(ctx, id, user)
+	return args.Get(0).(*views.Response)
+}
+
+// GetAuthorHistory implements service.AuthorSvc.
+func (m *MockAuthorSvc) GetAuthorHistory(ctx context.Context, id uuid.UUID) *views.Response {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*views.Response)
+}
+
+// RevertAuthor implements service.AuthorSvc.
+func (m *MockAuthorSvc) RevertAuthor(ctx context.Context, id uuid.UUID, revert *params.Revert, version int, actorId uuid.UUID) *views.Response {
+	args := m.Called(ctx, id, revert, version, actorId)
+	return args.Get(0).(*views.Response)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
 	"github.com/storyofhis/books-management/httpserver/controller/params"
 	"github.com/storyofhis/books-management/httpserver/controller/views"
 	"github.com/stretchr/testify/mock"
@@ -19,8 +20,8 @@ func (m *MockBookSvc) CreateBook(ctx context.Context, bookParams *params.CreateB
 	return args.Get(0).(*views.Response)
 }
 
-func (m *MockBookSvc) GetBooks(ctx context.Context) *views.Response {
-	args := m.Called(ctx)
+func (m *MockBookSvc) GetBooks(ctx context.Context, query *params.ListBooks) *views.Response {
+	args := m.Called(ctx, query)
 	return args.Get(0).(*views.Response)
 }
 
@@ -29,12 +30,27 @@ func (m *MockBookSvc) GetBookById(ctx context.Context, id uuid.UUID) *views.Resp
 	return args.Get(0).(*views.Response)
 }
 
-func (m *MockBookSvc) UpdateBook(ctx context.Context, bookParams *params.UpdateBook, id uuid.UUID) *views.Response {
-	args := m.Called(ctx, bookParams, id)
+func (m *MockBookSvc) UpdateBook(ctx context.Context, bookParams *params.UpdateBook, id uuid.UUID, version int, actorId uuid.UUID) *views.Response {
+	args := m.Called(ctx, bookParams, id, version, actorId)
 	return args.Get(0).(*views.Response)
 }
 
-func (m *MockBookSvc) DeleteBook(ctx context.Context, id uuid.UUID) *views.Response {
+func (m *MockBookSvc) DeleteBook(ctx context.Context, id uuid.UUID, version int, actorId uuid.UUID) *views.Response {
+	args := m.Called(ctx, id, version, actorId)
+	return args.Get(0).(*views.Response)
+}
+
+func (m *MockBookSvc) RestoreBook(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	args := m.Called(ctx, id, user)
+	return args.Get(0).(*views.Response)
+}
+
+func (m *MockBookSvc) GetBookHistory(ctx context.Context, id uuid.UUID) *views.Response {
 	args := m.Called(ctx, id)
 	return args.Get(0).(*views.Response)
 }
+
+func (m *MockBookSvc) RevertBook(ctx context.Context, id uuid.UUID, revert *params.Revert, version int, actorId uuid.UUID) *views.Response {
+	args := m.Called(ctx, id, revert, version, actorId)
+	return args.Get(0).(*views.Response)
+}
@@ -3,6 +3,8 @@ package mocks
 import (
 	"context"
 
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
 	"github.com/storyofhis/books-management/httpserver/controller/params"
 	"github.com/storyofhis/books-management/httpserver/controller/views"
 	"github.com/stretchr/testify/mock"
@@ -23,3 +25,46 @@ func (m *MockUserSvc) Login(ctx context.Context, user *params.Login) *views.Resp
 	args := m.Called(ctx, user)
 	return args.Get(0).(*views.Response)
 }
+
+// Refresh mocks the Refresh function of the UserSvc
+func (m *MockUserSvc) Refresh(ctx context.Context, token *params.Refresh) *views.Response {
+	args := m.Called(ctx, token)
+	return args.Get(0).(*views.Response)
+}
+
+// Logout mocks the Logout function of the UserSvc
+func (m *MockUserSvc) Logout(ctx context.Context, sessionId uuid.UUID) *views.Response {
+	args := m.Called(ctx, sessionId)
+	return args.Get(0).(*views.Response)
+}
+
+// LogoutAll mocks the LogoutAll function of the UserSvc
+func (m *MockUserSvc) LogoutAll(ctx context.Context, userId uuid.UUID) *views.Response {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(*views.Response)
+}
+
+// VerifySession mocks the VerifySession function of the UserSvc
+func (m *MockUserSvc) VerifySession(ctx context.Context, claims *common.CustomClaims) error {
+	args := m.Called(ctx, claims)
+	return args.Error(0)
+}
+
+// Authenticate mocks the Authenticate function of the UserSvc
+func (m *MockUserSvc) Authenticate(ctx context.Context, username, password string) (*common.CustomClaims, error) {
+	args := m.Called(ctx, username, password)
+	claims, _ := args.Get(0).(*common.CustomClaims)
+	return claims, args.Error(1)
+}
+
+// GetUsers mocks the GetUsers function of the UserSvc
+func (m *MockUserSvc) GetUsers(ctx context.Context) *views.Response {
+	args := m.Called(ctx)
+	return args.Get(0).(*views.Response)
+}
+
+// UpdateRole mocks the UpdateRole function of the UserSvc
+func (m *MockUserSvc) UpdateRole(ctx context.Context, role *params.UpdateRole, id uuid.UUID) *views.Response {
+	args := m.Called(ctx, role, id)
+	return args.Get(0).(*views.Response)
+}
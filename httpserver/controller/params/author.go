@@ -12,3 +12,21 @@ type UpdateAuthors struct {
 	Birthdate time.Time `json:"birthdate" validate:"required"`
 	UpdateAt  time.Time `json:"updated_at"`
 }
+
+type ListAuthors struct {
+	Page       int       `form:"page" validate:"omitempty,min=1"`
+	PageSize   int       `form:"page_size" validate:"omitempty,min=1,max=100"`
+	Cursor     string    `form:"cursor"`
+	Sort       string    `form:"sort"`
+	NamePrefix string    `form:"name_prefix"`
+	Name       string    `form:"name"`
+	BornFrom   time.Time `form:"born_from"`
+	BornTo     time.Time `form:"born_to"`
+}
+
+// DeleteAuthor selects what happens to the books crediting the author:
+// refuse (the default), cascade or reassign to ReassignTo.
+type DeleteAuthor struct {
+	Policy     string `form:"policy" validate:"omitempty,oneof=refuse cascade reassign"`
+	ReassignTo string `form:"reassign_to" validate:"required_if=Policy reassign,omitempty,uuid"`
+}
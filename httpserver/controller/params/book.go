@@ -1,15 +1,40 @@
 package params
 
-import "github.com/google/uuid"
+import (
+	"time"
 
-type CreateBook struct {
-	Title    string    `json:"title" validate:"required"`
-	Isbn     string    `json:"isbn" validate:"required"`
+	"github.com/google/uuid"
+)
+
+// Contributor is an author of a book in a given role, author when empty.
+// Contributors are ordered as they are listed.
+type Contributor struct {
 	AuthorId uuid.UUID `json:"author_id" validate:"required"`
+	Role     string    `json:"role" validate:"omitempty,oneof=author editor translator illustrator"`
+}
+
+type CreateBook struct {
+	Title        string        `json:"title" validate:"required"`
+	Isbn         string        `json:"isbn" validate:"required,isbn"`
+	Contributors []Contributor `json:"contributors" validate:"required,min=1,dive"`
 }
 
 type UpdateBook struct {
-	Title    string    `json:"title" validate:"required"`
-	Isbn     string    `json:"isbn" validate:"required"`
-	AuthorId uuid.UUID `json:"author_id" validate:"required"`
+	Title        string        `json:"title" validate:"required"`
+	Isbn         string        `json:"isbn" validate:"required,isbn"`
+	Contributors []Contributor `json:"contributors" validate:"required,min=1,dive"`
+}
+
+type ListBooks struct {
+	Page        int       `form:"page" validate:"omitempty,min=1"`
+	PageSize    int       `form:"page_size" validate:"omitempty,min=1,max=100"`
+	Cursor      string    `form:"cursor"`
+	Sort        string    `form:"sort"`
+	AuthorId    string    `form:"author_id" validate:"omitempty,uuid"`
+	Role        string    `form:"role" validate:"omitempty,oneof=author editor translator illustrator"`
+	UserId      string    `form:"user_id" validate:"omitempty,uuid"`
+	Title       string    `form:"title"`
+	Isbn        string    `form:"isbn"`
+	CreatedFrom time.Time `form:"created_from"`
+	CreatedTo   time.Time `form:"created_to"`
 }
@@ -0,0 +1,13 @@
+package params
+
+// CatalogPage selects a page of an OPDS feed.
+type CatalogPage struct {
+	Page     int `form:"page" validate:"omitempty,min=1"`
+	PageSize int `form:"page_size" validate:"omitempty,min=1,max=100"`
+}
+
+type CatalogSearch struct {
+	Q        string `form:"q" validate:"required"`
+	Page     int    `form:"page" validate:"omitempty,min=1"`
+	PageSize int    `form:"page_size" validate:"omitempty,min=1,max=100"`
+}
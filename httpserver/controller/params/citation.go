@@ -0,0 +1,14 @@
+package params
+
+// Citation picks the format of a citation. Format defaults to bibtex.
+type Citation struct {
+	Format string `form:"format" validate:"omitempty,oneof=bibtex ris csl-json"`
+}
+
+// Citations cites either a comma separated list of book ids, in order, or
+// the books of a shelf. Format defaults to bibtex.
+type Citations struct {
+	Format  string `form:"format" validate:"omitempty,oneof=bibtex ris csl-json"`
+	Ids     string `form:"ids" validate:"required_without=ShelfId,excluded_with=ShelfId"`
+	ShelfId string `form:"shelf_id" validate:"omitempty,uuid"`
+}
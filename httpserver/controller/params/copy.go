@@ -0,0 +1,21 @@
+package params
+
+import "time"
+
+type CreateCopy struct {
+	Barcode    string     `json:"barcode" validate:"required,printascii,max=64"`
+	Location   string     `json:"location" validate:"max=255"`
+	Condition  string     `json:"condition" validate:"omitempty,oneof=new good fair poor damaged"`
+	AcquiredAt *time.Time `json:"acquired_at"`
+}
+
+// UpdateCopy replaces every field of a copy, an empty location or acquisition
+// date clears it. The statuses on_loan and on_hold are managed by loans and
+// holds and cannot be set or cleared.
+type UpdateCopy struct {
+	Barcode    string     `json:"barcode" validate:"required,printascii,max=64"`
+	Location   string     `json:"location" validate:"max=255"`
+	Condition  string     `json:"condition" validate:"required,oneof=new good fair poor damaged"`
+	Status     string     `json:"status" validate:"required,oneof=available on_loan on_hold in_repair lost withdrawn"`
+	AcquiredAt *time.Time `json:"acquired_at"`
+}
@@ -0,0 +1,28 @@
+package params
+
+import "time"
+
+// ExportBooks selects the books of an export with the filters of ListBooks.
+// Format defaults to csv.
+type ExportBooks struct {
+	Format      string    `form:"format" validate:"omitempty,oneof=csv ndjson xlsx marc marcxml"`
+	Sort        string    `form:"sort"`
+	AuthorId    string    `form:"author_id" validate:"omitempty,uuid"`
+	Role        string    `form:"role" validate:"omitempty,oneof=author editor translator illustrator"`
+	UserId      string    `form:"user_id" validate:"omitempty,uuid"`
+	Title       string    `form:"title"`
+	Isbn        string    `form:"isbn"`
+	CreatedFrom time.Time `form:"created_from"`
+	CreatedTo   time.Time `form:"created_to"`
+}
+
+// ExportAuthors selects the authors of an export with the filters of
+// ListAuthors. Format defaults to csv.
+type ExportAuthors struct {
+	Format     string    `form:"format" validate:"omitempty,oneof=csv ndjson xlsx"`
+	Sort       string    `form:"sort"`
+	NamePrefix string    `form:"name_prefix"`
+	Name       string    `form:"name"`
+	BornFrom   time.Time `form:"born_from"`
+	BornTo     time.Time `form:"born_to"`
+}
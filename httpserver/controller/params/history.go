@@ -0,0 +1,6 @@
+package params
+
+// Revert selects the history revision a record is reverted to.
+type Revert struct {
+	Revision int `json:"revision" validate:"required,min=1"`
+}
@@ -0,0 +1,9 @@
+package params
+
+import "github.com/google/uuid"
+
+// PlaceHold queues a user for a copy of a book. UserId defaults to the
+// caller, only staff may place holds for other users.
+type PlaceHold struct {
+	UserId *uuid.UUID `json:"user_id"`
+}
@@ -0,0 +1,11 @@
+package params
+
+// Import selects how an uploaded file is read. Format defaults to the
+// content type of the upload, and Mapping maps the fields of the import to
+// the columns or keys of the file that hold them, for files that do not use
+// the field names.
+type Import struct {
+	Format  string            `form:"format" validate:"omitempty,oneof=csv json ndjson marc marcxml"`
+	DryRun  bool              `form:"dry_run"`
+	Mapping map[string]string `form:"-"`
+}
@@ -0,0 +1,14 @@
+package params
+
+// Credit records a payment or waiver, in minor units.
+type Credit struct {
+	Amount int64  `json:"amount" validate:"required,min=1"`
+	Note   string `json:"note" validate:"max=500"`
+}
+
+type ListTransactions struct {
+	Page     int    `form:"page" validate:"omitempty,min=1"`
+	PageSize int    `form:"page_size" validate:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+	Sort     string `form:"sort"`
+}
@@ -0,0 +1,23 @@
+package params
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Checkout lends the copy with the given barcode to a user. The due date
+// defaults to the loan period from now.
+type Checkout struct {
+	Barcode string     `json:"barcode" validate:"required"`
+	UserId  uuid.UUID  `json:"user_id" validate:"required"`
+	DueAt   *time.Time `json:"due_at"`
+}
+
+type ListLoans struct {
+	Page     int    `form:"page" validate:"omitempty,min=1"`
+	PageSize int    `form:"page_size" validate:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+	Sort     string `form:"sort"`
+	Status   string `form:"status" validate:"omitempty,oneof=current past"`
+}
@@ -0,0 +1,48 @@
+package params
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/storyofhis/books-management/jsonpatch"
+)
+
+const (
+	MergePatch = "application/merge-patch+json"
+	JsonPatch  = "application/json-patch+json"
+	// AcceptPatch lists the patch formats accepted by the PATCH endpoints.
+	AcceptPatch = MergePatch + ", " + JsonPatch
+)
+
+var ErrUnsupportedPatch = errors.New("unsupported patch format, use " + AcceptPatch)
+
+// Patch applies patch to the JSON encoding of current and decodes the result
+// into dst. contentType selects a merge patch or a json patch. Fields unknown
+// to dst are rejected so that misspelled fields are not silently dropped.
+func Patch(contentType string, current interface{}, patch []byte, dst interface{}) error {
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	switch contentType {
+	case MergePatch:
+		doc, err = jsonpatch.MergePatch(doc, patch)
+	case JsonPatch:
+		doc, err = jsonpatch.Apply(doc, patch)
+	default:
+		return ErrUnsupportedPatch
+	}
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("%w: %v", jsonpatch.ErrInvalidPatch, err)
+	}
+	return nil
+}
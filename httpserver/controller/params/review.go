@@ -0,0 +1,21 @@
+package params
+
+type Review struct {
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Body   string `json:"body" validate:"max=5000"`
+}
+
+type ModerateReview struct {
+	Status string `json:"status" validate:"required,oneof=visible flagged hidden"`
+	Note   string `json:"note" validate:"max=500"`
+}
+
+// ListReviews lists the reviews of a book. Only admins see hidden reviews
+// and can filter by status.
+type ListReviews struct {
+	Page     int    `form:"page" validate:"omitempty,min=1"`
+	PageSize int    `form:"page_size" validate:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+	Sort     string `form:"sort"`
+	Status   string `form:"status" validate:"omitempty,oneof=visible flagged hidden"`
+}
@@ -0,0 +1,8 @@
+package params
+
+type Search struct {
+	Q        string `form:"q" validate:"required"`
+	Type     string `form:"type" validate:"omitempty,oneof=books authors"`
+	Page     int    `form:"page" validate:"omitempty,min=1"`
+	PageSize int    `form:"page_size" validate:"omitempty,min=1,max=100"`
+}
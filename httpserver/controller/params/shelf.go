@@ -0,0 +1,32 @@
+package params
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Shelf creates or renames a shelf. Making a shelf public gives it a share
+// link, making it private again revokes the link.
+type Shelf struct {
+	Name   string `json:"name" validate:"required,max=100"`
+	Public bool   `json:"public"`
+}
+
+// AddShelfItem puts a book on a shelf, at the end unless Position is set.
+type AddShelfItem struct {
+	BookId     uuid.UUID  `json:"book_id" validate:"required"`
+	Position   int        `json:"position" validate:"min=0"`
+	Note       string     `json:"note" validate:"max=1000"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// UpdateShelfItem replaces the note and the dates of a book on a shelf and
+// moves it to Position, a Position of 0 leaving it where it is.
+type UpdateShelfItem struct {
+	Position   int        `json:"position" validate:"min=0"`
+	Note       string     `json:"note" validate:"max=1000"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
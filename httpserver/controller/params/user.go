@@ -9,3 +9,11 @@ type Login struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required,min=6"`
 }
+
+type Refresh struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type UpdateRole struct {
+	Role string `json:"role" validate:"required,oneof=admin librarian member read-only"`
+}
@@ -0,0 +1,174 @@
+package review_controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/service"
+)
+
+type ReviewController struct {
+	svc      service.ReviewSvc
+	validate *validator.Validate
+}
+
+func NewReviewController(svc service.ReviewSvc) *ReviewController {
+	return &ReviewController{
+		svc:      svc,
+		validate: validator.New(),
+	}
+}
+
+func (control *ReviewController) CreateReview(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	bookId, ok := idParam(ctx, "id", "Invalid book ID format")
+	if !ok {
+		return
+	}
+	var req params.Review
+	if !control.bind(ctx, &req) {
+		return
+	}
+
+	response := control.svc.CreateReview(ctx, bookId, &req, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+// GetReviews lists the reviews of the book. Hidden reviews are only listed
+// for admins.
+func (control *ReviewController) GetReviews(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	bookId, ok := idParam(ctx, "id", "Invalid book ID format")
+	if !ok {
+		return
+	}
+	var req params.ListReviews
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err := control.validate.Struct(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := control.svc.GetReviews(ctx, bookId, &req, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *ReviewController) UpdateReview(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	bookId, id, ok := reviewParams(ctx)
+	if !ok {
+		return
+	}
+	var req params.Review
+	if !control.bind(ctx, &req) {
+		return
+	}
+
+	response := control.svc.UpdateReview(ctx, bookId, id, &req, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *ReviewController) DeleteReview(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	bookId, id, ok := reviewParams(ctx)
+	if !ok {
+		return
+	}
+
+	response := control.svc.DeleteReview(ctx, bookId, id, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+// ModerateReview flags, hides or restores a review. Hidden reviews do not
+// count towards the rating of the book.
+func (control *ReviewController) ModerateReview(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	bookId, id, ok := reviewParams(ctx)
+	if !ok {
+		return
+	}
+	var req params.ModerateReview
+	if !control.bind(ctx, &req) {
+		return
+	}
+
+	response := control.svc.ModerateReview(ctx, bookId, id, &req, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *ReviewController) bind(ctx *gin.Context, req interface{}) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return false
+	}
+	if err := control.validate.Struct(req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+func reviewParams(ctx *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	bookId, ok := idParam(ctx, "id", "Invalid book ID format")
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, ok := idParam(ctx, "reviewId", "Invalid review ID format")
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	return bookId, id, true
+}
+
+func idParam(ctx *gin.Context, name, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(name))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": message,
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func claims(ctx *gin.Context) (*common.CustomClaims, bool) {
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return nil, false
+	}
+	return claims.(*common.CustomClaims), true
+}
@@ -0,0 +1,41 @@
+package search_controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/service"
+)
+
+type SearchController struct {
+	svc service.SearchSvc
+}
+
+func NewSearchController(svc service.SearchSvc) *SearchController {
+	return &SearchController{
+		svc: svc,
+	}
+}
+
+func (control *SearchController) Search(ctx *gin.Context) {
+	var req params.Search
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	err := validator.New().Struct(&req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	response := control.svc.Search(ctx, &req)
+	views.WriteJsonResponse(ctx, response)
+}
@@ -0,0 +1,216 @@
+package shelf_controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/service"
+)
+
+type ShelfController struct {
+	svc      service.ShelfSvc
+	validate *validator.Validate
+}
+
+func NewShelfController(svc service.ShelfSvc) *ShelfController {
+	return &ShelfController{
+		svc:      svc,
+		validate: validator.New(),
+	}
+}
+
+// GetShelves lists the shelves of a user, "me" being the caller. The shelves
+// of other users are only listed when they are public.
+func (control *ShelfController) GetShelves(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	userId := userData.Id
+	if idParam := ctx.Param("id"); idParam != "me" {
+		var err error
+		userId, err = uuid.Parse(idParam)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid user ID format",
+			})
+			return
+		}
+	}
+
+	response := control.svc.GetShelves(ctx, userId, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *ShelfController) CreateShelf(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	var req params.Shelf
+	if !control.bind(ctx, &req) {
+		return
+	}
+
+	response := control.svc.CreateShelf(ctx, &req, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *ShelfController) GetShelf(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	id, ok := idParam(ctx, "id", "Invalid shelf ID format")
+	if !ok {
+		return
+	}
+
+	response := control.svc.GetShelf(ctx, id, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+// GetSharedShelf returns a public shelf by its share link, without login.
+func (control *ShelfController) GetSharedShelf(ctx *gin.Context) {
+	response := control.svc.GetSharedShelf(ctx, ctx.Param("token"))
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *ShelfController) UpdateShelf(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	id, ok := idParam(ctx, "id", "Invalid shelf ID format")
+	if !ok {
+		return
+	}
+	var req params.Shelf
+	if !control.bind(ctx, &req) {
+		return
+	}
+
+	response := control.svc.UpdateShelf(ctx, id, &req, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *ShelfController) DeleteShelf(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	id, ok := idParam(ctx, "id", "Invalid shelf ID format")
+	if !ok {
+		return
+	}
+
+	response := control.svc.DeleteShelf(ctx, id, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *ShelfController) AddShelfItem(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	id, ok := idParam(ctx, "id", "Invalid shelf ID format")
+	if !ok {
+		return
+	}
+	var req params.AddShelfItem
+	if !control.bind(ctx, &req) {
+		return
+	}
+
+	response := control.svc.AddShelfItem(ctx, id, &req, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+// UpdateShelfItem replaces the note and the dates of a book on the shelf and
+// moves it when a position is given.
+func (control *ShelfController) UpdateShelfItem(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	id, bookId, ok := itemParams(ctx)
+	if !ok {
+		return
+	}
+	var req params.UpdateShelfItem
+	if !control.bind(ctx, &req) {
+		return
+	}
+
+	response := control.svc.UpdateShelfItem(ctx, id, bookId, &req, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *ShelfController) RemoveShelfItem(ctx *gin.Context) {
+	userData, ok := claims(ctx)
+	if !ok {
+		return
+	}
+	id, bookId, ok := itemParams(ctx)
+	if !ok {
+		return
+	}
+
+	response := control.svc.RemoveShelfItem(ctx, id, bookId, userData)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *ShelfController) bind(ctx *gin.Context, req interface{}) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return false
+	}
+	if err := control.validate.Struct(req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+func itemParams(ctx *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, ok := idParam(ctx, "id", "Invalid shelf ID format")
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	bookId, ok := idParam(ctx, "bookId", "Invalid book ID format")
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, bookId, true
+}
+
+func idParam(ctx *gin.Context, name, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(name))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": message,
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func claims(ctx *gin.Context) (*common.CustomClaims, bool) {
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return nil, false
+	}
+	return claims.(*common.CustomClaims), true
+}
@@ -0,0 +1,34 @@
+package trash_controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/service"
+)
+
+type TrashController struct {
+	svc service.TrashSvc
+}
+
+func NewTrashController(svc service.TrashSvc) *TrashController {
+	return &TrashController{
+		svc: svc,
+	}
+}
+
+func (control *TrashController) GetTrash(ctx *gin.Context) {
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return
+	}
+
+	userData := claims.(*common.CustomClaims)
+	response := control.svc.GetTrash(ctx, userData.Id)
+	views.WriteJsonResponse(ctx, response)
+}
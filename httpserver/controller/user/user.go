@@ -5,6 +5,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
 	"github.com/storyofhis/books-management/httpserver/controller/params"
 	"github.com/storyofhis/books-management/httpserver/controller/views"
 	"github.com/storyofhis/books-management/httpserver/service"
@@ -60,3 +62,85 @@ func (control *UserController) Login(ctx *gin.Context) {
 	response := control.svc.Login(ctx, &req)
 	views.WriteJsonResponse(ctx, response)
 }
+
+func (control *UserController) Refresh(ctx *gin.Context) {
+	var req params.Refresh
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	err = validator.New().Struct(req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	response := control.svc.Refresh(ctx, &req)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *UserController) Logout(ctx *gin.Context) {
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return
+	}
+
+	userData := claims.(*common.CustomClaims)
+	response := control.svc.Logout(ctx, userData.SessionId)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *UserController) LogoutAll(ctx *gin.Context) {
+	claims, exists := ctx.Get("userData")
+	if !exists {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Token doesn't exist",
+		})
+		return
+	}
+
+	userData := claims.(*common.CustomClaims)
+	response := control.svc.LogoutAll(ctx, userData.Id)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *UserController) GetUsers(ctx *gin.Context) {
+	response := control.svc.GetUsers(ctx)
+	views.WriteJsonResponse(ctx, response)
+}
+
+func (control *UserController) UpdateRole(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	userId, err := uuid.Parse(idParam)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID format",
+		})
+		return
+	}
+
+	var req params.UpdateRole
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	err = validator.New().Struct(req)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	response := control.svc.UpdateRole(ctx, &req, userId)
+	views.WriteJsonResponse(ctx, response)
+}
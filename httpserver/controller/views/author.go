@@ -12,6 +12,7 @@ type UpdateAuthor struct {
 	Name      string    `json:"name"`
 	Birthdate time.Time `json:"birthdate"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Version   int       `json:"version"`
 }
 
 type CreateAuthor struct {
@@ -29,4 +30,13 @@ type Author struct {
 	Birthdate time.Time `json:"birthdate"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Version   int       `json:"version"`
+}
+
+// AuthorBook is a book listed when an author cannot be deleted.
+type AuthorBook struct {
+	Id     uuid.UUID `json:"id"`
+	UserId uuid.UUID `json:"user_id"`
+	Title  string    `json:"title"`
+	Isbn   string    `json:"isbn"`
 }
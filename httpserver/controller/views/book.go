@@ -9,27 +9,41 @@ import (
 type CreateBook struct {
 	Id        uuid.UUID `json:"id"`
 	UserId    uuid.UUID `json:"user_id"`
-	AuthorId  uuid.UUID `json:"author_id"`
 	Title     string    `json:"title"`
 	Isbn      string    `json:"isbn"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
 type UpdateBook struct {
-	Id        uuid.UUID `json:"id"`
-	UserId    uuid.UUID `json:"user_id"`
-	AuthorId  uuid.UUID `json:"author_id"`
-	Title     string    `json:"title"`
-	Isbn      string    `json:"isbn"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Id           uuid.UUID     `json:"id"`
+	UserId       uuid.UUID     `json:"user_id"`
+	Title        string        `json:"title"`
+	Isbn         string        `json:"isbn"`
+	IsbnDisplay  string        `json:"isbn_display"`
+	Contributors []Contributor `json:"contributors"`
+	Availability Availability  `json:"availability"`
+	Rating       Rating        `json:"rating"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	Version      int           `json:"version"`
 }
 
 type Book struct {
-	Id        uuid.UUID `json:"id"`
-	UserId    uuid.UUID `json:"user_id"`
-	AuthorId  uuid.UUID `json:"author_id"`
-	Title     string    `json:"title"`
-	Isbn      string    `json:"isbn"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Id           uuid.UUID     `json:"id"`
+	UserId       uuid.UUID     `json:"user_id"`
+	Title        string        `json:"title"`
+	Isbn         string        `json:"isbn"`
+	IsbnDisplay  string        `json:"isbn_display"`
+	Contributors []Contributor `json:"contributors"`
+	Availability Availability  `json:"availability"`
+	Rating       Rating        `json:"rating"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	Version      int           `json:"version"`
+}
+
+type Contributor struct {
+	AuthorId uuid.UUID `json:"author_id"`
+	Name     string    `json:"name"`
+	Role     string    `json:"role"`
+	Position int       `json:"position"`
 }
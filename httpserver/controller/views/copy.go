@@ -0,0 +1,34 @@
+package views
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Copy struct {
+	Id         uuid.UUID  `json:"id"`
+	BookId     uuid.UUID  `json:"book_id"`
+	Barcode    string     `json:"barcode"`
+	Location   string     `json:"location"`
+	Condition  string     `json:"condition"`
+	Status     string     `json:"status"`
+	AcquiredAt *time.Time `json:"acquired_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	// Book is only set when a copy is looked up by barcode.
+	Book *CopyBook `json:"book,omitempty"`
+}
+
+type CopyBook struct {
+	Id          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Isbn        string    `json:"isbn"`
+	IsbnDisplay string    `json:"isbn_display"`
+}
+
+// Availability counts the copies of a book and those that can be lent.
+type Availability struct {
+	Total     int64 `json:"total"`
+	Available int64 `json:"available"`
+}
@@ -0,0 +1,81 @@
+package views
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errVersionMismatch = errors.New("the record was changed since it was read, fetch it again")
+
+// ETag returns the entity tag of a record at version. state lists values
+// shown with the record that change without its version changing, such as
+// the availability of a book.
+func ETag(version int, state ...int64) string {
+	tag := strconv.Itoa(version)
+	for _, v := range state {
+		tag += "-" + strconv.FormatInt(v, 10)
+	}
+	return `"` + tag + `"`
+}
+
+// SetETag sets the ETag header of the response to the tag of a record at
+// version with state.
+func SetETag(ctx *gin.Context, version int, state ...int64) {
+	ctx.Header("ETag", ETag(version, state...))
+}
+
+// NotModified reports whether the If-None-Match header of the request matches
+// the tag of a record at version with state, in which case it writes a 304
+// response.
+func NotModified(ctx *gin.Context, version int, state ...int64) bool {
+	header := ctx.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == ETag(version, state...) {
+			SetETag(ctx, version, state...)
+			ctx.AbortWithStatus(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// IfMatch checks the If-Match header of the request against the current
+// version of a record. The state of a tag is ignored, as it changes without
+// anyone editing the record. It returns the version the client expects, 0
+// when the header is missing or "*", and false after writing a 412 response
+// when none of the tags match.
+func IfMatch(ctx *gin.Context, version int) (int, bool) {
+	header := ctx.GetHeader("If-Match")
+	if header == "" {
+		return 0, true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return 0, true
+		}
+		if tagVersion(tag) == ETag(version) {
+			return version, true
+		}
+	}
+	SetETag(ctx, version)
+	WriteJsonResponse(ctx, ErrorReponse(http.StatusPreconditionFailed, M_PRECONDITION_FAILED, errVersionMismatch))
+	ctx.Abort()
+	return 0, false
+}
+
+// tagVersion returns tag without its state, the tag of its version.
+func tagVersion(tag string) string {
+	if i := strings.IndexByte(tag, '-'); i >= 0 {
+		return tag[:i] + `"`
+	}
+	return tag
+}
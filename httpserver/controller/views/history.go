@@ -0,0 +1,26 @@
+package views
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HistoryEntry is a change of a record. Revisions count the changes of the
+// record, they are not its version: deleting and restoring a record add a
+// revision without changing the version of its ETag.
+type HistoryEntry struct {
+	Revision  int             `json:"revision"`
+	Action    string          `json:"action"`
+	ActorId   uuid.UUID       `json:"actor_id"`
+	Changes   []FieldChange   `json:"changes"`
+	Snapshot  json.RawMessage `json:"snapshot"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type FieldChange struct {
+	Field string          `json:"field"`
+	From  json.RawMessage `json:"from"`
+	To    json.RawMessage `json:"to"`
+}
@@ -0,0 +1,22 @@
+package views
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Hold struct {
+	Id     uuid.UUID `json:"id"`
+	BookId uuid.UUID `json:"book_id"`
+	Title  string    `json:"title"`
+	UserId uuid.UUID `json:"user_id"`
+	Status string    `json:"status"`
+	// Position is the place of a waiting hold in the queue, from 1.
+	Position  int        `json:"position,omitempty"`
+	CopyId    *uuid.UUID `json:"copy_id,omitempty"`
+	Barcode   string     `json:"barcode,omitempty"`
+	ReadyAt   *time.Time `json:"ready_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
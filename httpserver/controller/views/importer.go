@@ -0,0 +1,26 @@
+package views
+
+import "github.com/google/uuid"
+
+// ImportReport is the outcome of an import, row by row. Nothing is written
+// by a dry run or when a row is invalid.
+type ImportReport struct {
+	DryRun     bool `json:"dry_run"`
+	Total      int  `json:"total"`
+	Created    int  `json:"created"`
+	Duplicates int  `json:"duplicates"`
+	Invalid    int  `json:"invalid"`
+	// NewAuthors are the names of the authors created for the books, or
+	// that would be by a dry run.
+	NewAuthors []string    `json:"new_authors,omitempty"`
+	Rows       []ImportRow `json:"rows"`
+}
+
+type ImportRow struct {
+	// Row is the number of the record in the file, from 1, not counting
+	// the CSV header.
+	Row    int        `json:"row"`
+	Status string     `json:"status"`
+	Id     *uuid.UUID `json:"id,omitempty"`
+	Errors []string   `json:"errors,omitempty"`
+}
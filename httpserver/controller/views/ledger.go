@@ -0,0 +1,27 @@
+package views
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Balance is what a user owes, in minor units. Blocked users cannot borrow
+// until their balance is back to Limit.
+type Balance struct {
+	UserId  uuid.UUID `json:"user_id"`
+	Balance int64     `json:"balance"`
+	Limit   int64     `json:"limit"`
+	Blocked bool      `json:"blocked"`
+}
+
+type Transaction struct {
+	Id        uuid.UUID  `json:"id"`
+	Kind      string     `json:"kind"`
+	Amount    int64      `json:"amount"`
+	LoanId    *uuid.UUID `json:"loan_id,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,25 @@
+package views
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Loan struct {
+	Id         uuid.UUID  `json:"id"`
+	CopyId     uuid.UUID  `json:"copy_id"`
+	Barcode    string     `json:"barcode"`
+	BookId     uuid.UUID  `json:"book_id"`
+	Title      string     `json:"title"`
+	UserId     uuid.UUID  `json:"user_id"`
+	BorrowedAt time.Time  `json:"borrowed_at"`
+	DueAt      time.Time  `json:"due_at"`
+	ReturnedAt *time.Time `json:"returned_at"`
+	Renewals   int        `json:"renewals"`
+	Overdue    bool       `json:"overdue"`
+	// Fine is the late fee of the loan so far, in minor units.
+	Fine int64 `json:"fine"`
+	// HoldId is the hold the copy was assigned to when it was returned.
+	HoldId *uuid.UUID `json:"hold_id,omitempty"`
+}
@@ -0,0 +1,30 @@
+package views
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Rating aggregates the reviews of a book that are not hidden, the average
+// being rounded to two decimals.
+type Rating struct {
+	Average float64 `json:"average"`
+	Count   int64   `json:"count"`
+}
+
+type Review struct {
+	Id       uuid.UUID `json:"id"`
+	BookId   uuid.UUID `json:"book_id"`
+	UserId   uuid.UUID `json:"user_id"`
+	Username string    `json:"username,omitempty"`
+	Rating   int       `json:"rating"`
+	Body     string    `json:"body"`
+	Status   string    `json:"status"`
+	// The moderation fields are only shown to admins.
+	ModeratedBy    *uuid.UUID `json:"moderated_by,omitempty"`
+	ModerationNote string     `json:"moderation_note,omitempty"`
+	ModeratedAt    *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
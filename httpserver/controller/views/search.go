@@ -0,0 +1,22 @@
+package views
+
+import "github.com/google/uuid"
+
+// SearchBook is a book search hit. Highlighted fields wrap the matched terms
+// in <mark> tags.
+type SearchBook struct {
+	Id             uuid.UUID `json:"id"`
+	Title          string    `json:"title"`
+	Isbn           string    `json:"isbn"`
+	Authors        string    `json:"authors"`
+	TitleHighlight string    `json:"title_highlight"`
+	Snippet        string    `json:"snippet"`
+	Rank           float64   `json:"rank"`
+}
+
+type SearchAuthor struct {
+	Id            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	NameHighlight string    `json:"name_highlight"`
+	Rank          float64   `json:"rank"`
+}
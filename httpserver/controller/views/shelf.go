@@ -0,0 +1,33 @@
+package views
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Shelf struct {
+	Id     uuid.UUID `json:"id"`
+	UserId uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+	Kind   string    `json:"kind"`
+	Public bool      `json:"public"`
+	// SharePath is the link to the public shelf, it is only shown to the
+	// owner.
+	SharePath string      `json:"share_path,omitempty"`
+	ItemCount int64       `json:"item_count"`
+	Items     []ShelfItem `json:"items,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+type ShelfItem struct {
+	BookId     uuid.UUID  `json:"book_id"`
+	Title      string     `json:"title"`
+	Isbn       string     `json:"isbn,omitempty"`
+	Position   int        `json:"position"`
+	Note       string     `json:"note,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	AddedAt    time.Time  `json:"added_at"`
+}
@@ -0,0 +1,29 @@
+package views
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Trash struct {
+	Books   []TrashBook   `json:"books"`
+	Authors []TrashAuthor `json:"authors"`
+}
+
+type TrashBook struct {
+	Id          uuid.UUID  `json:"id"`
+	Title       string     `json:"title"`
+	Isbn        string     `json:"isbn"`
+	IsbnDisplay string     `json:"isbn_display"`
+	DeletedAt   time.Time  `json:"deleted_at"`
+	PurgeAt     *time.Time `json:"purge_at,omitempty"`
+}
+
+type TrashAuthor struct {
+	Id        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	Birthdate time.Time  `json:"birthdate"`
+	DeletedAt time.Time  `json:"deleted_at"`
+	PurgeAt   *time.Time `json:"purge_at,omitempty"`
+}
@@ -10,12 +10,24 @@ type Register struct {
 	Id        uuid.UUID `json:"id"`
 	Username  string    `json:"username"`
 	Password  string    `json:"password"`
+	Role      string    `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type Login struct {
-	Id       uuid.UUID `json:"id"`
-	Username string    `json:"username"`
-	Token    string    `json:"token"`
+	Id           uuid.UUID `json:"id"`
+	Username     string    `json:"username"`
+	Role         string    `json:"role"`
+	Token        string    `json:"token"`
+	ExpiresIn    int       `json:"expires_in"`
+	RefreshToken string    `json:"refresh_token"`
+}
+
+type User struct {
+	Id        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -1,6 +1,8 @@
 package views
 
 import (
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -8,9 +10,20 @@ type Response struct {
 	Status  int         `json:"status"`
 	Message string      `json:"message"`
 	Payload interface{} `json:"payload,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
 	Error   interface{} `json:"error,omitempty"`
 }
 
+type Pagination struct {
+	Total      int64  `json:"total"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+}
+
 const (
 	M_BAD_REQUEST                 = "BAD_REQUEST"
 	M_INVALID_CREDENTIALS         = "INVALID_CREDENTIALS"
@@ -20,6 +33,50 @@ const (
 	M_INTERNAL_SERVER_ERROR       = "INTERNAL_SERVER_ERROR"
 	M_AUTHOR_SUCCESSFULLY_DELETED = "AUTHOR_SUCCESSFULLY_DELETED"
 	M_AUTHOR_NOT_FOUND            = "AUTHOR_NOT_FOUND"
+	M_UNAUTHORIZED                = "UNAUTHORIZED"
+	M_INVALID_REFRESH_TOKEN       = "INVALID_REFRESH_TOKEN"
+	M_REFRESH_TOKEN_REUSED        = "REFRESH_TOKEN_REUSED"
+	M_LOGGED_OUT                  = "LOGGED_OUT"
+	M_FORBIDDEN                   = "FORBIDDEN"
+	M_USER_NOT_FOUND              = "USER_NOT_FOUND"
+	M_INVALID_QUERY               = "INVALID_QUERY"
+	M_INVALID_ISBN                = "INVALID_ISBN"
+	M_DUPLICATE_ISBN              = "DUPLICATE_ISBN"
+	M_UNKNOWN_AUTHOR              = "UNKNOWN_AUTHOR"
+	M_AUTHOR_HAS_BOOKS            = "AUTHOR_HAS_BOOKS"
+	M_NOT_IN_TRASH                = "NOT_IN_TRASH"
+	M_AUTHOR_DELETED              = "AUTHOR_DELETED"
+	M_BOOK_NOT_FOUND              = "BOOK_NOT_FOUND"
+	M_VERSION_NOT_FOUND           = "VERSION_NOT_FOUND"
+	M_PRECONDITION_FAILED         = "PRECONDITION_FAILED"
+	M_COPY_NOT_FOUND              = "COPY_NOT_FOUND"
+	M_DUPLICATE_BARCODE           = "DUPLICATE_BARCODE"
+	M_COPY_IN_CIRCULATION         = "COPY_IN_CIRCULATION"
+	M_BOOK_IN_CIRCULATION         = "BOOK_IN_CIRCULATION"
+	M_COPY_HAS_LOANS              = "COPY_HAS_LOANS"
+	M_COPY_NOT_AVAILABLE          = "COPY_NOT_AVAILABLE"
+	M_LOAN_NOT_FOUND              = "LOAN_NOT_FOUND"
+	M_LOAN_RETURNED               = "LOAN_RETURNED"
+	M_LOAN_CHANGED                = "LOAN_CHANGED"
+	M_RENEWAL_LIMIT               = "RENEWAL_LIMIT"
+	M_LOAN_OVERDUE                = "LOAN_OVERDUE"
+	M_INVALID_DUE_DATE            = "INVALID_DUE_DATE"
+	M_COPY_ON_HOLD                = "COPY_ON_HOLD"
+	M_HOLDS_WAITING               = "HOLDS_WAITING"
+	M_HOLD_NOT_FOUND              = "HOLD_NOT_FOUND"
+	M_DUPLICATE_HOLD              = "DUPLICATE_HOLD"
+	M_HOLD_CLOSED                 = "HOLD_CLOSED"
+	M_BALANCE_LIMIT               = "BALANCE_LIMIT"
+	M_AMOUNT_EXCEEDS_BALANCE      = "AMOUNT_EXCEEDS_BALANCE"
+	M_REVIEW_NOT_FOUND            = "REVIEW_NOT_FOUND"
+	M_DUPLICATE_REVIEW            = "DUPLICATE_REVIEW"
+	M_SHELF_NOT_FOUND             = "SHELF_NOT_FOUND"
+	M_DUPLICATE_SHELF             = "DUPLICATE_SHELF"
+	M_BUILT_IN_SHELF              = "BUILT_IN_SHELF"
+	M_SHELF_ITEM_NOT_FOUND        = "SHELF_ITEM_NOT_FOUND"
+	M_DUPLICATE_SHELF_ITEM        = "DUPLICATE_SHELF_ITEM"
+	M_INVALID_DATES               = "INVALID_DATES"
+	M_INVALID_IMPORT              = "INVALID_IMPORT"
 )
 
 func SuccessResponse(status int, message string, payload interface{}) *Response {
@@ -30,6 +87,15 @@ func SuccessResponse(status int, message string, payload interface{}) *Response
 	}
 }
 
+func PagedResponse(status int, message string, payload interface{}, meta *Pagination) *Response {
+	return &Response{
+		Status:  status,
+		Message: message,
+		Payload: payload,
+		Meta:    meta,
+	}
+}
+
 func ErrorReponse(status int, message string, error error) *Response {
 	return &Response{
 		Status:  status,
@@ -39,5 +105,38 @@ func ErrorReponse(status int, message string, error error) *Response {
 }
 
 func WriteJsonResponse(ctx *gin.Context, res *Response) {
+	if meta, ok := res.Meta.(*Pagination); ok {
+		setPageLinks(ctx, meta)
+	}
 	ctx.JSON(res.Status, res)
 }
+
+// setPageLinks fills the next and prev links from the request URL, keeping
+// every other query parameter.
+func setPageLinks(ctx *gin.Context, meta *Pagination) {
+	link := func(set func(q map[string][]string)) string {
+		u := *ctx.Request.URL
+		q := u.Query()
+		q.Del("page")
+		q.Del("cursor")
+		set(q)
+		u.RawQuery = q.Encode()
+		return u.RequestURI()
+	}
+
+	if meta.Page > 0 {
+		if int64(meta.Page*meta.PageSize) < meta.Total {
+			meta.Next = link(func(q map[string][]string) { q["page"] = []string{strconv.Itoa(meta.Page + 1)} })
+		}
+		if meta.Page > 1 {
+			meta.Prev = link(func(q map[string][]string) { q["page"] = []string{strconv.Itoa(meta.Page - 1)} })
+		}
+		return
+	}
+	if meta.NextCursor != "" {
+		meta.Next = link(func(q map[string][]string) { q["cursor"] = []string{meta.NextCursor} })
+	}
+	if meta.PrevCursor != "" {
+		meta.Prev = link(func(q map[string][]string) { q["cursor"] = []string{meta.PrevCursor} })
+	}
+}
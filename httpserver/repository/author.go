@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+)
+
+var (
+	ErrUnknownAuthor = errors.New("unknown author")
+	ErrAuthorDeleted = errors.New("an author of the book is in the trash")
+)
+
+// AuthorDeletePolicy decides what happens to the books crediting an author
+// when the author is deleted.
+type AuthorDeletePolicy string
+
+const (
+	// DeleteRefuse keeps the author when books still credit it.
+	DeleteRefuse AuthorDeletePolicy = "refuse"
+	// DeleteCascade deletes the books crediting the author along with it.
+	// Restoring the author restores them too. Only books the author is the
+	// sole contributor of, the caller manages and without a copy on loan or
+	// on hold are deleted; any other book keeps the author, as with
+	// DeleteRefuse.
+	DeleteCascade AuthorDeletePolicy = "cascade"
+	// DeleteReassign credits the books to another author.
+	DeleteReassign AuthorDeletePolicy = "reassign"
+)
+
+type AuthorDelete struct {
+	Policy AuthorDeletePolicy
+	// ReassignTo is the author taking over the credits with DeleteReassign.
+	ReassignTo uuid.UUID
+	// Version is the version of the author read by the caller, the delete
+	// fails with ErrVersionConflict when it changed since.
+	Version int
+	// OwnerId limits DeleteCascade to the books of that user. uuid.Nil, for
+	// admins, deletes the books of any user.
+	OwnerId uuid.UUID
+}
+
+// BookChange is a book changed along with an author, with its contributors
+// before and after the change.
+type BookChange struct {
+	Before *models.Book
+	After  *models.Book
+}
+
+// AuthorHasBooksError is returned by DeleteRefuse when books still credit
+// the author, and by DeleteCascade when some of them cannot be deleted.
+type AuthorHasBooksError struct {
+	Books []*models.Book
+}
+
+func (e *AuthorHasBooksError) Error() string {
+	return fmt.Sprintf("author is credited on %d book(s)", len(e.Books))
+}
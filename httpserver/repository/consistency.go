@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+)
+
+// RepairMode selects what RepairConsistency does with books left without
+// any contributor.
+type RepairMode int
+
+const (
+	// RepairPlaceholder credits orphaned books to a placeholder author.
+	RepairPlaceholder RepairMode = iota
+	// RepairDelete deletes orphaned books.
+	RepairDelete
+)
+
+// PlaceholderAuthor is the name of the author orphaned books are credited to
+// by RepairPlaceholder.
+const PlaceholderAuthor = "Unknown author"
+
+// ConsistencyReport lists the rows referencing records that do not exist.
+type ConsistencyReport struct {
+	// DanglingContributors reference a missing book or author.
+	DanglingContributors []*models.BookContributor
+	// OrphanedBooks have no contributor with an existing author.
+	OrphanedBooks []*models.Book
+	// UnownedBooks and UnownedAuthors reference a missing user. They are
+	// reported but never repaired.
+	UnownedBooks   []*models.Book
+	UnownedAuthors []*models.Author
+}
+
+func (r *ConsistencyReport) Empty() bool {
+	return len(r.DanglingContributors) == 0 && len(r.OrphanedBooks) == 0 &&
+		len(r.UnownedBooks) == 0 && len(r.UnownedAuthors) == 0
+}
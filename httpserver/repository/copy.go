@@ -0,0 +1,12 @@
+package repository
+
+import "errors"
+
+var ErrDuplicateBarcode = errors.New("a copy with this barcode already exists")
+
+// CopyCount is the number of copies of a book and how many of them can be
+// lent. Withdrawn copies are not counted.
+type CopyCount struct {
+	Total     int64
+	Available int64
+}
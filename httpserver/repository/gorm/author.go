@@ -2,12 +2,14 @@ package gorm
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/storyofhis/books-management/httpserver/repository"
 	"github.com/storyofhis/books-management/httpserver/repository/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type authorRepo struct {
@@ -21,34 +23,282 @@ func NewAuthorRepo(db *gorm.DB) repository.AuthorRepo {
 // CreateAuthor implements repository.AuthorRepo.
 func (repo *authorRepo) CreateAuthor(ctx context.Context, author *models.Author) error {
 	author.Id = uuid.New()
+	author.NameKey = nameKey(author.Name)
 	author.CreatedAt = time.Now()
-	return repo.db.WithContext(ctx).Create(author).Error
+	author.Version = 1
+	return conn(ctx, repo.db).Create(author).Error
 }
 
-// DeleteAuthor implements repository.AuthorRepo.
-func (repo *authorRepo) DeleteAuthor(ctx context.Context, id uuid.UUID) error {
-	return repo.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Author{}).Error
+// DeleteAuthor implements repository.AuthorRepo. The author is moved to the
+// trash; with repository.DeleteCascade its books are moved along with it at
+// the same instant, which is how RestoreAuthor finds them again. Books
+// crediting other contributors too, owned by another user than opts.OwnerId
+// or with a copy on loan or on hold block a cascade. Every book the delete
+// changes gets a new version.
+func (repo *authorRepo) DeleteAuthor(ctx context.Context, id uuid.UUID, opts *repository.AuthorDelete) ([]*repository.BookChange, error) {
+	var changes []*repository.BookChange
+	err := conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		var credited []uuid.UUID
+		err := tx.Model(&models.Book{}).
+			Where("id IN (SELECT book_id FROM book_contributors WHERE author_id = ?)", id).
+			Pluck("id", &credited).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		res := tx.Model(&models.Author{}).Where("id = ? AND version = ?", id, opts.Version).UpdateColumn("deleted_at", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return repository.ErrVersionConflict
+		}
+
+		switch opts.Policy {
+		case repository.DeleteCascade:
+			if len(credited) == 0 {
+				break
+			}
+			blocked := tx.Where("id IN (SELECT book_id FROM book_contributors WHERE author_id <> ?)", id).
+				Or("id IN (SELECT book_id FROM copies WHERE status IN ?)", circulatingCopies)
+			if opts.OwnerId != uuid.Nil {
+				blocked = blocked.Or("user_id <> ?", opts.OwnerId)
+			}
+			var books []*models.Book
+			if err := tx.Where("id IN ?", credited).Where(blocked).Order("title").Find(&books).Error; err != nil {
+				return err
+			}
+			if len(books) > 0 {
+				return &repository.AuthorHasBooksError{Books: books}
+			}
+			err := tx.Model(&models.Book{}).Where("id IN ?", credited).
+				UpdateColumns(map[string]interface{}{"deleted_at": now, "version": gorm.Expr("version + 1")}).Error
+			if err != nil {
+				return err
+			}
+			deleted, err := findBooks(tx, credited)
+			if err != nil {
+				return err
+			}
+			for _, book := range deleted {
+				changes = append(changes, &repository.BookChange{Before: book, After: book})
+			}
+		case repository.DeleteReassign:
+			var ids []uuid.UUID
+			err := tx.Unscoped().Model(&models.Book{}).
+				Where("id IN (SELECT book_id FROM book_contributors WHERE author_id = ?)", id).
+				Pluck("id", &ids).Error
+			if err != nil {
+				return err
+			}
+			before, err := findBooks(tx, ids)
+			if err != nil {
+				return err
+			}
+			if err := reassignCredits(tx, id, opts.ReassignTo); err != nil {
+				return err
+			}
+			after, err := findBooks(tx, ids)
+			if err != nil {
+				return err
+			}
+			for i := range after {
+				changes = append(changes, &repository.BookChange{Before: before[i], After: after[i]})
+			}
+		default:
+			if len(credited) == 0 {
+				break
+			}
+			var books []*models.Book
+			if err := tx.Where("id IN ?", credited).Order("title").Find(&books).Error; err != nil {
+				return err
+			}
+			return &repository.AuthorHasBooksError{Books: books}
+		}
+		return nil
+	})
+	return changes, err
+}
+
+// GetDeletedAuthorById implements repository.AuthorRepo.
+func (repo *authorRepo) GetDeletedAuthorById(ctx context.Context, id uuid.UUID) (*models.Author, error) {
+	author := new(models.Author)
+	return author, conn(ctx, repo.db).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Take(author).Error
+}
+
+// RestoreAuthor implements repository.AuthorRepo. Books deleted together
+// with the author are restored as well, with a new version.
+func (repo *authorRepo) RestoreAuthor(ctx context.Context, id uuid.UUID) ([]*models.Book, error) {
+	var restored []*models.Book
+	err := conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		author := new(models.Author)
+		err := tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Take(author).Error
+		if err != nil {
+			return err
+		}
+
+		var books []*models.Book
+		err = tx.Unscoped().
+			Where("deleted_at = ? AND id IN (SELECT book_id FROM book_contributors WHERE author_id = ?)", author.DeletedAt, id).
+			Find(&books).Error
+		if err != nil {
+			return err
+		}
+		ids := make([]uuid.UUID, 0, len(books))
+		for _, book := range books {
+			ids = append(ids, book.Id)
+		}
+
+		err = tx.Unscoped().Model(&models.Author{}).Where("id = ?", id).UpdateColumn("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		err = tx.Unscoped().Model(&models.Book{}).Where("id IN ?", ids).
+			UpdateColumns(map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")}).Error
+		if err != nil {
+			return isbnError(err)
+		}
+		restored, err = findBooks(tx, ids)
+		return err
+	})
+	return restored, err
+}
+
+// findBooks returns the books with ids, in the trash or not, with their
+// contributors, ordered by id.
+func findBooks(tx *gorm.DB, ids []uuid.UUID) ([]*models.Book, error) {
+	var books []*models.Book
+	if len(ids) == 0 {
+		return books, nil
+	}
+	if err := tx.Unscoped().Where("id IN ?", ids).Order("id").Find(&books).Error; err != nil {
+		return nil, err
+	}
+	return books, loadContributors(tx, books)
+}
+
+// reassignCredits moves the contributions of an author to another one,
+// keeping their role and position, and gives the books a new version.
+// Credits the new author already has in the same role are kept as they are.
+func reassignCredits(tx *gorm.DB, from, to uuid.UUID) error {
+	var count int64
+	if err := tx.Model(&models.Author{}).Where("id = ?", to).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return repository.ErrUnknownAuthor
+	}
+
+	var credits []models.BookContributor
+	if err := tx.Where("author_id = ?", from).Find(&credits).Error; err != nil {
+		return err
+	}
+	if len(credits) == 0 {
+		return nil
+	}
+	if err := tx.Where("author_id = ?", from).Delete(&models.BookContributor{}).Error; err != nil {
+		return err
+	}
+	books := make([]uuid.UUID, 0, len(credits))
+	for i := range credits {
+		credits[i].AuthorId = to
+		books = append(books, credits[i].BookId)
+	}
+	if err := tx.Omit("Author").Clauses(clause.OnConflict{DoNothing: true}).Create(&credits).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Model(&models.Book{}).Where("id IN ?", books).
+		UpdateColumns(map[string]interface{}{"version": gorm.Expr("version + 1"), "updated_at": time.Now()}).Error
 }
 
 // GetAuthorById implements repository.AuthorRepo.
 func (repo *authorRepo) GetAuthorById(ctx context.Context, id uuid.UUID) (*models.Author, error) {
 	author := new(models.Author)
-	return author, repo.db.WithContext(ctx).Where("id = ?", id).Take(author).Error
+	return author, conn(ctx, repo.db).Where("id = ?", id).Take(author).Error
 }
 
-// GetAuthors implements repository.AuthorRepo.
-func (repo *authorRepo) GetAuthors(ctx context.Context) ([]*models.Author, error) {
+// GetAuthorsByIds implements repository.AuthorRepo.
+func (repo *authorRepo) GetAuthorsByIds(ctx context.Context, ids []uuid.UUID) ([]*models.Author, error) {
 	var authors []*models.Author
+	if len(ids) == 0 {
+		return authors, nil
+	}
+	return authors, conn(ctx, repo.db).Where("id IN ?", ids).Find(&authors).Error
+}
 
-	err := repo.db.WithContext(ctx).Find(&authors).Error
-	if err != nil {
-		return nil, err
+// GetAuthorsByNames implements repository.AuthorRepo.
+func (repo *authorRepo) GetAuthorsByNames(ctx context.Context, names []string) ([]*models.Author, error) {
+	var authors []*models.Author
+	if len(names) == 0 {
+		return authors, nil
+	}
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, nameKey(name))
 	}
-	return authors, nil
+	return authors, conn(ctx, repo.db).Where("name_key IN ?", keys).Find(&authors).Error
+}
+
+// nameKey returns the key authors are matched by name with, see
+// models.Author.NameKey.
+func nameKey(name string) string {
+	return strings.ToLower(name)
+}
+
+var authorSortFields = map[string]sortField[models.Author]{
+	"id":         {column: "authors.id", value: func(a *models.Author) interface{} { return a.Id }},
+	"user_id":    {column: "authors.user_id", value: func(a *models.Author) interface{} { return a.UserId }},
+	"name":       {column: "authors.name", value: func(a *models.Author) interface{} { return a.Name }},
+	"birthdate":  {column: "authors.birthdate", value: func(a *models.Author) interface{} { return a.Birthdate }},
+	"created_at": {column: "authors.created_at", value: func(a *models.Author) interface{} { return a.CreatedAt }},
+	"updated_at": {column: "authors.updated_at", value: func(a *models.Author) interface{} { return a.UpdatedAt }},
+}
+
+// GetAuthors implements repository.AuthorRepo.
+func (repo *authorRepo) GetAuthors(ctx context.Context, filter *repository.AuthorFilter, page *repository.Page) ([]*models.Author, *repository.PageInfo, error) {
+	db := filterAuthors(conn(ctx, repo.db).Model(&models.Author{}), filter)
+	return findPage(db, page, authorSortFields, "authors.id", func(a *models.Author) uuid.UUID { return a.Id }, "name")
+}
+
+// EachAuthor implements repository.AuthorRepo.
+func (repo *authorRepo) EachAuthor(ctx context.Context, filter *repository.AuthorFilter, sort string, fn func([]*models.Author) error) error {
+	db := filterAuthors(conn(ctx, repo.db).Model(&models.Author{}), filter)
+	return eachBatch(db, sort, authorSortFields, "authors.id", func(a *models.Author) uuid.UUID { return a.Id }, "name", fn)
+}
+
+// filterAuthors restricts db to the authors matching filter.
+func filterAuthors(db *gorm.DB, filter *repository.AuthorFilter) *gorm.DB {
+	if filter.NamePrefix != "" {
+		db = db.Where(`LOWER(authors.name) LIKE ? ESCAPE '\'`, strings.TrimPrefix(likePattern(filter.NamePrefix), "%"))
+	}
+	if filter.Name != "" {
+		db = db.Where(`LOWER(authors.name) LIKE ? ESCAPE '\'`, likePattern(filter.Name))
+	}
+	if !filter.BornFrom.IsZero() {
+		db = db.Where("authors.birthdate >= ?", filter.BornFrom)
+	}
+	if !filter.BornTo.IsZero() {
+		db = db.Where("authors.birthdate <= ?", filter.BornTo)
+	}
+	return db
 }
 
 // UpdateAuthor implements repository.AuthorRepo.
 func (repo *authorRepo) UpdateAuthor(ctx context.Context, author *models.Author, id uuid.UUID) error {
 	author.UpdatedAt = time.Now()
-	return repo.db.WithContext(ctx).Model(author).Where("id = ?", id).Updates(author).Error
+	author.NameKey = nameKey(author.Name)
+	version := author.Version
+	author.Version++
+	res := conn(ctx, repo.db).Model(author).Select("Name", "NameKey", "Birthdate", "Version", "UpdatedAt").Where("id = ? AND version = ?", id, version).Updates(author)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrVersionConflict
+	}
+	return nil
 }
@@ -2,6 +2,7 @@ package gorm
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,33 +25,255 @@ func NewBookRepo(db *gorm.DB) repository.BookRepo {
 func (repo *bookRepo) CreateBook(ctx context.Context, book *models.Book) error {
 	book.Id = uuid.New()
 	book.CreatedAt = time.Now()
-	return repo.db.WithContext(ctx).Create(book).Error
+	book.Version = 1
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Contributors").Create(book).Error; err != nil {
+			return isbnError(err)
+		}
+		if err := saveContributors(tx, book.Id, book.Contributors); err != nil {
+			return err
+		}
+		return loadContributors(tx, []*models.Book{book})
+	})
 }
 
-// DeleteBook implements repository.BookRepo.
-func (repo *bookRepo) DeleteBook(ctx context.Context, id uuid.UUID) error {
-	return repo.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Book{}).Error
+// GetBooksByIds implements repository.BookRepo.
+func (repo *bookRepo) GetBooksByIds(ctx context.Context, ids []uuid.UUID) ([]*models.Book, error) {
+	var found []*models.Book
+	if len(ids) == 0 {
+		return found, nil
+	}
+	if err := conn(ctx, repo.db).Where("id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, err
+	}
+	byId := make(map[uuid.UUID]*models.Book, len(found))
+	for _, b := range found {
+		byId[b.Id] = b
+	}
+	books := make([]*models.Book, 0, len(found))
+	for _, id := range ids {
+		if b, ok := byId[id]; ok {
+			books = append(books, b)
+		}
+	}
+	return books, loadContributors(conn(ctx, repo.db), books)
+}
+
+// GetBooksByIsbns implements repository.BookRepo.
+func (repo *bookRepo) GetBooksByIsbns(ctx context.Context, isbns []string) ([]*models.Book, error) {
+	var books []*models.Book
+	if len(isbns) == 0 {
+		return books, nil
+	}
+	return books, conn(ctx, repo.db).Where("isbn IN ?", isbns).Find(&books).Error
+}
+
+// DeleteBook implements repository.BookRepo. The book is moved to the trash
+// and keeps its contributors until it is purged.
+func (repo *bookRepo) DeleteBook(ctx context.Context, id uuid.UUID, version int) error {
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND version = ?", id, version).
+			Where("NOT EXISTS (SELECT 1 FROM copies WHERE copies.book_id = books.id AND copies.status IN ?)", circulatingCopies).
+			Delete(&models.Book{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			return nil
+		}
+		var out int64
+		err := tx.Model(&models.Copy{}).Where("book_id = ? AND status IN ?", id, circulatingCopies).Count(&out).Error
+		if err != nil {
+			return err
+		}
+		if out > 0 {
+			return repository.ErrBookInCirculation
+		}
+		return repository.ErrVersionConflict
+	})
+}
+
+// circulatingCopies are the statuses of the copies lent or kept for a
+// member, whose book cannot be moved to the trash.
+var circulatingCopies = []string{models.CopyOnLoan, models.CopyOnHold}
+
+// GetDeletedBookById implements repository.BookRepo.
+func (repo *bookRepo) GetDeletedBookById(ctx context.Context, id uuid.UUID) (*models.Book, error) {
+	book := new(models.Book)
+	err := conn(ctx, repo.db).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Take(book).Error
+	if err != nil {
+		return book, err
+	}
+	return book, loadContributors(conn(ctx, repo.db), []*models.Book{book})
+}
+
+// RestoreBook implements repository.BookRepo. A book cannot be restored
+// while one of its authors is in the trash or another book took its isbn.
+func (repo *bookRepo) RestoreBook(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		book := new(models.Book)
+		err := tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Take(book).Error
+		if err != nil {
+			return err
+		}
+		var deleted int64
+		err = tx.Model(&models.BookContributor{}).
+			Joins("JOIN authors ON authors.id = book_contributors.author_id").
+			Where("book_contributors.book_id = ? AND authors.deleted_at IS NOT NULL", id).
+			Count(&deleted).Error
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			return repository.ErrAuthorDeleted
+		}
+
+		err = tx.Unscoped().Model(&models.Book{}).Where("id = ?", id).UpdateColumn("deleted_at", nil).Error
+		return isbnError(err)
+	})
 }
 
 // GetBookById implements repository.BookRepo.
 func (repo *bookRepo) GetBookById(ctx context.Context, id uuid.UUID) (*models.Book, error) {
 	book := new(models.Book)
-	return book, repo.db.WithContext(ctx).Where("id = ?", id).Take(book).Error
+	err := conn(ctx, repo.db).Where("id = ?", id).Take(book).Error
+	if err != nil {
+		return book, err
+	}
+	return book, loadContributors(conn(ctx, repo.db), []*models.Book{book})
 }
 
-// GetBooks implements repository.BookRepo.
-func (repo *bookRepo) GetBooks(ctx context.Context) ([]*models.Book, error) {
-	var books []*models.Book
+var bookSortFields = map[string]sortField[models.Book]{
+	"id":           {column: "books.id", value: func(b *models.Book) interface{} { return b.Id }},
+	"user_id":      {column: "books.user_id", value: func(b *models.Book) interface{} { return b.UserId }},
+	"title":        {column: "books.title", value: func(b *models.Book) interface{} { return b.Title }},
+	"isbn":         {column: "books.isbn", value: func(b *models.Book) interface{} { return b.Isbn }},
+	"created_at":   {column: "books.created_at", value: func(b *models.Book) interface{} { return b.CreatedAt }},
+	"updated_at":   {column: "books.updated_at", value: func(b *models.Book) interface{} { return b.UpdatedAt }},
+	"rating":       {column: "books.rating_avg", value: func(b *models.Book) interface{} { return b.RatingAvg }},
+	"rating_count": {column: "books.rating_count", value: func(b *models.Book) interface{} { return b.RatingCount }},
+}
 
-	err := repo.db.WithContext(ctx).Find(&books).Error
+// GetBooks implements repository.BookRepo.
+func (repo *bookRepo) GetBooks(ctx context.Context, filter *repository.BookFilter, page *repository.Page) ([]*models.Book, *repository.PageInfo, error) {
+	db := repo.filter(conn(ctx, repo.db).Model(&models.Book{}), filter)
+	books, info, err := findPage(db, page, bookSortFields, "books.id", func(b *models.Book) uuid.UUID { return b.Id }, "created_at")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return books, nil
+	return books, info, loadContributors(conn(ctx, repo.db), books)
+}
+
+// EachBook implements repository.BookRepo.
+func (repo *bookRepo) EachBook(ctx context.Context, filter *repository.BookFilter, sort string, fn func([]*models.Book) error) error {
+	db := repo.filter(conn(ctx, repo.db).Model(&models.Book{}), filter)
+	return eachBatch(db, sort, bookSortFields, "books.id", func(b *models.Book) uuid.UUID { return b.Id }, "created_at", func(books []*models.Book) error {
+		if err := loadContributors(conn(ctx, repo.db), books); err != nil {
+			return err
+		}
+		return fn(books)
+	})
+}
+
+// filter restricts db to the books matching filter.
+func (repo *bookRepo) filter(db *gorm.DB, filter *repository.BookFilter) *gorm.DB {
+	if filter.AuthorId != uuid.Nil || filter.Role != "" {
+		contributors := repo.db.Model(&models.BookContributor{}).Select("1").Where("book_contributors.book_id = books.id")
+		if filter.AuthorId != uuid.Nil {
+			contributors = contributors.Where("book_contributors.author_id = ?", filter.AuthorId)
+		}
+		if filter.Role != "" {
+			contributors = contributors.Where("book_contributors.role = ?", filter.Role)
+		}
+		db = db.Where("EXISTS (?)", contributors)
+	}
+	if filter.UserId != uuid.Nil {
+		db = db.Where("books.user_id = ?", filter.UserId)
+	}
+	if filter.Title != "" {
+		db = db.Where(`LOWER(books.title) LIKE ? ESCAPE '\'`, likePattern(filter.Title))
+	}
+	if filter.Isbn != "" {
+		db = db.Where("books.isbn = ?", filter.Isbn)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		db = db.Where("books.created_at >= ?", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		db = db.Where("books.created_at <= ?", filter.CreatedTo)
+	}
+	return db
 }
 
 // UpdateBook implements repository.BookRepo.
 func (repo *bookRepo) UpdateBook(ctx context.Context, book *models.Book, id uuid.UUID) error {
 	book.UpdatedAt = time.Now()
-	return repo.db.WithContext(ctx).Model(book).Where("id = ?", id).Updates(book).Error
+	version := book.Version
+	book.Version++
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		// Select writes the zero values Updates skips, so that cleared
+		// fields are saved.
+		res := tx.Model(book).Select("Title", "Isbn", "Version", "UpdatedAt").Where("id = ? AND version = ?", id, version).Updates(book)
+		if res.Error != nil {
+			return isbnError(res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return repository.ErrVersionConflict
+		}
+		if err := tx.Where("book_id = ?", id).Delete(&models.BookContributor{}).Error; err != nil {
+			return err
+		}
+		if err := saveContributors(tx, id, book.Contributors); err != nil {
+			return err
+		}
+		return loadContributors(tx, []*models.Book{book})
+	})
+}
+
+// saveContributors inserts the contributors of a book, numbering them in
+// slice order.
+func saveContributors(tx *gorm.DB, bookId uuid.UUID, contributors []models.BookContributor) error {
+	if len(contributors) == 0 {
+		return nil
+	}
+	for i := range contributors {
+		contributors[i].BookId = bookId
+		contributors[i].Position = i
+	}
+	return tx.Omit("Author").Create(&contributors).Error
+}
+
+// loadContributors fills the contributors of books, with their authors, in a
+// single query.
+func loadContributors(db *gorm.DB, books []*models.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(books))
+	byId := make(map[uuid.UUID]*models.Book, len(books))
+	for _, book := range books {
+		ids = append(ids, book.Id)
+		byId[book.Id] = book
+		book.Contributors = nil
+	}
+
+	var contributors []models.BookContributor
+	err := db.Preload("Author").Where("book_id IN ?", ids).Order("position").Find(&contributors).Error
+	if err != nil {
+		return err
+	}
+	for _, c := range contributors {
+		book := byId[c.BookId]
+		book.Contributors = append(book.Contributors, c)
+	}
+	return nil
+}
+
+// isbnError returns repository.ErrDuplicateIsbn when err is a violation of
+// the unique index on the isbn of books that are not in the trash.
+func isbnError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return repository.ErrDuplicateIsbn
+	}
+	return err
 }
@@ -0,0 +1,135 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"gorm.io/gorm"
+)
+
+type consistencyRepo struct {
+	db *gorm.DB
+}
+
+func NewConsistencyRepo(db *gorm.DB) repository.ConsistencyRepo {
+	return &consistencyRepo{db: db}
+}
+
+// CheckConsistency implements repository.ConsistencyRepo.
+func (repo *consistencyRepo) CheckConsistency(ctx context.Context) (*repository.ConsistencyReport, error) {
+	return checkConsistency(conn(ctx, repo.db))
+}
+
+// RepairConsistency implements repository.ConsistencyRepo. It removes the
+// dangling contributors, then credits or deletes the orphaned books according
+// to mode, and returns what it found before repairing. Placeholder authors
+// belong to the owner of the book, so orphaned books without an owner are
+// only repaired by deleting them.
+func (repo *consistencyRepo) RepairConsistency(ctx context.Context, mode repository.RepairMode) (*repository.ConsistencyReport, error) {
+	var report *repository.ConsistencyReport
+	err := conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		var err error
+		report, err = checkConsistency(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range report.DanglingContributors {
+			err := tx.Where("book_id = ? AND author_id = ? AND role = ?", c.BookId, c.AuthorId, c.Role).
+				Delete(&models.BookContributor{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		unowned := make(map[uuid.UUID]bool)
+		for _, book := range report.UnownedBooks {
+			unowned[book.Id] = true
+		}
+		placeholders := make(map[uuid.UUID]uuid.UUID)
+		for _, book := range report.OrphanedBooks {
+			if mode == repository.RepairDelete {
+				if err := tx.Where("id = ?", book.Id).Delete(&models.Book{}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if unowned[book.Id] {
+				continue
+			}
+
+			authorId, ok := placeholders[book.UserId]
+			if !ok {
+				authorId, err = placeholderAuthor(tx, book.UserId)
+				if err != nil {
+					return err
+				}
+				placeholders[book.UserId] = authorId
+			}
+			err := tx.Create(&models.BookContributor{
+				BookId:   book.Id,
+				AuthorId: authorId,
+				Role:     models.ContributorAuthor,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return report, err
+}
+
+func checkConsistency(db *gorm.DB) (*repository.ConsistencyReport, error) {
+	report := new(repository.ConsistencyReport)
+
+	err := db.Where("book_id NOT IN (SELECT id FROM books) OR author_id NOT IN (SELECT id FROM authors)").
+		Order("book_id, position").Find(&report.DanglingContributors).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Where(`NOT EXISTS (SELECT 1 FROM book_contributors
+		JOIN authors ON authors.id = book_contributors.author_id
+		WHERE book_contributors.book_id = books.id)`).
+		Order("created_at").Find(&report.OrphanedBooks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Where("user_id NOT IN (SELECT id FROM users)").Order("created_at").Find(&report.UnownedBooks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Where("user_id NOT IN (SELECT id FROM users)").Order("created_at").Find(&report.UnownedAuthors).Error
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// placeholderAuthor returns the placeholder author of userId, creating it on
+// first use.
+func placeholderAuthor(tx *gorm.DB, userId uuid.UUID) (uuid.UUID, error) {
+	var existing []*models.Author
+	err := tx.Where("user_id = ? AND name = ?", userId, repository.PlaceholderAuthor).Limit(1).Find(&existing).Error
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if len(existing) > 0 {
+		return existing[0].Id, nil
+	}
+
+	author := &models.Author{
+		Id:        uuid.New(),
+		UserId:    userId,
+		Name:      repository.PlaceholderAuthor,
+		NameKey:   nameKey(repository.PlaceholderAuthor),
+		CreatedAt: time.Now(),
+	}
+	return author.Id, tx.Create(author).Error
+}
@@ -0,0 +1,107 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"gorm.io/gorm"
+)
+
+type copyRepo struct {
+	db *gorm.DB
+}
+
+func NewCopyRepo(db *gorm.DB) repository.CopyRepo {
+	return &copyRepo{db: db}
+}
+
+// CreateCopy implements repository.CopyRepo.
+func (repo *copyRepo) CreateCopy(ctx context.Context, c *models.Copy) error {
+	c.Id = uuid.New()
+	c.CreatedAt = time.Now()
+	c.UpdatedAt = c.CreatedAt
+	err := conn(ctx, repo.db).Omit("Book").Create(c).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return repository.ErrDuplicateBarcode
+	}
+	return err
+}
+
+// GetCopies implements repository.CopyRepo.
+func (repo *copyRepo) GetCopies(ctx context.Context, bookId uuid.UUID) ([]*models.Copy, error) {
+	var copies []*models.Copy
+	err := conn(ctx, repo.db).Where("book_id = ?", bookId).Order("barcode").Find(&copies).Error
+	return copies, err
+}
+
+// GetCopyById implements repository.CopyRepo.
+func (repo *copyRepo) GetCopyById(ctx context.Context, id uuid.UUID) (*models.Copy, error) {
+	c := new(models.Copy)
+	return c, conn(ctx, repo.db).Where("id = ?", id).Take(c).Error
+}
+
+// GetCopyByBarcode implements repository.CopyRepo.
+func (repo *copyRepo) GetCopyByBarcode(ctx context.Context, barcode string) (*models.Copy, error) {
+	c := new(models.Copy)
+	err := conn(ctx, repo.db).InnerJoins("Book").Where("copies.barcode = ?", barcode).Take(c).Error
+	return c, err
+}
+
+// UpdateCopy implements repository.CopyRepo. Cleared fields are saved as
+// well.
+func (repo *copyRepo) UpdateCopy(ctx context.Context, c *models.Copy) error {
+	c.UpdatedAt = time.Now()
+	db := conn(ctx, repo.db).Model(c)
+	if models.Circulating(c.Status) {
+		db = db.Select("Barcode", "Location", "Condition", "AcquiredAt", "UpdatedAt")
+	} else {
+		db = db.Select("Barcode", "Location", "Condition", "Status", "AcquiredAt", "UpdatedAt").
+			Where("status NOT IN ?", []string{models.CopyOnLoan, models.CopyOnHold})
+	}
+	res := db.Updates(c)
+	if errors.Is(res.Error, gorm.ErrDuplicatedKey) {
+		return repository.ErrDuplicateBarcode
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrCopyInCirculation
+	}
+	return nil
+}
+
+// DeleteCopy implements repository.CopyRepo.
+func (repo *copyRepo) DeleteCopy(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, repo.db).Where("id = ?", id).Delete(&models.Copy{}).Error
+}
+
+// CountCopies implements repository.CopyRepo.
+func (repo *copyRepo) CountCopies(ctx context.Context, bookIds []uuid.UUID) (map[uuid.UUID]*repository.CopyCount, error) {
+	counts := make(map[uuid.UUID]*repository.CopyCount)
+	if len(bookIds) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		BookId    uuid.UUID
+		Total     int64
+		Available int64
+	}
+	err := conn(ctx, repo.db).Model(&models.Copy{}).
+		Select("book_id, COUNT(*) AS total, SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS available", models.CopyAvailable).
+		Where("book_id IN ? AND status <> ?", bookIds, models.CopyWithdrawn).
+		Group("book_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.BookId] = &repository.CopyCount{Total: row.Total, Available: row.Available}
+	}
+	return counts, nil
+}
@@ -0,0 +1,6 @@
+package gorm
+
+var (
+	FtsQuery       = ftsQuery
+	MarkHighlights = markHighlights
+)
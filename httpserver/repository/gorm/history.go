@@ -0,0 +1,52 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"gorm.io/gorm"
+)
+
+type historyRepo struct {
+	db *gorm.DB
+}
+
+func NewHistoryRepo(db *gorm.DB) repository.HistoryRepo {
+	return &historyRepo{db: db}
+}
+
+// AddHistory implements repository.HistoryRepo.
+func (repo *historyRepo) AddHistory(ctx context.Context, entry *models.HistoryEntry) error {
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		var version int
+		err := tx.Model(&models.HistoryEntry{}).
+			Where("entity_type = ? AND entity_id = ?", entry.EntityType, entry.EntityId).
+			Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+		if err != nil {
+			return err
+		}
+
+		entry.Id = uuid.New()
+		entry.Version = version + 1
+		entry.CreatedAt = time.Now()
+		return tx.Create(entry).Error
+	})
+}
+
+// GetHistory implements repository.HistoryRepo.
+func (repo *historyRepo) GetHistory(ctx context.Context, entityType string, entityId uuid.UUID) ([]*models.HistoryEntry, error) {
+	var entries []*models.HistoryEntry
+	err := conn(ctx, repo.db).Where("entity_type = ? AND entity_id = ?", entityType, entityId).
+		Order("version DESC").Find(&entries).Error
+	return entries, err
+}
+
+// GetHistoryVersion implements repository.HistoryRepo.
+func (repo *historyRepo) GetHistoryVersion(ctx context.Context, entityType string, entityId uuid.UUID, version int) (*models.HistoryEntry, error) {
+	entry := new(models.HistoryEntry)
+	return entry, conn(ctx, repo.db).Where("entity_type = ? AND entity_id = ? AND version = ?", entityType, entityId, version).
+		Take(entry).Error
+}
@@ -0,0 +1,228 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"gorm.io/gorm"
+)
+
+// holdPosition counts the waiting holds of the same book placed before a
+// waiting hold, ties being broken by id as in the queue order.
+const holdPosition = `CASE WHEN holds.status = 'waiting' THEN 1 + (
+	SELECT COUNT(*) FROM holds AS ahead
+	WHERE ahead.book_id = holds.book_id AND ahead.status = 'waiting'
+	AND (ahead.created_at < holds.created_at OR (ahead.created_at = holds.created_at AND ahead.id < holds.id))
+) ELSE 0 END AS position`
+
+type holdRepo struct {
+	db *gorm.DB
+}
+
+func NewHoldRepo(db *gorm.DB) repository.HoldRepo {
+	return &holdRepo{db: db}
+}
+
+// CreateHold implements repository.HoldRepo.
+func (repo *holdRepo) CreateHold(ctx context.Context, hold *models.Hold) error {
+	hold.Id = uuid.New()
+	hold.Status = models.HoldWaiting
+	hold.CreatedAt = time.Now()
+	hold.UpdatedAt = hold.CreatedAt
+	err := conn(ctx, repo.db).Omit("Book", "User", "Copy", "Position").Create(hold).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return repository.ErrDuplicateHold
+	}
+	return err
+}
+
+// GetHoldById implements repository.HoldRepo.
+func (repo *holdRepo) GetHoldById(ctx context.Context, id uuid.UUID) (*models.Hold, error) {
+	hold := new(models.Hold)
+	err := conn(ctx, repo.db).
+		Preload("Book", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("id = ?", id).Take(hold).Error
+	return hold, err
+}
+
+// GetHolds implements repository.HoldRepo.
+func (repo *holdRepo) GetHolds(ctx context.Context, filter *repository.HoldFilter) ([]*models.Hold, error) {
+	db := conn(ctx, repo.db).
+		Select("holds.*", holdPosition).
+		Preload("Book", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Copy").
+		Where("holds.status IN ?", []string{models.HoldWaiting, models.HoldReady})
+	if filter.UserId != uuid.Nil {
+		db = db.Where("holds.user_id = ?", filter.UserId)
+	}
+	if filter.BookId != uuid.Nil {
+		db = db.Where("holds.book_id = ?", filter.BookId)
+	}
+
+	var holds []*models.Hold
+	err := db.Order("holds.created_at, holds.id").Find(&holds).Error
+	return holds, err
+}
+
+// GetReadyHold implements repository.HoldRepo.
+func (repo *holdRepo) GetReadyHold(ctx context.Context, copyId uuid.UUID) (*models.Hold, error) {
+	hold := new(models.Hold)
+	err := conn(ctx, repo.db).Where("copy_id = ? AND status = ?", copyId, models.HoldReady).Take(hold).Error
+	return hold, err
+}
+
+// CountWaitingHolds implements repository.HoldRepo.
+func (repo *holdRepo) CountWaitingHolds(ctx context.Context, bookId uuid.UUID) (int64, error) {
+	var count int64
+	err := conn(ctx, repo.db).Model(&models.Hold{}).
+		Where("book_id = ? AND status = ?", bookId, models.HoldWaiting).
+		Count(&count).Error
+	return count, err
+}
+
+// AssignHolds implements repository.HoldRepo. Holds are assigned one at a
+// time, the oldest waiting hold of a book with an available copy first.
+func (repo *holdRepo) AssignHolds(ctx context.Context, bookId uuid.UUID, expiresAt time.Time) ([]*models.Hold, error) {
+	var assigned []*models.Hold
+	err := conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		for {
+			available := tx.Model(&models.Copy{}).Select("1").
+				Where("copies.book_id = holds.book_id AND copies.status = ?", models.CopyAvailable)
+			db := tx.Where("holds.status = ? AND EXISTS (?)", models.HoldWaiting, available)
+			if bookId != uuid.Nil {
+				db = db.Where("holds.book_id = ?", bookId)
+			}
+			hold := new(models.Hold)
+			err := db.Order("holds.created_at, holds.id").Take(hold).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			c := new(models.Copy)
+			err = tx.Where("book_id = ? AND status = ?", hold.BookId, models.CopyAvailable).
+				Order("barcode").Take(c).Error
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			err = tx.Model(c).Updates(map[string]interface{}{"status": models.CopyOnHold, "updated_at": now}).Error
+			if err != nil {
+				return err
+			}
+			res := tx.Model(&models.Hold{}).
+				Where("id = ? AND status = ?", hold.Id, models.HoldWaiting).
+				Updates(map[string]interface{}{
+					"status":     models.HoldReady,
+					"copy_id":    c.Id,
+					"ready_at":   now,
+					"expires_at": expiresAt,
+					"updated_at": now,
+				})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return repository.ErrHoldChanged
+			}
+
+			c.Status = models.CopyOnHold
+			hold.Status = models.HoldReady
+			hold.CopyId = &c.Id
+			hold.Copy = c
+			hold.ReadyAt = &now
+			hold.ExpiresAt = &expiresAt
+			hold.UpdatedAt = now
+			assigned = append(assigned, hold)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assigned, nil
+}
+
+// FulfillHold implements repository.HoldRepo.
+func (repo *holdRepo) FulfillHold(ctx context.Context, id uuid.UUID) error {
+	res := conn(ctx, repo.db).Model(&models.Hold{}).
+		Where("id = ? AND status = ?", id, models.HoldReady).
+		Updates(map[string]interface{}{"status": models.HoldFulfilled, "updated_at": time.Now()})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrHoldChanged
+	}
+	return nil
+}
+
+// CancelHold implements repository.HoldRepo.
+func (repo *holdRepo) CancelHold(ctx context.Context, hold *models.Hold) error {
+	now := time.Now()
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.Hold{}).
+			Where("id = ? AND status = ?", hold.Id, hold.Status).
+			Updates(map[string]interface{}{"status": models.HoldCancelled, "updated_at": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return repository.ErrHoldChanged
+		}
+		if hold.Status == models.HoldReady && hold.CopyId != nil {
+			err := releaseCopies(tx, []uuid.UUID{*hold.CopyId}, now)
+			if err != nil {
+				return err
+			}
+		}
+		hold.Status = models.HoldCancelled
+		hold.UpdatedAt = now
+		return nil
+	})
+}
+
+// ExpireHolds implements repository.HoldRepo.
+func (repo *holdRepo) ExpireHolds(ctx context.Context, now time.Time) (int64, error) {
+	var expired int64
+	err := conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		var holds []*models.Hold
+		err := tx.Where("status = ? AND expires_at < ?", models.HoldReady, now).Find(&holds).Error
+		if err != nil || len(holds) == 0 {
+			return err
+		}
+		ids := make([]uuid.UUID, 0, len(holds))
+		copies := make([]uuid.UUID, 0, len(holds))
+		for _, h := range holds {
+			ids = append(ids, h.Id)
+			if h.CopyId != nil {
+				copies = append(copies, *h.CopyId)
+			}
+		}
+
+		res := tx.Model(&models.Hold{}).
+			Where("id IN ? AND status = ?", ids, models.HoldReady).
+			Updates(map[string]interface{}{"status": models.HoldExpired, "updated_at": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		expired = res.RowsAffected
+		return releaseCopies(tx, copies, now)
+	})
+	return expired, err
+}
+
+// releaseCopies makes copies that were kept for a hold available again.
+func releaseCopies(tx *gorm.DB, ids []uuid.UUID, now time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.Model(&models.Copy{}).
+		Where("id IN ? AND status = ?", ids, models.CopyOnHold).
+		Updates(map[string]interface{}{"status": models.CopyAvailable, "updated_at": now}).Error
+}
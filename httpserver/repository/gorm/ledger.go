@@ -0,0 +1,70 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ledgerRepo struct {
+	db *gorm.DB
+}
+
+func NewLedgerRepo(db *gorm.DB) repository.LedgerRepo {
+	return &ledgerRepo{db: db}
+}
+
+// SetFine implements repository.LedgerRepo.
+func (repo *ledgerRepo) SetFine(ctx context.Context, userId, loanId uuid.UUID, amount int64) error {
+	now := time.Now()
+	entry := models.LedgerEntry{
+		Id:        uuid.New(),
+		UserId:    userId,
+		Kind:      models.EntryFine,
+		Amount:    amount,
+		LoanId:    &loanId,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return conn(ctx, repo.db).Omit("User").Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "loan_id"}},
+		// The condition is written out for SQLite to match it against the
+		// partial index on loan_id.
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "kind = 'fine'"}}},
+		DoUpdates:   clause.AssignmentColumns([]string{"amount", "updated_at"}),
+	}).Create(&entry).Error
+}
+
+// CreateEntry implements repository.LedgerRepo.
+func (repo *ledgerRepo) CreateEntry(ctx context.Context, entry *models.LedgerEntry) error {
+	entry.Id = uuid.New()
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = entry.CreatedAt
+	return conn(ctx, repo.db).Omit("User").Create(entry).Error
+}
+
+// GetBalance implements repository.LedgerRepo.
+func (repo *ledgerRepo) GetBalance(ctx context.Context, userId uuid.UUID) (int64, error) {
+	var balance int64
+	err := conn(ctx, repo.db).Model(&models.LedgerEntry{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("user_id = ?", userId).
+		Scan(&balance).Error
+	return balance, err
+}
+
+var ledgerSortFields = map[string]sortField[models.LedgerEntry]{
+	"created_at": {column: "ledger_entries.created_at", value: func(e *models.LedgerEntry) interface{} { return e.CreatedAt }},
+	"amount":     {column: "ledger_entries.amount", value: func(e *models.LedgerEntry) interface{} { return e.Amount }},
+}
+
+// GetEntries implements repository.LedgerRepo.
+func (repo *ledgerRepo) GetEntries(ctx context.Context, userId uuid.UUID, page *repository.Page) ([]*models.LedgerEntry, *repository.PageInfo, error) {
+	db := conn(ctx, repo.db).Model(&models.LedgerEntry{}).Where("ledger_entries.user_id = ?", userId)
+	return findPage(db, page, ledgerSortFields, "ledger_entries.id", func(e *models.LedgerEntry) uuid.UUID { return e.Id }, "-created_at")
+}
@@ -0,0 +1,161 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"gorm.io/gorm"
+)
+
+type loanRepo struct {
+	db *gorm.DB
+}
+
+func NewLoanRepo(db *gorm.DB) repository.LoanRepo {
+	return &loanRepo{db: db}
+}
+
+// CreateLoan implements repository.LoanRepo.
+func (repo *loanRepo) CreateLoan(ctx context.Context, loan *models.Loan, status string) error {
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.Copy{}).
+			Where("id = ? AND status = ?", loan.CopyId, status).
+			Updates(map[string]interface{}{"status": models.CopyOnLoan, "updated_at": time.Now()})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return repository.ErrCopyNotAvailable
+		}
+
+		loan.Id = uuid.New()
+		loan.CreatedAt = time.Now()
+		loan.UpdatedAt = loan.CreatedAt
+		// The open loan index catches a copy whose status was reset while
+		// it was still lent.
+		err := tx.Omit("Copy", "User").Create(loan).Error
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return repository.ErrCopyNotAvailable
+		}
+		return err
+	})
+}
+
+// GetLoanById implements repository.LoanRepo.
+func (repo *loanRepo) GetLoanById(ctx context.Context, id uuid.UUID) (*models.Loan, error) {
+	loan := new(models.Loan)
+	err := conn(ctx, repo.db).Where("id = ?", id).Take(loan).Error
+	if err != nil {
+		return nil, err
+	}
+	return loan, loadLoanCopies(conn(ctx, repo.db), []*models.Loan{loan})
+}
+
+var loanSortFields = map[string]sortField[models.Loan]{
+	"borrowed_at": {column: "loans.borrowed_at", value: func(l *models.Loan) interface{} { return l.BorrowedAt }},
+	"due_at":      {column: "loans.due_at", value: func(l *models.Loan) interface{} { return l.DueAt }},
+}
+
+// GetLoans implements repository.LoanRepo.
+func (repo *loanRepo) GetLoans(ctx context.Context, filter *repository.LoanFilter, page *repository.Page) ([]*models.Loan, *repository.PageInfo, error) {
+	db := conn(ctx, repo.db).Model(&models.Loan{})
+	if filter.UserId != uuid.Nil {
+		db = db.Where("loans.user_id = ?", filter.UserId)
+	}
+	if filter.BookId != uuid.Nil {
+		db = db.Where("loans.copy_id IN (?)", repo.db.Model(&models.Copy{}).Select("id").Where("book_id = ?", filter.BookId))
+	}
+	switch filter.Status {
+	case repository.LoansCurrent:
+		db = db.Where("loans.returned_at IS NULL")
+	case repository.LoansPast:
+		db = db.Where("loans.returned_at IS NOT NULL")
+	}
+	if !filter.DueBefore.IsZero() {
+		db = db.Where("loans.returned_at IS NULL AND loans.due_at < ?", filter.DueBefore)
+	}
+
+	loans, info, err := findPage(db, page, loanSortFields, "loans.id", func(l *models.Loan) uuid.UUID { return l.Id }, "-borrowed_at")
+	if err != nil {
+		return nil, nil, err
+	}
+	return loans, info, loadLoanCopies(conn(ctx, repo.db), loans)
+}
+
+// ReturnLoan implements repository.LoanRepo.
+func (repo *loanRepo) ReturnLoan(ctx context.Context, loan *models.Loan) error {
+	now := time.Now()
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.Loan{}).
+			Where("id = ? AND returned_at IS NULL", loan.Id).
+			Updates(map[string]interface{}{"returned_at": now, "updated_at": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return repository.ErrLoanClosed
+		}
+		err := tx.Model(&models.Copy{}).
+			Where("id = ? AND status = ?", loan.CopyId, models.CopyOnLoan).
+			Updates(map[string]interface{}{"status": models.CopyAvailable, "updated_at": now}).Error
+		if err != nil {
+			return err
+		}
+		loan.ReturnedAt = &now
+		loan.UpdatedAt = now
+		if loan.Copy.Status == models.CopyOnLoan {
+			loan.Copy.Status = models.CopyAvailable
+		}
+		return nil
+	})
+}
+
+// RenewLoan implements repository.LoanRepo.
+func (repo *loanRepo) RenewLoan(ctx context.Context, loan *models.Loan) error {
+	now := time.Now()
+	res := conn(ctx, repo.db).Model(&models.Loan{}).
+		Where("id = ? AND returned_at IS NULL AND renewals = ?", loan.Id, loan.Renewals).
+		Updates(map[string]interface{}{"due_at": loan.DueAt, "renewals": loan.Renewals + 1, "updated_at": now})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrLoanChanged
+	}
+	loan.Renewals++
+	loan.UpdatedAt = now
+	return nil
+}
+
+// loadLoanCopies fills the copy of each loan with its book, including books
+// in the trash so that past loans keep their title.
+func loadLoanCopies(db *gorm.DB, loans []*models.Loan) error {
+	if len(loans) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(loans))
+	for _, l := range loans {
+		ids = append(ids, l.CopyId)
+	}
+
+	var copies []*models.Copy
+	err := db.Preload("Book", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("id IN ?", ids).Find(&copies).Error
+	if err != nil {
+		return err
+	}
+	byId := make(map[uuid.UUID]*models.Copy, len(copies))
+	for _, c := range copies {
+		byId[c.Id] = c
+	}
+	for _, l := range loans {
+		if c, ok := byId[l.CopyId]; ok {
+			l.Copy = *c
+		}
+	}
+	return nil
+}
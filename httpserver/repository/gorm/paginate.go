@@ -0,0 +1,193 @@
+package gorm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// batchSize is the number of rows eachBatch loads at once.
+	batchSize = 500
+)
+
+// sortField maps a sortable view field to its column and to the value of a
+// row that is stored in the cursor.
+type sortField[T any] struct {
+	column string
+	value  func(*T) interface{}
+}
+
+type cursor struct {
+	Sort   string          `json:"s"`
+	Value  json.RawMessage `json:"v"`
+	Id     uuid.UUID       `json:"id"`
+	Before bool            `json:"b,omitempty"`
+}
+
+// findPage loads one page of db. Rows are ordered by the requested sort field
+// and then by idColumn so that keyset cursors are stable.
+func findPage[T any](db *gorm.DB, page *repository.Page, fields map[string]sortField[T], idColumn string, id func(*T) uuid.UUID, defaultSort string) ([]*T, *repository.PageInfo, error) {
+	sort := page.Sort
+	if sort == "" {
+		sort = defaultSort
+	}
+	desc := strings.HasPrefix(sort, "-")
+	field, ok := fields[strings.TrimPrefix(sort, "-")]
+	if !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+
+	size := page.PageSize
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+
+	db = db.Session(&gorm.Session{})
+	info := &repository.PageInfo{PageSize: size}
+	if err := db.Count(&info.Total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var rows []*T
+	if page.Page > 0 {
+		offset := (page.Page - 1) * size
+		err := db.Order(orderBy(field.column, idColumn, desc)).Offset(offset).Limit(size).Find(&rows).Error
+		if err != nil {
+			return nil, nil, err
+		}
+		return rows, info, nil
+	}
+
+	var before bool
+	query := db
+	if page.Cursor != "" {
+		c, value, err := decodeCursor(page.Cursor, sort, field)
+		if err != nil {
+			return nil, nil, err
+		}
+		before = c.Before
+		op := ">"
+		if desc != before {
+			op = "<"
+		}
+		query = query.Where(fmt.Sprintf("(%s %s ?) OR (%s = ? AND %s %s ?)", field.column, op, field.column, idColumn, op), value, value, c.Id)
+	}
+
+	err := query.Order(orderBy(field.column, idColumn, desc != before)).Limit(size + 1).Find(&rows).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	more := len(rows) > size
+	if more {
+		rows = rows[:size]
+	}
+	hasPrev, hasNext := page.Cursor != "", more
+	if before {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+		hasPrev, hasNext = more, true
+	}
+
+	if len(rows) > 0 {
+		if hasNext {
+			info.NextCursor = encodeCursor(sort, field, rows[len(rows)-1], id, false)
+		}
+		if hasPrev {
+			info.PrevCursor = encodeCursor(sort, field, rows[0], id, true)
+		}
+	}
+	return rows, info, nil
+}
+
+// eachBatch calls fn with the rows of db in batches, ordered like findPage
+// orders them. Every batch starts after the last row of the previous one, so
+// the whole table is never loaded at once.
+func eachBatch[T any](db *gorm.DB, sort string, fields map[string]sortField[T], idColumn string, id func(*T) uuid.UUID, defaultSort string, fn func([]*T) error) error {
+	if sort == "" {
+		sort = defaultSort
+	}
+	desc := strings.HasPrefix(sort, "-")
+	field, ok := fields[strings.TrimPrefix(sort, "-")]
+	if !ok {
+		return repository.ErrInvalidSort
+	}
+	op := ">"
+	if desc {
+		op = "<"
+	}
+
+	db = db.Session(&gorm.Session{})
+	var last *T
+	for {
+		query := db
+		if last != nil {
+			value := field.value(last)
+			query = query.Where(fmt.Sprintf("(%s %s ?) OR (%s = ? AND %s %s ?)", field.column, op, field.column, idColumn, op), value, value, id(last))
+		}
+		var rows []*T
+		if err := query.Order(orderBy(field.column, idColumn, desc)).Limit(batchSize).Find(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := fn(rows); err != nil {
+			return err
+		}
+		if len(rows) < batchSize {
+			return nil
+		}
+		last = rows[len(rows)-1]
+	}
+}
+
+func orderBy(column, idColumn string, desc bool) string {
+	if desc {
+		return column + " DESC, " + idColumn + " DESC"
+	}
+	return column + ", " + idColumn
+}
+
+func encodeCursor[T any](sort string, field sortField[T], row *T, id func(*T) uuid.UUID, before bool) string {
+	value, _ := json.Marshal(field.value(row))
+	data, _ := json.Marshal(cursor{Sort: sort, Value: value, Id: id(row), Before: before})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor decodes the cursor value into the Go type of the sort field so
+// that it is bound the same way the column was written.
+func decodeCursor[T any](s string, sort string, field sortField[T]) (*cursor, interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, nil, repository.ErrInvalidCursor
+	}
+	c := new(cursor)
+	if err := json.Unmarshal(data, c); err != nil || c.Sort != sort {
+		return nil, nil, repository.ErrInvalidCursor
+	}
+	value := reflect.New(reflect.TypeOf(field.value(new(T))))
+	if err := json.Unmarshal(c.Value, value.Interface()); err != nil {
+		return nil, nil, repository.ErrInvalidCursor
+	}
+	return c, value.Elem().Interface(), nil
+}
+
+// likePattern returns a LIKE pattern matching s anywhere, to be used with
+// ESCAPE '\'.
+func likePattern(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + strings.ToLower(r.Replace(s)) + "%"
+}
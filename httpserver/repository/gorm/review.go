@@ -0,0 +1,108 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"gorm.io/gorm"
+)
+
+type reviewRepo struct {
+	db *gorm.DB
+}
+
+func NewReviewRepo(db *gorm.DB) repository.ReviewRepo {
+	return &reviewRepo{db: db}
+}
+
+// CreateReview implements repository.ReviewRepo.
+func (repo *reviewRepo) CreateReview(ctx context.Context, review *models.Review) error {
+	review.Id = uuid.New()
+	review.Status = models.ReviewVisible
+	review.CreatedAt = time.Now()
+	review.UpdatedAt = review.CreatedAt
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("Book", "User").Create(review).Error
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return repository.ErrDuplicateReview
+		}
+		if err != nil {
+			return err
+		}
+		return updateRating(tx, review.BookId)
+	})
+}
+
+// GetReviewById implements repository.ReviewRepo. The review comes with its
+// user.
+func (repo *reviewRepo) GetReviewById(ctx context.Context, id uuid.UUID) (*models.Review, error) {
+	review := new(models.Review)
+	return review, conn(ctx, repo.db).Preload("User").Where("id = ?", id).Take(review).Error
+}
+
+var reviewSortFields = map[string]sortField[models.Review]{
+	"created_at": {column: "reviews.created_at", value: func(r *models.Review) interface{} { return r.CreatedAt }},
+	"updated_at": {column: "reviews.updated_at", value: func(r *models.Review) interface{} { return r.UpdatedAt }},
+	"rating":     {column: "reviews.rating", value: func(r *models.Review) interface{} { return r.Rating }},
+}
+
+// GetReviews implements repository.ReviewRepo.
+func (repo *reviewRepo) GetReviews(ctx context.Context, filter *repository.ReviewFilter, page *repository.Page) ([]*models.Review, *repository.PageInfo, error) {
+	db := conn(ctx, repo.db).Model(&models.Review{}).Preload("User")
+	if filter.BookId != uuid.Nil {
+		db = db.Where("reviews.book_id = ?", filter.BookId)
+	}
+	if len(filter.Statuses) > 0 {
+		db = db.Where("reviews.status IN ?", filter.Statuses)
+	}
+	return findPage(db, page, reviewSortFields, "reviews.id", func(r *models.Review) uuid.UUID { return r.Id }, "-created_at")
+}
+
+// UpdateReview implements repository.ReviewRepo.
+func (repo *reviewRepo) UpdateReview(ctx context.Context, review *models.Review) error {
+	review.UpdatedAt = time.Now()
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(review).Select("Rating", "Body", "UpdatedAt").Updates(review).Error
+		if err != nil {
+			return err
+		}
+		return updateRating(tx, review.BookId)
+	})
+}
+
+// ModerateReview implements repository.ReviewRepo.
+func (repo *reviewRepo) ModerateReview(ctx context.Context, review *models.Review) error {
+	now := time.Now()
+	review.ModeratedAt = &now
+	review.UpdatedAt = now
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(review).Select("Status", "ModeratedBy", "ModerationNote", "ModeratedAt", "UpdatedAt").Updates(review).Error
+		if err != nil {
+			return err
+		}
+		return updateRating(tx, review.BookId)
+	})
+}
+
+// DeleteReview implements repository.ReviewRepo.
+func (repo *reviewRepo) DeleteReview(ctx context.Context, review *models.Review) error {
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.Review{}, "id = ?", review.Id).Error; err != nil {
+			return err
+		}
+		return updateRating(tx, review.BookId)
+	})
+}
+
+// updateRating recomputes the rating of the book from its reviews. It leaves
+// the version and updated_at alone, reviews are not edits of the book.
+func updateRating(tx *gorm.DB, bookId uuid.UUID) error {
+	return tx.Exec(`UPDATE books SET
+		rating_count = (SELECT COUNT(*) FROM reviews WHERE book_id = books.id AND status <> ?),
+		rating_avg = (SELECT COALESCE(AVG(rating), 0) FROM reviews WHERE book_id = books.id AND status <> ?)
+		WHERE id = ?`, models.ReviewHidden, models.ReviewHidden, bookId).Error
+}
@@ -0,0 +1,155 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"html"
+	"strings"
+
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"gorm.io/gorm"
+)
+
+type searchRepo struct {
+	db *gorm.DB
+}
+
+func NewSearchRepo(db *gorm.DB) repository.SearchRepo {
+	return &searchRepo{db: db}
+}
+
+// SearchBooks implements repository.SearchRepo.
+func (repo *searchRepo) SearchBooks(ctx context.Context, query string, limit, offset int) ([]*repository.BookHit, int64, error) {
+	match := ftsQuery(query)
+	if match == "" {
+		return nil, 0, repository.ErrInvalidSearch
+	}
+
+	var total int64
+	err := conn(ctx, repo.db).Raw(`SELECT COUNT(*) FROM books_fts WHERE books_fts MATCH ?`, match).Scan(&total).Error
+	if err != nil {
+		return nil, 0, searchError(err)
+	}
+
+	var hits []*repository.BookHit
+	err = conn(ctx, repo.db).Raw(`
+		SELECT books.id, books.title, books.isbn, books_fts.authors,
+			highlight(books_fts, 0, char(2), char(3)) AS title_highlight,
+			snippet(books_fts, -1, char(2), char(3), '…', 16) AS snippet,
+			bm25(books_fts, 10.0, 5.0, 1.0) AS rank
+		FROM books_fts
+		JOIN search_ids ON search_ids.seq = books_fts.rowid
+		JOIN books ON books.id = search_ids.id
+		WHERE books_fts MATCH ?
+		ORDER BY rank
+		LIMIT ? OFFSET ?`, match, limit, offset).Scan(&hits).Error
+	if err != nil {
+		return nil, 0, searchError(err)
+	}
+	for _, hit := range hits {
+		hit.TitleHighlight = markHighlights(hit.TitleHighlight)
+		hit.Snippet = markHighlights(hit.Snippet)
+	}
+	return hits, total, nil
+}
+
+// SearchAuthors implements repository.SearchRepo.
+func (repo *searchRepo) SearchAuthors(ctx context.Context, query string, limit, offset int) ([]*repository.AuthorHit, int64, error) {
+	match := ftsQuery(query)
+	if match == "" {
+		return nil, 0, repository.ErrInvalidSearch
+	}
+
+	var total int64
+	err := conn(ctx, repo.db).Raw(`SELECT COUNT(*) FROM authors_fts WHERE authors_fts MATCH ?`, match).Scan(&total).Error
+	if err != nil {
+		return nil, 0, searchError(err)
+	}
+
+	var hits []*repository.AuthorHit
+	err = conn(ctx, repo.db).Raw(`
+		SELECT authors.id, authors.name,
+			highlight(authors_fts, 0, char(2), char(3)) AS name_highlight,
+			bm25(authors_fts) AS rank
+		FROM authors_fts
+		JOIN search_ids ON search_ids.seq = authors_fts.rowid
+		JOIN authors ON authors.id = search_ids.id
+		WHERE authors_fts MATCH ?
+		ORDER BY rank
+		LIMIT ? OFFSET ?`, match, limit, offset).Scan(&hits).Error
+	if err != nil {
+		return nil, 0, searchError(err)
+	}
+	for _, hit := range hits {
+		hit.NameHighlight = markHighlights(hit.NameHighlight)
+	}
+	return hits, total, nil
+}
+
+// highlightMarks turns the markers the queries put around matches into HTML
+// once the text around them is escaped.
+var highlightMarks = strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>")
+
+func markHighlights(text string) string {
+	return highlightMarks.Replace(html.EscapeString(text))
+}
+
+func searchError(err error) error {
+	if strings.Contains(err.Error(), "fts5") {
+		return errors.Join(repository.ErrInvalidSearch, err)
+	}
+	return err
+}
+
+// ftsQuery turns user input into an FTS5 query. Words and "quoted phrases"
+// are matched literally and must all occur, a trailing * matches a prefix and
+// an uppercase OR between two terms matches either of them.
+func ftsQuery(input string) string {
+	var terms []string
+	add := func(term string, prefix bool) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			return
+		}
+		term = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+		if prefix {
+			term += "*"
+		}
+		terms = append(terms, term)
+	}
+
+	for {
+		input = strings.TrimLeft(input, " \t\r\n")
+		if input == "" {
+			break
+		}
+
+		if input[0] == '"' {
+			phrase, rest, _ := strings.Cut(input[1:], `"`)
+			prefix := strings.HasPrefix(rest, "*")
+			add(phrase, prefix)
+			input = strings.TrimPrefix(rest, "*")
+			continue
+		}
+
+		end := strings.IndexAny(input, " \t\r\n\"")
+		if end < 0 {
+			end = len(input)
+		}
+		word := input[:end]
+		input = input[end:]
+		if word == "OR" {
+			if len(terms) > 0 && terms[len(terms)-1] != "OR" {
+				terms = append(terms, "OR")
+			}
+			continue
+		}
+		prefix := strings.HasSuffix(word, "*")
+		add(strings.TrimRight(word, "*"), prefix)
+	}
+
+	if len(terms) > 0 && terms[len(terms)-1] == "OR" {
+		terms = terms[:len(terms)-1]
+	}
+	return strings.Join(terms, " ")
+}
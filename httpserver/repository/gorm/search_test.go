@@ -0,0 +1,91 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/storyofhis/books-management/httpserver/repository/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFtsQuery(t *testing.T) {
+	t.Run("success - it should quote words and phrases", func(t *testing.T) {
+		for _, tt := range []struct{ input, want string }{
+			{"", ""},
+			{"  \t\n", ""},
+			{"tolkien", `"tolkien"`},
+			{"lord  rings", `"lord" "rings"`},
+			{`"lord of the rings"`, `"lord of the rings"`},
+			{`hobbit "lord of" rings`, `"hobbit" "lord of" "rings"`},
+		} {
+			assert.Equal(t, tt.want, gorm.FtsQuery(tt.input), tt.input)
+		}
+	})
+
+	t.Run("success - it should match prefixes", func(t *testing.T) {
+		for _, tt := range []struct{ input, want string }{
+			{"tolk*", `"tolk"*`},
+			{"tolk**", `"tolk"*`},
+			{`"lord of"*`, `"lord of"*`},
+			{`"lord of" *`, `"lord of"`},
+			{"*", ""},
+		} {
+			assert.Equal(t, tt.want, gorm.FtsQuery(tt.input), tt.input)
+		}
+	})
+
+	t.Run("success - it should keep an uppercase OR between terms", func(t *testing.T) {
+		for _, tt := range []struct{ input, want string }{
+			{"hobbit OR silmarillion", `"hobbit" OR "silmarillion"`},
+			{"hobbit OR OR silmarillion", `"hobbit" OR "silmarillion"`},
+			{"OR hobbit OR", `"hobbit"`},
+			{"OR", ""},
+			{"hobbit or silmarillion", `"hobbit" "or" "silmarillion"`},
+		} {
+			assert.Equal(t, tt.want, gorm.FtsQuery(tt.input), tt.input)
+		}
+	})
+
+	t.Run("success - it should close unbalanced quotes", func(t *testing.T) {
+		for _, tt := range []struct{ input, want string }{
+			{`"lord of`, `"lord of"`},
+			{`tolkien "`, `"tolkien"`},
+			{`""`, ""},
+			{`""""`, ""},
+			{`lord"rings`, `"lord" "rings"`},
+		} {
+			assert.Equal(t, tt.want, gorm.FtsQuery(tt.input), tt.input)
+		}
+	})
+
+	t.Run("success - it should match operators and punctuation as terms", func(t *testing.T) {
+		for _, tt := range []struct{ input, want string }{
+			{"NOT hobbit", `"NOT" "hobbit"`},
+			{"hobbit AND rings", `"hobbit" "AND" "rings"`},
+			{"NEAR(hobbit rings)", `"NEAR(hobbit" "rings)"`},
+			{"title:hobbit", `"title:hobbit"`},
+			{"^hobbit -rings +ring", `"^hobbit" "-rings" "+ring"`},
+			{"{title name}:x", `"{title" "name}:x"`},
+			{`it's`, `"it's"`},
+		} {
+			assert.Equal(t, tt.want, gorm.FtsQuery(tt.input), tt.input)
+		}
+	})
+}
+
+func TestMarkHighlights(t *testing.T) {
+	for _, tt := range []struct {
+		name, text, want string
+	}{
+		{"it should mark matches", "The \x02Hobbit\x03", "The <mark>Hobbit</mark>"},
+		{"it should mark every match", "\x02Lord\x03 of the \x02Rings\x03", "<mark>Lord</mark> of the <mark>Rings</mark>"},
+		{"it should leave text without matches", "The Hobbit", "The Hobbit"},
+		{"it should escape markup around matches", "a < b & \x02c\x03", "a &lt; b &amp; <mark>c</mark>"},
+		{"it should escape markup inside matches", "\x02<b>&amp;\x03", "<mark>&lt;b&gt;&amp;amp;</mark>"},
+		{"it should escape marks in the text", "<mark>Hobbit</mark>", "&lt;mark&gt;Hobbit&lt;/mark&gt;"},
+		{"it should escape quotes", `"Hobbit" 'Rings'`, "&#34;Hobbit&#34; &#39;Rings&#39;"},
+	} {
+		t.Run("success - "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, gorm.MarkHighlights(tt.text))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"gorm.io/gorm"
+)
+
+type sessionRepo struct {
+	db *gorm.DB
+}
+
+func NewSessionRepo(db *gorm.DB) repository.SessionRepo {
+	return &sessionRepo{db: db}
+}
+
+// CreateSession implements repository.SessionRepo.
+func (repo *sessionRepo) CreateSession(ctx context.Context, session *models.Session) error {
+	session.Id = uuid.New()
+	session.CreatedAt = time.Now()
+	return conn(ctx, repo.db).Create(session).Error
+}
+
+// GetSessionById implements repository.SessionRepo.
+func (repo *sessionRepo) GetSessionById(ctx context.Context, id uuid.UUID) (*models.Session, error) {
+	session := new(models.Session)
+	return session, conn(ctx, repo.db).Where("id = ?", id).Take(session).Error
+}
+
+// RevokeSession implements repository.SessionRepo.
+func (repo *sessionRepo) RevokeSession(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	return conn(ctx, repo.db).Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": now, "updated_at": now}).Error
+}
+
+// RevokeUserSessions implements repository.SessionRepo.
+func (repo *sessionRepo) RevokeUserSessions(ctx context.Context, userId uuid.UUID) error {
+	now := time.Now()
+	return conn(ctx, repo.db).Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userId).
+		Updates(map[string]interface{}{"revoked_at": now, "updated_at": now}).Error
+}
+
+// CreateRefreshToken implements repository.SessionRepo.
+func (repo *sessionRepo) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	token.Id = uuid.New()
+	token.CreatedAt = time.Now()
+	return conn(ctx, repo.db).Create(token).Error
+}
+
+// GetRefreshTokenByHash implements repository.SessionRepo.
+func (repo *sessionRepo) GetRefreshTokenByHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	token := new(models.RefreshToken)
+	return token, conn(ctx, repo.db).Where("token_hash = ?", hash).Take(token).Error
+}
+
+// UseRefreshToken implements repository.SessionRepo.
+func (repo *sessionRepo) UseRefreshToken(ctx context.Context, id uuid.UUID) (bool, error) {
+	res := conn(ctx, repo.db).Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	return res.RowsAffected == 1, res.Error
+}
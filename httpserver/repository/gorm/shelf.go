@@ -0,0 +1,206 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type shelfRepo struct {
+	db *gorm.DB
+}
+
+func NewShelfRepo(db *gorm.DB) repository.ShelfRepo {
+	return &shelfRepo{db: db}
+}
+
+// CreateShelf implements repository.ShelfRepo.
+func (repo *shelfRepo) CreateShelf(ctx context.Context, shelf *models.Shelf) error {
+	shelf.Id = uuid.New()
+	if shelf.Kind == "" {
+		shelf.Kind = models.ShelfCustom
+	}
+	shelf.CreatedAt = time.Now()
+	shelf.UpdatedAt = shelf.CreatedAt
+	err := conn(ctx, repo.db).Omit("User", "Items").Create(shelf).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return repository.ErrDuplicateShelf
+	}
+	return err
+}
+
+// CreateDefaultShelves implements repository.ShelfRepo. A built-in shelf
+// whose name the user already gave to a list of their own is not created.
+func (repo *shelfRepo) CreateDefaultShelves(ctx context.Context, userId uuid.UUID) error {
+	now := time.Now()
+	shelves := make([]models.Shelf, 0, len(models.DefaultShelves))
+	for _, s := range models.DefaultShelves {
+		shelves = append(shelves, models.Shelf{
+			Id:        uuid.New(),
+			UserId:    userId,
+			Name:      s.Name,
+			Kind:      s.Kind,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+	return conn(ctx, repo.db).Omit("User", "Items").Clauses(clause.OnConflict{DoNothing: true}).Create(&shelves).Error
+}
+
+// GetShelfById implements repository.ShelfRepo.
+func (repo *shelfRepo) GetShelfById(ctx context.Context, id uuid.UUID) (*models.Shelf, error) {
+	shelf := new(models.Shelf)
+	return shelf, conn(ctx, repo.db).Where("id = ?", id).Take(shelf).Error
+}
+
+// GetShelfByToken implements repository.ShelfRepo.
+func (repo *shelfRepo) GetShelfByToken(ctx context.Context, token string) (*models.Shelf, error) {
+	shelf := new(models.Shelf)
+	return shelf, conn(ctx, repo.db).Where("share_token = ? AND public", token).Take(shelf).Error
+}
+
+// GetShelves implements repository.ShelfRepo.
+func (repo *shelfRepo) GetShelves(ctx context.Context, userId uuid.UUID, publicOnly bool) ([]*models.Shelf, error) {
+	db := conn(ctx, repo.db).Model(&models.Shelf{}).
+		Select(`shelves.*, (SELECT COUNT(*) FROM shelf_items
+			JOIN books ON books.id = shelf_items.book_id AND books.deleted_at IS NULL
+			WHERE shelf_items.shelf_id = shelves.id) AS item_count`).
+		Where("user_id = ?", userId)
+	if publicOnly {
+		db = db.Where("public")
+	}
+	var shelves []*models.Shelf
+	err := db.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:  "CASE kind WHEN ? THEN 0 WHEN ? THEN 1 WHEN ? THEN 2 ELSE 3 END, created_at, id",
+		Vars: []interface{}{models.ShelfToRead, models.ShelfReading, models.ShelfFinished},
+	}}).Find(&shelves).Error
+	return shelves, err
+}
+
+// UpdateShelf implements repository.ShelfRepo.
+func (repo *shelfRepo) UpdateShelf(ctx context.Context, shelf *models.Shelf) error {
+	shelf.UpdatedAt = time.Now()
+	err := conn(ctx, repo.db).Model(shelf).Select("Name", "Public", "ShareToken", "UpdatedAt").Updates(shelf).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return repository.ErrDuplicateShelf
+	}
+	return err
+}
+
+// DeleteShelf implements repository.ShelfRepo.
+func (repo *shelfRepo) DeleteShelf(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("shelf_id = ?", id).Delete(&models.ShelfItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Shelf{}, "id = ?", id).Error
+	})
+}
+
+// GetShelfItems implements repository.ShelfRepo.
+func (repo *shelfRepo) GetShelfItems(ctx context.Context, shelfId uuid.UUID) ([]*models.ShelfItem, error) {
+	var items []*models.ShelfItem
+	err := conn(ctx, repo.db).InnerJoins("Book").
+		Where("shelf_items.shelf_id = ?", shelfId).
+		Order("shelf_items.position").
+		Find(&items).Error
+	return items, err
+}
+
+// GetShelfItem implements repository.ShelfRepo.
+func (repo *shelfRepo) GetShelfItem(ctx context.Context, shelfId, bookId uuid.UUID) (*models.ShelfItem, error) {
+	item := new(models.ShelfItem)
+	return item, conn(ctx, repo.db).InnerJoins("Book").
+		Where("shelf_items.shelf_id = ? AND shelf_items.book_id = ?", shelfId, bookId).
+		Take(item).Error
+}
+
+// AddShelfItem implements repository.ShelfRepo.
+func (repo *shelfRepo) AddShelfItem(ctx context.Context, item *models.ShelfItem) error {
+	item.Id = uuid.New()
+	item.CreatedAt = time.Now()
+	item.UpdatedAt = item.CreatedAt
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.ShelfItem{}).Where("shelf_id = ?", item.ShelfId).Count(&count).Error; err != nil {
+			return err
+		}
+		if item.Position < 1 || item.Position > int(count) {
+			item.Position = int(count) + 1
+		} else {
+			err := tx.Model(&models.ShelfItem{}).
+				Where("shelf_id = ? AND position >= ?", item.ShelfId, item.Position).
+				Update("position", gorm.Expr("position + 1")).Error
+			if err != nil {
+				return err
+			}
+		}
+		err := tx.Omit("Book").Create(item).Error
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return repository.ErrDuplicateShelfItem
+		}
+		return err
+	})
+}
+
+// UpdateShelfItem implements repository.ShelfRepo.
+func (repo *shelfRepo) UpdateShelfItem(ctx context.Context, item *models.ShelfItem, position int) error {
+	item.UpdatedAt = time.Now()
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(item).Select("Note", "StartedAt", "FinishedAt", "UpdatedAt").Updates(item).Error
+		if err != nil || position == 0 {
+			return err
+		}
+
+		// The position is read again, the item may have been moved since
+		// it was loaded.
+		var count int64
+		if err := tx.Model(&models.ShelfItem{}).Where("shelf_id = ?", item.ShelfId).Count(&count).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.ShelfItem{}).Select("position").Where("id = ?", item.Id).Scan(&item.Position).Error; err != nil {
+			return err
+		}
+		if position > int(count) {
+			position = int(count)
+		}
+		items := tx.Model(&models.ShelfItem{}).Where("shelf_id = ?", item.ShelfId)
+		switch {
+		case position < item.Position:
+			err = items.Where("position >= ? AND position < ?", position, item.Position).
+				Update("position", gorm.Expr("position + 1")).Error
+		case position > item.Position:
+			err = items.Where("position > ? AND position <= ?", item.Position, position).
+				Update("position", gorm.Expr("position - 1")).Error
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		item.Position = position
+		return tx.Model(&models.ShelfItem{}).Where("id = ?", item.Id).Update("position", position).Error
+	})
+}
+
+// RemoveShelfItem implements repository.ShelfRepo.
+func (repo *shelfRepo) RemoveShelfItem(ctx context.Context, item *models.ShelfItem) error {
+	return conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		var position int
+		if err := tx.Model(&models.ShelfItem{}).Select("position").Where("id = ?", item.Id).Scan(&position).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.ShelfItem{}, "id = ?", item.Id).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.ShelfItem{}).
+			Where("shelf_id = ? AND position > ?", item.ShelfId, position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
+}
@@ -0,0 +1,92 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"gorm.io/gorm"
+)
+
+type trashRepo struct {
+	db *gorm.DB
+}
+
+func NewTrashRepo(db *gorm.DB) repository.TrashRepo {
+	return &trashRepo{db: db}
+}
+
+// GetTrash implements repository.TrashRepo.
+func (repo *trashRepo) GetTrash(ctx context.Context, userId uuid.UUID) ([]*models.Book, []*models.Author, error) {
+	db := conn(ctx, repo.db).Unscoped().Session(&gorm.Session{})
+
+	var books []*models.Book
+	err := db.Where("user_id = ? AND deleted_at IS NOT NULL", userId).Order("deleted_at DESC").Find(&books).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var authors []*models.Author
+	err = db.Where("user_id = ? AND deleted_at IS NOT NULL", userId).Order("deleted_at DESC").Find(&authors).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	return books, authors, nil
+}
+
+// PurgeTrash implements repository.TrashRepo. Authors still credited on a
+// book in the trash are kept until that book is purged as well, and books
+// with an open loan, moved to the trash before deletes checked for them,
+// until the loan is returned.
+func (repo *trashRepo) PurgeTrash(ctx context.Context, before time.Time) (int64, int64, error) {
+	var books, authors int64
+	err := conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		err := tx.Unscoped().Model(&models.Book{}).
+			Where("deleted_at < ?", before).
+			Where(`NOT EXISTS (SELECT 1 FROM loans JOIN copies ON copies.id = loans.copy_id
+				WHERE copies.book_id = books.id AND loans.returned_at IS NULL)`).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			if err := tx.Where("book_id IN ?", ids).Delete(&models.BookContributor{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("book_id IN ?", ids).Delete(&models.Hold{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("book_id IN ?", ids).Delete(&models.Review{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("book_id IN ?", ids).Delete(&models.ShelfItem{}).Error; err != nil {
+				return err
+			}
+			copies := tx.Model(&models.Copy{}).Select("id").Where("book_id IN ?", ids)
+			if err := tx.Where("copy_id IN (?)", copies).Delete(&models.Loan{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("book_id IN ?", ids).Delete(&models.Copy{}).Error; err != nil {
+				return err
+			}
+			result := tx.Unscoped().Where("id IN ?", ids).Delete(&models.Book{})
+			if result.Error != nil {
+				return result.Error
+			}
+			books = result.RowsAffected
+		}
+
+		result := tx.Unscoped().
+			Where("deleted_at < ? AND NOT EXISTS (SELECT 1 FROM book_contributors WHERE author_id = authors.id)", before).
+			Delete(&models.Author{})
+		if result.Error != nil {
+			return result.Error
+		}
+		authors = result.RowsAffected
+		return nil
+	})
+	return books, authors, err
+}
@@ -25,11 +25,34 @@ func (repo *userRepo) CreateUser(ctx context.Context, user *models.User) error {
 		user.Id = uuid.New()
 	}
 	user.CreatedAt = time.Now()
-	return repo.db.WithContext(ctx).Create(user).Error
+	return conn(ctx, repo.db).Create(user).Error
 }
 
 // GetUserById implements repository.UserRepo.
+func (repo *userRepo) GetUserById(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	user := new(models.User)
+	return user, conn(ctx, repo.db).Where("id = ?", id).Take(user).Error
+}
+
+// GetUserByUsername implements repository.UserRepo.
 func (repo *userRepo) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
 	user := new(models.User)
-	return user, repo.db.WithContext(ctx).Where("LOWER(username) = ?", strings.ToLower(username)).Take(user).Error
+	return user, conn(ctx, repo.db).Where("LOWER(username) = ?", strings.ToLower(username)).Take(user).Error
+}
+
+// GetUsers implements repository.UserRepo.
+func (repo *userRepo) GetUsers(ctx context.Context) ([]*models.User, error) {
+	var users []*models.User
+
+	err := conn(ctx, repo.db).Order("username").Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// UpdateUserRole implements repository.UserRepo.
+func (repo *userRepo) UpdateUserRole(ctx context.Context, id uuid.UUID, role string) error {
+	return conn(ctx, repo.db).Model(&models.User{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"role": role, "updated_at": time.Now()}).Error
 }
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDuplicateHold = errors.New("you already have a hold on this book")
+	ErrHoldChanged   = errors.New("the hold was changed since it was read")
+)
+
+type HoldFilter struct {
+	UserId uuid.UUID
+	BookId uuid.UUID
+}
@@ -2,28 +2,231 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/storyofhis/books-management/httpserver/repository/models"
 )
 
+type Transactor interface {
+	// Transaction runs fn in a database transaction. Repositories called
+	// with the context passed to fn take part in it.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type UserRepo interface {
 	CreateUser(ctx context.Context, user *models.User) error
+	GetUserById(ctx context.Context, id uuid.UUID) (*models.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+	GetUsers(ctx context.Context) ([]*models.User, error)
+	UpdateUserRole(ctx context.Context, id uuid.UUID, role string) error
+}
+
+type SessionRepo interface {
+	CreateSession(ctx context.Context, session *models.Session) error
+	GetSessionById(ctx context.Context, id uuid.UUID) (*models.Session, error)
+	RevokeSession(ctx context.Context, id uuid.UUID) error
+	RevokeUserSessions(ctx context.Context, userId uuid.UUID) error
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, hash string) (*models.RefreshToken, error)
+	// UseRefreshToken marks the token as used and reports whether it was
+	// still unused.
+	UseRefreshToken(ctx context.Context, id uuid.UUID) (bool, error)
 }
 
+var (
+	ErrDuplicateIsbn = errors.New("a book with this isbn already exists")
+	// ErrVersionConflict is returned when a record was changed since the
+	// version the caller read.
+	ErrVersionConflict = errors.New("the record was changed by someone else")
+)
+
 type BookRepo interface {
 	CreateBook(ctx context.Context, book *models.Book) error
-	GetBooks(ctx context.Context) ([]*models.Book, error)
+	GetBooks(ctx context.Context, filter *BookFilter, page *Page) ([]*models.Book, *PageInfo, error)
+	// EachBook calls fn with the books matching filter, with their
+	// contributors, in batches ordered by sort. It stops at the first error.
+	EachBook(ctx context.Context, filter *BookFilter, sort string, fn func([]*models.Book) error) error
 	GetBookById(ctx context.Context, id uuid.UUID) (*models.Book, error)
-	DeleteBook(ctx context.Context, id uuid.UUID) error
+	// GetBooksByIds returns the books with one of the ids, with their
+	// contributors, in the order of ids.
+	GetBooksByIds(ctx context.Context, ids []uuid.UUID) ([]*models.Book, error)
+	// GetBooksByIsbns returns the books with one of the normalized isbns,
+	// without their contributors.
+	GetBooksByIsbns(ctx context.Context, isbns []string) ([]*models.Book, error)
+	// DeleteBook deletes the book if it is still at version. A book with a
+	// copy on loan or on hold is kept and ErrBookInCirculation returned.
+	DeleteBook(ctx context.Context, id uuid.UUID, version int) error
+	// UpdateBook saves book if it is still at book.Version and increments
+	// the version.
 	UpdateBook(ctx context.Context, book *models.Book, id uuid.UUID) error
+	GetDeletedBookById(ctx context.Context, id uuid.UUID) (*models.Book, error)
+	RestoreBook(ctx context.Context, id uuid.UUID) error
 }
 
 type AuthorRepo interface {
 	CreateAuthor(ctx context.Context, author *models.Author) error
-	GetAuthors(ctx context.Context) ([]*models.Author, error)
+	GetAuthors(ctx context.Context, filter *AuthorFilter, page *Page) ([]*models.Author, *PageInfo, error)
+	// EachAuthor calls fn with the authors matching filter in batches
+	// ordered by sort. It stops at the first error.
+	EachAuthor(ctx context.Context, filter *AuthorFilter, sort string, fn func([]*models.Author) error) error
 	GetAuthorById(ctx context.Context, id uuid.UUID) (*models.Author, error)
+	GetAuthorsByIds(ctx context.Context, ids []uuid.UUID) ([]*models.Author, error)
+	// GetAuthorsByNames returns the authors with one of the names, ignoring
+	// case as strings.ToLower does.
+	GetAuthorsByNames(ctx context.Context, names []string) ([]*models.Author, error)
+	// UpdateAuthor saves author if it is still at author.Version and
+	// increments the version.
 	UpdateAuthor(ctx context.Context, author *models.Author, id uuid.UUID) error
-	DeleteAuthor(ctx context.Context, id uuid.UUID) error
+	// DeleteAuthor returns the books deleted or credited to another author
+	// along with it.
+	DeleteAuthor(ctx context.Context, id uuid.UUID, opts *AuthorDelete) ([]*BookChange, error)
+	GetDeletedAuthorById(ctx context.Context, id uuid.UUID) (*models.Author, error)
+	// RestoreAuthor returns the books restored along with the author.
+	RestoreAuthor(ctx context.Context, id uuid.UUID) ([]*models.Book, error)
+}
+
+type CopyRepo interface {
+	CreateCopy(ctx context.Context, c *models.Copy) error
+	GetCopies(ctx context.Context, bookId uuid.UUID) ([]*models.Copy, error)
+	GetCopyById(ctx context.Context, id uuid.UUID) (*models.Copy, error)
+	// GetCopyByBarcode returns the copy with its book, copies of books in
+	// the trash are not found.
+	GetCopyByBarcode(ctx context.Context, barcode string) (*models.Copy, error)
+	// UpdateCopy saves the copy. The status of a copy on loan or on hold is
+	// left to the loans and holds, and a copy lent or held since it was read
+	// fails with ErrCopyInCirculation.
+	UpdateCopy(ctx context.Context, c *models.Copy) error
+	DeleteCopy(ctx context.Context, id uuid.UUID) error
+	// CountCopies returns the copy counts of the books, books without copies
+	// are left out.
+	CountCopies(ctx context.Context, bookIds []uuid.UUID) (map[uuid.UUID]*CopyCount, error)
+}
+
+type LoanRepo interface {
+	// CreateLoan marks the copy as on loan and creates the loan. status is
+	// the status of the copy read by the caller, available or on_hold, and
+	// the loan fails with ErrCopyNotAvailable when it changed since.
+	CreateLoan(ctx context.Context, loan *models.Loan, status string) error
+	// GetLoanById returns the loan with its copy and book.
+	GetLoanById(ctx context.Context, id uuid.UUID) (*models.Loan, error)
+	GetLoans(ctx context.Context, filter *LoanFilter, page *Page) ([]*models.Loan, *PageInfo, error)
+	// ReturnLoan closes the loan and makes the copy available again, it
+	// fails with ErrLoanClosed when the loan was already returned.
+	ReturnLoan(ctx context.Context, loan *models.Loan) error
+	// RenewLoan saves the new due date of the loan and counts the renewal.
+	// It fails with ErrLoanChanged when the loan was returned or renewed
+	// since it was read.
+	RenewLoan(ctx context.Context, loan *models.Loan) error
+}
+
+type HoldRepo interface {
+	// CreateHold fails with ErrDuplicateHold when the user already has an
+	// active hold on the book.
+	CreateHold(ctx context.Context, hold *models.Hold) error
+	// GetHoldById returns the hold with its book.
+	GetHoldById(ctx context.Context, id uuid.UUID) (*models.Hold, error)
+	// GetHolds returns the waiting and ready holds matching filter with
+	// their book and copy, oldest first.
+	GetHolds(ctx context.Context, filter *HoldFilter) ([]*models.Hold, error)
+	// GetReadyHold returns the hold the copy is kept for.
+	GetReadyHold(ctx context.Context, copyId uuid.UUID) (*models.Hold, error)
+	CountWaitingHolds(ctx context.Context, bookId uuid.UUID) (int64, error)
+	// AssignHolds gives the available copies of the book, or of every book
+	// when bookId is uuid.Nil, to its oldest waiting holds. The copies are
+	// kept until expiresAt and the holds that became ready are returned.
+	AssignHolds(ctx context.Context, bookId uuid.UUID, expiresAt time.Time) ([]*models.Hold, error)
+	// FulfillHold closes a ready hold once its copy is lent, ErrHoldChanged
+	// when it is no longer ready.
+	FulfillHold(ctx context.Context, id uuid.UUID) error
+	// CancelHold cancels a waiting or ready hold and makes the copy of a
+	// ready one available, ErrHoldChanged when it was closed since.
+	CancelHold(ctx context.Context, hold *models.Hold) error
+	// ExpireHolds expires the ready holds not picked up before now, makes
+	// their copies available and reports how many expired.
+	ExpireHolds(ctx context.Context, now time.Time) (int64, error)
+}
+
+// ReviewRepo updates the rating of the book with every change to its
+// reviews.
+type ShelfRepo interface {
+	// CreateShelf fails with ErrDuplicateShelf when the user already has a
+	// shelf with the name.
+	CreateShelf(ctx context.Context, shelf *models.Shelf) error
+	// CreateDefaultShelves creates the built-in shelves the user does not
+	// have yet.
+	CreateDefaultShelves(ctx context.Context, userId uuid.UUID) error
+	GetShelfById(ctx context.Context, id uuid.UUID) (*models.Shelf, error)
+	GetShelfByToken(ctx context.Context, token string) (*models.Shelf, error)
+	// GetShelves returns the shelves of the user with their item count, the
+	// built-in ones first. publicOnly leaves the private shelves out.
+	GetShelves(ctx context.Context, userId uuid.UUID, publicOnly bool) ([]*models.Shelf, error)
+	// UpdateShelf saves the name, the visibility and the share token of the
+	// shelf, ErrDuplicateShelf when the name is taken.
+	UpdateShelf(ctx context.Context, shelf *models.Shelf) error
+	// DeleteShelf deletes the shelf and its items.
+	DeleteShelf(ctx context.Context, id uuid.UUID) error
+	// GetShelfItems returns the items of the shelf with their book, in
+	// order. Books in the trash are left out.
+	GetShelfItems(ctx context.Context, shelfId uuid.UUID) ([]*models.ShelfItem, error)
+	// GetShelfItem returns the item of the book with the book, not found when
+	// the book is in the trash.
+	GetShelfItem(ctx context.Context, shelfId, bookId uuid.UUID) (*models.ShelfItem, error)
+	// AddShelfItem puts the item at its position, moving the items from
+	// there down, or at the end when the position is 0 or past the end. It
+	// fails with ErrDuplicateShelfItem when the book is already on the
+	// shelf.
+	AddShelfItem(ctx context.Context, item *models.ShelfItem) error
+	// UpdateShelfItem saves the note and the dates of the item and moves it
+	// to position, past the end being the end. A position of 0 leaves it
+	// where it is.
+	UpdateShelfItem(ctx context.Context, item *models.ShelfItem, position int) error
+	// RemoveShelfItem deletes the item and closes the gap it leaves.
+	RemoveShelfItem(ctx context.Context, item *models.ShelfItem) error
+}
+
+type ReviewRepo interface {
+	// CreateReview fails with ErrDuplicateReview when the user already
+	// reviewed the book.
+	CreateReview(ctx context.Context, review *models.Review) error
+	GetReviewById(ctx context.Context, id uuid.UUID) (*models.Review, error)
+	GetReviews(ctx context.Context, filter *ReviewFilter, page *Page) ([]*models.Review, *PageInfo, error)
+	// UpdateReview saves the rating and the body of the review.
+	UpdateReview(ctx context.Context, review *models.Review) error
+	// ModerateReview saves the status of the review and who changed it.
+	ModerateReview(ctx context.Context, review *models.Review) error
+	DeleteReview(ctx context.Context, review *models.Review) error
+}
+
+type LedgerRepo interface {
+	// SetFine creates or updates the fine entry of the loan.
+	SetFine(ctx context.Context, userId, loanId uuid.UUID, amount int64) error
+	CreateEntry(ctx context.Context, entry *models.LedgerEntry) error
+	GetBalance(ctx context.Context, userId uuid.UUID) (int64, error)
+	GetEntries(ctx context.Context, userId uuid.UUID, page *Page) ([]*models.LedgerEntry, *PageInfo, error)
+}
+
+type TrashRepo interface {
+	GetTrash(ctx context.Context, userId uuid.UUID) ([]*models.Book, []*models.Author, error)
+	// PurgeTrash permanently removes the books and authors deleted before
+	// the given time and reports how many of each were removed.
+	PurgeTrash(ctx context.Context, before time.Time) (int64, int64, error)
+}
+
+type HistoryRepo interface {
+	// AddHistory stores entry as the next version of its record.
+	AddHistory(ctx context.Context, entry *models.HistoryEntry) error
+	GetHistory(ctx context.Context, entityType string, entityId uuid.UUID) ([]*models.HistoryEntry, error)
+	GetHistoryVersion(ctx context.Context, entityType string, entityId uuid.UUID, version int) (*models.HistoryEntry, error)
+}
+
+type ConsistencyRepo interface {
+	CheckConsistency(ctx context.Context) (*ConsistencyReport, error)
+	RepairConsistency(ctx context.Context, mode RepairMode) (*ConsistencyReport, error)
+}
+
+type SearchRepo interface {
+	SearchBooks(ctx context.Context, query string, limit, offset int) ([]*BookHit, int64, error)
+	SearchAuthors(ctx context.Context, query string, limit, offset int) ([]*AuthorHit, int64, error)
 }
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCopyNotAvailable  = errors.New("the copy is not available for loan")
+	ErrCopyInCirculation = errors.New("the copy is on loan or on hold")
+	ErrBookInCirculation = errors.New("a copy of the book is on loan or on hold")
+	ErrLoanClosed        = errors.New("the loan was already returned")
+	ErrLoanChanged       = errors.New("the loan was changed since it was read")
+)
+
+const (
+	LoansCurrent = "current"
+	LoansPast    = "past"
+)
+
+type LoanFilter struct {
+	UserId uuid.UUID
+	BookId uuid.UUID
+	// Status is LoansCurrent for open loans, LoansPast for returned ones or
+	// empty for both.
+	Status string
+	// DueBefore only keeps the open loans due before it.
+	DueBefore time.Time
+}
@@ -71,22 +71,34 @@ func (_c *MockAuthorRepo_CreateAuthor_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
-// DeleteAuthor provides a mock function with given fields: ctx, id
-func (_m *MockAuthorRepo) DeleteAuthor(ctx context.Context, id uuid.UUID) error {
-	ret := _m.Called(ctx, id)
+// DeleteAuthor provides a mock function with given fields: ctx, id, opts
+func (_m *MockAuthorRepo) DeleteAuthor(ctx context.Context, id uuid.UUID, opts *AuthorDelete) ([]*BookChange, error) {
+	ret := _m.Called(ctx, id, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteAuthor")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, id)
+	var r0 []*BookChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *AuthorDelete) ([]*BookChange, error)); ok {
+		return rf(ctx, id, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *AuthorDelete) []*BookChange); ok {
+		r0 = rf(ctx, id, opts)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*BookChange)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *AuthorDelete) error); ok {
+		r1 = rf(ctx, id, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // MockAuthorRepo_DeleteAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAuthor'
@@ -97,23 +109,73 @@ type MockAuthorRepo_DeleteAuthor_Call struct {
 // DeleteAuthor is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
-func (_e *MockAuthorRepo_Expecter) DeleteAuthor(ctx interface{}, id interface{}) *MockAuthorRepo_DeleteAuthor_Call {
-	return &MockAuthorRepo_DeleteAuthor_Call{Call: _e.mock.On("DeleteAuthor", ctx, id)}
+//   - opts *AuthorDelete
+func (_e *MockAuthorRepo_Expecter) DeleteAuthor(ctx interface{}, id interface{}, opts interface{}) *MockAuthorRepo_DeleteAuthor_Call {
+	return &MockAuthorRepo_DeleteAuthor_Call{Call: _e.mock.On("DeleteAuthor", ctx, id, opts)}
 }
 
-func (_c *MockAuthorRepo_DeleteAuthor_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockAuthorRepo_DeleteAuthor_Call {
+func (_c *MockAuthorRepo_DeleteAuthor_Call) Run(run func(ctx context.Context, id uuid.UUID, opts *AuthorDelete)) *MockAuthorRepo_DeleteAuthor_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*AuthorDelete))
+	})
+	return _c
+}
+
+func (_c *MockAuthorRepo_DeleteAuthor_Call) Return(_a0 []*BookChange, _a1 error) *MockAuthorRepo_DeleteAuthor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthorRepo_DeleteAuthor_Call) RunAndReturn(run func(context.Context, uuid.UUID, *AuthorDelete) ([]*BookChange, error)) *MockAuthorRepo_DeleteAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachAuthor provides a mock function with given fields: ctx, filter, sort, fn
+func (_m *MockAuthorRepo) EachAuthor(ctx context.Context, filter *AuthorFilter, sort string, fn func([]*models.Author) error) error {
+	ret := _m.Called(ctx, filter, sort, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachAuthor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *AuthorFilter, string, func([]*models.Author) error) error); ok {
+		r0 = rf(ctx, filter, sort, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuthorRepo_EachAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachAuthor'
+type MockAuthorRepo_EachAuthor_Call struct {
+	*mock.Call
+}
+
+// EachAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *AuthorFilter
+//   - sort string
+//   - fn func([]*models.Author) error
+func (_e *MockAuthorRepo_Expecter) EachAuthor(ctx interface{}, filter interface{}, sort interface{}, fn interface{}) *MockAuthorRepo_EachAuthor_Call {
+	return &MockAuthorRepo_EachAuthor_Call{Call: _e.mock.On("EachAuthor", ctx, filter, sort, fn)}
+}
+
+func (_c *MockAuthorRepo_EachAuthor_Call) Run(run func(ctx context.Context, filter *AuthorFilter, sort string, fn func([]*models.Author) error)) *MockAuthorRepo_EachAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*AuthorFilter), args[2].(string), args[3].(func([]*models.Author) error))
 	})
 	return _c
 }
 
-func (_c *MockAuthorRepo_DeleteAuthor_Call) Return(_a0 error) *MockAuthorRepo_DeleteAuthor_Call {
+func (_c *MockAuthorRepo_EachAuthor_Call) Return(_a0 error) *MockAuthorRepo_EachAuthor_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockAuthorRepo_DeleteAuthor_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockAuthorRepo_DeleteAuthor_Call {
+func (_c *MockAuthorRepo_EachAuthor_Call) RunAndReturn(run func(context.Context, *AuthorFilter, string, func([]*models.Author) error) error) *MockAuthorRepo_EachAuthor_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -177,34 +239,43 @@ func (_c *MockAuthorRepo_GetAuthorById_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
-// GetAuthors provides a mock function with given fields: ctx
-func (_m *MockAuthorRepo) GetAuthors(ctx context.Context) ([]*models.Author, error) {
-	ret := _m.Called(ctx)
+// GetAuthors provides a mock function with given fields: ctx, filter, page
+func (_m *MockAuthorRepo) GetAuthors(ctx context.Context, filter *AuthorFilter, page *Page) ([]*models.Author, *PageInfo, error) {
+	ret := _m.Called(ctx, filter, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAuthors")
 	}
 
 	var r0 []*models.Author
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Author, error)); ok {
-		return rf(ctx)
+	var r1 *PageInfo
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *AuthorFilter, *Page) ([]*models.Author, *PageInfo, error)); ok {
+		return rf(ctx, filter, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*models.Author); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, *AuthorFilter, *Page) []*models.Author); ok {
+		r0 = rf(ctx, filter, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Author)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, *AuthorFilter, *Page) *PageInfo); ok {
+		r1 = rf(ctx, filter, page)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*PageInfo)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, *AuthorFilter, *Page) error); ok {
+		r2 = rf(ctx, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // MockAuthorRepo_GetAuthors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthors'
@@ -214,23 +285,261 @@ type MockAuthorRepo_GetAuthors_Call struct {
 
 // GetAuthors is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockAuthorRepo_Expecter) GetAuthors(ctx interface{}) *MockAuthorRepo_GetAuthors_Call {
-	return &MockAuthorRepo_GetAuthors_Call{Call: _e.mock.On("GetAuthors", ctx)}
+//   - filter *AuthorFilter
+//   - page *Page
+func (_e *MockAuthorRepo_Expecter) GetAuthors(ctx interface{}, filter interface{}, page interface{}) *MockAuthorRepo_GetAuthors_Call {
+	return &MockAuthorRepo_GetAuthors_Call{Call: _e.mock.On("GetAuthors", ctx, filter, page)}
+}
+
+func (_c *MockAuthorRepo_GetAuthors_Call) Run(run func(ctx context.Context, filter *AuthorFilter, page *Page)) *MockAuthorRepo_GetAuthors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*AuthorFilter), args[2].(*Page))
+	})
+	return _c
+}
+
+func (_c *MockAuthorRepo_GetAuthors_Call) Return(_a0 []*models.Author, _a1 *PageInfo, _a2 error) *MockAuthorRepo_GetAuthors_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockAuthorRepo_GetAuthors_Call) RunAndReturn(run func(context.Context, *AuthorFilter, *Page) ([]*models.Author, *PageInfo, error)) *MockAuthorRepo_GetAuthors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthorsByIds provides a mock function with given fields: ctx, ids
+func (_m *MockAuthorRepo) GetAuthorsByIds(ctx context.Context, ids []uuid.UUID) ([]*models.Author, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorsByIds")
+	}
+
+	var r0 []*models.Author
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]*models.Author, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []*models.Author); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Author)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthorRepo_GetAuthorsByIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorsByIds'
+type MockAuthorRepo_GetAuthorsByIds_Call struct {
+	*mock.Call
+}
+
+// GetAuthorsByIds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+func (_e *MockAuthorRepo_Expecter) GetAuthorsByIds(ctx interface{}, ids interface{}) *MockAuthorRepo_GetAuthorsByIds_Call {
+	return &MockAuthorRepo_GetAuthorsByIds_Call{Call: _e.mock.On("GetAuthorsByIds", ctx, ids)}
+}
+
+func (_c *MockAuthorRepo_GetAuthorsByIds_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockAuthorRepo_GetAuthorsByIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAuthorRepo_GetAuthorsByIds_Call) Return(_a0 []*models.Author, _a1 error) *MockAuthorRepo_GetAuthorsByIds_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthorRepo_GetAuthorsByIds_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]*models.Author, error)) *MockAuthorRepo_GetAuthorsByIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthorsByNames provides a mock function with given fields: ctx, names
+func (_m *MockAuthorRepo) GetAuthorsByNames(ctx context.Context, names []string) ([]*models.Author, error) {
+	ret := _m.Called(ctx, names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorsByNames")
+	}
+
+	var r0 []*models.Author
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*models.Author, error)); ok {
+		return rf(ctx, names)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*models.Author); ok {
+		r0 = rf(ctx, names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Author)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, names)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthorRepo_GetAuthorsByNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorsByNames'
+type MockAuthorRepo_GetAuthorsByNames_Call struct {
+	*mock.Call
+}
+
+// GetAuthorsByNames is a helper method to define mock.On call
+//   - ctx context.Context
+//   - names []string
+func (_e *MockAuthorRepo_Expecter) GetAuthorsByNames(ctx interface{}, names interface{}) *MockAuthorRepo_GetAuthorsByNames_Call {
+	return &MockAuthorRepo_GetAuthorsByNames_Call{Call: _e.mock.On("GetAuthorsByNames", ctx, names)}
 }
 
-func (_c *MockAuthorRepo_GetAuthors_Call) Run(run func(ctx context.Context)) *MockAuthorRepo_GetAuthors_Call {
+func (_c *MockAuthorRepo_GetAuthorsByNames_Call) Run(run func(ctx context.Context, names []string)) *MockAuthorRepo_GetAuthorsByNames_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockAuthorRepo_GetAuthorsByNames_Call) Return(_a0 []*models.Author, _a1 error) *MockAuthorRepo_GetAuthorsByNames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthorRepo_GetAuthorsByNames_Call) RunAndReturn(run func(context.Context, []string) ([]*models.Author, error)) *MockAuthorRepo_GetAuthorsByNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedAuthorById provides a mock function with given fields: ctx, id
+func (_m *MockAuthorRepo) GetDeletedAuthorById(ctx context.Context, id uuid.UUID) (*models.Author, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedAuthorById")
+	}
+
+	var r0 *models.Author
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Author, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Author); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Author)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthorRepo_GetDeletedAuthorById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedAuthorById'
+type MockAuthorRepo_GetDeletedAuthorById_Call struct {
+	*mock.Call
+}
+
+// GetDeletedAuthorById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockAuthorRepo_Expecter) GetDeletedAuthorById(ctx interface{}, id interface{}) *MockAuthorRepo_GetDeletedAuthorById_Call {
+	return &MockAuthorRepo_GetDeletedAuthorById_Call{Call: _e.mock.On("GetDeletedAuthorById", ctx, id)}
+}
+
+func (_c *MockAuthorRepo_GetDeletedAuthorById_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockAuthorRepo_GetDeletedAuthorById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAuthorRepo_GetDeletedAuthorById_Call) Return(_a0 *models.Author, _a1 error) *MockAuthorRepo_GetDeletedAuthorById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthorRepo_GetDeletedAuthorById_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Author, error)) *MockAuthorRepo_GetDeletedAuthorById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreAuthor provides a mock function with given fields: ctx, id
+func (_m *MockAuthorRepo) RestoreAuthor(ctx context.Context, id uuid.UUID) ([]*models.Book, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAuthor")
+	}
+
+	var r0 []*models.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.Book, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.Book); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthorRepo_RestoreAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreAuthor'
+type MockAuthorRepo_RestoreAuthor_Call struct {
+	*mock.Call
+}
+
+// RestoreAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockAuthorRepo_Expecter) RestoreAuthor(ctx interface{}, id interface{}) *MockAuthorRepo_RestoreAuthor_Call {
+	return &MockAuthorRepo_RestoreAuthor_Call{Call: _e.mock.On("RestoreAuthor", ctx, id)}
+}
+
+func (_c *MockAuthorRepo_RestoreAuthor_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockAuthorRepo_RestoreAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockAuthorRepo_GetAuthors_Call) Return(_a0 []*models.Author, _a1 error) *MockAuthorRepo_GetAuthors_Call {
+func (_c *MockAuthorRepo_RestoreAuthor_Call) Return(_a0 []*models.Book, _a1 error) *MockAuthorRepo_RestoreAuthor_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockAuthorRepo_GetAuthors_Call) RunAndReturn(run func(context.Context) ([]*models.Author, error)) *MockAuthorRepo_GetAuthors_Call {
+func (_c *MockAuthorRepo_RestoreAuthor_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.Book, error)) *MockAuthorRepo_RestoreAuthor_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -71,17 +71,17 @@ func (_c *MockBookRepo_CreateBook_Call) RunAndReturn(run func(context.Context, *
 	return _c
 }
 
-// DeleteBook provides a mock function with given fields: ctx, id
-func (_m *MockBookRepo) DeleteBook(ctx context.Context, id uuid.UUID) error {
-	ret := _m.Called(ctx, id)
+// DeleteBook provides a mock function with given fields: ctx, id, version
+func (_m *MockBookRepo) DeleteBook(ctx context.Context, id uuid.UUID, version int) error {
+	ret := _m.Called(ctx, id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteBook")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, id, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -97,13 +97,14 @@ type MockBookRepo_DeleteBook_Call struct {
 // DeleteBook is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
-func (_e *MockBookRepo_Expecter) DeleteBook(ctx interface{}, id interface{}) *MockBookRepo_DeleteBook_Call {
-	return &MockBookRepo_DeleteBook_Call{Call: _e.mock.On("DeleteBook", ctx, id)}
+//   - version int
+func (_e *MockBookRepo_Expecter) DeleteBook(ctx interface{}, id interface{}, version interface{}) *MockBookRepo_DeleteBook_Call {
+	return &MockBookRepo_DeleteBook_Call{Call: _e.mock.On("DeleteBook", ctx, id, version)}
 }
 
-func (_c *MockBookRepo_DeleteBook_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockBookRepo_DeleteBook_Call {
+func (_c *MockBookRepo_DeleteBook_Call) Run(run func(ctx context.Context, id uuid.UUID, version int)) *MockBookRepo_DeleteBook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
 	})
 	return _c
 }
@@ -113,7 +114,56 @@ func (_c *MockBookRepo_DeleteBook_Call) Return(_a0 error) *MockBookRepo_DeleteBo
 	return _c
 }
 
-func (_c *MockBookRepo_DeleteBook_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockBookRepo_DeleteBook_Call {
+func (_c *MockBookRepo_DeleteBook_Call) RunAndReturn(run func(context.Context, uuid.UUID, int) error) *MockBookRepo_DeleteBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EachBook provides a mock function with given fields: ctx, filter, sort, fn
+func (_m *MockBookRepo) EachBook(ctx context.Context, filter *BookFilter, sort string, fn func([]*models.Book) error) error {
+	ret := _m.Called(ctx, filter, sort, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EachBook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *BookFilter, string, func([]*models.Book) error) error); ok {
+		r0 = rf(ctx, filter, sort, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBookRepo_EachBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EachBook'
+type MockBookRepo_EachBook_Call struct {
+	*mock.Call
+}
+
+// EachBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *BookFilter
+//   - sort string
+//   - fn func([]*models.Book) error
+func (_e *MockBookRepo_Expecter) EachBook(ctx interface{}, filter interface{}, sort interface{}, fn interface{}) *MockBookRepo_EachBook_Call {
+	return &MockBookRepo_EachBook_Call{Call: _e.mock.On("EachBook", ctx, filter, sort, fn)}
+}
+
+func (_c *MockBookRepo_EachBook_Call) Run(run func(ctx context.Context, filter *BookFilter, sort string, fn func([]*models.Book) error)) *MockBookRepo_EachBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*BookFilter), args[2].(string), args[3].(func([]*models.Book) error))
+	})
+	return _c
+}
+
+func (_c *MockBookRepo_EachBook_Call) Return(_a0 error) *MockBookRepo_EachBook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBookRepo_EachBook_Call) RunAndReturn(run func(context.Context, *BookFilter, string, func([]*models.Book) error) error) *MockBookRepo_EachBook_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -177,34 +227,43 @@ func (_c *MockBookRepo_GetBookById_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
-// GetBooks provides a mock function with given fields: ctx
-func (_m *MockBookRepo) GetBooks(ctx context.Context) ([]*models.Book, error) {
-	ret := _m.Called(ctx)
+// GetBooks provides a mock function with given fields: ctx, filter, page
+func (_m *MockBookRepo) GetBooks(ctx context.Context, filter *BookFilter, page *Page) ([]*models.Book, *PageInfo, error) {
+	ret := _m.Called(ctx, filter, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBooks")
 	}
 
 	var r0 []*models.Book
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Book, error)); ok {
-		return rf(ctx)
+	var r1 *PageInfo
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *BookFilter, *Page) ([]*models.Book, *PageInfo, error)); ok {
+		return rf(ctx, filter, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*models.Book); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, *BookFilter, *Page) []*models.Book); ok {
+		r0 = rf(ctx, filter, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Book)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, *BookFilter, *Page) *PageInfo); ok {
+		r1 = rf(ctx, filter, page)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*PageInfo)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, *BookFilter, *Page) error); ok {
+		r2 = rf(ctx, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // MockBookRepo_GetBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBooks'
@@ -214,23 +273,249 @@ type MockBookRepo_GetBooks_Call struct {
 
 // GetBooks is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockBookRepo_Expecter) GetBooks(ctx interface{}) *MockBookRepo_GetBooks_Call {
-	return &MockBookRepo_GetBooks_Call{Call: _e.mock.On("GetBooks", ctx)}
+//   - filter *BookFilter
+//   - page *Page
+func (_e *MockBookRepo_Expecter) GetBooks(ctx interface{}, filter interface{}, page interface{}) *MockBookRepo_GetBooks_Call {
+	return &MockBookRepo_GetBooks_Call{Call: _e.mock.On("GetBooks", ctx, filter, page)}
+}
+
+func (_c *MockBookRepo_GetBooks_Call) Run(run func(ctx context.Context, filter *BookFilter, page *Page)) *MockBookRepo_GetBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*BookFilter), args[2].(*Page))
+	})
+	return _c
+}
+
+func (_c *MockBookRepo_GetBooks_Call) Return(_a0 []*models.Book, _a1 *PageInfo, _a2 error) *MockBookRepo_GetBooks_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockBookRepo_GetBooks_Call) RunAndReturn(run func(context.Context, *BookFilter, *Page) ([]*models.Book, *PageInfo, error)) *MockBookRepo_GetBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBooksByIds provides a mock function with given fields: ctx, ids
+func (_m *MockBookRepo) GetBooksByIds(ctx context.Context, ids []uuid.UUID) ([]*models.Book, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBooksByIds")
+	}
+
+	var r0 []*models.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]*models.Book, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []*models.Book); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBookRepo_GetBooksByIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBooksByIds'
+type MockBookRepo_GetBooksByIds_Call struct {
+	*mock.Call
+}
+
+// GetBooksByIds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+func (_e *MockBookRepo_Expecter) GetBooksByIds(ctx interface{}, ids interface{}) *MockBookRepo_GetBooksByIds_Call {
+	return &MockBookRepo_GetBooksByIds_Call{Call: _e.mock.On("GetBooksByIds", ctx, ids)}
 }
 
-func (_c *MockBookRepo_GetBooks_Call) Run(run func(ctx context.Context)) *MockBookRepo_GetBooks_Call {
+func (_c *MockBookRepo_GetBooksByIds_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockBookRepo_GetBooksByIds_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockBookRepo_GetBooks_Call) Return(_a0 []*models.Book, _a1 error) *MockBookRepo_GetBooks_Call {
+func (_c *MockBookRepo_GetBooksByIds_Call) Return(_a0 []*models.Book, _a1 error) *MockBookRepo_GetBooksByIds_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockBookRepo_GetBooks_Call) RunAndReturn(run func(context.Context) ([]*models.Book, error)) *MockBookRepo_GetBooks_Call {
+func (_c *MockBookRepo_GetBooksByIds_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]*models.Book, error)) *MockBookRepo_GetBooksByIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBooksByIsbns provides a mock function with given fields: ctx, isbns
+func (_m *MockBookRepo) GetBooksByIsbns(ctx context.Context, isbns []string) ([]*models.Book, error) {
+	ret := _m.Called(ctx, isbns)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBooksByIsbns")
+	}
+
+	var r0 []*models.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*models.Book, error)); ok {
+		return rf(ctx, isbns)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*models.Book); ok {
+		r0 = rf(ctx, isbns)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, isbns)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBookRepo_GetBooksByIsbns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBooksByIsbns'
+type MockBookRepo_GetBooksByIsbns_Call struct {
+	*mock.Call
+}
+
+// GetBooksByIsbns is a helper method to define mock.On call
+//   - ctx context.Context
+//   - isbns []string
+func (_e *MockBookRepo_Expecter) GetBooksByIsbns(ctx interface{}, isbns interface{}) *MockBookRepo_GetBooksByIsbns_Call {
+	return &MockBookRepo_GetBooksByIsbns_Call{Call: _e.mock.On("GetBooksByIsbns", ctx, isbns)}
+}
+
+func (_c *MockBookRepo_GetBooksByIsbns_Call) Run(run func(ctx context.Context, isbns []string)) *MockBookRepo_GetBooksByIsbns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockBookRepo_GetBooksByIsbns_Call) Return(_a0 []*models.Book, _a1 error) *MockBookRepo_GetBooksByIsbns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBookRepo_GetBooksByIsbns_Call) RunAndReturn(run func(context.Context, []string) ([]*models.Book, error)) *MockBookRepo_GetBooksByIsbns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedBookById provides a mock function with given fields: ctx, id
+func (_m *MockBookRepo) GetDeletedBookById(ctx context.Context, id uuid.UUID) (*models.Book, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedBookById")
+	}
+
+	var r0 *models.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Book, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Book); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBookRepo_GetDeletedBookById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedBookById'
+type MockBookRepo_GetDeletedBookById_Call struct {
+	*mock.Call
+}
+
+// GetDeletedBookById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockBookRepo_Expecter) GetDeletedBookById(ctx interface{}, id interface{}) *MockBookRepo_GetDeletedBookById_Call {
+	return &MockBookRepo_GetDeletedBookById_Call{Call: _e.mock.On("GetDeletedBookById", ctx, id)}
+}
+
+func (_c *MockBookRepo_GetDeletedBookById_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockBookRepo_GetDeletedBookById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBookRepo_GetDeletedBookById_Call) Return(_a0 *models.Book, _a1 error) *MockBookRepo_GetDeletedBookById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBookRepo_GetDeletedBookById_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Book, error)) *MockBookRepo_GetDeletedBookById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreBook provides a mock function with given fields: ctx, id
+func (_m *MockBookRepo) RestoreBook(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreBook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBookRepo_RestoreBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreBook'
+type MockBookRepo_RestoreBook_Call struct {
+	*mock.Call
+}
+
+// RestoreBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockBookRepo_Expecter) RestoreBook(ctx interface{}, id interface{}) *MockBookRepo_RestoreBook_Call {
+	return &MockBookRepo_RestoreBook_Call{Call: _e.mock.On("RestoreBook", ctx, id)}
+}
+
+func (_c *MockBookRepo_RestoreBook_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockBookRepo_RestoreBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBookRepo_RestoreBook_Call) Return(_a0 error) *MockBookRepo_RestoreBook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBookRepo_RestoreBook_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockBookRepo_RestoreBook_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,153 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockConsistencyRepo is an autogenerated mock type for the ConsistencyRepo type
+type MockConsistencyRepo struct {
+	mock.Mock
+}
+
+type MockConsistencyRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockConsistencyRepo) EXPECT() *MockConsistencyRepo_Expecter {
+	return &MockConsistencyRepo_Expecter{mock: &_m.Mock}
+}
+
+// CheckConsistency provides a mock function with given fields: ctx
+func (_m *MockConsistencyRepo) CheckConsistency(ctx context.Context) (*ConsistencyReport, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckConsistency")
+	}
+
+	var r0 *ConsistencyReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*ConsistencyReport, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *ConsistencyReport); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ConsistencyReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConsistencyRepo_CheckConsistency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckConsistency'
+type MockConsistencyRepo_CheckConsistency_Call struct {
+	*mock.Call
+}
+
+// CheckConsistency is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockConsistencyRepo_Expecter) CheckConsistency(ctx interface{}) *MockConsistencyRepo_CheckConsistency_Call {
+	return &MockConsistencyRepo_CheckConsistency_Call{Call: _e.mock.On("CheckConsistency", ctx)}
+}
+
+func (_c *MockConsistencyRepo_CheckConsistency_Call) Run(run func(ctx context.Context)) *MockConsistencyRepo_CheckConsistency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockConsistencyRepo_CheckConsistency_Call) Return(_a0 *ConsistencyReport, _a1 error) *MockConsistencyRepo_CheckConsistency_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConsistencyRepo_CheckConsistency_Call) RunAndReturn(run func(context.Context) (*ConsistencyReport, error)) *MockConsistencyRepo_CheckConsistency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RepairConsistency provides a mock function with given fields: ctx, mode
+func (_m *MockConsistencyRepo) RepairConsistency(ctx context.Context, mode RepairMode) (*ConsistencyReport, error) {
+	ret := _m.Called(ctx, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RepairConsistency")
+	}
+
+	var r0 *ConsistencyReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, RepairMode) (*ConsistencyReport, error)); ok {
+		return rf(ctx, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, RepairMode) *ConsistencyReport); ok {
+		r0 = rf(ctx, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ConsistencyReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, RepairMode) error); ok {
+		r1 = rf(ctx, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConsistencyRepo_RepairConsistency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RepairConsistency'
+type MockConsistencyRepo_RepairConsistency_Call struct {
+	*mock.Call
+}
+
+// RepairConsistency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mode RepairMode
+func (_e *MockConsistencyRepo_Expecter) RepairConsistency(ctx interface{}, mode interface{}) *MockConsistencyRepo_RepairConsistency_Call {
+	return &MockConsistencyRepo_RepairConsistency_Call{Call: _e.mock.On("RepairConsistency", ctx, mode)}
+}
+
+func (_c *MockConsistencyRepo_RepairConsistency_Call) Run(run func(ctx context.Context, mode RepairMode)) *MockConsistencyRepo_RepairConsistency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(RepairMode))
+	})
+	return _c
+}
+
+func (_c *MockConsistencyRepo_RepairConsistency_Call) Return(_a0 *ConsistencyReport, _a1 error) *MockConsistencyRepo_RepairConsistency_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConsistencyRepo_RepairConsistency_Call) RunAndReturn(run func(context.Context, RepairMode) (*ConsistencyReport, error)) *MockConsistencyRepo_RepairConsistency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockConsistencyRepo creates a new instance of MockConsistencyRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockConsistencyRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockConsistencyRepo {
+	mock := &MockConsistencyRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
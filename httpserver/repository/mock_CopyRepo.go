@@ -0,0 +1,416 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/storyofhis/books-management/httpserver/repository/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockCopyRepo is an autogenerated mock type for the CopyRepo type
+type MockCopyRepo struct {
+	mock.Mock
+}
+
+type MockCopyRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCopyRepo) EXPECT() *MockCopyRepo_Expecter {
+	return &MockCopyRepo_Expecter{mock: &_m.Mock}
+}
+
+// CountCopies provides a mock function with given fields: ctx, bookIds
+func (_m *MockCopyRepo) CountCopies(ctx context.Context, bookIds []uuid.UUID) (map[uuid.UUID]*CopyCount, error) {
+	ret := _m.Called(ctx, bookIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountCopies")
+	}
+
+	var r0 map[uuid.UUID]*CopyCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) (map[uuid.UUID]*CopyCount, error)); ok {
+		return rf(ctx, bookIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) map[uuid.UUID]*CopyCount); ok {
+		r0 = rf(ctx, bookIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]*CopyCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, bookIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCopyRepo_CountCopies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountCopies'
+type MockCopyRepo_CountCopies_Call struct {
+	*mock.Call
+}
+
+// CountCopies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookIds []uuid.UUID
+func (_e *MockCopyRepo_Expecter) CountCopies(ctx interface{}, bookIds interface{}) *MockCopyRepo_CountCopies_Call {
+	return &MockCopyRepo_CountCopies_Call{Call: _e.mock.On("CountCopies", ctx, bookIds)}
+}
+
+func (_c *MockCopyRepo_CountCopies_Call) Run(run func(ctx context.Context, bookIds []uuid.UUID)) *MockCopyRepo_CountCopies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCopyRepo_CountCopies_Call) Return(_a0 map[uuid.UUID]*CopyCount, _a1 error) *MockCopyRepo_CountCopies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCopyRepo_CountCopies_Call) RunAndReturn(run func(context.Context, []uuid.UUID) (map[uuid.UUID]*CopyCount, error)) *MockCopyRepo_CountCopies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCopy provides a mock function with given fields: ctx, c
+func (_m *MockCopyRepo) CreateCopy(ctx context.Context, c *models.Copy) error {
+	ret := _m.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCopy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Copy) error); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCopyRepo_CreateCopy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCopy'
+type MockCopyRepo_CreateCopy_Call struct {
+	*mock.Call
+}
+
+// CreateCopy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c *models.Copy
+func (_e *MockCopyRepo_Expecter) CreateCopy(ctx interface{}, c interface{}) *MockCopyRepo_CreateCopy_Call {
+	return &MockCopyRepo_CreateCopy_Call{Call: _e.mock.On("CreateCopy", ctx, c)}
+}
+
+func (_c *MockCopyRepo_CreateCopy_Call) Run(run func(ctx context.Context, c *models.Copy)) *MockCopyRepo_CreateCopy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Copy))
+	})
+	return _c
+}
+
+func (_c *MockCopyRepo_CreateCopy_Call) Return(_a0 error) *MockCopyRepo_CreateCopy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCopyRepo_CreateCopy_Call) RunAndReturn(run func(context.Context, *models.Copy) error) *MockCopyRepo_CreateCopy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCopy provides a mock function with given fields: ctx, id
+func (_m *MockCopyRepo) DeleteCopy(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCopy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCopyRepo_DeleteCopy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCopy'
+type MockCopyRepo_DeleteCopy_Call struct {
+	*mock.Call
+}
+
+// DeleteCopy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockCopyRepo_Expecter) DeleteCopy(ctx interface{}, id interface{}) *MockCopyRepo_DeleteCopy_Call {
+	return &MockCopyRepo_DeleteCopy_Call{Call: _e.mock.On("DeleteCopy", ctx, id)}
+}
+
+func (_c *MockCopyRepo_DeleteCopy_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockCopyRepo_DeleteCopy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCopyRepo_DeleteCopy_Call) Return(_a0 error) *MockCopyRepo_DeleteCopy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCopyRepo_DeleteCopy_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockCopyRepo_DeleteCopy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCopies provides a mock function with given fields: ctx, bookId
+func (_m *MockCopyRepo) GetCopies(ctx context.Context, bookId uuid.UUID) ([]*models.Copy, error) {
+	ret := _m.Called(ctx, bookId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCopies")
+	}
+
+	var r0 []*models.Copy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.Copy, error)); ok {
+		return rf(ctx, bookId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.Copy); ok {
+		r0 = rf(ctx, bookId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Copy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, bookId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCopyRepo_GetCopies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCopies'
+type MockCopyRepo_GetCopies_Call struct {
+	*mock.Call
+}
+
+// GetCopies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+func (_e *MockCopyRepo_Expecter) GetCopies(ctx interface{}, bookId interface{}) *MockCopyRepo_GetCopies_Call {
+	return &MockCopyRepo_GetCopies_Call{Call: _e.mock.On("GetCopies", ctx, bookId)}
+}
+
+func (_c *MockCopyRepo_GetCopies_Call) Run(run func(ctx context.Context, bookId uuid.UUID)) *MockCopyRepo_GetCopies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCopyRepo_GetCopies_Call) Return(_a0 []*models.Copy, _a1 error) *MockCopyRepo_GetCopies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCopyRepo_GetCopies_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.Copy, error)) *MockCopyRepo_GetCopies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCopyByBarcode provides a mock function with given fields: ctx, barcode
+func (_m *MockCopyRepo) GetCopyByBarcode(ctx context.Context, barcode string) (*models.Copy, error) {
+	ret := _m.Called(ctx, barcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCopyByBarcode")
+	}
+
+	var r0 *models.Copy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Copy, error)); ok {
+		return rf(ctx, barcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Copy); ok {
+		r0 = rf(ctx, barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Copy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCopyRepo_GetCopyByBarcode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCopyByBarcode'
+type MockCopyRepo_GetCopyByBarcode_Call struct {
+	*mock.Call
+}
+
+// GetCopyByBarcode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - barcode string
+func (_e *MockCopyRepo_Expecter) GetCopyByBarcode(ctx interface{}, barcode interface{}) *MockCopyRepo_GetCopyByBarcode_Call {
+	return &MockCopyRepo_GetCopyByBarcode_Call{Call: _e.mock.On("GetCopyByBarcode", ctx, barcode)}
+}
+
+func (_c *MockCopyRepo_GetCopyByBarcode_Call) Run(run func(ctx context.Context, barcode string)) *MockCopyRepo_GetCopyByBarcode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCopyRepo_GetCopyByBarcode_Call) Return(_a0 *models.Copy, _a1 error) *MockCopyRepo_GetCopyByBarcode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCopyRepo_GetCopyByBarcode_Call) RunAndReturn(run func(context.Context, string) (*models.Copy, error)) *MockCopyRepo_GetCopyByBarcode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCopyById provides a mock function with given fields: ctx, id
+func (_m *MockCopyRepo) GetCopyById(ctx context.Context, id uuid.UUID) (*models.Copy, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCopyById")
+	}
+
+	var r0 *models.Copy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Copy, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Copy); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Copy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCopyRepo_GetCopyById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCopyById'
+type MockCopyRepo_GetCopyById_Call struct {
+	*mock.Call
+}
+
+// GetCopyById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockCopyRepo_Expecter) GetCopyById(ctx interface{}, id interface{}) *MockCopyRepo_GetCopyById_Call {
+	return &MockCopyRepo_GetCopyById_Call{Call: _e.mock.On("GetCopyById", ctx, id)}
+}
+
+func (_c *MockCopyRepo_GetCopyById_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockCopyRepo_GetCopyById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCopyRepo_GetCopyById_Call) Return(_a0 *models.Copy, _a1 error) *MockCopyRepo_GetCopyById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCopyRepo_GetCopyById_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Copy, error)) *MockCopyRepo_GetCopyById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCopy provides a mock function with given fields: ctx, c
+func (_m *MockCopyRepo) UpdateCopy(ctx context.Context, c *models.Copy) error {
+	ret := _m.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCopy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Copy) error); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCopyRepo_UpdateCopy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCopy'
+type MockCopyRepo_UpdateCopy_Call struct {
+	*mock.Call
+}
+
+// UpdateCopy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c *models.Copy
+func (_e *MockCopyRepo_Expecter) UpdateCopy(ctx interface{}, c interface{}) *MockCopyRepo_UpdateCopy_Call {
+	return &MockCopyRepo_UpdateCopy_Call{Call: _e.mock.On("UpdateCopy", ctx, c)}
+}
+
+func (_c *MockCopyRepo_UpdateCopy_Call) Run(run func(ctx context.Context, c *models.Copy)) *MockCopyRepo_UpdateCopy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Copy))
+	})
+	return _c
+}
+
+func (_c *MockCopyRepo_UpdateCopy_Call) Return(_a0 error) *MockCopyRepo_UpdateCopy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCopyRepo_UpdateCopy_Call) RunAndReturn(run func(context.Context, *models.Copy) error) *MockCopyRepo_UpdateCopy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCopyRepo creates a new instance of MockCopyRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCopyRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCopyRepo {
+	mock := &MockCopyRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,207 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/storyofhis/books-management/httpserver/repository/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockHistoryRepo is an autogenerated mock type for the HistoryRepo type
+type MockHistoryRepo struct {
+	mock.Mock
+}
+
+type MockHistoryRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHistoryRepo) EXPECT() *MockHistoryRepo_Expecter {
+	return &MockHistoryRepo_Expecter{mock: &_m.Mock}
+}
+
+// AddHistory provides a mock function with given fields: ctx, entry
+func (_m *MockHistoryRepo) AddHistory(ctx context.Context, entry *models.HistoryEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.HistoryEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHistoryRepo_AddHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddHistory'
+type MockHistoryRepo_AddHistory_Call struct {
+	*mock.Call
+}
+
+// AddHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *models.HistoryEntry
+func (_e *MockHistoryRepo_Expecter) AddHistory(ctx interface{}, entry interface{}) *MockHistoryRepo_AddHistory_Call {
+	return &MockHistoryRepo_AddHistory_Call{Call: _e.mock.On("AddHistory", ctx, entry)}
+}
+
+func (_c *MockHistoryRepo_AddHistory_Call) Run(run func(ctx context.Context, entry *models.HistoryEntry)) *MockHistoryRepo_AddHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.HistoryEntry))
+	})
+	return _c
+}
+
+func (_c *MockHistoryRepo_AddHistory_Call) Return(_a0 error) *MockHistoryRepo_AddHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHistoryRepo_AddHistory_Call) RunAndReturn(run func(context.Context, *models.HistoryEntry) error) *MockHistoryRepo_AddHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHistory provides a mock function with given fields: ctx, entityType, entityId
+func (_m *MockHistoryRepo) GetHistory(ctx context.Context, entityType string, entityId uuid.UUID) ([]*models.HistoryEntry, error) {
+	ret := _m.Called(ctx, entityType, entityId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 []*models.HistoryEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) ([]*models.HistoryEntry, error)); ok {
+		return rf(ctx, entityType, entityId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) []*models.HistoryEntry); ok {
+		r0 = rf(ctx, entityType, entityId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.HistoryEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, entityType, entityId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHistoryRepo_GetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHistory'
+type MockHistoryRepo_GetHistory_Call struct {
+	*mock.Call
+}
+
+// GetHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityType string
+//   - entityId uuid.UUID
+func (_e *MockHistoryRepo_Expecter) GetHistory(ctx interface{}, entityType interface{}, entityId interface{}) *MockHistoryRepo_GetHistory_Call {
+	return &MockHistoryRepo_GetHistory_Call{Call: _e.mock.On("GetHistory", ctx, entityType, entityId)}
+}
+
+func (_c *MockHistoryRepo_GetHistory_Call) Run(run func(ctx context.Context, entityType string, entityId uuid.UUID)) *MockHistoryRepo_GetHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHistoryRepo_GetHistory_Call) Return(_a0 []*models.HistoryEntry, _a1 error) *MockHistoryRepo_GetHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHistoryRepo_GetHistory_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) ([]*models.HistoryEntry, error)) *MockHistoryRepo_GetHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHistoryVersion provides a mock function with given fields: ctx, entityType, entityId, version
+func (_m *MockHistoryRepo) GetHistoryVersion(ctx context.Context, entityType string, entityId uuid.UUID, version int) (*models.HistoryEntry, error) {
+	ret := _m.Called(ctx, entityType, entityId, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistoryVersion")
+	}
+
+	var r0 *models.HistoryEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, int) (*models.HistoryEntry, error)); ok {
+		return rf(ctx, entityType, entityId, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, int) *models.HistoryEntry); ok {
+		r0 = rf(ctx, entityType, entityId, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.HistoryEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, entityType, entityId, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHistoryRepo_GetHistoryVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHistoryVersion'
+type MockHistoryRepo_GetHistoryVersion_Call struct {
+	*mock.Call
+}
+
+// GetHistoryVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityType string
+//   - entityId uuid.UUID
+//   - version int
+func (_e *MockHistoryRepo_Expecter) GetHistoryVersion(ctx interface{}, entityType interface{}, entityId interface{}, version interface{}) *MockHistoryRepo_GetHistoryVersion_Call {
+	return &MockHistoryRepo_GetHistoryVersion_Call{Call: _e.mock.On("GetHistoryVersion", ctx, entityType, entityId, version)}
+}
+
+func (_c *MockHistoryRepo_GetHistoryVersion_Call) Run(run func(ctx context.Context, entityType string, entityId uuid.UUID, version int)) *MockHistoryRepo_GetHistoryVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockHistoryRepo_GetHistoryVersion_Call) Return(_a0 *models.HistoryEntry, _a1 error) *MockHistoryRepo_GetHistoryVersion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHistoryRepo_GetHistoryVersion_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, int) (*models.HistoryEntry, error)) *MockHistoryRepo_GetHistoryVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHistoryRepo creates a new instance of MockHistoryRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHistoryRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHistoryRepo {
+	mock := &MockHistoryRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,533 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/storyofhis/books-management/httpserver/repository/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockHoldRepo is an autogenerated mock type for the HoldRepo type
+type MockHoldRepo struct {
+	mock.Mock
+}
+
+type MockHoldRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHoldRepo) EXPECT() *MockHoldRepo_Expecter {
+	return &MockHoldRepo_Expecter{mock: &_m.Mock}
+}
+
+// AssignHolds provides a mock function with given fields: ctx, bookId, expiresAt
+func (_m *MockHoldRepo) AssignHolds(ctx context.Context, bookId uuid.UUID, expiresAt time.Time) ([]*models.Hold, error) {
+	ret := _m.Called(ctx, bookId, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignHolds")
+	}
+
+	var r0 []*models.Hold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) ([]*models.Hold, error)); ok {
+		return rf(ctx, bookId, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) []*models.Hold); ok {
+		r0 = rf(ctx, bookId, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Hold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, bookId, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHoldRepo_AssignHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignHolds'
+type MockHoldRepo_AssignHolds_Call struct {
+	*mock.Call
+}
+
+// AssignHolds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+//   - expiresAt time.Time
+func (_e *MockHoldRepo_Expecter) AssignHolds(ctx interface{}, bookId interface{}, expiresAt interface{}) *MockHoldRepo_AssignHolds_Call {
+	return &MockHoldRepo_AssignHolds_Call{Call: _e.mock.On("AssignHolds", ctx, bookId, expiresAt)}
+}
+
+func (_c *MockHoldRepo_AssignHolds_Call) Run(run func(ctx context.Context, bookId uuid.UUID, expiresAt time.Time)) *MockHoldRepo_AssignHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_AssignHolds_Call) Return(_a0 []*models.Hold, _a1 error) *MockHoldRepo_AssignHolds_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHoldRepo_AssignHolds_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) ([]*models.Hold, error)) *MockHoldRepo_AssignHolds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancelHold provides a mock function with given fields: ctx, hold
+func (_m *MockHoldRepo) CancelHold(ctx context.Context, hold *models.Hold) error {
+	ret := _m.Called(ctx, hold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelHold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Hold) error); ok {
+		r0 = rf(ctx, hold)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHoldRepo_CancelHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelHold'
+type MockHoldRepo_CancelHold_Call struct {
+	*mock.Call
+}
+
+// CancelHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hold *models.Hold
+func (_e *MockHoldRepo_Expecter) CancelHold(ctx interface{}, hold interface{}) *MockHoldRepo_CancelHold_Call {
+	return &MockHoldRepo_CancelHold_Call{Call: _e.mock.On("CancelHold", ctx, hold)}
+}
+
+func (_c *MockHoldRepo_CancelHold_Call) Run(run func(ctx context.Context, hold *models.Hold)) *MockHoldRepo_CancelHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Hold))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_CancelHold_Call) Return(_a0 error) *MockHoldRepo_CancelHold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHoldRepo_CancelHold_Call) RunAndReturn(run func(context.Context, *models.Hold) error) *MockHoldRepo_CancelHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountWaitingHolds provides a mock function with given fields: ctx, bookId
+func (_m *MockHoldRepo) CountWaitingHolds(ctx context.Context, bookId uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, bookId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountWaitingHolds")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, bookId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, bookId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, bookId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHoldRepo_CountWaitingHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountWaitingHolds'
+type MockHoldRepo_CountWaitingHolds_Call struct {
+	*mock.Call
+}
+
+// CountWaitingHolds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+func (_e *MockHoldRepo_Expecter) CountWaitingHolds(ctx interface{}, bookId interface{}) *MockHoldRepo_CountWaitingHolds_Call {
+	return &MockHoldRepo_CountWaitingHolds_Call{Call: _e.mock.On("CountWaitingHolds", ctx, bookId)}
+}
+
+func (_c *MockHoldRepo_CountWaitingHolds_Call) Run(run func(ctx context.Context, bookId uuid.UUID)) *MockHoldRepo_CountWaitingHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_CountWaitingHolds_Call) Return(_a0 int64, _a1 error) *MockHoldRepo_CountWaitingHolds_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHoldRepo_CountWaitingHolds_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockHoldRepo_CountWaitingHolds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateHold provides a mock function with given fields: ctx, hold
+func (_m *MockHoldRepo) CreateHold(ctx context.Context, hold *models.Hold) error {
+	ret := _m.Called(ctx, hold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateHold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Hold) error); ok {
+		r0 = rf(ctx, hold)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHoldRepo_CreateHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateHold'
+type MockHoldRepo_CreateHold_Call struct {
+	*mock.Call
+}
+
+// CreateHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hold *models.Hold
+func (_e *MockHoldRepo_Expecter) CreateHold(ctx interface{}, hold interface{}) *MockHoldRepo_CreateHold_Call {
+	return &MockHoldRepo_CreateHold_Call{Call: _e.mock.On("CreateHold", ctx, hold)}
+}
+
+func (_c *MockHoldRepo_CreateHold_Call) Run(run func(ctx context.Context, hold *models.Hold)) *MockHoldRepo_CreateHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Hold))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_CreateHold_Call) Return(_a0 error) *MockHoldRepo_CreateHold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHoldRepo_CreateHold_Call) RunAndReturn(run func(context.Context, *models.Hold) error) *MockHoldRepo_CreateHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExpireHolds provides a mock function with given fields: ctx, now
+func (_m *MockHoldRepo) ExpireHolds(ctx context.Context, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireHolds")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHoldRepo_ExpireHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireHolds'
+type MockHoldRepo_ExpireHolds_Call struct {
+	*mock.Call
+}
+
+// ExpireHolds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MockHoldRepo_Expecter) ExpireHolds(ctx interface{}, now interface{}) *MockHoldRepo_ExpireHolds_Call {
+	return &MockHoldRepo_ExpireHolds_Call{Call: _e.mock.On("ExpireHolds", ctx, now)}
+}
+
+func (_c *MockHoldRepo_ExpireHolds_Call) Run(run func(ctx context.Context, now time.Time)) *MockHoldRepo_ExpireHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_ExpireHolds_Call) Return(_a0 int64, _a1 error) *MockHoldRepo_ExpireHolds_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHoldRepo_ExpireHolds_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockHoldRepo_ExpireHolds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FulfillHold provides a mock function with given fields: ctx, id
+func (_m *MockHoldRepo) FulfillHold(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FulfillHold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHoldRepo_FulfillHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FulfillHold'
+type MockHoldRepo_FulfillHold_Call struct {
+	*mock.Call
+}
+
+// FulfillHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockHoldRepo_Expecter) FulfillHold(ctx interface{}, id interface{}) *MockHoldRepo_FulfillHold_Call {
+	return &MockHoldRepo_FulfillHold_Call{Call: _e.mock.On("FulfillHold", ctx, id)}
+}
+
+func (_c *MockHoldRepo_FulfillHold_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockHoldRepo_FulfillHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_FulfillHold_Call) Return(_a0 error) *MockHoldRepo_FulfillHold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHoldRepo_FulfillHold_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockHoldRepo_FulfillHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHoldById provides a mock function with given fields: ctx, id
+func (_m *MockHoldRepo) GetHoldById(ctx context.Context, id uuid.UUID) (*models.Hold, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHoldById")
+	}
+
+	var r0 *models.Hold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Hold, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Hold); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Hold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHoldRepo_GetHoldById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHoldById'
+type MockHoldRepo_GetHoldById_Call struct {
+	*mock.Call
+}
+
+// GetHoldById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockHoldRepo_Expecter) GetHoldById(ctx interface{}, id interface{}) *MockHoldRepo_GetHoldById_Call {
+	return &MockHoldRepo_GetHoldById_Call{Call: _e.mock.On("GetHoldById", ctx, id)}
+}
+
+func (_c *MockHoldRepo_GetHoldById_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockHoldRepo_GetHoldById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_GetHoldById_Call) Return(_a0 *models.Hold, _a1 error) *MockHoldRepo_GetHoldById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHoldRepo_GetHoldById_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Hold, error)) *MockHoldRepo_GetHoldById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHolds provides a mock function with given fields: ctx, filter
+func (_m *MockHoldRepo) GetHolds(ctx context.Context, filter *HoldFilter) ([]*models.Hold, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHolds")
+	}
+
+	var r0 []*models.Hold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *HoldFilter) ([]*models.Hold, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *HoldFilter) []*models.Hold); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Hold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *HoldFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHoldRepo_GetHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHolds'
+type MockHoldRepo_GetHolds_Call struct {
+	*mock.Call
+}
+
+// GetHolds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *HoldFilter
+func (_e *MockHoldRepo_Expecter) GetHolds(ctx interface{}, filter interface{}) *MockHoldRepo_GetHolds_Call {
+	return &MockHoldRepo_GetHolds_Call{Call: _e.mock.On("GetHolds", ctx, filter)}
+}
+
+func (_c *MockHoldRepo_GetHolds_Call) Run(run func(ctx context.Context, filter *HoldFilter)) *MockHoldRepo_GetHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*HoldFilter))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_GetHolds_Call) Return(_a0 []*models.Hold, _a1 error) *MockHoldRepo_GetHolds_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHoldRepo_GetHolds_Call) RunAndReturn(run func(context.Context, *HoldFilter) ([]*models.Hold, error)) *MockHoldRepo_GetHolds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReadyHold provides a mock function with given fields: ctx, copyId
+func (_m *MockHoldRepo) GetReadyHold(ctx context.Context, copyId uuid.UUID) (*models.Hold, error) {
+	ret := _m.Called(ctx, copyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReadyHold")
+	}
+
+	var r0 *models.Hold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Hold, error)); ok {
+		return rf(ctx, copyId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Hold); ok {
+		r0 = rf(ctx, copyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Hold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, copyId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHoldRepo_GetReadyHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReadyHold'
+type MockHoldRepo_GetReadyHold_Call struct {
+	*mock.Call
+}
+
+// GetReadyHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - copyId uuid.UUID
+func (_e *MockHoldRepo_Expecter) GetReadyHold(ctx interface{}, copyId interface{}) *MockHoldRepo_GetReadyHold_Call {
+	return &MockHoldRepo_GetReadyHold_Call{Call: _e.mock.On("GetReadyHold", ctx, copyId)}
+}
+
+func (_c *MockHoldRepo_GetReadyHold_Call) Run(run func(ctx context.Context, copyId uuid.UUID)) *MockHoldRepo_GetReadyHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_GetReadyHold_Call) Return(_a0 *models.Hold, _a1 error) *MockHoldRepo_GetReadyHold_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHoldRepo_GetReadyHold_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Hold, error)) *MockHoldRepo_GetReadyHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHoldRepo creates a new instance of MockHoldRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHoldRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHoldRepo {
+	mock := &MockHoldRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
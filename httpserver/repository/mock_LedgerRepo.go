@@ -0,0 +1,261 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/storyofhis/books-management/httpserver/repository/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockLedgerRepo is an autogenerated mock type for the LedgerRepo type
+type MockLedgerRepo struct {
+	mock.Mock
+}
+
+type MockLedgerRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLedgerRepo) EXPECT() *MockLedgerRepo_Expecter {
+	return &MockLedgerRepo_Expecter{mock: &_m.Mock}
+}
+
+// CreateEntry provides a mock function with given fields: ctx, entry
+func (_m *MockLedgerRepo) CreateEntry(ctx context.Context, entry *models.LedgerEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.LedgerEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLedgerRepo_CreateEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEntry'
+type MockLedgerRepo_CreateEntry_Call struct {
+	*mock.Call
+}
+
+// CreateEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *models.LedgerEntry
+func (_e *MockLedgerRepo_Expecter) CreateEntry(ctx interface{}, entry interface{}) *MockLedgerRepo_CreateEntry_Call {
+	return &MockLedgerRepo_CreateEntry_Call{Call: _e.mock.On("CreateEntry", ctx, entry)}
+}
+
+func (_c *MockLedgerRepo_CreateEntry_Call) Run(run func(ctx context.Context, entry *models.LedgerEntry)) *MockLedgerRepo_CreateEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.LedgerEntry))
+	})
+	return _c
+}
+
+func (_c *MockLedgerRepo_CreateEntry_Call) Return(_a0 error) *MockLedgerRepo_CreateEntry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerRepo_CreateEntry_Call) RunAndReturn(run func(context.Context, *models.LedgerEntry) error) *MockLedgerRepo_CreateEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalance provides a mock function with given fields: ctx, userId
+func (_m *MockLedgerRepo) GetBalance(ctx context.Context, userId uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalance")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLedgerRepo_GetBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalance'
+type MockLedgerRepo_GetBalance_Call struct {
+	*mock.Call
+}
+
+// GetBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+func (_e *MockLedgerRepo_Expecter) GetBalance(ctx interface{}, userId interface{}) *MockLedgerRepo_GetBalance_Call {
+	return &MockLedgerRepo_GetBalance_Call{Call: _e.mock.On("GetBalance", ctx, userId)}
+}
+
+func (_c *MockLedgerRepo_GetBalance_Call) Run(run func(ctx context.Context, userId uuid.UUID)) *MockLedgerRepo_GetBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockLedgerRepo_GetBalance_Call) Return(_a0 int64, _a1 error) *MockLedgerRepo_GetBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLedgerRepo_GetBalance_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockLedgerRepo_GetBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEntries provides a mock function with given fields: ctx, userId, page
+func (_m *MockLedgerRepo) GetEntries(ctx context.Context, userId uuid.UUID, page *Page) ([]*models.LedgerEntry, *PageInfo, error) {
+	ret := _m.Called(ctx, userId, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEntries")
+	}
+
+	var r0 []*models.LedgerEntry
+	var r1 *PageInfo
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *Page) ([]*models.LedgerEntry, *PageInfo, error)); ok {
+		return rf(ctx, userId, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *Page) []*models.LedgerEntry); ok {
+		r0 = rf(ctx, userId, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LedgerEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *Page) *PageInfo); ok {
+		r1 = rf(ctx, userId, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*PageInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, *Page) error); ok {
+		r2 = rf(ctx, userId, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockLedgerRepo_GetEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEntries'
+type MockLedgerRepo_GetEntries_Call struct {
+	*mock.Call
+}
+
+// GetEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+//   - page *Page
+func (_e *MockLedgerRepo_Expecter) GetEntries(ctx interface{}, userId interface{}, page interface{}) *MockLedgerRepo_GetEntries_Call {
+	return &MockLedgerRepo_GetEntries_Call{Call: _e.mock.On("GetEntries", ctx, userId, page)}
+}
+
+func (_c *MockLedgerRepo_GetEntries_Call) Run(run func(ctx context.Context, userId uuid.UUID, page *Page)) *MockLedgerRepo_GetEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*Page))
+	})
+	return _c
+}
+
+func (_c *MockLedgerRepo_GetEntries_Call) Return(_a0 []*models.LedgerEntry, _a1 *PageInfo, _a2 error) *MockLedgerRepo_GetEntries_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockLedgerRepo_GetEntries_Call) RunAndReturn(run func(context.Context, uuid.UUID, *Page) ([]*models.LedgerEntry, *PageInfo, error)) *MockLedgerRepo_GetEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetFine provides a mock function with given fields: ctx, userId, loanId, amount
+func (_m *MockLedgerRepo) SetFine(ctx context.Context, userId uuid.UUID, loanId uuid.UUID, amount int64) error {
+	ret := _m.Called(ctx, userId, loanId, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFine")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, userId, loanId, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLedgerRepo_SetFine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFine'
+type MockLedgerRepo_SetFine_Call struct {
+	*mock.Call
+}
+
+// SetFine is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+//   - loanId uuid.UUID
+//   - amount int64
+func (_e *MockLedgerRepo_Expecter) SetFine(ctx interface{}, userId interface{}, loanId interface{}, amount interface{}) *MockLedgerRepo_SetFine_Call {
+	return &MockLedgerRepo_SetFine_Call{Call: _e.mock.On("SetFine", ctx, userId, loanId, amount)}
+}
+
+func (_c *MockLedgerRepo_SetFine_Call) Run(run func(ctx context.Context, userId uuid.UUID, loanId uuid.UUID, amount int64)) *MockLedgerRepo_SetFine_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockLedgerRepo_SetFine_Call) Return(_a0 error) *MockLedgerRepo_SetFine_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerRepo_SetFine_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, int64) error) *MockLedgerRepo_SetFine_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLedgerRepo creates a new instance of MockLedgerRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLedgerRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLedgerRepo {
+	mock := &MockLedgerRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,309 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/storyofhis/books-management/httpserver/repository/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockLoanRepo is an autogenerated mock type for the LoanRepo type
+type MockLoanRepo struct {
+	mock.Mock
+}
+
+type MockLoanRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLoanRepo) EXPECT() *MockLoanRepo_Expecter {
+	return &MockLoanRepo_Expecter{mock: &_m.Mock}
+}
+
+// CreateLoan provides a mock function with given fields: ctx, loan, status
+func (_m *MockLoanRepo) CreateLoan(ctx context.Context, loan *models.Loan, status string) error {
+	ret := _m.Called(ctx, loan, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLoan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Loan, string) error); ok {
+		r0 = rf(ctx, loan, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLoanRepo_CreateLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLoan'
+type MockLoanRepo_CreateLoan_Call struct {
+	*mock.Call
+}
+
+// CreateLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loan *models.Loan
+//   - status string
+func (_e *MockLoanRepo_Expecter) CreateLoan(ctx interface{}, loan interface{}, status interface{}) *MockLoanRepo_CreateLoan_Call {
+	return &MockLoanRepo_CreateLoan_Call{Call: _e.mock.On("CreateLoan", ctx, loan, status)}
+}
+
+func (_c *MockLoanRepo_CreateLoan_Call) Run(run func(ctx context.Context, loan *models.Loan, status string)) *MockLoanRepo_CreateLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Loan), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockLoanRepo_CreateLoan_Call) Return(_a0 error) *MockLoanRepo_CreateLoan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLoanRepo_CreateLoan_Call) RunAndReturn(run func(context.Context, *models.Loan, string) error) *MockLoanRepo_CreateLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanById provides a mock function with given fields: ctx, id
+func (_m *MockLoanRepo) GetLoanById(ctx context.Context, id uuid.UUID) (*models.Loan, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanById")
+	}
+
+	var r0 *models.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Loan, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Loan); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLoanRepo_GetLoanById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanById'
+type MockLoanRepo_GetLoanById_Call struct {
+	*mock.Call
+}
+
+// GetLoanById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockLoanRepo_Expecter) GetLoanById(ctx interface{}, id interface{}) *MockLoanRepo_GetLoanById_Call {
+	return &MockLoanRepo_GetLoanById_Call{Call: _e.mock.On("GetLoanById", ctx, id)}
+}
+
+func (_c *MockLoanRepo_GetLoanById_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockLoanRepo_GetLoanById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockLoanRepo_GetLoanById_Call) Return(_a0 *models.Loan, _a1 error) *MockLoanRepo_GetLoanById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLoanRepo_GetLoanById_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Loan, error)) *MockLoanRepo_GetLoanById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoans provides a mock function with given fields: ctx, filter, page
+func (_m *MockLoanRepo) GetLoans(ctx context.Context, filter *LoanFilter, page *Page) ([]*models.Loan, *PageInfo, error) {
+	ret := _m.Called(ctx, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoans")
+	}
+
+	var r0 []*models.Loan
+	var r1 *PageInfo
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *LoanFilter, *Page) ([]*models.Loan, *PageInfo, error)); ok {
+		return rf(ctx, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *LoanFilter, *Page) []*models.Loan); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *LoanFilter, *Page) *PageInfo); ok {
+		r1 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*PageInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *LoanFilter, *Page) error); ok {
+		r2 = rf(ctx, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockLoanRepo_GetLoans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoans'
+type MockLoanRepo_GetLoans_Call struct {
+	*mock.Call
+}
+
+// GetLoans is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *LoanFilter
+//   - page *Page
+func (_e *MockLoanRepo_Expecter) GetLoans(ctx interface{}, filter interface{}, page interface{}) *MockLoanRepo_GetLoans_Call {
+	return &MockLoanRepo_GetLoans_Call{Call: _e.mock.On("GetLoans", ctx, filter, page)}
+}
+
+func (_c *MockLoanRepo_GetLoans_Call) Run(run func(ctx context.Context, filter *LoanFilter, page *Page)) *MockLoanRepo_GetLoans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*LoanFilter), args[2].(*Page))
+	})
+	return _c
+}
+
+func (_c *MockLoanRepo_GetLoans_Call) Return(_a0 []*models.Loan, _a1 *PageInfo, _a2 error) *MockLoanRepo_GetLoans_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockLoanRepo_GetLoans_Call) RunAndReturn(run func(context.Context, *LoanFilter, *Page) ([]*models.Loan, *PageInfo, error)) *MockLoanRepo_GetLoans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenewLoan provides a mock function with given fields: ctx, loan
+func (_m *MockLoanRepo) RenewLoan(ctx context.Context, loan *models.Loan) error {
+	ret := _m.Called(ctx, loan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewLoan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Loan) error); ok {
+		r0 = rf(ctx, loan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLoanRepo_RenewLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewLoan'
+type MockLoanRepo_RenewLoan_Call struct {
+	*mock.Call
+}
+
+// RenewLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loan *models.Loan
+func (_e *MockLoanRepo_Expecter) RenewLoan(ctx interface{}, loan interface{}) *MockLoanRepo_RenewLoan_Call {
+	return &MockLoanRepo_RenewLoan_Call{Call: _e.mock.On("RenewLoan", ctx, loan)}
+}
+
+func (_c *MockLoanRepo_RenewLoan_Call) Run(run func(ctx context.Context, loan *models.Loan)) *MockLoanRepo_RenewLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Loan))
+	})
+	return _c
+}
+
+func (_c *MockLoanRepo_RenewLoan_Call) Return(_a0 error) *MockLoanRepo_RenewLoan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLoanRepo_RenewLoan_Call) RunAndReturn(run func(context.Context, *models.Loan) error) *MockLoanRepo_RenewLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReturnLoan provides a mock function with given fields: ctx, loan
+func (_m *MockLoanRepo) ReturnLoan(ctx context.Context, loan *models.Loan) error {
+	ret := _m.Called(ctx, loan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReturnLoan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Loan) error); ok {
+		r0 = rf(ctx, loan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLoanRepo_ReturnLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReturnLoan'
+type MockLoanRepo_ReturnLoan_Call struct {
+	*mock.Call
+}
+
+// ReturnLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loan *models.Loan
+func (_e *MockLoanRepo_Expecter) ReturnLoan(ctx interface{}, loan interface{}) *MockLoanRepo_ReturnLoan_Call {
+	return &MockLoanRepo_ReturnLoan_Call{Call: _e.mock.On("ReturnLoan", ctx, loan)}
+}
+
+func (_c *MockLoanRepo_ReturnLoan_Call) Run(run func(ctx context.Context, loan *models.Loan)) *MockLoanRepo_ReturnLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Loan))
+	})
+	return _c
+}
+
+func (_c *MockLoanRepo_ReturnLoan_Call) Return(_a0 error) *MockLoanRepo_ReturnLoan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLoanRepo_ReturnLoan_Call) RunAndReturn(run func(context.Context, *models.Loan) error) *MockLoanRepo_ReturnLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLoanRepo creates a new instance of MockLoanRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLoanRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLoanRepo {
+	mock := &MockLoanRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,355 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/storyofhis/books-management/httpserver/repository/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockReviewRepo is an autogenerated mock type for the ReviewRepo type
+type MockReviewRepo struct {
+	mock.Mock
+}
+
+type MockReviewRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReviewRepo) EXPECT() *MockReviewRepo_Expecter {
+	return &MockReviewRepo_Expecter{mock: &_m.Mock}
+}
+
+// CreateReview provides a mock function with given fields: ctx, review
+func (_m *MockReviewRepo) CreateReview(ctx context.Context, review *models.Review) error {
+	ret := _m.Called(ctx, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Review) error); ok {
+		r0 = rf(ctx, review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockReviewRepo_CreateReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReview'
+type MockReviewRepo_CreateReview_Call struct {
+	*mock.Call
+}
+
+// CreateReview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - review *models.Review
+func (_e *MockReviewRepo_Expecter) CreateReview(ctx interface{}, review interface{}) *MockReviewRepo_CreateReview_Call {
+	return &MockReviewRepo_CreateReview_Call{Call: _e.mock.On("CreateReview", ctx, review)}
+}
+
+func (_c *MockReviewRepo_CreateReview_Call) Run(run func(ctx context.Context, review *models.Review)) *MockReviewRepo_CreateReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Review))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepo_CreateReview_Call) Return(_a0 error) *MockReviewRepo_CreateReview_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReviewRepo_CreateReview_Call) RunAndReturn(run func(context.Context, *models.Review) error) *MockReviewRepo_CreateReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteReview provides a mock function with given fields: ctx, review
+func (_m *MockReviewRepo) DeleteReview(ctx context.Context, review *models.Review) error {
+	ret := _m.Called(ctx, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Review) error); ok {
+		r0 = rf(ctx, review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockReviewRepo_DeleteReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReview'
+type MockReviewRepo_DeleteReview_Call struct {
+	*mock.Call
+}
+
+// DeleteReview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - review *models.Review
+func (_e *MockReviewRepo_Expecter) DeleteReview(ctx interface{}, review interface{}) *MockReviewRepo_DeleteReview_Call {
+	return &MockReviewRepo_DeleteReview_Call{Call: _e.mock.On("DeleteReview", ctx, review)}
+}
+
+func (_c *MockReviewRepo_DeleteReview_Call) Run(run func(ctx context.Context, review *models.Review)) *MockReviewRepo_DeleteReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Review))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepo_DeleteReview_Call) Return(_a0 error) *MockReviewRepo_DeleteReview_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReviewRepo_DeleteReview_Call) RunAndReturn(run func(context.Context, *models.Review) error) *MockReviewRepo_DeleteReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReviewById provides a mock function with given fields: ctx, id
+func (_m *MockReviewRepo) GetReviewById(ctx context.Context, id uuid.UUID) (*models.Review, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviewById")
+	}
+
+	var r0 *models.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Review, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Review); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReviewRepo_GetReviewById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviewById'
+type MockReviewRepo_GetReviewById_Call struct {
+	*mock.Call
+}
+
+// GetReviewById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockReviewRepo_Expecter) GetReviewById(ctx interface{}, id interface{}) *MockReviewRepo_GetReviewById_Call {
+	return &MockReviewRepo_GetReviewById_Call{Call: _e.mock.On("GetReviewById", ctx, id)}
+}
+
+func (_c *MockReviewRepo_GetReviewById_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockReviewRepo_GetReviewById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepo_GetReviewById_Call) Return(_a0 *models.Review, _a1 error) *MockReviewRepo_GetReviewById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReviewRepo_GetReviewById_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Review, error)) *MockReviewRepo_GetReviewById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReviews provides a mock function with given fields: ctx, filter, page
+func (_m *MockReviewRepo) GetReviews(ctx context.Context, filter *ReviewFilter, page *Page) ([]*models.Review, *PageInfo, error) {
+	ret := _m.Called(ctx, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviews")
+	}
+
+	var r0 []*models.Review
+	var r1 *PageInfo
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *ReviewFilter, *Page) ([]*models.Review, *PageInfo, error)); ok {
+		return rf(ctx, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *ReviewFilter, *Page) []*models.Review); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *ReviewFilter, *Page) *PageInfo); ok {
+		r1 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*PageInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *ReviewFilter, *Page) error); ok {
+		r2 = rf(ctx, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockReviewRepo_GetReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviews'
+type MockReviewRepo_GetReviews_Call struct {
+	*mock.Call
+}
+
+// GetReviews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *ReviewFilter
+//   - page *Page
+func (_e *MockReviewRepo_Expecter) GetReviews(ctx interface{}, filter interface{}, page interface{}) *MockReviewRepo_GetReviews_Call {
+	return &MockReviewRepo_GetReviews_Call{Call: _e.mock.On("GetReviews", ctx, filter, page)}
+}
+
+func (_c *MockReviewRepo_GetReviews_Call) Run(run func(ctx context.Context, filter *ReviewFilter, page *Page)) *MockReviewRepo_GetReviews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*ReviewFilter), args[2].(*Page))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepo_GetReviews_Call) Return(_a0 []*models.Review, _a1 *PageInfo, _a2 error) *MockReviewRepo_GetReviews_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockReviewRepo_GetReviews_Call) RunAndReturn(run func(context.Context, *ReviewFilter, *Page) ([]*models.Review, *PageInfo, error)) *MockReviewRepo_GetReviews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ModerateReview provides a mock function with given fields: ctx, review
+func (_m *MockReviewRepo) ModerateReview(ctx context.Context, review *models.Review) error {
+	ret := _m.Called(ctx, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ModerateReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Review) error); ok {
+		r0 = rf(ctx, review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockReviewRepo_ModerateReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ModerateReview'
+type MockReviewRepo_ModerateReview_Call struct {
+	*mock.Call
+}
+
+// ModerateReview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - review *models.Review
+func (_e *MockReviewRepo_Expecter) ModerateReview(ctx interface{}, review interface{}) *MockReviewRepo_ModerateReview_Call {
+	return &MockReviewRepo_ModerateReview_Call{Call: _e.mock.On("ModerateReview", ctx, review)}
+}
+
+func (_c *MockReviewRepo_ModerateReview_Call) Run(run func(ctx context.Context, review *models.Review)) *MockReviewRepo_ModerateReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Review))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepo_ModerateReview_Call) Return(_a0 error) *MockReviewRepo_ModerateReview_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReviewRepo_ModerateReview_Call) RunAndReturn(run func(context.Context, *models.Review) error) *MockReviewRepo_ModerateReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateReview provides a mock function with given fields: ctx, review
+func (_m *MockReviewRepo) UpdateReview(ctx context.Context, review *models.Review) error {
+	ret := _m.Called(ctx, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReview")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Review) error); ok {
+		r0 = rf(ctx, review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockReviewRepo_UpdateReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateReview'
+type MockReviewRepo_UpdateReview_Call struct {
+	*mock.Call
+}
+
+// UpdateReview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - review *models.Review
+func (_e *MockReviewRepo_Expecter) UpdateReview(ctx interface{}, review interface{}) *MockReviewRepo_UpdateReview_Call {
+	return &MockReviewRepo_UpdateReview_Call{Call: _e.mock.On("UpdateReview", ctx, review)}
+}
+
+func (_c *MockReviewRepo_UpdateReview_Call) Run(run func(ctx context.Context, review *models.Review)) *MockReviewRepo_UpdateReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Review))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepo_UpdateReview_Call) Return(_a0 error) *MockReviewRepo_UpdateReview_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReviewRepo_UpdateReview_Call) RunAndReturn(run func(context.Context, *models.Review) error) *MockReviewRepo_UpdateReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReviewRepo creates a new instance of MockReviewRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReviewRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReviewRepo {
+	mock := &MockReviewRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,172 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSearchRepo is an autogenerated mock type for the SearchRepo type
+type MockSearchRepo struct {
+	mock.Mock
+}
+
+type MockSearchRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSearchRepo) EXPECT() *MockSearchRepo_Expecter {
+	return &MockSearchRepo_Expecter{mock: &_m.Mock}
+}
+
+// SearchAuthors provides a mock function with given fields: ctx, query, limit, offset
+func (_m *MockSearchRepo) SearchAuthors(ctx context.Context, query string, limit int, offset int) ([]*AuthorHit, int64, error) {
+	ret := _m.Called(ctx, query, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchAuthors")
+	}
+
+	var r0 []*AuthorHit
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*AuthorHit, int64, error)); ok {
+		return rf(ctx, query, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*AuthorHit); ok {
+		r0 = rf(ctx, query, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*AuthorHit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
+		r1 = rf(ctx, query, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, query, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSearchRepo_SearchAuthors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchAuthors'
+type MockSearchRepo_SearchAuthors_Call struct {
+	*mock.Call
+}
+
+// SearchAuthors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - limit int
+//   - offset int
+func (_e *MockSearchRepo_Expecter) SearchAuthors(ctx interface{}, query interface{}, limit interface{}, offset interface{}) *MockSearchRepo_SearchAuthors_Call {
+	return &MockSearchRepo_SearchAuthors_Call{Call: _e.mock.On("SearchAuthors", ctx, query, limit, offset)}
+}
+
+func (_c *MockSearchRepo_SearchAuthors_Call) Run(run func(ctx context.Context, query string, limit int, offset int)) *MockSearchRepo_SearchAuthors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockSearchRepo_SearchAuthors_Call) Return(_a0 []*AuthorHit, _a1 int64, _a2 error) *MockSearchRepo_SearchAuthors_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSearchRepo_SearchAuthors_Call) RunAndReturn(run func(context.Context, string, int, int) ([]*AuthorHit, int64, error)) *MockSearchRepo_SearchAuthors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchBooks provides a mock function with given fields: ctx, query, limit, offset
+func (_m *MockSearchRepo) SearchBooks(ctx context.Context, query string, limit int, offset int) ([]*BookHit, int64, error) {
+	ret := _m.Called(ctx, query, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchBooks")
+	}
+
+	var r0 []*BookHit
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*BookHit, int64, error)); ok {
+		return rf(ctx, query, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*BookHit); ok {
+		r0 = rf(ctx, query, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*BookHit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
+		r1 = rf(ctx, query, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, query, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSearchRepo_SearchBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchBooks'
+type MockSearchRepo_SearchBooks_Call struct {
+	*mock.Call
+}
+
+// SearchBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - limit int
+//   - offset int
+func (_e *MockSearchRepo_Expecter) SearchBooks(ctx interface{}, query interface{}, limit interface{}, offset interface{}) *MockSearchRepo_SearchBooks_Call {
+	return &MockSearchRepo_SearchBooks_Call{Call: _e.mock.On("SearchBooks", ctx, query, limit, offset)}
+}
+
+func (_c *MockSearchRepo_SearchBooks_Call) Run(run func(ctx context.Context, query string, limit int, offset int)) *MockSearchRepo_SearchBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockSearchRepo_SearchBooks_Call) Return(_a0 []*BookHit, _a1 int64, _a2 error) *MockSearchRepo_SearchBooks_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSearchRepo_SearchBooks_Call) RunAndReturn(run func(context.Context, string, int, int) ([]*BookHit, int64, error)) *MockSearchRepo_SearchBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSearchRepo creates a new instance of MockSearchRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSearchRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSearchRepo {
+	mock := &MockSearchRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,402 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/storyofhis/books-management/httpserver/repository/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockSessionRepo is an autogenerated mock type for the SessionRepo type
+type MockSessionRepo struct {
+	mock.Mock
+}
+
+type MockSessionRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSessionRepo) EXPECT() *MockSessionRepo_Expecter {
+	return &MockSessionRepo_Expecter{mock: &_m.Mock}
+}
+
+// CreateRefreshToken provides a mock function with given fields: ctx, token
+func (_m *MockSessionRepo) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RefreshToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionRepo_CreateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRefreshToken'
+type MockSessionRepo_CreateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// CreateRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *models.RefreshToken
+func (_e *MockSessionRepo_Expecter) CreateRefreshToken(ctx interface{}, token interface{}) *MockSessionRepo_CreateRefreshToken_Call {
+	return &MockSessionRepo_CreateRefreshToken_Call{Call: _e.mock.On("CreateRefreshToken", ctx, token)}
+}
+
+func (_c *MockSessionRepo_CreateRefreshToken_Call) Run(run func(ctx context.Context, token *models.RefreshToken)) *MockSessionRepo_CreateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.RefreshToken))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_CreateRefreshToken_Call) Return(_a0 error) *MockSessionRepo_CreateRefreshToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionRepo_CreateRefreshToken_Call) RunAndReturn(run func(context.Context, *models.RefreshToken) error) *MockSessionRepo_CreateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSession provides a mock function with given fields: ctx, session
+func (_m *MockSessionRepo) CreateSession(ctx context.Context, session *models.Session) error {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionRepo_CreateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSession'
+type MockSessionRepo_CreateSession_Call struct {
+	*mock.Call
+}
+
+// CreateSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session *models.Session
+func (_e *MockSessionRepo_Expecter) CreateSession(ctx interface{}, session interface{}) *MockSessionRepo_CreateSession_Call {
+	return &MockSessionRepo_CreateSession_Call{Call: _e.mock.On("CreateSession", ctx, session)}
+}
+
+func (_c *MockSessionRepo_CreateSession_Call) Run(run func(ctx context.Context, session *models.Session)) *MockSessionRepo_CreateSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Session))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_CreateSession_Call) Return(_a0 error) *MockSessionRepo_CreateSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionRepo_CreateSession_Call) RunAndReturn(run func(context.Context, *models.Session) error) *MockSessionRepo_CreateSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRefreshTokenByHash provides a mock function with given fields: ctx, hash
+func (_m *MockSessionRepo) GetRefreshTokenByHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshTokenByHash")
+	}
+
+	var r0 *models.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.RefreshToken, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.RefreshToken); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepo_GetRefreshTokenByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRefreshTokenByHash'
+type MockSessionRepo_GetRefreshTokenByHash_Call struct {
+	*mock.Call
+}
+
+// GetRefreshTokenByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash string
+func (_e *MockSessionRepo_Expecter) GetRefreshTokenByHash(ctx interface{}, hash interface{}) *MockSessionRepo_GetRefreshTokenByHash_Call {
+	return &MockSessionRepo_GetRefreshTokenByHash_Call{Call: _e.mock.On("GetRefreshTokenByHash", ctx, hash)}
+}
+
+func (_c *MockSessionRepo_GetRefreshTokenByHash_Call) Run(run func(ctx context.Context, hash string)) *MockSessionRepo_GetRefreshTokenByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_GetRefreshTokenByHash_Call) Return(_a0 *models.RefreshToken, _a1 error) *MockSessionRepo_GetRefreshTokenByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepo_GetRefreshTokenByHash_Call) RunAndReturn(run func(context.Context, string) (*models.RefreshToken, error)) *MockSessionRepo_GetRefreshTokenByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSessionById provides a mock function with given fields: ctx, id
+func (_m *MockSessionRepo) GetSessionById(ctx context.Context, id uuid.UUID) (*models.Session, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessionById")
+	}
+
+	var r0 *models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Session, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Session); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepo_GetSessionById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessionById'
+type MockSessionRepo_GetSessionById_Call struct {
+	*mock.Call
+}
+
+// GetSessionById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockSessionRepo_Expecter) GetSessionById(ctx interface{}, id interface{}) *MockSessionRepo_GetSessionById_Call {
+	return &MockSessionRepo_GetSessionById_Call{Call: _e.mock.On("GetSessionById", ctx, id)}
+}
+
+func (_c *MockSessionRepo_GetSessionById_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSessionRepo_GetSessionById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_GetSessionById_Call) Return(_a0 *models.Session, _a1 error) *MockSessionRepo_GetSessionById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepo_GetSessionById_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Session, error)) *MockSessionRepo_GetSessionById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function with given fields: ctx, id
+func (_m *MockSessionRepo) RevokeSession(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionRepo_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type MockSessionRepo_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockSessionRepo_Expecter) RevokeSession(ctx interface{}, id interface{}) *MockSessionRepo_RevokeSession_Call {
+	return &MockSessionRepo_RevokeSession_Call{Call: _e.mock.On("RevokeSession", ctx, id)}
+}
+
+func (_c *MockSessionRepo_RevokeSession_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSessionRepo_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_RevokeSession_Call) Return(_a0 error) *MockSessionRepo_RevokeSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionRepo_RevokeSession_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockSessionRepo_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeUserSessions provides a mock function with given fields: ctx, userId
+func (_m *MockSessionRepo) RevokeUserSessions(ctx context.Context, userId uuid.UUID) error {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionRepo_RevokeUserSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserSessions'
+type MockSessionRepo_RevokeUserSessions_Call struct {
+	*mock.Call
+}
+
+// RevokeUserSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+func (_e *MockSessionRepo_Expecter) RevokeUserSessions(ctx interface{}, userId interface{}) *MockSessionRepo_RevokeUserSessions_Call {
+	return &MockSessionRepo_RevokeUserSessions_Call{Call: _e.mock.On("RevokeUserSessions", ctx, userId)}
+}
+
+func (_c *MockSessionRepo_RevokeUserSessions_Call) Run(run func(ctx context.Context, userId uuid.UUID)) *MockSessionRepo_RevokeUserSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_RevokeUserSessions_Call) Return(_a0 error) *MockSessionRepo_RevokeUserSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionRepo_RevokeUserSessions_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockSessionRepo_RevokeUserSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseRefreshToken provides a mock function with given fields: ctx, id
+func (_m *MockSessionRepo) UseRefreshToken(ctx context.Context, id uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseRefreshToken")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepo_UseRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseRefreshToken'
+type MockSessionRepo_UseRefreshToken_Call struct {
+	*mock.Call
+}
+
+// UseRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockSessionRepo_Expecter) UseRefreshToken(ctx interface{}, id interface{}) *MockSessionRepo_UseRefreshToken_Call {
+	return &MockSessionRepo_UseRefreshToken_Call{Call: _e.mock.On("UseRefreshToken", ctx, id)}
+}
+
+func (_c *MockSessionRepo_UseRefreshToken_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSessionRepo_UseRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_UseRefreshToken_Call) Return(_a0 bool, _a1 error) *MockSessionRepo_UseRefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepo_UseRefreshToken_Call) RunAndReturn(run func(context.Context, uuid.UUID) (bool, error)) *MockSessionRepo_UseRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSessionRepo creates a new instance of MockSessionRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSessionRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSessionRepo {
+	mock := &MockSessionRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
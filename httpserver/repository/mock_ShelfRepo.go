@@ -0,0 +1,666 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/storyofhis/books-management/httpserver/repository/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockShelfRepo is an autogenerated mock type for the ShelfRepo type
+type MockShelfRepo struct {
+	mock.Mock
+}
+
+type MockShelfRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockShelfRepo) EXPECT() *MockShelfRepo_Expecter {
+	return &MockShelfRepo_Expecter{mock: &_m.Mock}
+}
+
+// AddShelfItem provides a mock function with given fields: ctx, item
+func (_m *MockShelfRepo) AddShelfItem(ctx context.Context, item *models.ShelfItem) error {
+	ret := _m.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddShelfItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ShelfItem) error); ok {
+		r0 = rf(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockShelfRepo_AddShelfItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddShelfItem'
+type MockShelfRepo_AddShelfItem_Call struct {
+	*mock.Call
+}
+
+// AddShelfItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item *models.ShelfItem
+func (_e *MockShelfRepo_Expecter) AddShelfItem(ctx interface{}, item interface{}) *MockShelfRepo_AddShelfItem_Call {
+	return &MockShelfRepo_AddShelfItem_Call{Call: _e.mock.On("AddShelfItem", ctx, item)}
+}
+
+func (_c *MockShelfRepo_AddShelfItem_Call) Run(run func(ctx context.Context, item *models.ShelfItem)) *MockShelfRepo_AddShelfItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ShelfItem))
+	})
+	return _c
+}
+
+func (_c *MockShelfRepo_AddShelfItem_Call) Return(_a0 error) *MockShelfRepo_AddShelfItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShelfRepo_AddShelfItem_Call) RunAndReturn(run func(context.Context, *models.ShelfItem) error) *MockShelfRepo_AddShelfItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateDefaultShelves provides a mock function with given fields: ctx, userId
+func (_m *MockShelfRepo) CreateDefaultShelves(ctx context.Context, userId uuid.UUID) error {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDefaultShelves")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockShelfRepo_CreateDefaultShelves_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDefaultShelves'
+type MockShelfRepo_CreateDefaultShelves_Call struct {
+	*mock.Call
+}
+
+// CreateDefaultShelves is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+func (_e *MockShelfRepo_Expecter) CreateDefaultShelves(ctx interface{}, userId interface{}) *MockShelfRepo_CreateDefaultShelves_Call {
+	return &MockShelfRepo_CreateDefaultShelves_Call{Call: _e.mock.On("CreateDefaultShelves", ctx, userId)}
+}
+
+func (_c *MockShelfRepo_CreateDefaultShelves_Call) Run(run func(ctx context.Context, userId uuid.UUID)) *MockShelfRepo_CreateDefaultShelves_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockShelfRepo_CreateDefaultShelves_Call) Return(_a0 error) *MockShelfRepo_CreateDefaultShelves_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShelfRepo_CreateDefaultShelves_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockShelfRepo_CreateDefaultShelves_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateShelf provides a mock function with given fields: ctx, shelf
+func (_m *MockShelfRepo) CreateShelf(ctx context.Context, shelf *models.Shelf) error {
+	ret := _m.Called(ctx, shelf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateShelf")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Shelf) error); ok {
+		r0 = rf(ctx, shelf)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockShelfRepo_CreateShelf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateShelf'
+type MockShelfRepo_CreateShelf_Call struct {
+	*mock.Call
+}
+
+// CreateShelf is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shelf *models.Shelf
+func (_e *MockShelfRepo_Expecter) CreateShelf(ctx interface{}, shelf interface{}) *MockShelfRepo_CreateShelf_Call {
+	return &MockShelfRepo_CreateShelf_Call{Call: _e.mock.On("CreateShelf", ctx, shelf)}
+}
+
+func (_c *MockShelfRepo_CreateShelf_Call) Run(run func(ctx context.Context, shelf *models.Shelf)) *MockShelfRepo_CreateShelf_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Shelf))
+	})
+	return _c
+}
+
+func (_c *MockShelfRepo_CreateShelf_Call) Return(_a0 error) *MockShelfRepo_CreateShelf_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShelfRepo_CreateShelf_Call) RunAndReturn(run func(context.Context, *models.Shelf) error) *MockShelfRepo_CreateShelf_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteShelf provides a mock function with given fields: ctx, id
+func (_m *MockShelfRepo) DeleteShelf(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteShelf")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockShelfRepo_DeleteShelf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteShelf'
+type MockShelfRepo_DeleteShelf_Call struct {
+	*mock.Call
+}
+
+// DeleteShelf is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockShelfRepo_Expecter) DeleteShelf(ctx interface{}, id interface{}) *MockShelfRepo_DeleteShelf_Call {
+	return &MockShelfRepo_DeleteShelf_Call{Call: _e.mock.On("DeleteShelf", ctx, id)}
+}
+
+func (_c *MockShelfRepo_DeleteShelf_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockShelfRepo_DeleteShelf_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockShelfRepo_DeleteShelf_Call) Return(_a0 error) *MockShelfRepo_DeleteShelf_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShelfRepo_DeleteShelf_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockShelfRepo_DeleteShelf_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShelfById provides a mock function with given fields: ctx, id
+func (_m *MockShelfRepo) GetShelfById(ctx context.Context, id uuid.UUID) (*models.Shelf, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShelfById")
+	}
+
+	var r0 *models.Shelf
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Shelf, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Shelf); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Shelf)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShelfRepo_GetShelfById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShelfById'
+type MockShelfRepo_GetShelfById_Call struct {
+	*mock.Call
+}
+
+// GetShelfById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockShelfRepo_Expecter) GetShelfById(ctx interface{}, id interface{}) *MockShelfRepo_GetShelfById_Call {
+	return &MockShelfRepo_GetShelfById_Call{Call: _e.mock.On("GetShelfById", ctx, id)}
+}
+
+func (_c *MockShelfRepo_GetShelfById_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockShelfRepo_GetShelfById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockShelfRepo_GetShelfById_Call) Return(_a0 *models.Shelf, _a1 error) *MockShelfRepo_GetShelfById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShelfRepo_GetShelfById_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Shelf, error)) *MockShelfRepo_GetShelfById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShelfByToken provides a mock function with given fields: ctx, token
+func (_m *MockShelfRepo) GetShelfByToken(ctx context.Context, token string) (*models.Shelf, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShelfByToken")
+	}
+
+	var r0 *models.Shelf
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Shelf, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Shelf); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Shelf)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShelfRepo_GetShelfByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShelfByToken'
+type MockShelfRepo_GetShelfByToken_Call struct {
+	*mock.Call
+}
+
+// GetShelfByToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockShelfRepo_Expecter) GetShelfByToken(ctx interface{}, token interface{}) *MockShelfRepo_GetShelfByToken_Call {
+	return &MockShelfRepo_GetShelfByToken_Call{Call: _e.mock.On("GetShelfByToken", ctx, token)}
+}
+
+func (_c *MockShelfRepo_GetShelfByToken_Call) Run(run func(ctx context.Context, token string)) *MockShelfRepo_GetShelfByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockShelfRepo_GetShelfByToken_Call) Return(_a0 *models.Shelf, _a1 error) *MockShelfRepo_GetShelfByToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShelfRepo_GetShelfByToken_Call) RunAndReturn(run func(context.Context, string) (*models.Shelf, error)) *MockShelfRepo_GetShelfByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShelfItem provides a mock function with given fields: ctx, shelfId, bookId
+func (_m *MockShelfRepo) GetShelfItem(ctx context.Context, shelfId uuid.UUID, bookId uuid.UUID) (*models.ShelfItem, error) {
+	ret := _m.Called(ctx, shelfId, bookId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShelfItem")
+	}
+
+	var r0 *models.ShelfItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*models.ShelfItem, error)); ok {
+		return rf(ctx, shelfId, bookId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *models.ShelfItem); ok {
+		r0 = rf(ctx, shelfId, bookId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ShelfItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, shelfId, bookId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShelfRepo_GetShelfItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShelfItem'
+type MockShelfRepo_GetShelfItem_Call struct {
+	*mock.Call
+}
+
+// GetShelfItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shelfId uuid.UUID
+//   - bookId uuid.UUID
+func (_e *MockShelfRepo_Expecter) GetShelfItem(ctx interface{}, shelfId interface{}, bookId interface{}) *MockShelfRepo_GetShelfItem_Call {
+	return &MockShelfRepo_GetShelfItem_Call{Call: _e.mock.On("GetShelfItem", ctx, shelfId, bookId)}
+}
+
+func (_c *MockShelfRepo_GetShelfItem_Call) Run(run func(ctx context.Context, shelfId uuid.UUID, bookId uuid.UUID)) *MockShelfRepo_GetShelfItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockShelfRepo_GetShelfItem_Call) Return(_a0 *models.ShelfItem, _a1 error) *MockShelfRepo_GetShelfItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShelfRepo_GetShelfItem_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*models.ShelfItem, error)) *MockShelfRepo_GetShelfItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShelfItems provides a mock function with given fields: ctx, shelfId
+func (_m *MockShelfRepo) GetShelfItems(ctx context.Context, shelfId uuid.UUID) ([]*models.ShelfItem, error) {
+	ret := _m.Called(ctx, shelfId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShelfItems")
+	}
+
+	var r0 []*models.ShelfItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.ShelfItem, error)); ok {
+		return rf(ctx, shelfId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.ShelfItem); ok {
+		r0 = rf(ctx, shelfId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ShelfItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, shelfId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShelfRepo_GetShelfItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShelfItems'
+type MockShelfRepo_GetShelfItems_Call struct {
+	*mock.Call
+}
+
+// GetShelfItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shelfId uuid.UUID
+func (_e *MockShelfRepo_Expecter) GetShelfItems(ctx interface{}, shelfId interface{}) *MockShelfRepo_GetShelfItems_Call {
+	return &MockShelfRepo_GetShelfItems_Call{Call: _e.mock.On("GetShelfItems", ctx, shelfId)}
+}
+
+func (_c *MockShelfRepo_GetShelfItems_Call) Run(run func(ctx context.Context, shelfId uuid.UUID)) *MockShelfRepo_GetShelfItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockShelfRepo_GetShelfItems_Call) Return(_a0 []*models.ShelfItem, _a1 error) *MockShelfRepo_GetShelfItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShelfRepo_GetShelfItems_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.ShelfItem, error)) *MockShelfRepo_GetShelfItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShelves provides a mock function with given fields: ctx, userId, publicOnly
+func (_m *MockShelfRepo) GetShelves(ctx context.Context, userId uuid.UUID, publicOnly bool) ([]*models.Shelf, error) {
+	ret := _m.Called(ctx, userId, publicOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShelves")
+	}
+
+	var r0 []*models.Shelf
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) ([]*models.Shelf, error)); ok {
+		return rf(ctx, userId, publicOnly)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) []*models.Shelf); ok {
+		r0 = rf(ctx, userId, publicOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Shelf)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool) error); ok {
+		r1 = rf(ctx, userId, publicOnly)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShelfRepo_GetShelves_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShelves'
+type MockShelfRepo_GetShelves_Call struct {
+	*mock.Call
+}
+
+// GetShelves is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+//   - publicOnly bool
+func (_e *MockShelfRepo_Expecter) GetShelves(ctx interface{}, userId interface{}, publicOnly interface{}) *MockShelfRepo_GetShelves_Call {
+	return &MockShelfRepo_GetShelves_Call{Call: _e.mock.On("GetShelves", ctx, userId, publicOnly)}
+}
+
+func (_c *MockShelfRepo_GetShelves_Call) Run(run func(ctx context.Context, userId uuid.UUID, publicOnly bool)) *MockShelfRepo_GetShelves_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockShelfRepo_GetShelves_Call) Return(_a0 []*models.Shelf, _a1 error) *MockShelfRepo_GetShelves_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShelfRepo_GetShelves_Call) RunAndReturn(run func(context.Context, uuid.UUID, bool) ([]*models.Shelf, error)) *MockShelfRepo_GetShelves_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveShelfItem provides a mock function with given fields: ctx, item
+func (_m *MockShelfRepo) RemoveShelfItem(ctx context.Context, item *models.ShelfItem) error {
+	ret := _m.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveShelfItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ShelfItem) error); ok {
+		r0 = rf(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockShelfRepo_RemoveShelfItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveShelfItem'
+type MockShelfRepo_RemoveShelfItem_Call struct {
+	*mock.Call
+}
+
+// RemoveShelfItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item *models.ShelfItem
+func (_e *MockShelfRepo_Expecter) RemoveShelfItem(ctx interface{}, item interface{}) *MockShelfRepo_RemoveShelfItem_Call {
+	return &MockShelfRepo_RemoveShelfItem_Call{Call: _e.mock.On("RemoveShelfItem", ctx, item)}
+}
+
+func (_c *MockShelfRepo_RemoveShelfItem_Call) Run(run func(ctx context.Context, item *models.ShelfItem)) *MockShelfRepo_RemoveShelfItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ShelfItem))
+	})
+	return _c
+}
+
+func (_c *MockShelfRepo_RemoveShelfItem_Call) Return(_a0 error) *MockShelfRepo_RemoveShelfItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShelfRepo_RemoveShelfItem_Call) RunAndReturn(run func(context.Context, *models.ShelfItem) error) *MockShelfRepo_RemoveShelfItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateShelf provides a mock function with given fields: ctx, shelf
+func (_m *MockShelfRepo) UpdateShelf(ctx context.Context, shelf *models.Shelf) error {
+	ret := _m.Called(ctx, shelf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateShelf")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Shelf) error); ok {
+		r0 = rf(ctx, shelf)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockShelfRepo_UpdateShelf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateShelf'
+type MockShelfRepo_UpdateShelf_Call struct {
+	*mock.Call
+}
+
+// UpdateShelf is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shelf *models.Shelf
+func (_e *MockShelfRepo_Expecter) UpdateShelf(ctx interface{}, shelf interface{}) *MockShelfRepo_UpdateShelf_Call {
+	return &MockShelfRepo_UpdateShelf_Call{Call: _e.mock.On("UpdateShelf", ctx, shelf)}
+}
+
+func (_c *MockShelfRepo_UpdateShelf_Call) Run(run func(ctx context.Context, shelf *models.Shelf)) *MockShelfRepo_UpdateShelf_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Shelf))
+	})
+	return _c
+}
+
+func (_c *MockShelfRepo_UpdateShelf_Call) Return(_a0 error) *MockShelfRepo_UpdateShelf_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShelfRepo_UpdateShelf_Call) RunAndReturn(run func(context.Context, *models.Shelf) error) *MockShelfRepo_UpdateShelf_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateShelfItem provides a mock function with given fields: ctx, item, position
+func (_m *MockShelfRepo) UpdateShelfItem(ctx context.Context, item *models.ShelfItem, position int) error {
+	ret := _m.Called(ctx, item, position)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateShelfItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ShelfItem, int) error); ok {
+		r0 = rf(ctx, item, position)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockShelfRepo_UpdateShelfItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateShelfItem'
+type MockShelfRepo_UpdateShelfItem_Call struct {
+	*mock.Call
+}
+
+// UpdateShelfItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item *models.ShelfItem
+//   - position int
+func (_e *MockShelfRepo_Expecter) UpdateShelfItem(ctx interface{}, item interface{}, position interface{}) *MockShelfRepo_UpdateShelfItem_Call {
+	return &MockShelfRepo_UpdateShelfItem_Call{Call: _e.mock.On("UpdateShelfItem", ctx, item, position)}
+}
+
+func (_c *MockShelfRepo_UpdateShelfItem_Call) Run(run func(ctx context.Context, item *models.ShelfItem, position int)) *MockShelfRepo_UpdateShelfItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ShelfItem), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockShelfRepo_UpdateShelfItem_Call) Return(_a0 error) *MockShelfRepo_UpdateShelfItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShelfRepo_UpdateShelfItem_Call) RunAndReturn(run func(context.Context, *models.ShelfItem, int) error) *MockShelfRepo_UpdateShelfItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockShelfRepo creates a new instance of MockShelfRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockShelfRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockShelfRepo {
+	mock := &MockShelfRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,173 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/storyofhis/books-management/httpserver/repository/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockTrashRepo is an autogenerated mock type for the TrashRepo type
+type MockTrashRepo struct {
+	mock.Mock
+}
+
+type MockTrashRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrashRepo) EXPECT() *MockTrashRepo_Expecter {
+	return &MockTrashRepo_Expecter{mock: &_m.Mock}
+}
+
+// GetTrash provides a mock function with given fields: ctx, userId
+func (_m *MockTrashRepo) GetTrash(ctx context.Context, userId uuid.UUID) ([]*models.Book, []*models.Author, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrash")
+	}
+
+	var r0 []*models.Book
+	var r1 []*models.Author
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.Book, []*models.Author, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.Book); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) []*models.Author); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*models.Author)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID) error); ok {
+		r2 = rf(ctx, userId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockTrashRepo_GetTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrash'
+type MockTrashRepo_GetTrash_Call struct {
+	*mock.Call
+}
+
+// GetTrash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+func (_e *MockTrashRepo_Expecter) GetTrash(ctx interface{}, userId interface{}) *MockTrashRepo_GetTrash_Call {
+	return &MockTrashRepo_GetTrash_Call{Call: _e.mock.On("GetTrash", ctx, userId)}
+}
+
+func (_c *MockTrashRepo_GetTrash_Call) Run(run func(ctx context.Context, userId uuid.UUID)) *MockTrashRepo_GetTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTrashRepo_GetTrash_Call) Return(_a0 []*models.Book, _a1 []*models.Author, _a2 error) *MockTrashRepo_GetTrash_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockTrashRepo_GetTrash_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.Book, []*models.Author, error)) *MockTrashRepo_GetTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTrash provides a mock function with given fields: ctx, before
+func (_m *MockTrashRepo) PurgeTrash(ctx context.Context, before time.Time) (int64, int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrash")
+	}
+
+	var r0 int64
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) int64); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time) error); ok {
+		r2 = rf(ctx, before)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockTrashRepo_PurgeTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTrash'
+type MockTrashRepo_PurgeTrash_Call struct {
+	*mock.Call
+}
+
+// PurgeTrash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockTrashRepo_Expecter) PurgeTrash(ctx interface{}, before interface{}) *MockTrashRepo_PurgeTrash_Call {
+	return &MockTrashRepo_PurgeTrash_Call{Call: _e.mock.On("PurgeTrash", ctx, before)}
+}
+
+func (_c *MockTrashRepo_PurgeTrash_Call) Run(run func(ctx context.Context, before time.Time)) *MockTrashRepo_PurgeTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTrashRepo_PurgeTrash_Call) Return(_a0 int64, _a1 int64, _a2 error) *MockTrashRepo_PurgeTrash_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockTrashRepo_PurgeTrash_Call) RunAndReturn(run func(context.Context, time.Time) (int64, int64, error)) *MockTrashRepo_PurgeTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTrashRepo creates a new instance of MockTrashRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrashRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrashRepo {
+	mock := &MockTrashRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
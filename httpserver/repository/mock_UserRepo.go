@@ -7,6 +7,8 @@ import (
 
 	models "github.com/storyofhis/books-management/httpserver/repository/models"
 	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
 )
 
 // MockUserRepo is an autogenerated mock type for the UserRepo type
@@ -69,6 +71,65 @@ func (_c *MockUserRepo_CreateUser_Call) RunAndReturn(run func(context.Context, *
 	return _c
 }
 
+// GetUserById provides a mock function with given fields: ctx, id
+func (_m *MockUserRepo) GetUserById(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserById")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepo_GetUserById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserById'
+type MockUserRepo_GetUserById_Call struct {
+	*mock.Call
+}
+
+// GetUserById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockUserRepo_Expecter) GetUserById(ctx interface{}, id interface{}) *MockUserRepo_GetUserById_Call {
+	return &MockUserRepo_GetUserById_Call{Call: _e.mock.On("GetUserById", ctx, id)}
+}
+
+func (_c *MockUserRepo_GetUserById_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepo_GetUserById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_GetUserById_Call) Return(_a0 *models.User, _a1 error) *MockUserRepo_GetUserById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepo_GetUserById_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.User, error)) *MockUserRepo_GetUserById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUserByUsername provides a mock function with given fields: ctx, username
 func (_m *MockUserRepo) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
 	ret := _m.Called(ctx, username)
@@ -128,6 +189,112 @@ func (_c *MockUserRepo_GetUserByUsername_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// GetUsers provides a mock function with given fields: ctx
+func (_m *MockUserRepo) GetUsers(ctx context.Context) ([]*models.User, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 []*models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepo_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
+type MockUserRepo_GetUsers_Call struct {
+	*mock.Call
+}
+
+// GetUsers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUserRepo_Expecter) GetUsers(ctx interface{}) *MockUserRepo_GetUsers_Call {
+	return &MockUserRepo_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx)}
+}
+
+func (_c *MockUserRepo_GetUsers_Call) Run(run func(ctx context.Context)) *MockUserRepo_GetUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_GetUsers_Call) Return(_a0 []*models.User, _a1 error) *MockUserRepo_GetUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepo_GetUsers_Call) RunAndReturn(run func(context.Context) ([]*models.User, error)) *MockUserRepo_GetUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserRole provides a mock function with given fields: ctx, id, role
+func (_m *MockUserRepo) UpdateUserRole(ctx context.Context, id uuid.UUID, role string) error {
+	ret := _m.Called(ctx, id, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserRepo_UpdateUserRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserRole'
+type MockUserRepo_UpdateUserRole_Call struct {
+	*mock.Call
+}
+
+// UpdateUserRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - role string
+func (_e *MockUserRepo_Expecter) UpdateUserRole(ctx interface{}, id interface{}, role interface{}) *MockUserRepo_UpdateUserRole_Call {
+	return &MockUserRepo_UpdateUserRole_Call{Call: _e.mock.On("UpdateUserRole", ctx, id, role)}
+}
+
+func (_c *MockUserRepo_UpdateUserRole_Call) Run(run func(ctx context.Context, id uuid.UUID, role string)) *MockUserRepo_UpdateUserRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_UpdateUserRole_Call) Return(_a0 error) *MockUserRepo_UpdateUserRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserRepo_UpdateUserRole_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *MockUserRepo_UpdateUserRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockUserRepo creates a new instance of MockUserRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUserRepo(t interface {
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Author struct {
@@ -12,6 +13,12 @@ type Author struct {
 	User      User `gorm:"foreignKey:UserId"`
 	Name      string
 	Birthdate time.Time
+	// NameKey is the name in lower case, folded in Go as SQLite's LOWER
+	// only folds ASCII letters, to match names ignoring case.
+	NameKey string `gorm:"index"`
+	// Version is incremented by every update, it is the ETag of the author.
+	Version   int `gorm:"not null;default:1"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
@@ -4,16 +4,23 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Book struct {
-	Id        uuid.UUID `gorm:"type:uuid;primaryKey"`
-	UserId    uuid.UUID
-	User      User `gorm:"foreignKey:UserId"`
-	AuthorId  uuid.UUID
-	Author    Author `gorm:"foreignKey:AuthorId"`
-	Title     string
-	Isbn      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	Id           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserId       uuid.UUID
+	User         User `gorm:"foreignKey:UserId"`
+	Title        string
+	Isbn         string            `gorm:"index"`
+	Contributors []BookContributor `gorm:"foreignKey:BookId"`
+	// Version is incremented by every update, it is the ETag of the book.
+	Version int `gorm:"not null;default:1"`
+	// RatingAvg and RatingCount aggregate the reviews that are not hidden.
+	// They are kept up to date by ReviewRepo and left out of the version.
+	RatingAvg   float64 `gorm:"not null;default:0;index"`
+	RatingCount int64   `gorm:"not null;default:0"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
 }
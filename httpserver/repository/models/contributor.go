@@ -0,0 +1,22 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	ContributorAuthor      = "author"
+	ContributorEditor      = "editor"
+	ContributorTranslator  = "translator"
+	ContributorIllustrator = "illustrator"
+)
+
+var ContributorRoles = []string{ContributorAuthor, ContributorEditor, ContributorTranslator, ContributorIllustrator}
+
+// BookContributor links a book to an author in a given role. Position orders
+// the contributors of a book as they appear on the title page.
+type BookContributor struct {
+	BookId   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	AuthorId uuid.UUID `gorm:"type:uuid;primaryKey;index"`
+	Author   Author    `gorm:"foreignKey:AuthorId"`
+	Role     string    `gorm:"primaryKey"`
+	Position int       `gorm:"not null;default:0"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CopyAvailable = "available"
+	CopyOnLoan    = "on_loan"
+	// CopyOnHold is a copy kept for the member whose hold it was assigned
+	// to.
+	CopyOnHold    = "on_hold"
+	CopyInRepair  = "in_repair"
+	CopyLost      = "lost"
+	CopyWithdrawn = "withdrawn"
+)
+
+// Circulating reports whether status is managed by loans and holds, copies
+// cannot be given it or taken out of it by editing them.
+func Circulating(status string) bool {
+	return status == CopyOnLoan || status == CopyOnHold
+}
+
+const (
+	ConditionNew     = "new"
+	ConditionGood    = "good"
+	ConditionFair    = "fair"
+	ConditionPoor    = "poor"
+	ConditionDamaged = "damaged"
+)
+
+// Copy is a physical copy of a book, identified by the barcode on its label.
+type Copy struct {
+	Id         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	BookId     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Book       Book      `gorm:"foreignKey:BookId"`
+	Barcode    string    `gorm:"not null;uniqueIndex"`
+	Location   string
+	Condition  string `gorm:"not null;default:good"`
+	Status     string `gorm:"not null;default:available;index"`
+	AcquiredAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	HistoryBook   = "book"
+	HistoryAuthor = "author"
+)
+
+const (
+	HistoryCreate  = "create"
+	HistoryUpdate  = "update"
+	HistoryDelete  = "delete"
+	HistoryRestore = "restore"
+	HistoryRevert  = "revert"
+)
+
+// HistoryEntry records one change of a book or an author. Versions count
+// the changes of a record from 1 and are shown as revisions, apart from the
+// version of the record itself. Snapshot holds the record as it was after
+// the change and Changes the fields that changed, both JSON encoded.
+type HistoryEntry struct {
+	Id         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EntityType string    `gorm:"uniqueIndex:idx_history_version;not null"`
+	EntityId   uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_history_version;not null"`
+	Version    int       `gorm:"uniqueIndex:idx_history_version;not null"`
+	Action     string    `gorm:"not null"`
+	ActorId    uuid.UUID `gorm:"type:uuid"`
+	Changes    string
+	Snapshot   string
+	CreatedAt  time.Time
+}
+
+// FieldChange is a changed field of a HistoryEntry, with its JSON encoded
+// values before and after the change.
+type FieldChange struct {
+	Field string          `json:"field"`
+	From  json.RawMessage `json:"from"`
+	To    json.RawMessage `json:"to"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	HoldWaiting   = "waiting"
+	HoldReady     = "ready"
+	HoldFulfilled = "fulfilled"
+	HoldCancelled = "cancelled"
+	HoldExpired   = "expired"
+)
+
+// Hold queues a user for a copy of a book. Holds are served in the order they
+// were placed: the oldest waiting hold is given the next copy that becomes
+// available, which is then kept for its user until ExpiresAt. A user has at
+// most one waiting or ready hold per book.
+type Hold struct {
+	Id        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	BookId    uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_holds_active_user,where:status = 'waiting' OR status = 'ready'"`
+	Book      Book       `gorm:"foreignKey:BookId"`
+	UserId    uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_holds_active_user,where:status = 'waiting' OR status = 'ready'"`
+	User      User       `gorm:"foreignKey:UserId"`
+	Status    string     `gorm:"not null;default:waiting;index"`
+	CopyId    *uuid.UUID `gorm:"type:uuid"`
+	Copy      *Copy      `gorm:"foreignKey:CopyId"`
+	ReadyAt   *time.Time
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Position is the place of a waiting hold in the queue of its book,
+	// from 1. It is only loaded by HoldRepo.GetHolds.
+	Position int `gorm:"->;-:migration"`
+}
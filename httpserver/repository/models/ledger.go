@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EntryFine    = "fine"
+	EntryPayment = "payment"
+	EntryWaiver  = "waiver"
+)
+
+// LedgerEntry is a transaction on the account of a user, in minor units.
+// Fines are positive and payments and waivers negative, so the balance of a
+// user is the sum of their entries. A loan has a single fine entry, which
+// grows while the loan stays overdue.
+type LedgerEntry struct {
+	Id     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserId uuid.UUID `gorm:"type:uuid;not null;index"`
+	User   User      `gorm:"foreignKey:UserId"`
+	Kind   string    `gorm:"not null"`
+	Amount int64     `gorm:"not null"`
+	// LoanId is not a foreign key so that fines outlive the loans purged
+	// with their book.
+	LoanId *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_ledger_loan_fine,where:kind = 'fine'"`
+	Note   string
+	// CreatedBy is the member of staff who recorded a payment or waiver.
+	CreatedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
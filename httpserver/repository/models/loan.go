@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Loan lends a copy to a user until it is returned. A copy has at most one
+// open loan, the partial unique index on CopyId enforces it.
+type Loan struct {
+	Id         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CopyId     uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_loans_open_copy,where:returned_at IS NULL"`
+	Copy       Copy      `gorm:"foreignKey:CopyId"`
+	UserId     uuid.UUID `gorm:"type:uuid;not null;index"`
+	User       User      `gorm:"foreignKey:UserId"`
+	BorrowedAt time.Time `gorm:"not null"`
+	DueAt      time.Time `gorm:"not null;index"`
+	ReturnedAt *time.Time
+	Renewals   int `gorm:"not null;default:0"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Overdue reports whether the loan is still open after its due date.
+func (l *Loan) Overdue(now time.Time) bool {
+	return l.ReturnedAt == nil && now.After(l.DueAt)
+}
+
+// Fine returns the late fee of the loan at now, or when it was returned:
+// rate for every started day past the due date, up to limit unless it is
+// zero.
+func (l *Loan) Fine(now time.Time, rate, limit int64) int64 {
+	end := now
+	if l.ReturnedAt != nil {
+		end = *l.ReturnedAt
+	}
+	late := end.Sub(l.DueAt)
+	if late <= 0 {
+		return 0
+	}
+	days := int64((late + 24*time.Hour - 1) / (24 * time.Hour))
+	fine := days * rate
+	if limit > 0 && fine > limit {
+		fine = limit
+	}
+	return fine
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReviewVisible = "visible"
+	// ReviewFlagged is a review an admin marked for attention, it is still
+	// shown.
+	ReviewFlagged = "flagged"
+	// ReviewHidden is a review an admin took down, it is only shown to
+	// admins and does not count in the rating of the book.
+	ReviewHidden = "hidden"
+)
+
+// Review is the rating, from 1 to 5, and the optional text a member gives a
+// book. A member reviews a book at most once.
+type Review struct {
+	Id     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	BookId uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reviews_book_user"`
+	Book   Book      `gorm:"foreignKey:BookId"`
+	UserId uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reviews_book_user;index"`
+	User   User      `gorm:"foreignKey:UserId"`
+	Rating int       `gorm:"not null"`
+	Body   string
+	Status string `gorm:"not null;default:visible;index"`
+	// ModeratedBy is the admin who last changed the status.
+	ModeratedBy    *uuid.UUID `gorm:"type:uuid"`
+	ModerationNote string
+	ModeratedAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
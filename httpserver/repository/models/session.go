@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Session struct {
+	Id        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserId    uuid.UUID `gorm:"index"`
+	User      User      `gorm:"foreignKey:UserId"`
+	RevokedAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type RefreshToken struct {
+	Id        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	SessionId uuid.UUID `gorm:"index"`
+	Session   Session   `gorm:"foreignKey:SessionId"`
+	TokenHash string    `gorm:"uniqueIndex"`
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
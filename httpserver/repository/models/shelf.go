@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ShelfToRead   = "to_read"
+	ShelfReading  = "reading"
+	ShelfFinished = "finished"
+	// ShelfCustom is a list the user made, the other kinds are the built-in
+	// shelves every user has once.
+	ShelfCustom = "custom"
+)
+
+// DefaultShelves are the built-in shelves of every user, by kind.
+var DefaultShelves = []struct{ Kind, Name string }{
+	{ShelfToRead, "To read"},
+	{ShelfReading, "Reading"},
+	{ShelfFinished, "Finished"},
+}
+
+// Shelf is a named reading list of a user. Shelves are private unless they
+// are public, in which case anyone with their share token can read them.
+type Shelf struct {
+	Id     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserId uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_shelves_user_name;uniqueIndex:idx_shelves_user_kind,where:kind <> 'custom'"`
+	User   User      `gorm:"foreignKey:UserId"`
+	Name   string    `gorm:"not null;uniqueIndex:idx_shelves_user_name"`
+	Kind   string    `gorm:"not null;default:custom;uniqueIndex:idx_shelves_user_kind,where:kind <> 'custom'"`
+	Public bool      `gorm:"not null;default:false"`
+	// ShareToken is set while the shelf is public, it is the secret part of
+	// the link the shelf is shared by.
+	ShareToken *string `gorm:"uniqueIndex"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Items      []ShelfItem `gorm:"foreignKey:ShelfId"`
+	// ItemCount is only loaded by ShelfRepo.GetShelves.
+	ItemCount int64 `gorm:"->;-:migration"`
+}
+
+// ShelfItem is a book on a shelf. Items are ordered by Position, from 1.
+type ShelfItem struct {
+	Id         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ShelfId    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_shelf_items_shelf_book"`
+	BookId     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_shelf_items_shelf_book;index"`
+	Book       Book      `gorm:"foreignKey:BookId"`
+	Position   int       `gorm:"not null"`
+	Note       string
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -10,6 +10,7 @@ type User struct {
 	Id        uuid.UUID `gorm:"type:uuid;primaryKey"`
 	Username  string
 	Password  string
+	Role      string `gorm:"not null;default:member"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
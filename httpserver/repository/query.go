@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidSort   = errors.New("invalid sort field")
+)
+
+// Page selects a page of a list either by page number or, when Page is zero,
+// by keyset cursor. Sort is a field name optionally prefixed with "-" for
+// descending order.
+type Page struct {
+	Page     int
+	PageSize int
+	Cursor   string
+	Sort     string
+}
+
+// PageInfo describes the page that was returned.
+type PageInfo struct {
+	Total      int64
+	PageSize   int
+	NextCursor string
+	PrevCursor string
+}
+
+type BookFilter struct {
+	AuthorId    uuid.UUID
+	Role        string
+	UserId      uuid.UUID
+	Title       string
+	Isbn        string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+type AuthorFilter struct {
+	NamePrefix string
+	Name       string
+	BornFrom   time.Time
+	BornTo     time.Time
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrDuplicateReview = errors.New("you already reviewed this book")
+
+type ReviewFilter struct {
+	BookId uuid.UUID
+	// Statuses only keeps the reviews with one of the statuses, all of them
+	// when it is empty.
+	Statuses []string
+}
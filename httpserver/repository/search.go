@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidSearch = errors.New("invalid search query")
+
+// BookHit is a book matching a full-text search. Highlighted fields wrap the
+// matched terms in <mark> tags.
+type BookHit struct {
+	Id             uuid.UUID
+	Title          string
+	Isbn           string
+	Authors        string
+	TitleHighlight string
+	Snippet        string
+	Rank           float64
+}
+
+type AuthorHit struct {
+	Id            uuid.UUID
+	Name          string
+	NameHighlight string
+	Rank          float64
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "errors"
+
+var (
+	ErrDuplicateShelf     = errors.New("you already have a shelf with this name")
+	ErrDuplicateShelfItem = errors.New("the book is already on the shelf")
+)
@@ -1,48 +1,176 @@
 package httpserver
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/config"
 	author_controller "github.com/storyofhis/books-management/httpserver/controller/author"
 	book_controller "github.com/storyofhis/books-management/httpserver/controller/book"
+	catalog_controller "github.com/storyofhis/books-management/httpserver/controller/catalog"
+	circulation_controller "github.com/storyofhis/books-management/httpserver/controller/circulation"
+	citation_controller "github.com/storyofhis/books-management/httpserver/controller/citation"
+	export_controller "github.com/storyofhis/books-management/httpserver/controller/export"
+	importer_controller "github.com/storyofhis/books-management/httpserver/controller/importer"
+	inventory_controller "github.com/storyofhis/books-management/httpserver/controller/inventory"
+	ledger_controller "github.com/storyofhis/books-management/httpserver/controller/ledger"
+	review_controller "github.com/storyofhis/books-management/httpserver/controller/review"
+	search_controller "github.com/storyofhis/books-management/httpserver/controller/search"
+	shelf_controller "github.com/storyofhis/books-management/httpserver/controller/shelf"
+	trash_controller "github.com/storyofhis/books-management/httpserver/controller/trash"
 	user_controller "github.com/storyofhis/books-management/httpserver/controller/user"
+	"github.com/storyofhis/books-management/httpserver/service"
 )
 
 type router struct {
 	router *gin.Engine
 
-	user   user_controller.UserController
-	author author_controller.AuthorController
-	book   book_controller.BookController
+	user      user_controller.UserController
+	author    author_controller.AuthorController
+	book      book_controller.BookController
+	copies    inventory_controller.CopyController
+	loans     circulation_controller.LoanController
+	holds     circulation_controller.HoldController
+	ledger    ledger_controller.LedgerController
+	reviews   review_controller.ReviewController
+	shelves   shelf_controller.ShelfController
+	imports   importer_controller.ImportController
+	exports   export_controller.ExportController
+	search    search_controller.SearchController
+	catalog   catalog_controller.CatalogController
+	citations citation_controller.CitationController
+	trash     trash_controller.TrashController
+
+	auth service.UserSvc
 }
 
-func NewRouter(r *gin.Engine, user user_controller.UserController, author author_controller.AuthorController, book book_controller.BookController) *router {
+func NewRouter(r *gin.Engine, auth service.UserSvc, user user_controller.UserController, author author_controller.AuthorController, book book_controller.BookController, copies inventory_controller.CopyController, loans circulation_controller.LoanController, holds circulation_controller.HoldController, ledger ledger_controller.LedgerController, reviews review_controller.ReviewController, shelves shelf_controller.ShelfController, imports importer_controller.ImportController, exports export_controller.ExportController, search search_controller.SearchController, catalog catalog_controller.CatalogController, citations citation_controller.CitationController, trash trash_controller.TrashController) *router {
 	return &router{
-		router: r,
-		user:   user,
-		author: author,
-		book:   book,
+		router:    r,
+		auth:      auth,
+		user:      user,
+		author:    author,
+		book:      book,
+		copies:    copies,
+		loans:     loans,
+		holds:     holds,
+		ledger:    ledger,
+		reviews:   reviews,
+		shelves:   shelves,
+		imports:   imports,
+		exports:   exports,
+		search:    search,
+		catalog:   catalog,
+		citations: citations,
+		trash:     trash,
 	}
 }
 
 func (r *router) Start(port string) {
+	catalogWrite := r.authorize(common.RoleAdmin, common.RoleLibrarian, common.RoleMember)
+	userAdmin := r.authorize(common.RoleAdmin)
+	staff := r.authorize(common.RoleAdmin, common.RoleLibrarian)
+	ifMatch := r.requireIfMatch(config.GetRequireIfMatch())
+
 	r.router.POST("/auth/register", r.user.Register)
 	r.router.POST("/auth/login", r.user.Login)
+	r.router.POST("/auth/refresh", r.user.Refresh)
+	r.router.POST("/auth/logout", r.verifyToken, r.user.Logout)
+	r.router.POST("/auth/logout-all", r.verifyToken, r.user.LogoutAll)
+
+	r.router.GET("/users", r.verifyToken, userAdmin, r.user.GetUsers)
+	r.router.PUT("/users/:id/role", r.verifyToken, userAdmin, r.user.UpdateRole)
 
-	r.router.POST("/authors", r.verifyToken, r.author.CreateAuthor)
+	r.router.POST("/authors", r.verifyToken, catalogWrite, r.author.CreateAuthor)
 	r.router.GET("/authors", r.verifyToken, r.author.GetAuthors)
 	r.router.GET("/authors/:id", r.verifyToken, r.author.GetAuthorById)
-	r.router.PUT("/authors/:id", r.verifyToken, r.author.UpdateAuthor)
-	r.router.DELETE("/authors/:id", r.verifyToken, r.author.DeleteAuthor)
+	r.router.PUT("/authors/:id", r.verifyToken, catalogWrite, ifMatch, r.author.UpdateAuthor)
+	r.router.PATCH("/authors/:id", r.verifyToken, catalogWrite, ifMatch, r.author.PatchAuthor)
+	r.router.DELETE("/authors/:id", r.verifyToken, catalogWrite, ifMatch, r.author.DeleteAuthor)
+	r.router.POST("/authors/:id/restore", r.verifyToken, catalogWrite, r.author.RestoreAuthor)
+	r.router.GET("/authors/:id/history", r.verifyToken, r.author.GetAuthorHistory)
+	r.router.POST("/authors/:id/revert", r.verifyToken, catalogWrite, ifMatch, r.author.RevertAuthor)
 
-	r.router.POST("/books", r.verifyToken, r.book.CreateBook)
+	r.router.POST("/books", r.verifyToken, catalogWrite, r.book.CreateBook)
 	r.router.GET("/books", r.verifyToken, r.book.GetBooks)
 	r.router.GET("/books/:id", r.verifyToken, r.book.GetBookById)
-	r.router.PUT("/books/:id", r.verifyToken, r.book.UpdateBook)
-	r.router.DELETE("books/:id", r.verifyToken, r.book.DeleteBook)
+	r.router.PUT("/books/:id", r.verifyToken, catalogWrite, ifMatch, r.book.UpdateBook)
+	r.router.PATCH("/books/:id", r.verifyToken, catalogWrite, ifMatch, r.book.PatchBook)
+	r.router.DELETE("books/:id", r.verifyToken, catalogWrite, ifMatch, r.book.DeleteBook)
+	r.router.POST("/books/:id/restore", r.verifyToken, catalogWrite, r.book.RestoreBook)
+	r.router.GET("/books/:id/history", r.verifyToken, r.book.GetBookHistory)
+	r.router.POST("/books/:id/revert", r.verifyToken, catalogWrite, ifMatch, r.book.RevertBook)
+
+	r.router.POST("/books/:id/copies", r.verifyToken, staff, r.copies.CreateCopy)
+	r.router.GET("/books/:id/copies", r.verifyToken, r.copies.GetCopies)
+	r.router.GET("/books/:id/copies/:copyId", r.verifyToken, r.copies.GetCopy)
+	r.router.PUT("/books/:id/copies/:copyId", r.verifyToken, staff, r.copies.UpdateCopy)
+	r.router.DELETE("/books/:id/copies/:copyId", r.verifyToken, staff, r.copies.DeleteCopy)
+	r.router.GET("/copies/by-barcode/:code", r.verifyToken, r.copies.GetCopyByBarcode)
+
+	r.router.POST("/loans", r.verifyToken, staff, r.loans.Checkout)
+	r.router.GET("/loans/overdue", r.verifyToken, staff, r.loans.GetOverdueLoans)
+	r.router.GET("/loans/:id", r.verifyToken, r.loans.GetLoan)
+	r.router.POST("/loans/:id/return", r.verifyToken, staff, r.loans.ReturnLoan)
+	r.router.POST("/loans/:id/renew", r.verifyToken, r.loans.RenewLoan)
+	r.router.GET("/users/:id/loans", r.verifyToken, r.loans.GetUserLoans)
+	r.router.GET("/books/:id/loans", r.verifyToken, staff, r.loans.GetBookLoans)
+
+	r.router.POST("/books/:id/holds", r.verifyToken, r.holds.PlaceHold)
+	r.router.GET("/books/:id/holds", r.verifyToken, staff, r.holds.GetBookHolds)
+	r.router.GET("/users/:id/holds", r.verifyToken, r.holds.GetUserHolds)
+	r.router.DELETE("/holds/:id", r.verifyToken, r.holds.CancelHold)
+
+	r.router.GET("/users/:id/balance", r.verifyToken, r.ledger.GetBalance)
+	r.router.GET("/users/:id/transactions", r.verifyToken, r.ledger.GetTransactions)
+	r.router.POST("/users/:id/payments", r.verifyToken, staff, r.ledger.RecordPayment)
+	r.router.POST("/users/:id/waivers", r.verifyToken, staff, r.ledger.RecordWaiver)
+
+	r.router.POST("/books/:id/reviews", r.verifyToken, catalogWrite, r.reviews.CreateReview)
+	r.router.GET("/books/:id/reviews", r.verifyToken, r.reviews.GetReviews)
+	r.router.PUT("/books/:id/reviews/:reviewId", r.verifyToken, catalogWrite, r.reviews.UpdateReview)
+	r.router.DELETE("/books/:id/reviews/:reviewId", r.verifyToken, catalogWrite, r.reviews.DeleteReview)
+	r.router.PUT("/books/:id/reviews/:reviewId/status", r.verifyToken, userAdmin, r.reviews.ModerateReview)
+
+	r.router.GET("/users/:id/shelves", r.verifyToken, r.shelves.GetShelves)
+	r.router.POST("/shelves", r.verifyToken, r.shelves.CreateShelf)
+	r.router.GET("/shelves/:id", r.verifyToken, r.shelves.GetShelf)
+	r.router.PUT("/shelves/:id", r.verifyToken, r.shelves.UpdateShelf)
+	r.router.DELETE("/shelves/:id", r.verifyToken, r.shelves.DeleteShelf)
+	r.router.POST("/shelves/:id/items", r.verifyToken, r.shelves.AddShelfItem)
+	r.router.PUT("/shelves/:id/items/:bookId", r.verifyToken, r.shelves.UpdateShelfItem)
+	r.router.DELETE("/shelves/:id/items/:bookId", r.verifyToken, r.shelves.RemoveShelfItem)
+	r.router.GET("/shared/shelves/:token", r.shelves.GetSharedShelf)
+
+	r.router.POST("/import/books", r.verifyToken, staff, r.imports.ImportBooks)
+	r.router.POST("/import/authors", r.verifyToken, staff, r.imports.ImportAuthors)
+
+	r.router.GET("/export/books", r.verifyToken, staff, r.exports.ExportBooks)
+	r.router.GET("/export/authors", r.verifyToken, staff, r.exports.ExportAuthors)
+
+	r.router.GET("/trash", r.verifyToken, r.trash.GetTrash)
+
+	r.router.GET("/search", r.verifyToken, r.search.Search)
+
+	r.router.GET("/opds", r.verifyReader, r.catalog.GetRoot)
+	r.router.GET("/opds/new", r.verifyReader, r.catalog.GetNewest)
+	r.router.GET("/opds/authors", r.verifyReader, r.catalog.GetAuthors)
+	r.router.GET("/opds/authors/:id", r.verifyReader, r.catalog.GetAuthorBooks)
+	r.router.GET("/opds/search", r.verifyReader, r.catalog.Search)
+	r.router.GET("/opds/authentication", r.catalog.GetAuthentication)
+	r.router.GET("/opds/opensearch.xml", r.verifyReader, r.catalog.GetOpenSearch)
+	r.router.GET("/opds/v2", r.verifyReader, r.catalog.GetRoot)
+	r.router.GET("/opds/v2/new", r.verifyReader, r.catalog.GetNewest)
+	r.router.GET("/opds/v2/authors", r.verifyReader, r.catalog.GetAuthors)
+	r.router.GET("/opds/v2/authors/:id", r.verifyReader, r.catalog.GetAuthorBooks)
+	r.router.GET("/opds/v2/search", r.verifyReader, r.catalog.Search)
+	r.router.GET("/opds/v2/authentication", r.catalog.GetAuthentication)
+
+	r.router.GET("/books/:id/citation", r.verifyToken, r.citations.GetCitation)
+	r.router.GET("/citations", r.verifyToken, r.citations.GetCitations)
 	r.router.Run(port)
 }
 
@@ -61,5 +189,72 @@ func (r *router) verifyToken(ctx *gin.Context) {
 		})
 		return
 	}
+	err = r.auth.VerifySession(ctx, claims)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
 	ctx.Set("userData", claims)
 }
+
+// verifyReader authenticates the requests to the OPDS catalog. Readers send
+// the username and password of the user with HTTP Basic authentication, as
+// they cannot log in for a token, and are answered with the authentication
+// document of the catalog until they do. Bearer tokens are accepted too.
+func (r *router) verifyReader(ctx *gin.Context) {
+	if strings.HasPrefix(ctx.GetHeader("Authorization"), "Bearer ") {
+		r.verifyToken(ctx)
+		return
+	}
+	username, password, ok := ctx.Request.BasicAuth()
+	if !ok {
+		r.catalog.Unauthorized(ctx)
+		return
+	}
+	claims, err := r.auth.Authenticate(ctx, username, password)
+	if errors.Is(err, common.ErrInvalidCredentials) {
+		r.catalog.Unauthorized(ctx)
+		return
+	}
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	ctx.Set("userData", claims)
+}
+
+// authorize only lets requests through whose token carries one of roles. It
+// must run after verifyToken.
+func (r *router) authorize(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, exists := ctx.Get("userData")
+		if !exists {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "token doesn't exist",
+			})
+			return
+		}
+		if !claims.(*common.CustomClaims).HasRole(roles...) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "You do not have permission to perform this action",
+			})
+			return
+		}
+	}
+}
+
+// requireIfMatch rejects requests without an If-Match header when required is
+// set, so that a client cannot overwrite changes it has not seen.
+func (r *router) requireIfMatch(required bool) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if required && ctx.GetHeader("If-Match") == "" {
+			ctx.AbortWithStatusJSON(http.StatusPreconditionRequired, gin.H{
+				"error": "the If-Match header is required, send the ETag of the record",
+			})
+		}
+	}
+}
@@ -2,19 +2,46 @@ package author
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
 	"github.com/storyofhis/books-management/httpserver/controller/params"
 	"github.com/storyofhis/books-management/httpserver/controller/views"
 	"github.com/storyofhis/books-management/httpserver/repository"
 	"github.com/storyofhis/books-management/httpserver/repository/models"
 	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/book"
+	"github.com/storyofhis/books-management/httpserver/service/history"
 	"gorm.io/gorm"
 )
 
 type authorSvc struct {
-	repo repository.AuthorRepo
+	repo    repository.AuthorRepo
+	history repository.HistoryRepo
+	tx      repository.Transactor
+}
+
+// authorState is the state of an author recorded in its history.
+type authorState struct {
+	Name      string    `json:"name"`
+	Birthdate time.Time `json:"birthdate"`
+}
+
+func newAuthorState(author *models.Author) *authorState {
+	return &authorState{
+		Name:      author.Name,
+		Birthdate: author.Birthdate,
+	}
+}
+
+// CreatedEntry returns the history entry recording the creation of the
+// author, for the authors created without AuthorSvc.
+func CreatedEntry(author *models.Author, actorId uuid.UUID) (*models.HistoryEntry, error) {
+	return history.NewEntry(models.HistoryAuthor, author.Id, models.HistoryCreate, actorId, nil, newAuthorState(author))
 }
 
 // CreateAuthor implements service.AuthorSvc.
@@ -25,7 +52,12 @@ func (svc *authorSvc) CreateAuthor(ctx context.Context, author *params.CreateAut
 		Birthdate: author.Birthdate,
 	}
 
-	err := svc.repo.CreateAuthor(ctx, &param)
+	err := svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := svc.repo.CreateAuthor(ctx, &param); err != nil {
+			return err
+		}
+		return svc.record(ctx, param.Id, models.HistoryCreate, id, nil, newAuthorState(&param))
+	})
 	if err != nil {
 		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
 	}
@@ -37,11 +69,30 @@ func (svc *authorSvc) CreateAuthor(ctx context.Context, author *params.CreateAut
 		Birthdate: param.Birthdate,
 		CreatedAt: param.CreatedAt,
 		UpdatedAt: param.UpdatedAt,
+		Version:   param.Version,
 	})
 }
 
 // DeleteAuthor implements service.AuthorSvc.
-func (svc *authorSvc) DeleteAuthor(ctx context.Context, id uuid.UUID) *views.Response {
+func (svc *authorSvc) DeleteAuthor(ctx context.Context, id uuid.UUID, query *params.DeleteAuthor, version int, user *common.CustomClaims) *views.Response {
+	opts := repository.AuthorDelete{Policy: repository.DeleteRefuse}
+	if !user.HasRole(common.RoleAdmin) {
+		opts.OwnerId = user.Id
+	}
+	if query.Policy != "" {
+		opts.Policy = repository.AuthorDeletePolicy(query.Policy)
+	}
+	if opts.Policy == repository.DeleteReassign {
+		to, err := uuid.Parse(query.ReassignTo)
+		if err != nil {
+			return views.ErrorReponse(http.StatusBadRequest, views.M_BAD_REQUEST, err)
+		}
+		if to == id {
+			return views.ErrorReponse(http.StatusBadRequest, views.M_BAD_REQUEST, errors.New("cannot reassign books to the deleted author"))
+		}
+		opts.ReassignTo = to
+	}
+
 	author, err := svc.repo.GetAuthorById(ctx, id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -49,9 +100,46 @@ func (svc *authorSvc) DeleteAuthor(ctx context.Context, id uuid.UUID) *views.Res
 		}
 		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
 	}
+	if version != 0 && version != author.Version {
+		return views.ErrorReponse(http.StatusPreconditionFailed, views.M_PRECONDITION_FAILED, repository.ErrVersionConflict)
+	}
+	opts.Version = author.Version
 
-	err = svc.repo.DeleteAuthor(ctx, id)
+	// Books moved to the trash with the author are recorded as deleted,
+	// books credited to another author as updated.
+	bookAction := models.HistoryDelete
+	if opts.Policy == repository.DeleteReassign {
+		bookAction = models.HistoryUpdate
+	}
+	err = svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		changes, err := svc.repo.DeleteAuthor(ctx, id, &opts)
+		if err != nil {
+			return err
+		}
+		for _, c := range changes {
+			if err := svc.recordBook(ctx, c.Before, c.After, bookAction, user.Id); err != nil {
+				return err
+			}
+		}
+		state := newAuthorState(author)
+		return svc.record(ctx, id, models.HistoryDelete, user.Id, state, state)
+	})
 	if err != nil {
+		var inUse *repository.AuthorHasBooksError
+		if errors.As(err, &inUse) {
+			res := views.ErrorReponse(http.StatusConflict, views.M_AUTHOR_HAS_BOOKS, err)
+			res.Payload = authorBooks(inUse.Books)
+			return res
+		}
+		if err == repository.ErrUnknownAuthor {
+			return views.ErrorReponse(http.StatusUnprocessableEntity, views.M_UNKNOWN_AUTHOR, err)
+		}
+		if err == repository.ErrVersionConflict {
+			return views.ErrorReponse(http.StatusPreconditionFailed, views.M_PRECONDITION_FAILED, err)
+		}
+		if err == gorm.ErrForeignKeyViolated {
+			return views.ErrorReponse(http.StatusConflict, views.M_AUTHOR_HAS_BOOKS, err)
+		}
 		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
 	}
 
@@ -60,6 +148,41 @@ func (svc *authorSvc) DeleteAuthor(ctx context.Context, id uuid.UUID) *views.Res
 	})
 }
 
+// RestoreAuthor implements service.AuthorSvc.
+func (svc *authorSvc) RestoreAuthor(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	author, err := svc.repo.GetDeletedAuthorById(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_NOT_IN_TRASH, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if !user.CanManage(author.UserId) {
+		return views.ErrorReponse(http.StatusForbidden, views.M_FORBIDDEN, errors.New("you do not have permission to restore this author"))
+	}
+
+	err = svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		books, err := svc.repo.RestoreAuthor(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, b := range books {
+			if err := svc.recordBook(ctx, b, b, models.HistoryRestore, user.Id); err != nil {
+				return err
+			}
+		}
+		state := newAuthorState(author)
+		return svc.record(ctx, id, models.HistoryRestore, user.Id, state, state)
+	})
+	if err != nil {
+		if err == repository.ErrDuplicateIsbn {
+			return views.ErrorReponse(http.StatusConflict, views.M_DUPLICATE_ISBN, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return svc.GetAuthorById(ctx, id)
+}
+
 // GetAuthorById implements service.AuthorSvc.
 func (svc *authorSvc) GetAuthorById(ctx context.Context, id uuid.UUID) *views.Response {
 	author, err := svc.repo.GetAuthorById(ctx, id)
@@ -77,13 +200,30 @@ func (svc *authorSvc) GetAuthorById(ctx context.Context, id uuid.UUID) *views.Re
 		Birthdate: author.Birthdate,
 		CreatedAt: author.CreatedAt,
 		UpdatedAt: author.UpdatedAt,
+		Version:   author.Version,
 	})
 }
 
 // GetAuthors implements service.AuthorSvc.
-func (svc *authorSvc) GetAuthors(ctx context.Context) *views.Response {
-	author, err := svc.repo.GetAuthors(ctx)
+func (svc *authorSvc) GetAuthors(ctx context.Context, query *params.ListAuthors) *views.Response {
+	filter := repository.AuthorFilter{
+		NamePrefix: query.NamePrefix,
+		Name:       query.Name,
+		BornFrom:   query.BornFrom,
+		BornTo:     query.BornTo,
+	}
+	page := repository.Page{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+		Cursor:   query.Cursor,
+		Sort:     query.Sort,
+	}
+
+	author, info, err := svc.repo.GetAuthors(ctx, &filter, &page)
 	if err != nil {
+		if err == repository.ErrInvalidCursor || err == repository.ErrInvalidSort {
+			return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_QUERY, err)
+		}
 		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
 	}
 
@@ -96,13 +236,20 @@ func (svc *authorSvc) GetAuthors(ctx context.Context) *views.Response {
 			Birthdate: ath.Birthdate,
 			CreatedAt: ath.CreatedAt,
 			UpdatedAt: ath.UpdatedAt,
+			Version:   ath.Version,
 		})
 	}
-	return views.SuccessResponse(http.StatusOK, views.M_OK, authors)
+	return views.PagedResponse(http.StatusOK, views.M_OK, authors, &views.Pagination{
+		Total:      info.Total,
+		Page:       query.Page,
+		PageSize:   info.PageSize,
+		NextCursor: info.NextCursor,
+		PrevCursor: info.PrevCursor,
+	})
 }
 
 // UpdateAuthor implements service.AuthorSvc.
-func (svc *authorSvc) UpdateAuthor(ctx context.Context, author *params.UpdateAuthors, id uuid.UUID) *views.Response {
+func (svc *authorSvc) UpdateAuthor(ctx context.Context, author *params.UpdateAuthors, id uuid.UUID, version int, actorId uuid.UUID) *views.Response {
 	a, err := svc.repo.GetAuthorById(ctx, id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -110,12 +257,55 @@ func (svc *authorSvc) UpdateAuthor(ctx context.Context, author *params.UpdateAut
 		}
 		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
 	}
+	if version != 0 && version != a.Version {
+		return views.ErrorReponse(http.StatusPreconditionFailed, views.M_PRECONDITION_FAILED, repository.ErrVersionConflict)
+	}
+	return svc.update(ctx, a, &authorState{Name: author.Name, Birthdate: author.Birthdate}, models.HistoryUpdate, actorId)
+}
 
-	a.Name = author.Name
-	a.Birthdate = author.Birthdate
+// RevertAuthor implements service.AuthorSvc.
+func (svc *authorSvc) RevertAuthor(ctx context.Context, id uuid.UUID, revert *params.Revert, version int, actorId uuid.UUID) *views.Response {
+	a, err := svc.repo.GetAuthorById(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusBadRequest, views.M_BAD_REQUEST, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if version != 0 && version != a.Version {
+		return views.ErrorReponse(http.StatusPreconditionFailed, views.M_PRECONDITION_FAILED, repository.ErrVersionConflict)
+	}
 
-	err = svc.repo.UpdateAuthor(ctx, a, id)
+	entry, err := svc.history.GetHistoryVersion(ctx, models.HistoryAuthor, id, revert.Revision)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_VERSION_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	state := new(authorState)
+	if err := json.Unmarshal([]byte(entry.Snapshot), state); err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return svc.update(ctx, a, state, models.HistoryRevert, actorId)
+}
+
+// update sets a to state and records the change in its history.
+func (svc *authorSvc) update(ctx context.Context, a *models.Author, state *authorState, action string, actorId uuid.UUID) *views.Response {
+	before := newAuthorState(a)
+	a.Name = state.Name
+	a.Birthdate = state.Birthdate
+
+	err := svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := svc.repo.UpdateAuthor(ctx, a, a.Id); err != nil {
+			return err
+		}
+		return svc.record(ctx, a.Id, action, actorId, before, newAuthorState(a))
+	})
 	if err != nil {
+		if err == repository.ErrVersionConflict {
+			return views.ErrorReponse(http.StatusPreconditionFailed, views.M_PRECONDITION_FAILED, err)
+		}
 		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
 	}
 
@@ -124,11 +314,73 @@ func (svc *authorSvc) UpdateAuthor(ctx context.Context, author *params.UpdateAut
 		UserId:    a.UserId,
 		Name:      a.Name,
 		Birthdate: a.Birthdate,
+		UpdatedAt: a.UpdatedAt,
+		Version:   a.Version,
 	})
 }
 
-func NewAuthorSvc(repo repository.AuthorRepo) service.AuthorSvc {
+// GetAuthorHistory implements service.AuthorSvc.
+func (svc *authorSvc) GetAuthorHistory(ctx context.Context, id uuid.UUID) *views.Response {
+	entries, err := svc.history.GetHistory(ctx, models.HistoryAuthor, id)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if len(entries) == 0 {
+		if _, err := svc.repo.GetAuthorById(ctx, id); err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return views.ErrorReponse(http.StatusNotFound, views.M_AUTHOR_NOT_FOUND, err)
+			}
+			return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+		}
+	}
+
+	list, err := history.Views(entries)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, list)
+}
+
+// record adds the change of an author from before to after to its history.
+func (svc *authorSvc) record(ctx context.Context, id uuid.UUID, action string, actorId uuid.UUID, before, after *authorState) error {
+	var from interface{}
+	if before != nil {
+		from = before
+	}
+	entry, err := history.NewEntry(models.HistoryAuthor, id, action, actorId, from, after)
+	if err != nil || entry == nil {
+		return err
+	}
+	return svc.history.AddHistory(ctx, entry)
+}
+
+// recordBook adds the change of a book the author took along to the history
+// of the book.
+func (svc *authorSvc) recordBook(ctx context.Context, before, after *models.Book, action string, actorId uuid.UUID) error {
+	entry, err := book.ChangedEntry(before, after, action, actorId)
+	if err != nil || entry == nil {
+		return err
+	}
+	return svc.history.AddHistory(ctx, entry)
+}
+
+func authorBooks(books []*models.Book) []views.AuthorBook {
+	list := make([]views.AuthorBook, 0, len(books))
+	for _, b := range books {
+		list = append(list, views.AuthorBook{
+			Id:     b.Id,
+			UserId: b.UserId,
+			Title:  b.Title,
+			Isbn:   b.Isbn,
+		})
+	}
+	return list
+}
+
+func NewAuthorSvc(repo repository.AuthorRepo, history repository.HistoryRepo, tx repository.Transactor) service.AuthorSvc {
 	return &authorSvc{
-		repo: repo,
+		repo:    repo,
+		history: history,
+		tx:      tx,
 	}
 }
@@ -3,10 +3,12 @@ package author_test
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
 	"github.com/storyofhis/books-management/httpserver/controller/params"
 	"github.com/storyofhis/books-management/httpserver/controller/views"
 	"github.com/storyofhis/books-management/httpserver/repository"
@@ -20,14 +22,20 @@ import (
 
 type authorSvcTest struct {
 	repo    *repository.MockAuthorRepo
+	history *repository.MockHistoryRepo
 	service service.AuthorSvc
 }
 
 func newAuthorSvcTest(t *testing.T) authorSvcTest {
 	mockRepo := repository.NewMockAuthorRepo(t)
-	authorSvc := author.NewAuthorSvc(mockRepo)
+	mockHistory := repository.NewMockHistoryRepo(t)
+	mockTx := repository.NewMockTransactor(t)
+	mockTx.EXPECT().Transaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).Maybe()
+	authorSvc := author.NewAuthorSvc(mockRepo, mockHistory, mockTx)
 	return authorSvcTest{
 		repo:    mockRepo,
+		history: mockHistory,
 		service: authorSvc,
 	}
 }
@@ -36,6 +44,7 @@ func TestAuthorSvc_CreateAuthor(t *testing.T) {
 	t.Run("success - it shoult return nil", func(t *testing.T) {
 		instance := newAuthorSvcTest(t)
 		instance.repo.EXPECT().CreateAuthor(mock.Anything, mock.Anything).Return(nil)
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool { return e.Action == models.HistoryCreate })).Return(nil)
 		res := instance.service.CreateAuthor(context.Background(), &params.CreateAuthors{}, uuid.New())
 		assert.Equal(t, http.StatusCreated, res.Status)
 	})
@@ -49,6 +58,8 @@ func TestAuthorSvc_CreateAuthor(t *testing.T) {
 }
 
 func TestAuthorSvc_DeleteAuthor(t *testing.T) {
+	member := &common.CustomClaims{Id: uuid.New(), Role: common.RoleMember}
+
 	t.Run("success - it should delete the author", func(t *testing.T) {
 		instance := newAuthorSvcTest(t)
 		id := uuid.New()
@@ -58,8 +69,9 @@ func TestAuthorSvc_DeleteAuthor(t *testing.T) {
 		}
 
 		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(mockAuthor, nil)
-		instance.repo.EXPECT().DeleteAuthor(mock.Anything, id).Return(nil)
-		res := instance.service.DeleteAuthor(context.Background(), id)
+		instance.repo.EXPECT().DeleteAuthor(mock.Anything, id, mock.Anything).Return(nil, nil)
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool { return e.Action == models.HistoryDelete })).Return(nil)
+		res := instance.service.DeleteAuthor(context.Background(), id, &params.DeleteAuthor{}, 0, member)
 
 		assert.Equal(t, http.StatusNoContent, res.Status)
 		authorData, ok := res.Payload.(views.Author)
@@ -72,7 +84,7 @@ func TestAuthorSvc_DeleteAuthor(t *testing.T) {
 		id := uuid.New()
 		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
 
-		res := instance.service.DeleteAuthor(context.Background(), id)
+		res := instance.service.DeleteAuthor(context.Background(), id, &params.DeleteAuthor{}, 0, member)
 		assert.Equal(t, http.StatusBadRequest, res.Status)
 		assert.Equal(t, views.M_BAD_REQUEST, res.Message)
 	})
@@ -86,12 +98,162 @@ func TestAuthorSvc_DeleteAuthor(t *testing.T) {
 			UserId: uuid.New(),
 		}
 		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(mockAuthor, nil)
-		instance.repo.EXPECT().DeleteAuthor(mock.Anything, id).Return(assert.AnError)
-		res := instance.service.DeleteAuthor(context.Background(), id)
+		instance.repo.EXPECT().DeleteAuthor(mock.Anything, id, mock.Anything).Return(nil, assert.AnError)
+		res := instance.service.DeleteAuthor(context.Background(), id, &params.DeleteAuthor{}, 0, member)
 
 		assert.Equal(t, http.StatusInternalServerError, res.Status)
 		assert.Equal(t, views.M_INTERNAL_SERVER_ERROR, res.Message)
 	})
+
+	t.Run("error - it should return 409 if books still reference the author", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+
+		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(&models.Author{Id: id}, nil)
+		instance.repo.EXPECT().DeleteAuthor(mock.Anything, id, mock.Anything).Return(nil, gorm.ErrForeignKeyViolated)
+		res := instance.service.DeleteAuthor(context.Background(), id, &params.DeleteAuthor{}, 0, member)
+
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_AUTHOR_HAS_BOOKS, res.Message)
+	})
+
+	t.Run("error - it should list the books blocking the delete", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+		book := &models.Book{Id: uuid.New(), Title: "Good Omens"}
+
+		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(&models.Author{Id: id}, nil)
+		instance.repo.EXPECT().DeleteAuthor(mock.Anything, id, &repository.AuthorDelete{Policy: repository.DeleteRefuse, OwnerId: member.Id}).
+			Return(nil, &repository.AuthorHasBooksError{Books: []*models.Book{book}})
+		res := instance.service.DeleteAuthor(context.Background(), id, &params.DeleteAuthor{}, 0, member)
+
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_AUTHOR_HAS_BOOKS, res.Message)
+		assert.Equal(t, []views.AuthorBook{{Id: book.Id, Title: book.Title}}, res.Payload)
+	})
+
+	t.Run("success - it should cascade to the books", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+
+		book := &models.Book{Id: uuid.New(), Title: "Dune", Contributors: []models.BookContributor{{AuthorId: id, Role: models.ContributorAuthor}}}
+
+		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(&models.Author{Id: id}, nil)
+		instance.repo.EXPECT().DeleteAuthor(mock.Anything, id, &repository.AuthorDelete{Policy: repository.DeleteCascade, OwnerId: member.Id}).
+			Return([]*repository.BookChange{{Before: book, After: book}}, nil)
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool {
+			return e.EntityType == models.HistoryBook && e.EntityId == book.Id && e.Action == models.HistoryDelete
+		})).Return(nil).Once()
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool {
+			return e.EntityType == models.HistoryAuthor && e.Action == models.HistoryDelete
+		})).Return(nil).Once()
+		res := instance.service.DeleteAuthor(context.Background(), id, &params.DeleteAuthor{Policy: "cascade"}, 0, member)
+
+		assert.Equal(t, http.StatusNoContent, res.Status)
+	})
+
+	t.Run("success - it should let an admin cascade to the books of any user", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+
+		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(&models.Author{Id: id}, nil)
+		instance.repo.EXPECT().DeleteAuthor(mock.Anything, id, &repository.AuthorDelete{Policy: repository.DeleteCascade}).Return(nil, nil)
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.Anything).Return(nil)
+		res := instance.service.DeleteAuthor(context.Background(), id, &params.DeleteAuthor{Policy: "cascade"}, 0, &common.CustomClaims{Id: uuid.New(), Role: common.RoleAdmin})
+
+		assert.Equal(t, http.StatusNoContent, res.Status)
+	})
+
+	t.Run("success - it should reassign the books", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id, to := uuid.New(), uuid.New()
+
+		before := &models.Book{Id: uuid.New(), Title: "Dune", Contributors: []models.BookContributor{{AuthorId: id, Role: models.ContributorAuthor}}}
+		after := &models.Book{Id: before.Id, Title: "Dune", Contributors: []models.BookContributor{{AuthorId: to, Role: models.ContributorAuthor}}}
+
+		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(&models.Author{Id: id}, nil)
+		instance.repo.EXPECT().DeleteAuthor(mock.Anything, id, &repository.AuthorDelete{Policy: repository.DeleteReassign, ReassignTo: to, OwnerId: member.Id}).
+			Return([]*repository.BookChange{{Before: before, After: after}}, nil)
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool {
+			return e.EntityType == models.HistoryBook && e.EntityId == before.Id && e.Action == models.HistoryUpdate &&
+				strings.Contains(e.Changes, `"field":"contributors"`)
+		})).Return(nil).Once()
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool {
+			return e.EntityType == models.HistoryAuthor && e.Action == models.HistoryDelete
+		})).Return(nil).Once()
+		res := instance.service.DeleteAuthor(context.Background(), id, &params.DeleteAuthor{Policy: "reassign", ReassignTo: to.String()}, 0, member)
+
+		assert.Equal(t, http.StatusNoContent, res.Status)
+	})
+
+	t.Run("error - it should return 422 if the new author does not exist", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+
+		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(&models.Author{Id: id}, nil)
+		instance.repo.EXPECT().DeleteAuthor(mock.Anything, id, mock.Anything).Return(nil, repository.ErrUnknownAuthor)
+		res := instance.service.DeleteAuthor(context.Background(), id, &params.DeleteAuthor{Policy: "reassign", ReassignTo: uuid.NewString()}, 0, member)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+		assert.Equal(t, views.M_UNKNOWN_AUTHOR, res.Message)
+	})
+
+	t.Run("error - it should not reassign the books to the deleted author", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+
+		res := instance.service.DeleteAuthor(context.Background(), id, &params.DeleteAuthor{Policy: "reassign", ReassignTo: id.String()}, 0, member)
+
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+	})
+}
+
+func TestAuthorSvc_RestoreAuthor(t *testing.T) {
+	t.Run("success - it should restore the author", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id, owner := uuid.New(), uuid.New()
+		author := &models.Author{Id: id, UserId: owner, Name: "John Doe"}
+
+		book := &models.Book{Id: uuid.New(), Title: "Dune"}
+
+		instance.repo.EXPECT().GetDeletedAuthorById(mock.Anything, id).Return(author, nil)
+		instance.repo.EXPECT().RestoreAuthor(mock.Anything, id).Return([]*models.Book{book}, nil)
+		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(author, nil)
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool {
+			return e.EntityType == models.HistoryBook && e.EntityId == book.Id && e.Action == models.HistoryRestore
+		})).Return(nil).Once()
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool {
+			return e.EntityType == models.HistoryAuthor && e.Action == models.HistoryRestore
+		})).Return(nil).Once()
+		res := instance.service.RestoreAuthor(context.Background(), id, &common.CustomClaims{Id: owner})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, "John Doe", res.Payload.(views.Author).Name)
+	})
+
+	t.Run("error - it should return 404 if the author is not in the trash", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+
+		instance.repo.EXPECT().GetDeletedAuthorById(mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+		res := instance.service.RestoreAuthor(context.Background(), id, &common.CustomClaims{Id: uuid.New()})
+
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_NOT_IN_TRASH, res.Message)
+	})
+
+	t.Run("error - it should let admins restore authors of other users", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+		author := &models.Author{Id: id, UserId: uuid.New()}
+
+		instance.repo.EXPECT().GetDeletedAuthorById(mock.Anything, id).Return(author, nil)
+		instance.repo.EXPECT().RestoreAuthor(mock.Anything, id).Return(nil, repository.ErrDuplicateIsbn)
+		res := instance.service.RestoreAuthor(context.Background(), id, &common.CustomClaims{Id: uuid.New(), Role: common.RoleAdmin})
+
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_DUPLICATE_ISBN, res.Message)
+	})
 }
 
 func TestAuthorSvc_GetAuthorById(t *testing.T) {
@@ -163,19 +325,54 @@ func TestAuthorSvc_GetAuthors(t *testing.T) {
 			},
 		}
 
-		instance.repo.EXPECT().GetAuthors(mock.Anything).Return(mockAuthors, nil)
-		res := instance.service.GetAuthors(context.Background())
+		instance.repo.EXPECT().GetAuthors(mock.Anything, mock.Anything, mock.Anything).Return(mockAuthors, &repository.PageInfo{Total: 2, PageSize: 20}, nil)
+		res := instance.service.GetAuthors(context.Background(), &params.ListAuthors{})
 		assert.Equal(t, http.StatusOK, res.Status)
 
 		authorsData, ok := res.Payload.([]views.Author)
 		assert.True(t, ok)
 		assert.Len(t, authorsData, len(mockAuthors))
+
+		meta, ok := res.Meta.(*views.Pagination)
+		assert.True(t, ok)
+		assert.Equal(t, int64(2), meta.Total)
+	})
+
+	t.Run("success - it should pass the search and cursor to the repository", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		bornFrom := time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		instance.repo.EXPECT().GetAuthors(mock.Anything, mock.MatchedBy(func(f *repository.AuthorFilter) bool {
+			return f.NamePrefix == "jo" && f.BornFrom.Equal(bornFrom)
+		}), mock.MatchedBy(func(p *repository.Page) bool {
+			return p.Cursor == "cursor" && p.PageSize == 5
+		})).Return([]*models.Author{}, &repository.PageInfo{Total: 12, PageSize: 5, NextCursor: "next"}, nil)
+
+		res := instance.service.GetAuthors(context.Background(), &params.ListAuthors{
+			PageSize:   5,
+			Cursor:     "cursor",
+			NamePrefix: "jo",
+			BornFrom:   bornFrom,
+		})
+		assert.Equal(t, http.StatusOK, res.Status)
+		meta := res.Meta.(*views.Pagination)
+		assert.Equal(t, "next", meta.NextCursor)
+		assert.Equal(t, int64(12), meta.Total)
+	})
+
+	t.Run("error - it should return 400 for an invalid cursor", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		instance.repo.EXPECT().GetAuthors(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, repository.ErrInvalidCursor)
+		res := instance.service.GetAuthors(context.Background(), &params.ListAuthors{Cursor: "garbage"})
+
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+		assert.Equal(t, views.M_INVALID_QUERY, res.Message)
 	})
 
 	t.Run("error - it should return 500 if there is a database error", func(t *testing.T) {
 		instance := newAuthorSvcTest(t)
-		instance.repo.EXPECT().GetAuthors(mock.Anything).Return(nil, assert.AnError)
-		res := instance.service.GetAuthors(context.Background())
+		instance.repo.EXPECT().GetAuthors(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, assert.AnError)
+		res := instance.service.GetAuthors(context.Background(), &params.ListAuthors{})
 
 		assert.Equal(t, http.StatusInternalServerError, res.Status)
 		assert.Equal(t, views.M_INTERNAL_SERVER_ERROR, res.Message)
@@ -203,7 +400,8 @@ func TestAuthorSvc_UpdateAuthor(t *testing.T) {
 
 		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(mockAuthor, nil)
 		instance.repo.EXPECT().UpdateAuthor(mock.Anything, mock.Anything, id).Return(nil)
-		res := instance.service.UpdateAuthor(context.Background(), updatedAuthor, id)
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool { return e.Action == models.HistoryUpdate })).Return(nil)
+		res := instance.service.UpdateAuthor(context.Background(), updatedAuthor, id, 0, uuid.New())
 
 		assert.Equal(t, http.StatusOK, res.Status)
 		updatedAuthorData, ok := res.Payload.(views.UpdateAuthor)
@@ -221,7 +419,7 @@ func TestAuthorSvc_UpdateAuthor(t *testing.T) {
 		}
 
 		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
-		res := instance.service.UpdateAuthor(context.Background(), updatedAuthor, id)
+		res := instance.service.UpdateAuthor(context.Background(), updatedAuthor, id, 0, uuid.New())
 
 		assert.Equal(t, http.StatusBadRequest, res.Status)
 		assert.Equal(t, views.M_BAD_REQUEST, res.Message)
@@ -248,8 +446,88 @@ func TestAuthorSvc_UpdateAuthor(t *testing.T) {
 		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(mockAuthor, nil)
 		instance.repo.EXPECT().UpdateAuthor(mock.Anything, mock.Anything, id).Return(assert.AnError)
 
-		res := instance.service.UpdateAuthor(context.Background(), updatedAuthor, id)
+		res := instance.service.UpdateAuthor(context.Background(), updatedAuthor, id, 0, uuid.New())
 		assert.Equal(t, http.StatusInternalServerError, res.Status)
 		assert.Equal(t, views.M_INTERNAL_SERVER_ERROR, res.Message)
 	})
+
+	t.Run("error - it should return 412 when the If-Match version is stale", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(&models.Author{Id: id, Version: 2}, nil)
+
+		res := instance.service.UpdateAuthor(context.Background(), &params.UpdateAuthors{Name: "John Updated"}, id, 1, uuid.New())
+		assert.Equal(t, http.StatusPreconditionFailed, res.Status)
+		assert.Equal(t, views.M_PRECONDITION_FAILED, res.Message)
+	})
+}
+
+func TestAuthorSvc_GetAuthorHistory(t *testing.T) {
+	t.Run("success - it should return the changes of the author", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+		instance.history.EXPECT().GetHistory(mock.Anything, models.HistoryAuthor, id).Return([]*models.HistoryEntry{
+			{Version: 1, Action: models.HistoryCreate, Changes: `[{"field":"name","from":null,"to":"John Doe"}]`},
+		}, nil)
+
+		res := instance.service.GetAuthorHistory(context.Background(), id)
+		assert.Equal(t, http.StatusOK, res.Status)
+		list := res.Payload.([]views.HistoryEntry)
+		assert.Len(t, list, 1)
+		assert.Equal(t, "name", list[0].Changes[0].Field)
+	})
+
+	t.Run("error - it should return 404 when the author does not exist", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+		instance.history.EXPECT().GetHistory(mock.Anything, models.HistoryAuthor, id).Return(nil, nil)
+		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.GetAuthorHistory(context.Background(), id)
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_AUTHOR_NOT_FOUND, res.Message)
+	})
+}
+
+func TestAuthorSvc_RevertAuthor(t *testing.T) {
+	t.Run("success - it should restore the fields of the version", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(&models.Author{Id: id, Name: "John Updated"}, nil)
+		instance.history.EXPECT().GetHistoryVersion(mock.Anything, models.HistoryAuthor, id, 1).Return(&models.HistoryEntry{
+			Version:  1,
+			Snapshot: `{"name":"John Doe","birthdate":"1970-01-01T00:00:00Z"}`,
+		}, nil)
+		instance.repo.EXPECT().UpdateAuthor(mock.Anything, mock.MatchedBy(func(a *models.Author) bool {
+			return a.Name == "John Doe"
+		}), id).Return(nil)
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool {
+			return e.Action == models.HistoryRevert
+		})).Return(nil)
+
+		res := instance.service.RevertAuthor(context.Background(), id, &params.Revert{Revision: 1}, 0, uuid.New())
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, "John Doe", res.Payload.(views.UpdateAuthor).Name)
+	})
+
+	t.Run("error - it should return 404 for an unknown version", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(&models.Author{Id: id}, nil)
+		instance.history.EXPECT().GetHistoryVersion(mock.Anything, models.HistoryAuthor, id, 9).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.RevertAuthor(context.Background(), id, &params.Revert{Revision: 9}, 0, uuid.New())
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_VERSION_NOT_FOUND, res.Message)
+	})
+
+	t.Run("error - it should return 412 when the author changed since it was read", func(t *testing.T) {
+		instance := newAuthorSvcTest(t)
+		id := uuid.New()
+		instance.repo.EXPECT().GetAuthorById(mock.Anything, id).Return(&models.Author{Id: id, Version: 3}, nil)
+
+		res := instance.service.RevertAuthor(context.Background(), id, &params.Revert{Revision: 1}, 2, uuid.New())
+		assert.Equal(t, http.StatusPreconditionFailed, res.Status)
+		assert.Equal(t, views.M_PRECONDITION_FAILED, res.Message)
+	})
 }
@@ -2,63 +2,191 @@ package book
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
 	"github.com/storyofhis/books-management/httpserver/controller/params"
 	"github.com/storyofhis/books-management/httpserver/controller/views"
 	"github.com/storyofhis/books-management/httpserver/repository"
 	"github.com/storyofhis/books-management/httpserver/repository/models"
 	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/history"
+	"github.com/storyofhis/books-management/isbn"
 	"gorm.io/gorm"
 )
 
+var errUnknownAuthor = errors.New("unknown author")
+
 type bookSvc struct {
-	repo repository.BookRepo
+	repo    repository.BookRepo
+	authors repository.AuthorRepo
+	copies  repository.CopyRepo
+	history repository.HistoryRepo
+	tx      repository.Transactor
+}
+
+// bookState is the state of a book recorded in its history.
+type bookState struct {
+	Title        string             `json:"title"`
+	Isbn         string             `json:"isbn"`
+	Contributors []contributorState `json:"contributors"`
+}
+
+type contributorState struct {
+	AuthorId uuid.UUID `json:"author_id"`
+	Role     string    `json:"role"`
+}
+
+func newBookState(book *models.Book) *bookState {
+	state := &bookState{
+		Title:        book.Title,
+		Isbn:         book.Isbn,
+		Contributors: make([]contributorState, 0, len(book.Contributors)),
+	}
+	for _, c := range book.Contributors {
+		state.Contributors = append(state.Contributors, contributorState{AuthorId: c.AuthorId, Role: c.Role})
+	}
+	return state
+}
+
+// CreatedEntry returns the history entry recording the creation of the book,
+// for the books created without BookSvc.
+func CreatedEntry(book *models.Book, actorId uuid.UUID) (*models.HistoryEntry, error) {
+	return history.NewEntry(models.HistoryBook, book.Id, models.HistoryCreate, actorId, nil, newBookState(book))
+}
+
+// ChangedEntry returns the history entry recording a change of the book from
+// before to after, for the books changed without BookSvc. It is nil for an
+// update that changes nothing.
+func ChangedEntry(before, after *models.Book, action string, actorId uuid.UUID) (*models.HistoryEntry, error) {
+	return history.NewEntry(models.HistoryBook, after.Id, action, actorId, newBookState(before), newBookState(after))
 }
 
 // CreateBook implements service.BookSvc.
 func (svc *bookSvc) CreateBook(ctx context.Context, book *params.CreateBook, id uuid.UUID) *views.Response {
+	code, err := isbn.Normalize(book.Isbn)
+	if err != nil {
+		return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_ISBN, err)
+	}
+
+	contributors, err := newContributors(book.Contributors)
+	if err != nil {
+		return views.ErrorReponse(http.StatusBadRequest, views.M_BAD_REQUEST, err)
+	}
+	err = svc.checkAuthors(ctx, contributors)
+	if err != nil {
+		if errors.Is(err, errUnknownAuthor) {
+			return views.ErrorReponse(http.StatusUnprocessableEntity, views.M_UNKNOWN_AUTHOR, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
 	param := models.Book{
-		UserId:   id,
-		AuthorId: book.AuthorId,
-		Title:    book.Title,
-		Isbn:     book.Isbn,
+		UserId:       id,
+		Title:        book.Title,
+		Isbn:         code,
+		Contributors: contributors,
 	}
-	err := svc.repo.CreateBook(ctx, &param)
+	err = svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := svc.repo.CreateBook(ctx, &param); err != nil {
+			return err
+		}
+		return svc.record(ctx, param.Id, models.HistoryCreate, id, nil, newBookState(&param))
+	})
 	if err != nil {
+		if err == repository.ErrDuplicateIsbn {
+			return views.ErrorReponse(http.StatusConflict, views.M_DUPLICATE_ISBN, err)
+		}
+		if err == gorm.ErrForeignKeyViolated {
+			return views.ErrorReponse(http.StatusUnprocessableEntity, views.M_UNKNOWN_AUTHOR, err)
+		}
 		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
 	}
 
 	return views.SuccessResponse(http.StatusCreated, views.M_CREATED, views.Book{
-		Id:        param.Id,
-		UserId:    param.UserId,
-		AuthorId:  param.AuthorId,
-		Title:     param.Title,
-		Isbn:      param.Isbn,
-		CreatedAt: param.CreatedAt,
-		UpdatedAt: param.UpdatedAt,
+		Id:           param.Id,
+		UserId:       param.UserId,
+		Title:        param.Title,
+		Isbn:         param.Isbn,
+		IsbnDisplay:  isbn.Format(param.Isbn),
+		Contributors: contributorViews(param.Contributors),
+		CreatedAt:    param.CreatedAt,
+		UpdatedAt:    param.UpdatedAt,
+		Version:      param.Version,
 	})
 }
 
 // DeleteBook implements service.BookSvc.
-func (svc *bookSvc) DeleteBook(ctx context.Context, id uuid.UUID) *views.Response {
-	_, err := svc.repo.GetBookById(ctx, id)
+func (svc *bookSvc) DeleteBook(ctx context.Context, id uuid.UUID, version int, actorId uuid.UUID) *views.Response {
+	book, err := svc.repo.GetBookById(ctx, id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return views.ErrorReponse(http.StatusBadRequest, views.M_BAD_REQUEST, err)
 		}
 		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
 	}
+	if version != 0 && version != book.Version {
+		return views.ErrorReponse(http.StatusPreconditionFailed, views.M_PRECONDITION_FAILED, repository.ErrVersionConflict)
+	}
 
-	err = svc.repo.DeleteBook(ctx, id)
+	err = svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := svc.repo.DeleteBook(ctx, id, book.Version); err != nil {
+			return err
+		}
+		state := newBookState(book)
+		return svc.record(ctx, id, models.HistoryDelete, actorId, state, state)
+	})
 	if err != nil {
+		if err == repository.ErrBookInCirculation {
+			return views.ErrorReponse(http.StatusConflict, views.M_BOOK_IN_CIRCULATION, err)
+		}
+		if err == repository.ErrVersionConflict {
+			return views.ErrorReponse(http.StatusPreconditionFailed, views.M_PRECONDITION_FAILED, err)
+		}
 		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
 	}
 
 	return views.SuccessResponse(http.StatusNoContent, views.M_AUTHOR_SUCCESSFULLY_DELETED, nil)
 }
 
+// RestoreBook implements service.BookSvc.
+func (svc *bookSvc) RestoreBook(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	book, err := svc.repo.GetDeletedBookById(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_NOT_IN_TRASH, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if !user.CanManage(book.UserId) {
+		return views.ErrorReponse(http.StatusForbidden, views.M_FORBIDDEN, errors.New("you do not have permission to restore this book"))
+	}
+
+	err = svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := svc.repo.RestoreBook(ctx, id); err != nil {
+			return err
+		}
+		state := newBookState(book)
+		return svc.record(ctx, id, models.HistoryRestore, user.Id, state, state)
+	})
+	if err != nil {
+		if err == repository.ErrAuthorDeleted {
+			return views.ErrorReponse(http.StatusConflict, views.M_AUTHOR_DELETED, err)
+		}
+		if err == repository.ErrDuplicateIsbn {
+			return views.ErrorReponse(http.StatusConflict, views.M_DUPLICATE_ISBN, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return svc.GetBookById(ctx, id)
+}
+
 // GetAuthorById implements service.BookSvc.
 func (svc *bookSvc) GetBookById(ctx context.Context, id uuid.UUID) *views.Response {
 	book, err := svc.repo.GetBookById(ctx, id)
@@ -68,20 +196,63 @@ func (svc *bookSvc) GetBookById(ctx context.Context, id uuid.UUID) *views.Respon
 		}
 		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
 	}
+	availability, err := svc.availability(ctx, book.Id)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
 	return views.SuccessResponse(http.StatusOK, views.M_OK, views.Book{
-		Id:        book.Id,
-		UserId:    book.UserId,
-		AuthorId:  book.AuthorId,
-		Title:     book.Title,
-		Isbn:      book.Isbn,
-		CreatedAt: book.CreatedAt,
-		UpdatedAt: book.UpdatedAt,
+		Id:           book.Id,
+		UserId:       book.UserId,
+		Title:        book.Title,
+		Isbn:         book.Isbn,
+		IsbnDisplay:  isbn.Format(book.Isbn),
+		Contributors: contributorViews(book.Contributors),
+		Availability: availability,
+		Rating:       ratingView(book),
+		CreatedAt:    book.CreatedAt,
+		UpdatedAt:    book.UpdatedAt,
+		Version:      book.Version,
 	})
 }
 
 // GetBooks implements service.BookSvc.
-func (svc *bookSvc) GetBooks(ctx context.Context) *views.Response {
-	book, err := svc.repo.GetBooks(ctx)
+func (svc *bookSvc) GetBooks(ctx context.Context, query *params.ListBooks) *views.Response {
+	filter := repository.BookFilter{
+		Title:       query.Title,
+		Isbn:        query.Isbn,
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+	}
+	if code, err := isbn.Normalize(query.Isbn); err == nil {
+		filter.Isbn = code
+	}
+	filter.Role = query.Role
+	if query.AuthorId != "" {
+		filter.AuthorId = uuid.MustParse(query.AuthorId)
+	}
+	if query.UserId != "" {
+		filter.UserId = uuid.MustParse(query.UserId)
+	}
+	page := repository.Page{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+		Cursor:   query.Cursor,
+		Sort:     query.Sort,
+	}
+
+	book, info, err := svc.repo.GetBooks(ctx, &filter, &page)
+	if err != nil {
+		if err == repository.ErrInvalidCursor || err == repository.ErrInvalidSort {
+			return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_QUERY, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(book))
+	for _, b := range book {
+		ids = append(ids, b.Id)
+	}
+	counts, err := svc.copies.CountCopies(ctx, ids)
 	if err != nil {
 		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
 	}
@@ -89,20 +260,30 @@ func (svc *bookSvc) GetBooks(ctx context.Context) *views.Response {
 	books := make([]views.Book, 0)
 	for _, b := range book {
 		books = append(books, views.Book{
-			Id:        b.Id,
-			UserId:    b.UserId,
-			AuthorId:  b.AuthorId,
-			Title:     b.Title,
-			Isbn:      b.Isbn,
-			CreatedAt: b.CreatedAt,
-			UpdatedAt: b.UpdatedAt,
+			Id:           b.Id,
+			UserId:       b.UserId,
+			Title:        b.Title,
+			Isbn:         b.Isbn,
+			IsbnDisplay:  isbn.Format(b.Isbn),
+			Contributors: contributorViews(b.Contributors),
+			Availability: availabilityView(counts[b.Id]),
+			Rating:       ratingView(b),
+			CreatedAt:    b.CreatedAt,
+			UpdatedAt:    b.UpdatedAt,
+			Version:      b.Version,
 		})
 	}
-	return views.SuccessResponse(http.StatusOK, views.M_OK, books)
+	return views.PagedResponse(http.StatusOK, views.M_OK, books, &views.Pagination{
+		Total:      info.Total,
+		Page:       query.Page,
+		PageSize:   info.PageSize,
+		NextCursor: info.NextCursor,
+		PrevCursor: info.PrevCursor,
+	})
 }
 
 // UpdateAuthor implements service.BookSvc.
-func (svc *bookSvc) UpdateBook(ctx context.Context, book *params.UpdateBook, id uuid.UUID) *views.Response {
+func (svc *bookSvc) UpdateBook(ctx context.Context, book *params.UpdateBook, id uuid.UUID, version int, actorId uuid.UUID) *views.Response {
 	b, err := svc.repo.GetBookById(ctx, id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -110,28 +291,242 @@ func (svc *bookSvc) UpdateBook(ctx context.Context, book *params.UpdateBook, id
 		}
 		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
 	}
+	if version != 0 && version != b.Version {
+		return views.ErrorReponse(http.StatusPreconditionFailed, views.M_PRECONDITION_FAILED, repository.ErrVersionConflict)
+	}
+	return svc.update(ctx, b, book.Title, book.Isbn, book.Contributors, models.HistoryUpdate, actorId)
+}
 
-	b.AuthorId = book.AuthorId
-	b.Title = book.Title
-	b.Isbn = book.Isbn
+// RevertBook implements service.BookSvc.
+func (svc *bookSvc) RevertBook(ctx context.Context, id uuid.UUID, revert *params.Revert, version int, actorId uuid.UUID) *views.Response {
+	b, err := svc.repo.GetBookById(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusBadRequest, views.M_BAD_REQUEST, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if version != 0 && version != b.Version {
+		return views.ErrorReponse(http.StatusPreconditionFailed, views.M_PRECONDITION_FAILED, repository.ErrVersionConflict)
+	}
 
-	err = svc.repo.UpdateBook(ctx, b, id)
+	entry, err := svc.history.GetHistoryVersion(ctx, models.HistoryBook, id, revert.Revision)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_VERSION_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	var state bookState
+	if err := json.Unmarshal([]byte(entry.Snapshot), &state); err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	contributors := make([]params.Contributor, 0, len(state.Contributors))
+	for _, c := range state.Contributors {
+		contributors = append(contributors, params.Contributor{AuthorId: c.AuthorId, Role: c.Role})
+	}
+	return svc.update(ctx, b, state.Title, state.Isbn, contributors, models.HistoryRevert, actorId)
+}
+
+// update replaces the title, isbn and contributors of b and records the
+// change in its history.
+func (svc *bookSvc) update(ctx context.Context, b *models.Book, title, code string, list []params.Contributor, action string, actorId uuid.UUID) *views.Response {
+	code, err := isbn.Normalize(code)
+	if err != nil {
+		return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_ISBN, err)
+	}
+
+	contributors, err := newContributors(list)
+	if err != nil {
+		return views.ErrorReponse(http.StatusBadRequest, views.M_BAD_REQUEST, err)
+	}
+	err = svc.checkAuthors(ctx, contributors)
+	if err != nil {
+		if errors.Is(err, errUnknownAuthor) {
+			return views.ErrorReponse(http.StatusUnprocessableEntity, views.M_UNKNOWN_AUTHOR, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	before := newBookState(b)
+	b.Title = title
+	b.Isbn = code
+	b.Contributors = contributors
+
+	err = svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := svc.repo.UpdateBook(ctx, b, b.Id); err != nil {
+			return err
+		}
+		return svc.record(ctx, b.Id, action, actorId, before, newBookState(b))
+	})
+	if err != nil {
+		if err == repository.ErrDuplicateIsbn {
+			return views.ErrorReponse(http.StatusConflict, views.M_DUPLICATE_ISBN, err)
+		}
+		if err == repository.ErrVersionConflict {
+			return views.ErrorReponse(http.StatusPreconditionFailed, views.M_PRECONDITION_FAILED, err)
+		}
+		if err == gorm.ErrForeignKeyViolated {
+			return views.ErrorReponse(http.StatusUnprocessableEntity, views.M_UNKNOWN_AUTHOR, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	availability, err := svc.availability(ctx, b.Id)
 	if err != nil {
 		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
 	}
 
 	return views.SuccessResponse(http.StatusOK, views.M_OK, views.UpdateBook{
-		Id:        b.Id,
-		UserId:    b.UserId,
-		AuthorId:  b.AuthorId,
-		Title:     b.Title,
-		Isbn:      b.Isbn,
-		UpdatedAt: b.UpdatedAt,
+		Id:           b.Id,
+		UserId:       b.UserId,
+		Title:        b.Title,
+		Isbn:         b.Isbn,
+		IsbnDisplay:  isbn.Format(b.Isbn),
+		Contributors: contributorViews(b.Contributors),
+		Availability: availability,
+		Rating:       ratingView(b),
+		UpdatedAt:    b.UpdatedAt,
+		Version:      b.Version,
 	})
 }
 
-func NewBookSvc(repo repository.BookRepo) service.BookSvc {
+// GetBookHistory implements service.BookSvc.
+func (svc *bookSvc) GetBookHistory(ctx context.Context, id uuid.UUID) *views.Response {
+	entries, err := svc.history.GetHistory(ctx, models.HistoryBook, id)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if len(entries) == 0 {
+		if _, err := svc.repo.GetBookById(ctx, id); err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return views.ErrorReponse(http.StatusNotFound, views.M_BOOK_NOT_FOUND, err)
+			}
+			return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+		}
+	}
+
+	list, err := history.Views(entries)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, list)
+}
+
+// record adds the change of a book from before to after to its history.
+func (svc *bookSvc) record(ctx context.Context, id uuid.UUID, action string, actorId uuid.UUID, before, after *bookState) error {
+	var from interface{}
+	if before != nil {
+		from = before
+	}
+	entry, err := history.NewEntry(models.HistoryBook, id, action, actorId, from, after)
+	if err != nil || entry == nil {
+		return err
+	}
+	return svc.history.AddHistory(ctx, entry)
+}
+
+// newContributors converts the requested contributors, defaulting their role
+// to author. An author may only be listed once per role.
+func newContributors(list []params.Contributor) ([]models.BookContributor, error) {
+	contributors := make([]models.BookContributor, 0, len(list))
+	type contribution struct {
+		authorId uuid.UUID
+		role     string
+	}
+	seen := make(map[contribution]bool, len(list))
+	for i, c := range list {
+		role := c.Role
+		if role == "" {
+			role = models.ContributorAuthor
+		}
+		key := contribution{c.AuthorId, role}
+		if seen[key] {
+			return nil, fmt.Errorf("author %s is listed twice as %s", c.AuthorId, role)
+		}
+		seen[key] = true
+		contributors = append(contributors, models.BookContributor{
+			AuthorId: c.AuthorId,
+			Role:     role,
+			Position: i,
+		})
+	}
+	return contributors, nil
+}
+
+// checkAuthors returns an error naming the contributors whose author does not
+// exist.
+func (svc *bookSvc) checkAuthors(ctx context.Context, contributors []models.BookContributor) error {
+	if len(contributors) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(contributors))
+	for _, c := range contributors {
+		ids = append(ids, c.AuthorId)
+	}
+	authors, err := svc.authors.GetAuthorsByIds(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[uuid.UUID]bool, len(authors))
+	for _, a := range authors {
+		found[a.Id] = true
+	}
+	var missing []string
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id.String())
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", errUnknownAuthor, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func contributorViews(list []models.BookContributor) []views.Contributor {
+	contributors := make([]views.Contributor, 0, len(list))
+	for _, c := range list {
+		contributors = append(contributors, views.Contributor{
+			AuthorId: c.AuthorId,
+			Name:     c.Author.Name,
+			Role:     c.Role,
+			Position: c.Position,
+		})
+	}
+	return contributors
+}
+
+// availability counts the copies of the book with the given id.
+func (svc *bookSvc) availability(ctx context.Context, id uuid.UUID) (views.Availability, error) {
+	counts, err := svc.copies.CountCopies(ctx, []uuid.UUID{id})
+	if err != nil {
+		return views.Availability{}, err
+	}
+	return availabilityView(counts[id]), nil
+}
+
+func ratingView(b *models.Book) views.Rating {
+	return views.Rating{
+		Average: math.Round(b.RatingAvg*100) / 100,
+		Count:   b.RatingCount,
+	}
+}
+
+func availabilityView(count *repository.CopyCount) views.Availability {
+	if count == nil {
+		return views.Availability{}
+	}
+	return views.Availability{Total: count.Total, Available: count.Available}
+}
+
+func NewBookSvc(repo repository.BookRepo, authors repository.AuthorRepo, copies repository.CopyRepo, history repository.HistoryRepo, tx repository.Transactor) service.BookSvc {
 	return &bookSvc{
-		repo: repo,
+		repo:    repo,
+		authors: authors,
+		copies:  copies,
+		history: history,
+		tx:      tx,
 	}
 }
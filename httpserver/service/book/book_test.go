@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
 	"github.com/storyofhis/books-management/httpserver/controller/params"
 	"github.com/storyofhis/books-management/httpserver/controller/views"
 	"github.com/storyofhis/books-management/httpserver/repository"
@@ -20,14 +21,26 @@ import (
 
 type bookSvcTest struct {
 	repo    *repository.MockBookRepo
+	authors *repository.MockAuthorRepo
+	copies  *repository.MockCopyRepo
+	history *repository.MockHistoryRepo
 	service service.BookSvc
 }
 
 func newBookSvcTestTest(t *testing.T) bookSvcTest {
 	mockRepo := repository.NewMockBookRepo(t)
-	bookSvc := book.NewBookSvc(mockRepo)
+	mockAuthors := repository.NewMockAuthorRepo(t)
+	mockCopies := repository.NewMockCopyRepo(t)
+	mockHistory := repository.NewMockHistoryRepo(t)
+	mockTx := repository.NewMockTransactor(t)
+	mockTx.EXPECT().Transaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).Maybe()
+	bookSvc := book.NewBookSvc(mockRepo, mockAuthors, mockCopies, mockHistory, mockTx)
 	return bookSvcTest{
 		repo:    mockRepo,
+		authors: mockAuthors,
+		copies:  mockCopies,
+		history: mockHistory,
 		service: bookSvc,
 	}
 }
@@ -35,17 +48,63 @@ func newBookSvcTestTest(t *testing.T) bookSvcTest {
 func TestBookSvc_CreateBook(t *testing.T) {
 	t.Run("success - it should return nil", func(t *testing.T) {
 		instance := newBookSvcTestTest(t)
-		instance.repo.EXPECT().CreateBook(mock.Anything, mock.Anything).Return(nil)
-		res := instance.service.CreateBook(context.Background(), &params.CreateBook{}, uuid.New())
+		instance.repo.EXPECT().CreateBook(mock.Anything, mock.MatchedBy(func(b *models.Book) bool {
+			return b.Isbn == "9780306406157"
+		})).Return(nil)
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool { return e.Action == models.HistoryCreate })).Return(nil)
+		res := instance.service.CreateBook(context.Background(), &params.CreateBook{Isbn: "0-306-40615-2"}, uuid.New())
 		assert.Equal(t, http.StatusCreated, res.Status)
+		assert.Equal(t, "978-0-306-40615-7", res.Payload.(views.Book).IsbnDisplay)
 	})
 
 	t.Run("error - it should return an error if CreateBook returns an error", func(t *testing.T) {
 		instance := newBookSvcTestTest(t)
 		instance.repo.EXPECT().CreateBook(mock.Anything, mock.Anything).Return(assert.AnError)
-		resp := instance.service.CreateBook(context.Background(), &params.CreateBook{}, uuid.New())
+		resp := instance.service.CreateBook(context.Background(), &params.CreateBook{Isbn: "9780306406157"}, uuid.New())
 		assert.Equal(t, http.StatusInternalServerError, resp.Status)
 	})
+
+	t.Run("error - it should return 400 for an invalid isbn", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		resp := instance.service.CreateBook(context.Background(), &params.CreateBook{Isbn: "123"}, uuid.New())
+		assert.Equal(t, http.StatusBadRequest, resp.Status)
+		assert.Equal(t, views.M_INVALID_ISBN, resp.Message)
+	})
+
+	t.Run("error - it should return 400 when an author is listed twice in the same role", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		authorId := uuid.New()
+		resp := instance.service.CreateBook(context.Background(), &params.CreateBook{
+			Isbn: "9780306406157",
+			Contributors: []params.Contributor{
+				{AuthorId: authorId},
+				{AuthorId: authorId, Role: models.ContributorAuthor},
+			},
+		}, uuid.New())
+		assert.Equal(t, http.StatusBadRequest, resp.Status)
+	})
+
+	t.Run("error - it should return 422 for an unknown author", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		known, unknown := uuid.New(), uuid.New()
+		instance.authors.EXPECT().GetAuthorsByIds(mock.Anything, []uuid.UUID{known, unknown}).
+			Return([]*models.Author{{Id: known}}, nil)
+		resp := instance.service.CreateBook(context.Background(), &params.CreateBook{
+			Isbn:         "9780306406157",
+			Contributors: []params.Contributor{{AuthorId: known}, {AuthorId: unknown}},
+		}, uuid.New())
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Status)
+		assert.Equal(t, views.M_UNKNOWN_AUTHOR, resp.Message)
+		assert.Contains(t, resp.Error, unknown.String())
+	})
+
+	t.Run("error - it should return 409 for a duplicate isbn", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		instance.repo.EXPECT().CreateBook(mock.Anything, mock.Anything).Return(repository.ErrDuplicateIsbn)
+		resp := instance.service.CreateBook(context.Background(), &params.CreateBook{Isbn: "9780306406157"}, uuid.New())
+		assert.Equal(t, http.StatusConflict, resp.Status)
+		assert.Equal(t, views.M_DUPLICATE_ISBN, resp.Message)
+	})
 }
 
 func TestBookSvc_DeleteBook(t *testing.T) {
@@ -53,15 +112,15 @@ func TestBookSvc_DeleteBook(t *testing.T) {
 		instance := newBookSvcTestTest(t)
 		id := uuid.New()
 		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(&models.Book{
-			Id:       id,
-			UserId:   uuid.New(),
-			AuthorId: uuid.New(),
-			Title:    "Test Book",
-			Isbn:     "123456789",
+			Id:     id,
+			UserId: uuid.New(),
+			Title:  "Test Book",
+			Isbn:   "123456789",
 		}, nil)
 
-		instance.repo.EXPECT().DeleteBook(mock.Anything, id).Return(nil)
-		res := instance.service.DeleteBook(context.Background(), id)
+		instance.repo.EXPECT().DeleteBook(mock.Anything, id, 0).Return(nil)
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool { return e.Action == models.HistoryDelete })).Return(nil)
+		res := instance.service.DeleteBook(context.Background(), id, 0, uuid.New())
 		assert.Equal(t, http.StatusNoContent, res.Status)
 		assert.Nil(t, res.Payload)
 	})
@@ -71,7 +130,7 @@ func TestBookSvc_DeleteBook(t *testing.T) {
 		id := uuid.New()
 
 		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
-		res := instance.service.DeleteBook(context.Background(), id)
+		res := instance.service.DeleteBook(context.Background(), id, 0, uuid.New())
 		assert.Equal(t, http.StatusBadRequest, res.Status)
 	})
 
@@ -79,17 +138,88 @@ func TestBookSvc_DeleteBook(t *testing.T) {
 		instance := newBookSvcTestTest(t)
 		id := uuid.New()
 		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(&models.Book{
-			Id:       id,
-			UserId:   uuid.New(),
-			AuthorId: uuid.New(),
-			Title:    "Test Book",
-			Isbn:     "123456789",
+			Id:     id,
+			UserId: uuid.New(),
+			Title:  "Test Book",
+			Isbn:   "123456789",
 		}, nil)
 
-		instance.repo.EXPECT().DeleteBook(mock.Anything, id).Return(assert.AnError)
-		res := instance.service.DeleteBook(context.Background(), id)
+		instance.repo.EXPECT().DeleteBook(mock.Anything, id, 0).Return(assert.AnError)
+		res := instance.service.DeleteBook(context.Background(), id, 0, uuid.New())
 		assert.Equal(t, http.StatusInternalServerError, res.Status)
 	})
+
+	t.Run("error - it should return 409 while a copy is on loan or on hold", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id := uuid.New()
+		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(&models.Book{Id: id, Version: 2}, nil)
+
+		instance.repo.EXPECT().DeleteBook(mock.Anything, id, 2).Return(repository.ErrBookInCirculation)
+		res := instance.service.DeleteBook(context.Background(), id, 2, uuid.New())
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_BOOK_IN_CIRCULATION, res.Message)
+	})
+
+	t.Run("error - it should return 412 when the book changed since it was read", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id := uuid.New()
+		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(&models.Book{Id: id, Version: 3}, nil)
+
+		res := instance.service.DeleteBook(context.Background(), id, 2, uuid.New())
+		assert.Equal(t, http.StatusPreconditionFailed, res.Status)
+		assert.Equal(t, views.M_PRECONDITION_FAILED, res.Message)
+	})
+}
+
+func TestBookSvc_RestoreBook(t *testing.T) {
+	t.Run("success - it should restore the book", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id, owner := uuid.New(), uuid.New()
+		book := &models.Book{Id: id, UserId: owner, Title: "Test Book", Isbn: "9780306406157"}
+
+		instance.repo.EXPECT().GetDeletedBookById(mock.Anything, id).Return(book, nil)
+		instance.repo.EXPECT().RestoreBook(mock.Anything, id).Return(nil)
+		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(book, nil)
+		instance.copies.EXPECT().CountCopies(mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID]*repository.CopyCount{}, nil)
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool { return e.Action == models.HistoryRestore })).Return(nil)
+		res := instance.service.RestoreBook(context.Background(), id, &common.CustomClaims{Id: owner})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, id, res.Payload.(views.Book).Id)
+	})
+
+	t.Run("error - it should return 404 if the book is not in the trash", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id := uuid.New()
+
+		instance.repo.EXPECT().GetDeletedBookById(mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+		res := instance.service.RestoreBook(context.Background(), id, &common.CustomClaims{Id: uuid.New()})
+
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_NOT_IN_TRASH, res.Message)
+	})
+
+	t.Run("error - it should not restore books of other users", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id := uuid.New()
+
+		instance.repo.EXPECT().GetDeletedBookById(mock.Anything, id).Return(&models.Book{Id: id, UserId: uuid.New()}, nil)
+		res := instance.service.RestoreBook(context.Background(), id, &common.CustomClaims{Id: uuid.New(), Role: common.RoleMember})
+
+		assert.Equal(t, http.StatusForbidden, res.Status)
+	})
+
+	t.Run("error - it should return 409 if an author is in the trash", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id, owner := uuid.New(), uuid.New()
+
+		instance.repo.EXPECT().GetDeletedBookById(mock.Anything, id).Return(&models.Book{Id: id, UserId: owner}, nil)
+		instance.repo.EXPECT().RestoreBook(mock.Anything, id).Return(repository.ErrAuthorDeleted)
+		res := instance.service.RestoreBook(context.Background(), id, &common.CustomClaims{Id: owner})
+
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_AUTHOR_DELETED, res.Message)
+	})
 }
 
 func TestGetBookById(t *testing.T) {
@@ -100,7 +230,6 @@ func TestGetBookById(t *testing.T) {
 		mockBook := &models.Book{
 			Id:        id,
 			UserId:    uuid.New(),
-			AuthorId:  uuid.New(),
 			Title:     "Test Book",
 			Isbn:      "123456789",
 			CreatedAt: time.Now(),
@@ -108,6 +237,9 @@ func TestGetBookById(t *testing.T) {
 		}
 
 		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(mockBook, nil)
+		instance.copies.EXPECT().CountCopies(mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID]*repository.CopyCount{
+			id: {Total: 3, Available: 1},
+		}, nil)
 		res := instance.service.GetBookById(context.Background(), id)
 
 		assert.Equal(t, http.StatusOK, res.Status)
@@ -116,6 +248,18 @@ func TestGetBookById(t *testing.T) {
 		assert.Equal(t, mockBook.Id, bookData.Id)
 		assert.Equal(t, mockBook.Title, bookData.Title)
 		assert.Equal(t, mockBook.Isbn, bookData.Isbn)
+		assert.Equal(t, views.Availability{Total: 3, Available: 1}, bookData.Availability)
+	})
+
+	t.Run("error - it should return 500 if the copies cannot be counted", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id := uuid.New()
+
+		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(&models.Book{Id: id}, nil)
+		instance.copies.EXPECT().CountCopies(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+		res := instance.service.GetBookById(context.Background(), id)
+
+		assert.Equal(t, http.StatusInternalServerError, res.Status)
 	})
 
 	t.Run("error - it should return 400 if book not found", func(t *testing.T) {
@@ -148,7 +292,6 @@ func TestGetBooks(t *testing.T) {
 			{
 				Id:        uuid.New(),
 				UserId:    uuid.New(),
-				AuthorId:  uuid.New(),
 				Title:     "Test Book 1",
 				Isbn:      "1234567890",
 				CreatedAt: time.Now(),
@@ -157,7 +300,6 @@ func TestGetBooks(t *testing.T) {
 			{
 				Id:        uuid.New(),
 				UserId:    uuid.New(),
-				AuthorId:  uuid.New(),
 				Title:     "Test Book 2",
 				Isbn:      "0987654321",
 				CreatedAt: time.Now(),
@@ -166,10 +308,13 @@ func TestGetBooks(t *testing.T) {
 		}
 
 		// Mock GetBooks to return the list of books
-		instance.repo.EXPECT().GetBooks(mock.Anything).Return(mockBooks, nil)
+		instance.repo.EXPECT().GetBooks(mock.Anything, mock.Anything, mock.Anything).Return(mockBooks, &repository.PageInfo{Total: 2, PageSize: 20}, nil)
+		instance.copies.EXPECT().CountCopies(mock.Anything, []uuid.UUID{mockBooks[0].Id, mockBooks[1].Id}).Return(map[uuid.UUID]*repository.CopyCount{
+			mockBooks[1].Id: {Total: 2, Available: 2},
+		}, nil)
 
 		// Call GetBooks service
-		res := instance.service.GetBooks(context.Background())
+		res := instance.service.GetBooks(context.Background(), &params.ListBooks{})
 
 		// Assert response status is 200 OK
 		assert.Equal(t, http.StatusOK, res.Status)
@@ -184,16 +329,55 @@ func TestGetBooks(t *testing.T) {
 		assert.Equal(t, mockBooks[0].Title, books[0].Title)
 		assert.Equal(t, mockBooks[1].Id, books[1].Id)
 		assert.Equal(t, mockBooks[1].Title, books[1].Title)
+		assert.Equal(t, views.Availability{}, books[0].Availability)
+		assert.Equal(t, views.Availability{Total: 2, Available: 2}, books[1].Availability)
+
+		meta, ok := res.Meta.(*views.Pagination)
+		assert.True(t, ok)
+		assert.Equal(t, int64(2), meta.Total)
+	})
+
+	t.Run("success - it should pass filters and paging to the repository", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		authorId := uuid.New()
+
+		instance.repo.EXPECT().GetBooks(mock.Anything, mock.MatchedBy(func(f *repository.BookFilter) bool {
+			return f.AuthorId == authorId && f.Title == "go" && f.UserId == uuid.Nil
+		}), mock.MatchedBy(func(p *repository.Page) bool {
+			return p.Page == 2 && p.PageSize == 10 && p.Sort == "-title"
+		})).Return([]*models.Book{}, &repository.PageInfo{Total: 15, PageSize: 10}, nil)
+		instance.copies.EXPECT().CountCopies(mock.Anything, []uuid.UUID{}).Return(map[uuid.UUID]*repository.CopyCount{}, nil)
+
+		res := instance.service.GetBooks(context.Background(), &params.ListBooks{
+			Page:     2,
+			PageSize: 10,
+			Sort:     "-title",
+			AuthorId: authorId.String(),
+			Title:    "go",
+		})
+		assert.Equal(t, http.StatusOK, res.Status)
+		meta := res.Meta.(*views.Pagination)
+		assert.Equal(t, 2, meta.Page)
+		assert.Equal(t, int64(15), meta.Total)
+	})
+
+	t.Run("error - it should return 400 for an unknown sort field", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		instance.repo.EXPECT().GetBooks(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, repository.ErrInvalidSort)
+
+		res := instance.service.GetBooks(context.Background(), &params.ListBooks{Sort: "password"})
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+		assert.Equal(t, views.M_INVALID_QUERY, res.Message)
 	})
 
 	t.Run("error - it should return 500 if there is a database error", func(t *testing.T) {
 		instance := newBookSvcTestTest(t)
 
 		// Mock GetBooks to return a generic error
-		instance.repo.EXPECT().GetBooks(mock.Anything).Return(nil, assert.AnError)
+		instance.repo.EXPECT().GetBooks(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, assert.AnError)
 
 		// Call GetBooks service
-		res := instance.service.GetBooks(context.Background())
+		res := instance.service.GetBooks(context.Background(), &params.ListBooks{})
 
 		// Assert response status is 500 Internal Server Error
 		assert.Equal(t, http.StatusInternalServerError, res.Status)
@@ -210,7 +394,6 @@ func TestUpdateBook(t *testing.T) {
 		mockBook := &models.Book{
 			Id:        id,
 			UserId:    uuid.New(),
-			AuthorId:  uuid.New(),
 			Title:     "Original Title",
 			Isbn:      "1234567890",
 			CreatedAt: time.Now(),
@@ -218,20 +401,28 @@ func TestUpdateBook(t *testing.T) {
 		}
 
 		// Create the book update parameters
+		authorId, translatorId := uuid.New(), uuid.New()
 		updateParams := &params.UpdateBook{
-			AuthorId: uuid.New(),
-			Title:    "Updated Title",
-			Isbn:     "0987654321",
+			Title: "Updated Title",
+			Isbn:  "978-3-16-148410-0",
+			Contributors: []params.Contributor{
+				{AuthorId: authorId},
+				{AuthorId: translatorId, Role: models.ContributorTranslator},
+			},
 		}
 
 		// Mock GetBookById to return the original book
 		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(mockBook, nil)
+		instance.authors.EXPECT().GetAuthorsByIds(mock.Anything, mock.Anything).
+			Return([]*models.Author{{Id: authorId}, {Id: translatorId}}, nil)
 
 		// Mock UpdateBook to simulate successful update
 		instance.repo.EXPECT().UpdateBook(mock.Anything, mockBook, id).Return(nil)
 
 		// Call UpdateBook service
-		res := instance.service.UpdateBook(context.Background(), updateParams, id)
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool { return e.Action == models.HistoryUpdate })).Return(nil)
+		instance.copies.EXPECT().CountCopies(mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID]*repository.CopyCount{}, nil)
+		res := instance.service.UpdateBook(context.Background(), updateParams, id, 0, uuid.New())
 
 		// Assert response status is 200 OK
 		assert.Equal(t, http.StatusOK, res.Status)
@@ -240,8 +431,12 @@ func TestUpdateBook(t *testing.T) {
 		updatedBook, ok := res.Payload.(views.UpdateBook)
 		assert.True(t, ok)
 		assert.Equal(t, updateParams.Title, updatedBook.Title)
-		assert.Equal(t, updateParams.Isbn, updatedBook.Isbn)
-		assert.Equal(t, updateParams.AuthorId, updatedBook.AuthorId)
+		assert.Equal(t, "9783161484100", updatedBook.Isbn)
+		assert.Equal(t, "978-3-16-148410-0", updatedBook.IsbnDisplay)
+		assert.Equal(t, []views.Contributor{
+			{AuthorId: authorId, Role: models.ContributorAuthor, Position: 0},
+			{AuthorId: translatorId, Role: models.ContributorTranslator, Position: 1},
+		}, updatedBook.Contributors)
 	})
 
 	t.Run("error - it should return 404 if book not found", func(t *testing.T) {
@@ -252,7 +447,7 @@ func TestUpdateBook(t *testing.T) {
 		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
 
 		// Call UpdateBook service
-		res := instance.service.UpdateBook(context.Background(), &params.UpdateBook{}, id)
+		res := instance.service.UpdateBook(context.Background(), &params.UpdateBook{}, id, 0, uuid.New())
 
 		// Assert response status is 400 Bad Request
 		assert.Equal(t, http.StatusBadRequest, res.Status)
@@ -267,7 +462,6 @@ func TestUpdateBook(t *testing.T) {
 		mockBook := &models.Book{
 			Id:        id,
 			UserId:    uuid.New(),
-			AuthorId:  uuid.New(),
 			Title:     "Original Title",
 			Isbn:      "1234567890",
 			CreatedAt: time.Now(),
@@ -281,10 +475,111 @@ func TestUpdateBook(t *testing.T) {
 		instance.repo.EXPECT().UpdateBook(mock.Anything, mockBook, id).Return(assert.AnError)
 
 		// Call UpdateBook service
-		res := instance.service.UpdateBook(context.Background(), &params.UpdateBook{}, id)
+		res := instance.service.UpdateBook(context.Background(), &params.UpdateBook{Isbn: "9783161484100"}, id, 0, uuid.New())
 
 		// Assert response status is 500 Internal Server Error
 		assert.Equal(t, http.StatusInternalServerError, res.Status)
 		assert.Equal(t, views.M_INTERNAL_SERVER_ERROR, res.Message)
 	})
+
+	t.Run("error - it should return 412 when the If-Match version is stale", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id := uuid.New()
+		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(&models.Book{Id: id, Version: 3}, nil)
+
+		res := instance.service.UpdateBook(context.Background(), &params.UpdateBook{Isbn: "9783161484100"}, id, 2, uuid.New())
+		assert.Equal(t, http.StatusPreconditionFailed, res.Status)
+		assert.Equal(t, views.M_PRECONDITION_FAILED, res.Message)
+	})
+
+	t.Run("error - it should return 412 when the book is updated concurrently", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id := uuid.New()
+		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(&models.Book{Id: id, Version: 3}, nil)
+		instance.repo.EXPECT().UpdateBook(mock.Anything, mock.MatchedBy(func(b *models.Book) bool {
+			return b.Version == 3
+		}), id).Return(repository.ErrVersionConflict)
+
+		res := instance.service.UpdateBook(context.Background(), &params.UpdateBook{Isbn: "9783161484100"}, id, 3, uuid.New())
+		assert.Equal(t, http.StatusPreconditionFailed, res.Status)
+	})
+}
+
+func TestBookSvc_GetBookHistory(t *testing.T) {
+	t.Run("success - it should return the changes of the book", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id := uuid.New()
+		instance.history.EXPECT().GetHistory(mock.Anything, models.HistoryBook, id).Return([]*models.HistoryEntry{
+			{
+				Version:  2,
+				Action:   models.HistoryUpdate,
+				Changes:  `[{"field":"title","from":"Old","to":"New"}]`,
+				Snapshot: `{"title":"New","isbn":"9780306406157","contributors":[]}`,
+			},
+			{Version: 1, Action: models.HistoryCreate},
+		}, nil)
+
+		res := instance.service.GetBookHistory(context.Background(), id)
+		assert.Equal(t, http.StatusOK, res.Status)
+		list := res.Payload.([]views.HistoryEntry)
+		assert.Len(t, list, 2)
+		assert.Equal(t, "title", list[0].Changes[0].Field)
+		assert.JSONEq(t, `"Old"`, string(list[0].Changes[0].From))
+	})
+
+	t.Run("error - it should return 404 when the book does not exist", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id := uuid.New()
+		instance.history.EXPECT().GetHistory(mock.Anything, models.HistoryBook, id).Return(nil, nil)
+		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.GetBookHistory(context.Background(), id)
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_BOOK_NOT_FOUND, res.Message)
+	})
+}
+
+func TestBookSvc_RevertBook(t *testing.T) {
+	t.Run("success - it should restore the fields of the version", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id := uuid.New()
+		mockBook := &models.Book{Id: id, UserId: uuid.New(), Title: "New", Isbn: "9783161484100"}
+		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(mockBook, nil)
+		instance.history.EXPECT().GetHistoryVersion(mock.Anything, models.HistoryBook, id, 1).Return(&models.HistoryEntry{
+			Version:  1,
+			Snapshot: `{"title":"Old","isbn":"9780306406157","contributors":[]}`,
+		}, nil)
+		instance.repo.EXPECT().UpdateBook(mock.Anything, mock.MatchedBy(func(b *models.Book) bool {
+			return b.Title == "Old" && b.Isbn == "9780306406157"
+		}), id).Return(nil)
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.MatchedBy(func(e *models.HistoryEntry) bool {
+			return e.Action == models.HistoryRevert && e.EntityId == id
+		})).Return(nil)
+		instance.copies.EXPECT().CountCopies(mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID]*repository.CopyCount{}, nil)
+
+		res := instance.service.RevertBook(context.Background(), id, &params.Revert{Revision: 1}, 0, uuid.New())
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, "Old", res.Payload.(views.UpdateBook).Title)
+	})
+
+	t.Run("error - it should return 404 for an unknown version", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id := uuid.New()
+		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(&models.Book{Id: id}, nil)
+		instance.history.EXPECT().GetHistoryVersion(mock.Anything, models.HistoryBook, id, 9).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.RevertBook(context.Background(), id, &params.Revert{Revision: 9}, 0, uuid.New())
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_VERSION_NOT_FOUND, res.Message)
+	})
+
+	t.Run("error - it should return 412 when the book changed since it was read", func(t *testing.T) {
+		instance := newBookSvcTestTest(t)
+		id := uuid.New()
+		instance.repo.EXPECT().GetBookById(mock.Anything, id).Return(&models.Book{Id: id, Version: 3}, nil)
+
+		res := instance.service.RevertBook(context.Background(), id, &params.Revert{Revision: 1}, 2, uuid.New())
+		assert.Equal(t, http.StatusPreconditionFailed, res.Status)
+		assert.Equal(t, views.M_PRECONDITION_FAILED, res.Message)
+	})
 }
@@ -0,0 +1,231 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/opds"
+	"gorm.io/gorm"
+)
+
+const (
+	// Title is the title of the catalog, its root feed.
+	Title = "Library catalog"
+
+	defaultPageSize = 20
+	typeJson        = "application/json"
+)
+
+type catalogSvc struct {
+	books   repository.BookRepo
+	authors repository.AuthorRepo
+	search  repository.SearchRepo
+}
+
+// GetRoot implements service.CatalogSvc. The root lists the newest books
+// and the authors, the search is linked from every feed.
+func (svc *catalogSvc) GetRoot(ctx context.Context) *views.Response {
+	return views.SuccessResponse(http.StatusOK, views.M_OK, &opds.Feed{
+		Kind:    opds.KindNavigation,
+		Title:   Title,
+		Updated: time.Now(),
+		Navigation: []opds.Navigation{
+			{
+				Title:   "Newest books",
+				Summary: "The books added to the catalog last.",
+				Link:    opds.Link{Rel: opds.RelNew, Href: "new", Kind: opds.KindAcquisition},
+			},
+			{
+				Title:   "By author",
+				Summary: "The authors of the catalog by name, with their books.",
+				Link:    opds.Link{Rel: opds.RelSubsection, Href: "authors", Kind: opds.KindNavigation},
+			},
+		},
+	})
+}
+
+// GetNewest implements service.CatalogSvc.
+func (svc *catalogSvc) GetNewest(ctx context.Context, query *params.CatalogPage) *views.Response {
+	page := pageNumber(query.Page)
+	books, info, err := svc.books.GetBooks(ctx, &repository.BookFilter{}, &repository.Page{
+		Page:     page,
+		PageSize: query.PageSize,
+		Sort:     "-created_at",
+	})
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	feed := acquisition("Newest books", pagePath("new", nil, page, query.PageSize), books)
+	feed.Page, feed.PageSize, feed.Total = page, info.PageSize, info.Total
+	return views.SuccessResponse(http.StatusOK, views.M_OK, feed)
+}
+
+// GetAuthors implements service.CatalogSvc.
+func (svc *catalogSvc) GetAuthors(ctx context.Context, query *params.CatalogPage) *views.Response {
+	page := pageNumber(query.Page)
+	authors, info, err := svc.authors.GetAuthors(ctx, &repository.AuthorFilter{}, &repository.Page{
+		Page:     page,
+		PageSize: query.PageSize,
+		Sort:     "name",
+	})
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	feed := &opds.Feed{
+		Kind:     opds.KindNavigation,
+		Title:    "By author",
+		Path:     pagePath("authors", nil, page, query.PageSize),
+		Page:     page,
+		PageSize: info.PageSize,
+		Total:    info.Total,
+	}
+	for _, a := range authors {
+		feed.Navigation = append(feed.Navigation, opds.Navigation{
+			ID:      "urn:uuid:" + a.Id.String(),
+			Title:   a.Name,
+			Updated: a.UpdatedAt,
+			Link:    opds.Link{Rel: opds.RelSubsection, Href: "authors/" + a.Id.String(), Kind: opds.KindAcquisition},
+		})
+		if a.UpdatedAt.After(feed.Updated) {
+			feed.Updated = a.UpdatedAt
+		}
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, feed)
+}
+
+// GetAuthorBooks implements service.CatalogSvc. The books are those the
+// author contributed to in any role, by title.
+func (svc *catalogSvc) GetAuthorBooks(ctx context.Context, id uuid.UUID, query *params.CatalogPage) *views.Response {
+	author, err := svc.authors.GetAuthorById(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return views.ErrorReponse(http.StatusNotFound, views.M_AUTHOR_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	page := pageNumber(query.Page)
+	books, info, err := svc.books.GetBooks(ctx, &repository.BookFilter{AuthorId: id}, &repository.Page{
+		Page:     page,
+		PageSize: query.PageSize,
+		Sort:     "title",
+	})
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	feed := acquisition(author.Name, pagePath("authors/"+id.String(), nil, page, query.PageSize), books)
+	feed.Page, feed.PageSize, feed.Total = page, info.PageSize, info.Total
+	return views.SuccessResponse(http.StatusOK, views.M_OK, feed)
+}
+
+// Search implements service.CatalogSvc with the full-text search of the
+// books, the best matches first.
+func (svc *catalogSvc) Search(ctx context.Context, query *params.CatalogSearch) *views.Response {
+	page := pageNumber(query.Page)
+	size := query.PageSize
+	if size < 1 {
+		size = defaultPageSize
+	}
+
+	hits, total, err := svc.search.SearchBooks(ctx, query.Q, size, (page-1)*size)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidSearch) {
+			return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_QUERY, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	ids := make([]uuid.UUID, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.Id)
+	}
+	books, err := svc.books.GetBooksByIds(ctx, ids)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	feed := acquisition("Search: "+query.Q, pagePath("search", url.Values{"q": {query.Q}}, page, query.PageSize), books)
+	feed.Page, feed.PageSize, feed.Total = page, size, total
+	return views.SuccessResponse(http.StatusOK, views.M_OK, feed)
+}
+
+// acquisition returns the feed of the books, updated when the last of them
+// was.
+func acquisition(title, path string, books []*models.Book) *opds.Feed {
+	feed := &opds.Feed{Kind: opds.KindAcquisition, Title: title, Path: path}
+	for _, b := range books {
+		feed.Publications = append(feed.Publications, publication(b))
+		if b.UpdatedAt.After(feed.Updated) {
+			feed.Updated = b.UpdatedAt
+		}
+	}
+	return feed
+}
+
+// publication returns the entry of a book. The catalog holds printed books,
+// so a book is borrowed by placing a hold on it.
+func publication(b *models.Book) opds.Publication {
+	id := b.Id.String()
+	p := opds.Publication{
+		ID:      "urn:uuid:" + id,
+		Title:   b.Title,
+		Updated: b.UpdatedAt,
+		Links: []opds.Link{
+			{Rel: opds.RelAlternate, Href: "/books/" + id, Type: typeJson, Title: "Book"},
+			{Rel: opds.RelBorrow, Href: "/books/" + id + "/holds", Type: typeJson, Title: "Place a hold"},
+		},
+	}
+	if b.Isbn != "" {
+		p.Identifier = "urn:isbn:" + b.Isbn
+	}
+	for _, c := range b.Contributors {
+		role := c.Role
+		if role == "" {
+			role = models.ContributorAuthor
+		}
+		p.Contributors = append(p.Contributors, opds.Contributor{
+			Name: c.Author.Name,
+			Role: role,
+			Link: &opds.Link{Href: "authors/" + c.AuthorId.String(), Kind: opds.KindAcquisition},
+		})
+	}
+	return p
+}
+
+func pageNumber(page int) int {
+	if page < 1 {
+		return 1
+	}
+	return page
+}
+
+// pagePath returns the path of a page of a feed, keeping the page size the
+// client asked for.
+func pagePath(path string, query url.Values, page, pageSize int) string {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("page", strconv.Itoa(page))
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	return path + "?" + query.Encode()
+}
+
+func NewCatalogSvc(books repository.BookRepo, authors repository.AuthorRepo, search repository.SearchRepo) service.CatalogSvc {
+	return &catalogSvc{
+		books:   books,
+		authors: authors,
+		search:  search,
+	}
+}
@@ -0,0 +1,165 @@
+package catalog_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/catalog"
+	"github.com/storyofhis/books-management/opds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type catalogSvcTest struct {
+	books   *repository.MockBookRepo
+	authors *repository.MockAuthorRepo
+	search  *repository.MockSearchRepo
+	service service.CatalogSvc
+}
+
+func newCatalogSvcTest(t *testing.T) catalogSvcTest {
+	mockBooks := repository.NewMockBookRepo(t)
+	mockAuthors := repository.NewMockAuthorRepo(t)
+	mockSearch := repository.NewMockSearchRepo(t)
+	catalogSvc := catalog.NewCatalogSvc(mockBooks, mockAuthors, mockSearch)
+	return catalogSvcTest{
+		books:   mockBooks,
+		authors: mockAuthors,
+		search:  mockSearch,
+		service: catalogSvc,
+	}
+}
+
+func book(title string, updated time.Time) *models.Book {
+	authorId := uuid.New()
+	return &models.Book{
+		Id:    uuid.New(),
+		Title: title,
+		Isbn:  "9780441013593",
+		Contributors: []models.BookContributor{
+			{AuthorId: authorId, Author: models.Author{Id: authorId, Name: "Frank Herbert"}},
+		},
+		CreatedAt: updated,
+		UpdatedAt: updated,
+	}
+}
+
+func TestCatalogSvc_GetRoot(t *testing.T) {
+	t.Run("success - it should link the newest books and the authors", func(t *testing.T) {
+		instance := newCatalogSvcTest(t)
+
+		res := instance.service.GetRoot(context.Background())
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		feed := res.Payload.(*opds.Feed)
+		assert.Equal(t, opds.KindNavigation, feed.Kind)
+		assert.Equal(t, "new", feed.Navigation[0].Link.Href)
+		assert.Equal(t, opds.KindAcquisition, feed.Navigation[0].Link.Kind)
+		assert.Equal(t, "authors", feed.Navigation[1].Link.Href)
+	})
+}
+
+func TestCatalogSvc_GetNewest(t *testing.T) {
+	t.Run("success - it should list a page of the books added last", func(t *testing.T) {
+		instance := newCatalogSvcTest(t)
+		older := book("Dune", time.Date(2024, 9, 18, 10, 0, 0, 0, time.UTC))
+		newer := book("Dune Messiah", time.Date(2024, 9, 19, 10, 0, 0, 0, time.UTC))
+
+		instance.books.EXPECT().GetBooks(mock.Anything, &repository.BookFilter{}, &repository.Page{Page: 2, PageSize: 2, Sort: "-created_at"}).
+			Return([]*models.Book{newer, older}, &repository.PageInfo{Total: 5, PageSize: 2}, nil)
+		res := instance.service.GetNewest(context.Background(), &params.CatalogPage{Page: 2, PageSize: 2})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		feed := res.Payload.(*opds.Feed)
+		assert.Equal(t, "new?page=2&page_size=2", feed.Path)
+		assert.Equal(t, newer.UpdatedAt, feed.Updated)
+		assert.Equal(t, int64(5), feed.Total)
+		assert.Len(t, feed.Publications, 2)
+		p := feed.Publications[0]
+		assert.Equal(t, "urn:uuid:"+newer.Id.String(), p.ID)
+		assert.Equal(t, "urn:isbn:9780441013593", p.Identifier)
+		assert.Equal(t, opds.RoleAuthor, p.Contributors[0].Role)
+		assert.Equal(t, "authors/"+newer.Contributors[0].AuthorId.String(), p.Contributors[0].Link.Href)
+		assert.Equal(t, "/books/"+newer.Id.String()+"/holds", p.Links[1].Href)
+	})
+}
+
+func TestCatalogSvc_GetAuthors(t *testing.T) {
+	t.Run("success - it should list the authors by name", func(t *testing.T) {
+		instance := newCatalogSvcTest(t)
+		author := &models.Author{Id: uuid.New(), Name: "Frank Herbert"}
+
+		instance.authors.EXPECT().GetAuthors(mock.Anything, mock.Anything, &repository.Page{Page: 1, Sort: "name"}).
+			Return([]*models.Author{author}, &repository.PageInfo{Total: 1, PageSize: 20}, nil)
+		res := instance.service.GetAuthors(context.Background(), &params.CatalogPage{})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		feed := res.Payload.(*opds.Feed)
+		assert.Equal(t, "authors?page=1", feed.Path)
+		assert.Equal(t, 20, feed.PageSize)
+		assert.Equal(t, "authors/"+author.Id.String(), feed.Navigation[0].Link.Href)
+	})
+}
+
+func TestCatalogSvc_GetAuthorBooks(t *testing.T) {
+	t.Run("success - it should list the books of the author", func(t *testing.T) {
+		instance := newCatalogSvcTest(t)
+		author := &models.Author{Id: uuid.New(), Name: "Frank Herbert"}
+
+		instance.authors.EXPECT().GetAuthorById(mock.Anything, author.Id).Return(author, nil)
+		instance.books.EXPECT().GetBooks(mock.Anything, &repository.BookFilter{AuthorId: author.Id}, mock.Anything).
+			Return([]*models.Book{book("Dune", time.Now())}, &repository.PageInfo{Total: 1, PageSize: 20}, nil)
+		res := instance.service.GetAuthorBooks(context.Background(), author.Id, &params.CatalogPage{})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		feed := res.Payload.(*opds.Feed)
+		assert.Equal(t, "Frank Herbert", feed.Title)
+		assert.Equal(t, "authors/"+author.Id.String()+"?page=1", feed.Path)
+	})
+
+	t.Run("error - it should return 404 for an unknown author", func(t *testing.T) {
+		instance := newCatalogSvcTest(t)
+		instance.authors.EXPECT().GetAuthorById(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.GetAuthorBooks(context.Background(), uuid.New(), &params.CatalogPage{})
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_AUTHOR_NOT_FOUND, res.Message)
+	})
+}
+
+func TestCatalogSvc_Search(t *testing.T) {
+	t.Run("success - it should keep the order of the hits", func(t *testing.T) {
+		instance := newCatalogSvcTest(t)
+		first := book("Dune", time.Now())
+		second := book("Dune Messiah", time.Now())
+
+		instance.search.EXPECT().SearchBooks(mock.Anything, "dune", 10, 10).
+			Return([]*repository.BookHit{{Id: first.Id}, {Id: second.Id}}, int64(12), nil)
+		instance.books.EXPECT().GetBooksByIds(mock.Anything, []uuid.UUID{first.Id, second.Id}).Return([]*models.Book{first, second}, nil)
+		res := instance.service.Search(context.Background(), &params.CatalogSearch{Q: "dune", Page: 2, PageSize: 10})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		feed := res.Payload.(*opds.Feed)
+		assert.Equal(t, "search?page=2&page_size=10&q=dune", feed.Path)
+		assert.Equal(t, int64(12), feed.Total)
+		assert.Equal(t, "Dune", feed.Publications[0].Title)
+	})
+
+	t.Run("error - it should return 400 for an invalid query", func(t *testing.T) {
+		instance := newCatalogSvcTest(t)
+		instance.search.EXPECT().SearchBooks(mock.Anything, "\"", 20, 0).Return(nil, 0, repository.ErrInvalidSearch)
+
+		res := instance.service.Search(context.Background(), &params.CatalogSearch{Q: "\""})
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+		assert.Equal(t, views.M_INVALID_QUERY, res.Message)
+	})
+}
@@ -0,0 +1,220 @@
+package circulation
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/config"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"gorm.io/gorm"
+)
+
+var (
+	errNotYourHold = errors.New("you do not have permission to access this hold")
+	errHoldClosed  = errors.New("the hold was already fulfilled, cancelled or expired")
+)
+
+type holdSvc struct {
+	repo  repository.HoldRepo
+	books repository.BookRepo
+	users repository.UserRepo
+	tx    repository.Transactor
+}
+
+// PlaceHold implements service.HoldSvc. A copy already on the shelf is
+// assigned to the hold straight away.
+func (svc *holdSvc) PlaceHold(ctx context.Context, bookId uuid.UUID, req *params.PlaceHold, user *common.CustomClaims) *views.Response {
+	userId := user.Id
+	if req.UserId != nil && *req.UserId != user.Id {
+		if !user.IsStaff() {
+			return views.ErrorReponse(http.StatusForbidden, views.M_FORBIDDEN, errNotYourHold)
+		}
+		_, err := svc.users.GetUserById(ctx, *req.UserId)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return views.ErrorReponse(http.StatusNotFound, views.M_USER_NOT_FOUND, err)
+			}
+			return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+		}
+		userId = *req.UserId
+	}
+
+	book, err := svc.books.GetBookById(ctx, bookId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_BOOK_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	hold := models.Hold{BookId: bookId, Book: *book, UserId: userId}
+	err = svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := svc.repo.CreateHold(ctx, &hold); err != nil {
+			return err
+		}
+		assigned, err := svc.repo.AssignHolds(ctx, bookId, time.Now().Add(config.GetHoldPickupWindow()))
+		if err != nil {
+			return err
+		}
+		for _, h := range assigned {
+			if h.Id == hold.Id {
+				h.Book = hold.Book
+				hold = *h
+			}
+		}
+		if hold.Status != models.HoldWaiting {
+			return nil
+		}
+		// The new hold is the last in the queue.
+		waiting, err := svc.repo.CountWaitingHolds(ctx, bookId)
+		hold.Position = int(waiting)
+		return err
+	})
+	if err != nil {
+		if err == repository.ErrDuplicateHold {
+			return views.ErrorReponse(http.StatusConflict, views.M_DUPLICATE_HOLD, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusCreated, views.M_CREATED, holdView(&hold))
+}
+
+// GetBookHolds implements service.HoldSvc.
+func (svc *holdSvc) GetBookHolds(ctx context.Context, bookId uuid.UUID) *views.Response {
+	_, err := svc.books.GetBookById(ctx, bookId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_BOOK_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return svc.getHolds(ctx, &repository.HoldFilter{BookId: bookId})
+}
+
+// GetUserHolds implements service.HoldSvc.
+func (svc *holdSvc) GetUserHolds(ctx context.Context, userId uuid.UUID) *views.Response {
+	return svc.getHolds(ctx, &repository.HoldFilter{UserId: userId})
+}
+
+// CancelHold implements service.HoldSvc. The copy kept for a ready hold goes
+// to the next hold in the queue.
+func (svc *holdSvc) CancelHold(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	hold, err := svc.repo.GetHoldById(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_HOLD_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if hold.UserId != user.Id && !user.IsStaff() {
+		return views.ErrorReponse(http.StatusForbidden, views.M_FORBIDDEN, errNotYourHold)
+	}
+	if hold.Status != models.HoldWaiting && hold.Status != models.HoldReady {
+		return views.ErrorReponse(http.StatusConflict, views.M_HOLD_CLOSED, errHoldClosed)
+	}
+
+	ready := hold.Status == models.HoldReady
+	err = svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := svc.repo.CancelHold(ctx, hold); err != nil {
+			return err
+		}
+		if !ready {
+			return nil
+		}
+		_, err := svc.repo.AssignHolds(ctx, hold.BookId, time.Now().Add(config.GetHoldPickupWindow()))
+		return err
+	})
+	if err != nil {
+		if err == repository.ErrHoldChanged {
+			return views.ErrorReponse(http.StatusConflict, views.M_HOLD_CLOSED, errHoldClosed)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, holdView(hold))
+}
+
+func (svc *holdSvc) getHolds(ctx context.Context, filter *repository.HoldFilter) *views.Response {
+	list, err := svc.repo.GetHolds(ctx, filter)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	holds := make([]views.Hold, 0, len(list))
+	for _, h := range list {
+		holds = append(holds, holdView(h))
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, holds)
+}
+
+// StartHoldExpiry periodically expires the holds whose copy was not picked
+// up in time and passes the copies on to the next holds.
+func StartHoldExpiry(ctx context.Context, repo repository.HoldRepo) {
+	interval := config.GetHoldExpiryInterval()
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		expireHolds(ctx, repo)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func expireHolds(ctx context.Context, repo repository.HoldRepo) {
+	now := time.Now()
+	expired, err := repo.ExpireHolds(ctx, now)
+	if err != nil {
+		log.Printf("Failed to expire holds : %v", err)
+		return
+	}
+	if expired > 0 {
+		log.Printf("Expired %d holds", expired)
+	}
+	// Copies can also be left available by a failed assignment, so this runs
+	// even when nothing expired.
+	_, err = repo.AssignHolds(ctx, uuid.Nil, now.Add(config.GetHoldPickupWindow()))
+	if err != nil {
+		log.Printf("Failed to assign holds : %v", err)
+	}
+}
+
+func holdView(h *models.Hold) views.Hold {
+	view := views.Hold{
+		Id:        h.Id,
+		BookId:    h.BookId,
+		Title:     h.Book.Title,
+		UserId:    h.UserId,
+		Status:    h.Status,
+		Position:  h.Position,
+		CopyId:    h.CopyId,
+		ReadyAt:   h.ReadyAt,
+		ExpiresAt: h.ExpiresAt,
+		CreatedAt: h.CreatedAt,
+	}
+	if h.Copy != nil {
+		view.Barcode = h.Copy.Barcode
+	}
+	return view
+}
+
+func NewHoldSvc(repo repository.HoldRepo, books repository.BookRepo, users repository.UserRepo, tx repository.Transactor) service.HoldSvc {
+	return &holdSvc{
+		repo:  repo,
+		books: books,
+		users: users,
+		tx:    tx,
+	}
+}
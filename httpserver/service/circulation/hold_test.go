@@ -0,0 +1,186 @@
+package circulation_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/circulation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type holdSvcTest struct {
+	repo    *repository.MockHoldRepo
+	books   *repository.MockBookRepo
+	users   *repository.MockUserRepo
+	service service.HoldSvc
+}
+
+func newHoldSvcTest(t *testing.T) holdSvcTest {
+	mockRepo := repository.NewMockHoldRepo(t)
+	mockBooks := repository.NewMockBookRepo(t)
+	mockUsers := repository.NewMockUserRepo(t)
+	mockTx := repository.NewMockTransactor(t)
+	mockTx.EXPECT().Transaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).Maybe()
+	holdSvc := circulation.NewHoldSvc(mockRepo, mockBooks, mockUsers, mockTx)
+	return holdSvcTest{
+		repo:    mockRepo,
+		books:   mockBooks,
+		users:   mockUsers,
+		service: holdSvc,
+	}
+}
+
+func TestHoldSvc_PlaceHold(t *testing.T) {
+	t.Run("success - it should queue the caller at the end of the line", func(t *testing.T) {
+		instance := newHoldSvcTest(t)
+		userId, bookId := uuid.New(), uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId, Title: "Dune"}, nil)
+		instance.repo.EXPECT().CreateHold(mock.Anything, mock.MatchedBy(func(h *models.Hold) bool {
+			return h.BookId == bookId && h.UserId == userId
+		})).RunAndReturn(func(ctx context.Context, h *models.Hold) error {
+			h.Id = uuid.New()
+			h.Status = models.HoldWaiting
+			return nil
+		})
+		instance.repo.EXPECT().AssignHolds(mock.Anything, bookId, mock.Anything).Return(nil, nil)
+		instance.repo.EXPECT().CountWaitingHolds(mock.Anything, bookId).Return(3, nil)
+		res := instance.service.PlaceHold(context.Background(), bookId, &params.PlaceHold{}, &common.CustomClaims{Id: userId, Role: common.RoleMember})
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+		payload := res.Payload.(views.Hold)
+		assert.Equal(t, models.HoldWaiting, payload.Status)
+		assert.Equal(t, 3, payload.Position)
+		assert.Equal(t, "Dune", payload.Title)
+	})
+
+	t.Run("success - it should assign a copy on the shelf straight away", func(t *testing.T) {
+		instance := newHoldSvcTest(t)
+		bookId, holdId := uuid.New(), uuid.New()
+		copyId := uuid.New()
+		expires := time.Now().Add(time.Hour)
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().CreateHold(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, h *models.Hold) error {
+			h.Id = holdId
+			h.Status = models.HoldWaiting
+			return nil
+		})
+		instance.repo.EXPECT().AssignHolds(mock.Anything, bookId, mock.Anything).Return([]*models.Hold{{
+			Id:        holdId,
+			Status:    models.HoldReady,
+			CopyId:    &copyId,
+			Copy:      &models.Copy{Id: copyId, Barcode: "B-0001"},
+			ExpiresAt: &expires,
+		}}, nil)
+		res := instance.service.PlaceHold(context.Background(), bookId, &params.PlaceHold{}, &common.CustomClaims{Id: uuid.New()})
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+		payload := res.Payload.(views.Hold)
+		assert.Equal(t, models.HoldReady, payload.Status)
+		assert.Equal(t, "B-0001", payload.Barcode)
+		assert.Zero(t, payload.Position)
+	})
+
+	t.Run("error - it should not let members place holds for others", func(t *testing.T) {
+		instance := newHoldSvcTest(t)
+		other := uuid.New()
+
+		res := instance.service.PlaceHold(context.Background(), uuid.New(), &params.PlaceHold{UserId: &other}, &common.CustomClaims{Id: uuid.New(), Role: common.RoleMember})
+		assert.Equal(t, http.StatusForbidden, res.Status)
+	})
+
+	t.Run("error - it should return 409 for a second hold on the book", func(t *testing.T) {
+		instance := newHoldSvcTest(t)
+		instance.books.EXPECT().GetBookById(mock.Anything, mock.Anything).Return(&models.Book{}, nil)
+		instance.repo.EXPECT().CreateHold(mock.Anything, mock.Anything).Return(repository.ErrDuplicateHold)
+
+		res := instance.service.PlaceHold(context.Background(), uuid.New(), &params.PlaceHold{}, &common.CustomClaims{Id: uuid.New()})
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_DUPLICATE_HOLD, res.Message)
+	})
+
+	t.Run("error - it should return 404 if the book does not exist", func(t *testing.T) {
+		instance := newHoldSvcTest(t)
+		instance.books.EXPECT().GetBookById(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.PlaceHold(context.Background(), uuid.New(), &params.PlaceHold{}, &common.CustomClaims{Id: uuid.New()})
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_BOOK_NOT_FOUND, res.Message)
+	})
+}
+
+func TestHoldSvc_GetUserHolds(t *testing.T) {
+	t.Run("success - it should list the holds with their queue position", func(t *testing.T) {
+		instance := newHoldSvcTest(t)
+		userId := uuid.New()
+
+		instance.repo.EXPECT().GetHolds(mock.Anything, &repository.HoldFilter{UserId: userId}).Return([]*models.Hold{
+			{Id: uuid.New(), UserId: userId, Status: models.HoldWaiting, Position: 2},
+		}, nil)
+		res := instance.service.GetUserHolds(context.Background(), userId)
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		holds := res.Payload.([]views.Hold)
+		assert.Len(t, holds, 1)
+		assert.Equal(t, 2, holds[0].Position)
+	})
+}
+
+func TestHoldSvc_CancelHold(t *testing.T) {
+	t.Run("success - it should pass the copy of a ready hold to the next hold", func(t *testing.T) {
+		instance := newHoldSvcTest(t)
+		userId, bookId := uuid.New(), uuid.New()
+		hold := &models.Hold{Id: uuid.New(), BookId: bookId, UserId: userId, Status: models.HoldReady}
+
+		instance.repo.EXPECT().GetHoldById(mock.Anything, hold.Id).Return(hold, nil)
+		instance.repo.EXPECT().CancelHold(mock.Anything, hold).RunAndReturn(func(ctx context.Context, h *models.Hold) error {
+			h.Status = models.HoldCancelled
+			return nil
+		})
+		instance.repo.EXPECT().AssignHolds(mock.Anything, bookId, mock.Anything).Return(nil, nil)
+		res := instance.service.CancelHold(context.Background(), hold.Id, &common.CustomClaims{Id: userId})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, models.HoldCancelled, res.Payload.(views.Hold).Status)
+	})
+
+	t.Run("error - it should not cancel the holds of other members", func(t *testing.T) {
+		instance := newHoldSvcTest(t)
+		instance.repo.EXPECT().GetHoldById(mock.Anything, mock.Anything).Return(&models.Hold{UserId: uuid.New(), Status: models.HoldWaiting}, nil)
+
+		res := instance.service.CancelHold(context.Background(), uuid.New(), &common.CustomClaims{Id: uuid.New(), Role: common.RoleMember})
+		assert.Equal(t, http.StatusForbidden, res.Status)
+	})
+
+	t.Run("error - it should return 409 for a fulfilled hold", func(t *testing.T) {
+		instance := newHoldSvcTest(t)
+		userId := uuid.New()
+		instance.repo.EXPECT().GetHoldById(mock.Anything, mock.Anything).Return(&models.Hold{UserId: userId, Status: models.HoldFulfilled}, nil)
+
+		res := instance.service.CancelHold(context.Background(), uuid.New(), &common.CustomClaims{Id: userId})
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_HOLD_CLOSED, res.Message)
+	})
+
+	t.Run("error - it should return 404 for an unknown hold", func(t *testing.T) {
+		instance := newHoldSvcTest(t)
+		instance.repo.EXPECT().GetHoldById(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.CancelHold(context.Background(), uuid.New(), &common.CustomClaims{Id: uuid.New()})
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_HOLD_NOT_FOUND, res.Message)
+	})
+}
@@ -0,0 +1,287 @@
+package circulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/config"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"gorm.io/gorm"
+)
+
+var (
+	errDueInPast    = errors.New("the due date must be in the future")
+	errNotYourLoan  = errors.New("you do not have permission to access this loan")
+	errRenewalLimit = errors.New("the loan cannot be renewed again")
+	errLoanOverdue  = errors.New("an overdue loan has to be returned, not renewed")
+	errHeldForOther = errors.New("the copy is kept for another member's hold")
+	errHoldsWaiting = errors.New("other members are waiting for this book")
+	errBalanceLimit = errors.New("the member owes more than the balance limit")
+)
+
+type loanSvc struct {
+	repo   repository.LoanRepo
+	copies repository.CopyRepo
+	users  repository.UserRepo
+	holds  repository.HoldRepo
+	ledger repository.LedgerRepo
+	tx     repository.Transactor
+}
+
+// Checkout implements service.LoanSvc. A copy on hold can only be lent to
+// the member it is kept for, which fulfils their hold. Members owing more
+// than the balance limit cannot borrow.
+func (svc *loanSvc) Checkout(ctx context.Context, req *params.Checkout) *views.Response {
+	now := time.Now()
+	due := now.Add(config.GetLoanPeriod())
+	if req.DueAt != nil {
+		if !req.DueAt.After(now) {
+			return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_DUE_DATE, errDueInPast)
+		}
+		due = *req.DueAt
+	}
+
+	c, err := svc.copies.GetCopyByBarcode(ctx, req.Barcode)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_COPY_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	var hold *models.Hold
+	switch c.Status {
+	case models.CopyAvailable:
+	case models.CopyOnHold:
+		hold, err = svc.holds.GetReadyHold(ctx, c.Id)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+		}
+		if err != nil || hold.UserId != req.UserId {
+			return views.ErrorReponse(http.StatusConflict, views.M_COPY_ON_HOLD, errHeldForOther)
+		}
+	default:
+		return views.ErrorReponse(http.StatusConflict, views.M_COPY_NOT_AVAILABLE, fmt.Errorf("%w, it is %s", repository.ErrCopyNotAvailable, c.Status))
+	}
+
+	_, err = svc.users.GetUserById(ctx, req.UserId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_USER_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	balance, err := svc.ledger.GetBalance(ctx, req.UserId)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if balance > config.GetFineBalanceLimit() {
+		return views.ErrorReponse(http.StatusConflict, views.M_BALANCE_LIMIT, errBalanceLimit)
+	}
+
+	loan := models.Loan{
+		CopyId:     c.Id,
+		Copy:       *c,
+		UserId:     req.UserId,
+		BorrowedAt: now,
+		DueAt:      due,
+	}
+	err = svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := svc.repo.CreateLoan(ctx, &loan, c.Status); err != nil {
+			return err
+		}
+		if hold != nil {
+			return svc.holds.FulfillHold(ctx, hold.Id)
+		}
+		return nil
+	})
+	if err != nil {
+		if err == repository.ErrCopyNotAvailable || err == repository.ErrHoldChanged {
+			return views.ErrorReponse(http.StatusConflict, views.M_COPY_NOT_AVAILABLE, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusCreated, views.M_CREATED, loanView(&loan))
+}
+
+// GetLoan implements service.LoanSvc.
+func (svc *loanSvc) GetLoan(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	loan, resp := svc.getLoan(ctx, id, user)
+	if resp != nil {
+		return resp
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, loanView(loan))
+}
+
+// ReturnLoan implements service.LoanSvc. The fine of a late loan is settled
+// and the copy goes to the oldest waiting hold on its book, if any.
+func (svc *loanSvc) ReturnLoan(ctx context.Context, id uuid.UUID) *views.Response {
+	loan, err := svc.repo.GetLoanById(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_LOAN_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	var assigned []*models.Hold
+	err = svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := svc.repo.ReturnLoan(ctx, loan); err != nil {
+			return err
+		}
+		if fine := loan.Fine(time.Now(), config.GetFineDailyRate(), config.GetFineCap()); fine > 0 {
+			if err := svc.ledger.SetFine(ctx, loan.UserId, loan.Id, fine); err != nil {
+				return err
+			}
+		}
+		assigned, err = svc.holds.AssignHolds(ctx, loan.Copy.BookId, time.Now().Add(config.GetHoldPickupWindow()))
+		return err
+	})
+	if err != nil {
+		if err == repository.ErrLoanClosed {
+			return views.ErrorReponse(http.StatusConflict, views.M_LOAN_RETURNED, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	view := loanView(loan)
+	for _, h := range assigned {
+		if h.CopyId != nil && *h.CopyId == loan.CopyId {
+			view.HoldId = &h.Id
+		}
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, view)
+}
+
+// RenewLoan implements service.LoanSvc. The loan is extended by the loan
+// period from its due date. Overdue loans cannot be renewed, as moving the
+// due date would shrink the fine accrued so far, and neither can loans of
+// books other members are waiting for.
+func (svc *loanSvc) RenewLoan(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	loan, resp := svc.getLoan(ctx, id, user)
+	if resp != nil {
+		return resp
+	}
+	if loan.ReturnedAt != nil {
+		return views.ErrorReponse(http.StatusConflict, views.M_LOAN_RETURNED, repository.ErrLoanClosed)
+	}
+	if loan.Renewals >= config.GetMaxRenewals() {
+		return views.ErrorReponse(http.StatusConflict, views.M_RENEWAL_LIMIT, errRenewalLimit)
+	}
+	waiting, err := svc.holds.CountWaitingHolds(ctx, loan.Copy.BookId)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if waiting > 0 {
+		return views.ErrorReponse(http.StatusConflict, views.M_HOLDS_WAITING, errHoldsWaiting)
+	}
+	if time.Now().After(loan.DueAt) {
+		return views.ErrorReponse(http.StatusConflict, views.M_LOAN_OVERDUE, errLoanOverdue)
+	}
+
+	loan.DueAt = loan.DueAt.Add(config.GetLoanPeriod())
+	err = svc.repo.RenewLoan(ctx, loan)
+	if err != nil {
+		if err == repository.ErrLoanChanged {
+			return views.ErrorReponse(http.StatusConflict, views.M_LOAN_CHANGED, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, loanView(loan))
+}
+
+// GetUserLoans implements service.LoanSvc.
+func (svc *loanSvc) GetUserLoans(ctx context.Context, userId uuid.UUID, query *params.ListLoans) *views.Response {
+	return svc.getLoans(ctx, &repository.LoanFilter{UserId: userId, Status: query.Status}, query)
+}
+
+// GetBookLoans implements service.LoanSvc.
+func (svc *loanSvc) GetBookLoans(ctx context.Context, bookId uuid.UUID, query *params.ListLoans) *views.Response {
+	return svc.getLoans(ctx, &repository.LoanFilter{BookId: bookId, Status: query.Status}, query)
+}
+
+// GetOverdueLoans implements service.LoanSvc.
+func (svc *loanSvc) GetOverdueLoans(ctx context.Context, query *params.ListLoans) *views.Response {
+	return svc.getLoans(ctx, &repository.LoanFilter{DueBefore: time.Now()}, query)
+}
+
+func (svc *loanSvc) getLoans(ctx context.Context, filter *repository.LoanFilter, query *params.ListLoans) *views.Response {
+	page := repository.Page{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+		Cursor:   query.Cursor,
+		Sort:     query.Sort,
+	}
+	list, info, err := svc.repo.GetLoans(ctx, filter, &page)
+	if err != nil {
+		if err == repository.ErrInvalidCursor || err == repository.ErrInvalidSort {
+			return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_QUERY, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	loans := make([]views.Loan, 0, len(list))
+	for _, l := range list {
+		loans = append(loans, loanView(l))
+	}
+	return views.PagedResponse(http.StatusOK, views.M_OK, loans, &views.Pagination{
+		Total:      info.Total,
+		Page:       query.Page,
+		PageSize:   info.PageSize,
+		NextCursor: info.NextCursor,
+		PrevCursor: info.PrevCursor,
+	})
+}
+
+// getLoan returns the loan with the given id if user may see it, the
+// borrower or a member of staff.
+func (svc *loanSvc) getLoan(ctx context.Context, id uuid.UUID, user *common.CustomClaims) (*models.Loan, *views.Response) {
+	loan, err := svc.repo.GetLoanById(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, views.ErrorReponse(http.StatusNotFound, views.M_LOAN_NOT_FOUND, err)
+		}
+		return nil, views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if loan.UserId != user.Id && !user.IsStaff() {
+		return nil, views.ErrorReponse(http.StatusForbidden, views.M_FORBIDDEN, errNotYourLoan)
+	}
+	return loan, nil
+}
+
+func loanView(l *models.Loan) views.Loan {
+	now := time.Now()
+	return views.Loan{
+		Id:         l.Id,
+		CopyId:     l.CopyId,
+		Barcode:    l.Copy.Barcode,
+		BookId:     l.Copy.BookId,
+		Title:      l.Copy.Book.Title,
+		UserId:     l.UserId,
+		BorrowedAt: l.BorrowedAt,
+		DueAt:      l.DueAt,
+		ReturnedAt: l.ReturnedAt,
+		Renewals:   l.Renewals,
+		Overdue:    l.Overdue(now),
+		Fine:       l.Fine(now, config.GetFineDailyRate(), config.GetFineCap()),
+	}
+}
+
+func NewLoanSvc(repo repository.LoanRepo, copies repository.CopyRepo, users repository.UserRepo, holds repository.HoldRepo, ledger repository.LedgerRepo, tx repository.Transactor) service.LoanSvc {
+	return &loanSvc{
+		repo:   repo,
+		copies: copies,
+		users:  users,
+		holds:  holds,
+		ledger: ledger,
+		tx:     tx,
+	}
+}
@@ -0,0 +1,318 @@
+package circulation_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/circulation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type loanSvcTest struct {
+	repo    *repository.MockLoanRepo
+	copies  *repository.MockCopyRepo
+	users   *repository.MockUserRepo
+	holds   *repository.MockHoldRepo
+	ledger  *repository.MockLedgerRepo
+	service service.LoanSvc
+}
+
+func newLoanSvcTest(t *testing.T) loanSvcTest {
+	mockRepo := repository.NewMockLoanRepo(t)
+	mockCopies := repository.NewMockCopyRepo(t)
+	mockUsers := repository.NewMockUserRepo(t)
+	mockHolds := repository.NewMockHoldRepo(t)
+	mockLedger := repository.NewMockLedgerRepo(t)
+	mockTx := repository.NewMockTransactor(t)
+	mockTx.EXPECT().Transaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).Maybe()
+	loanSvc := circulation.NewLoanSvc(mockRepo, mockCopies, mockUsers, mockHolds, mockLedger, mockTx)
+	return loanSvcTest{
+		repo:    mockRepo,
+		copies:  mockCopies,
+		users:   mockUsers,
+		holds:   mockHolds,
+		ledger:  mockLedger,
+		service: loanSvc,
+	}
+}
+
+func TestLoanSvc_Checkout(t *testing.T) {
+	t.Run("success - it should lend the copy for the loan period", func(t *testing.T) {
+		t.Setenv("LOAN_PERIOD", "168h")
+		instance := newLoanSvcTest(t)
+		userId := uuid.New()
+		c := &models.Copy{Id: uuid.New(), Barcode: "B-0001", Status: models.CopyAvailable, Book: models.Book{Title: "Dune"}}
+
+		instance.copies.EXPECT().GetCopyByBarcode(mock.Anything, "B-0001").Return(c, nil)
+		instance.users.EXPECT().GetUserById(mock.Anything, userId).Return(&models.User{Id: userId}, nil)
+		instance.ledger.EXPECT().GetBalance(mock.Anything, userId).Return(0, nil)
+		instance.repo.EXPECT().CreateLoan(mock.Anything, mock.MatchedBy(func(l *models.Loan) bool {
+			return l.CopyId == c.Id && l.UserId == userId && l.DueAt.Sub(l.BorrowedAt) == 168*time.Hour
+		}), models.CopyAvailable).Return(nil)
+		res := instance.service.Checkout(context.Background(), &params.Checkout{Barcode: "B-0001", UserId: userId})
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+		payload := res.Payload.(views.Loan)
+		assert.Equal(t, "Dune", payload.Title)
+		assert.False(t, payload.Overdue)
+	})
+
+	t.Run("success - it should lend a copy on hold to its holder and fulfil the hold", func(t *testing.T) {
+		instance := newLoanSvcTest(t)
+		userId, holdId := uuid.New(), uuid.New()
+		c := &models.Copy{Id: uuid.New(), Status: models.CopyOnHold}
+
+		instance.copies.EXPECT().GetCopyByBarcode(mock.Anything, "B-0001").Return(c, nil)
+		instance.holds.EXPECT().GetReadyHold(mock.Anything, c.Id).Return(&models.Hold{Id: holdId, UserId: userId}, nil)
+		instance.users.EXPECT().GetUserById(mock.Anything, userId).Return(&models.User{Id: userId}, nil)
+		instance.ledger.EXPECT().GetBalance(mock.Anything, userId).Return(0, nil)
+		instance.repo.EXPECT().CreateLoan(mock.Anything, mock.Anything, models.CopyOnHold).Return(nil)
+		instance.holds.EXPECT().FulfillHold(mock.Anything, holdId).Return(nil)
+		res := instance.service.Checkout(context.Background(), &params.Checkout{Barcode: "B-0001", UserId: userId})
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+	})
+
+	t.Run("error - it should return 409 if the copy is kept for another member", func(t *testing.T) {
+		instance := newLoanSvcTest(t)
+		c := &models.Copy{Id: uuid.New(), Status: models.CopyOnHold}
+
+		instance.copies.EXPECT().GetCopyByBarcode(mock.Anything, mock.Anything).Return(c, nil)
+		instance.holds.EXPECT().GetReadyHold(mock.Anything, c.Id).Return(&models.Hold{UserId: uuid.New()}, nil)
+		res := instance.service.Checkout(context.Background(), &params.Checkout{Barcode: "B-0001", UserId: uuid.New()})
+
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_COPY_ON_HOLD, res.Message)
+	})
+
+	t.Run("error - it should return 409 if the copy is not available", func(t *testing.T) {
+		instance := newLoanSvcTest(t)
+		instance.copies.EXPECT().GetCopyByBarcode(mock.Anything, mock.Anything).Return(&models.Copy{Status: models.CopyInRepair}, nil)
+
+		res := instance.service.Checkout(context.Background(), &params.Checkout{Barcode: "B-0001", UserId: uuid.New()})
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_COPY_NOT_AVAILABLE, res.Message)
+	})
+
+	t.Run("error - it should return 409 if the copy was lent concurrently", func(t *testing.T) {
+		instance := newLoanSvcTest(t)
+		instance.copies.EXPECT().GetCopyByBarcode(mock.Anything, mock.Anything).Return(&models.Copy{Status: models.CopyAvailable}, nil)
+		instance.users.EXPECT().GetUserById(mock.Anything, mock.Anything).Return(&models.User{}, nil)
+		instance.ledger.EXPECT().GetBalance(mock.Anything, mock.Anything).Return(0, nil)
+		instance.repo.EXPECT().CreateLoan(mock.Anything, mock.Anything, mock.Anything).Return(repository.ErrCopyNotAvailable)
+
+		res := instance.service.Checkout(context.Background(), &params.Checkout{Barcode: "B-0001", UserId: uuid.New()})
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_COPY_NOT_AVAILABLE, res.Message)
+	})
+
+	t.Run("error - it should return 409 if the member owes more than the limit", func(t *testing.T) {
+		t.Setenv("FINE_BALANCE_LIMIT", "500")
+		instance := newLoanSvcTest(t)
+		userId := uuid.New()
+		instance.copies.EXPECT().GetCopyByBarcode(mock.Anything, mock.Anything).Return(&models.Copy{Status: models.CopyAvailable}, nil)
+		instance.users.EXPECT().GetUserById(mock.Anything, userId).Return(&models.User{Id: userId}, nil)
+		instance.ledger.EXPECT().GetBalance(mock.Anything, userId).Return(501, nil)
+
+		res := instance.service.Checkout(context.Background(), &params.Checkout{Barcode: "B-0001", UserId: userId})
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_BALANCE_LIMIT, res.Message)
+	})
+
+	t.Run("error - it should return 404 for an unknown user", func(t *testing.T) {
+		instance := newLoanSvcTest(t)
+		instance.copies.EXPECT().GetCopyByBarcode(mock.Anything, mock.Anything).Return(&models.Copy{Status: models.CopyAvailable}, nil)
+		instance.users.EXPECT().GetUserById(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.Checkout(context.Background(), &params.Checkout{Barcode: "B-0001", UserId: uuid.New()})
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_USER_NOT_FOUND, res.Message)
+	})
+
+	t.Run("error - it should return 400 for a due date in the past", func(t *testing.T) {
+		instance := newLoanSvcTest(t)
+		due := time.Now().Add(-time.Hour)
+
+		res := instance.service.Checkout(context.Background(), &params.Checkout{Barcode: "B-0001", UserId: uuid.New(), DueAt: &due})
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+		assert.Equal(t, views.M_INVALID_DUE_DATE, res.Message)
+	})
+}
+
+func TestLoanSvc_ReturnLoan(t *testing.T) {
+	t.Run("success - it should close the loan and charge the late fee", func(t *testing.T) {
+		t.Setenv("FINE_DAILY_RATE", "25")
+		instance := newLoanSvcTest(t)
+		loan := &models.Loan{Id: uuid.New(), UserId: uuid.New(), DueAt: time.Now().Add(-time.Hour)}
+
+		instance.repo.EXPECT().GetLoanById(mock.Anything, loan.Id).Return(loan, nil)
+		instance.repo.EXPECT().ReturnLoan(mock.Anything, loan).RunAndReturn(func(ctx context.Context, l *models.Loan) error {
+			now := time.Now()
+			l.ReturnedAt = &now
+			return nil
+		})
+		instance.ledger.EXPECT().SetFine(mock.Anything, loan.UserId, loan.Id, int64(25)).Return(nil)
+		instance.holds.EXPECT().AssignHolds(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		res := instance.service.ReturnLoan(context.Background(), loan.Id)
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		payload := res.Payload.(views.Loan)
+		assert.NotNil(t, payload.ReturnedAt)
+		assert.False(t, payload.Overdue)
+		assert.Equal(t, int64(25), payload.Fine)
+		assert.Nil(t, payload.HoldId)
+	})
+
+	t.Run("success - it should give the returned copy to the next hold", func(t *testing.T) {
+		t.Setenv("HOLD_PICKUP_WINDOW", "24h")
+		instance := newLoanSvcTest(t)
+		bookId, copyId, holdId := uuid.New(), uuid.New(), uuid.New()
+		loan := &models.Loan{Id: uuid.New(), CopyId: copyId, Copy: models.Copy{Id: copyId, BookId: bookId}, DueAt: time.Now().Add(time.Hour)}
+
+		instance.repo.EXPECT().GetLoanById(mock.Anything, loan.Id).Return(loan, nil)
+		instance.repo.EXPECT().ReturnLoan(mock.Anything, loan).Return(nil)
+		instance.holds.EXPECT().AssignHolds(mock.Anything, bookId, mock.MatchedBy(func(expires time.Time) bool {
+			return expires.After(time.Now().Add(23 * time.Hour))
+		})).Return([]*models.Hold{{Id: holdId, CopyId: &copyId}}, nil)
+		res := instance.service.ReturnLoan(context.Background(), loan.Id)
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, &holdId, res.Payload.(views.Loan).HoldId)
+	})
+
+	t.Run("error - it should return 409 if the loan was already returned", func(t *testing.T) {
+		instance := newLoanSvcTest(t)
+		instance.repo.EXPECT().GetLoanById(mock.Anything, mock.Anything).Return(&models.Loan{}, nil)
+		instance.repo.EXPECT().ReturnLoan(mock.Anything, mock.Anything).Return(repository.ErrLoanClosed)
+
+		res := instance.service.ReturnLoan(context.Background(), uuid.New())
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_LOAN_RETURNED, res.Message)
+	})
+}
+
+func TestLoanSvc_RenewLoan(t *testing.T) {
+	t.Run("success - it should extend the loan from its due date", func(t *testing.T) {
+		t.Setenv("LOAN_PERIOD", "24h")
+		instance := newLoanSvcTest(t)
+		userId := uuid.New()
+		due := time.Now().Add(48 * time.Hour)
+		loan := &models.Loan{Id: uuid.New(), UserId: userId, DueAt: due}
+
+		instance.repo.EXPECT().GetLoanById(mock.Anything, loan.Id).Return(loan, nil)
+		instance.holds.EXPECT().CountWaitingHolds(mock.Anything, mock.Anything).Return(0, nil)
+		instance.repo.EXPECT().RenewLoan(mock.Anything, mock.MatchedBy(func(l *models.Loan) bool {
+			return l.DueAt.Equal(due.Add(24 * time.Hour))
+		})).Return(nil)
+		res := instance.service.RenewLoan(context.Background(), loan.Id, &common.CustomClaims{Id: userId, Role: common.RoleMember})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+	})
+
+	t.Run("error - it should not renew an overdue loan, whose fine is charged in full on return", func(t *testing.T) {
+		t.Setenv("LOAN_PERIOD", "24h")
+		t.Setenv("FINE_DAILY_RATE", "25")
+		instance := newLoanSvcTest(t)
+		due := time.Now().Add(-71 * time.Hour)
+		loan := &models.Loan{Id: uuid.New(), UserId: uuid.New(), DueAt: due}
+
+		instance.repo.EXPECT().GetLoanById(mock.Anything, loan.Id).Return(loan, nil)
+		instance.holds.EXPECT().CountWaitingHolds(mock.Anything, mock.Anything).Return(0, nil)
+		res := instance.service.RenewLoan(context.Background(), loan.Id, &common.CustomClaims{Id: uuid.New(), Role: common.RoleLibrarian})
+
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_LOAN_OVERDUE, res.Message)
+		assert.True(t, loan.DueAt.Equal(due))
+
+		instance.repo.EXPECT().ReturnLoan(mock.Anything, loan).RunAndReturn(func(ctx context.Context, l *models.Loan) error {
+			now := time.Now()
+			l.ReturnedAt = &now
+			return nil
+		})
+		instance.ledger.EXPECT().SetFine(mock.Anything, loan.UserId, loan.Id, int64(75)).Return(nil)
+		instance.holds.EXPECT().AssignHolds(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		res = instance.service.ReturnLoan(context.Background(), loan.Id)
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, int64(75), res.Payload.(views.Loan).Fine)
+	})
+
+	t.Run("error - it should return 409 when the renewal limit is reached", func(t *testing.T) {
+		t.Setenv("MAX_RENEWALS", "1")
+		instance := newLoanSvcTest(t)
+		userId := uuid.New()
+		instance.repo.EXPECT().GetLoanById(mock.Anything, mock.Anything).Return(&models.Loan{UserId: userId, Renewals: 1}, nil)
+
+		res := instance.service.RenewLoan(context.Background(), uuid.New(), &common.CustomClaims{Id: userId})
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_RENEWAL_LIMIT, res.Message)
+	})
+
+	t.Run("error - it should return 409 when other members wait for the book", func(t *testing.T) {
+		instance := newLoanSvcTest(t)
+		userId, bookId := uuid.New(), uuid.New()
+		instance.repo.EXPECT().GetLoanById(mock.Anything, mock.Anything).Return(&models.Loan{UserId: userId, Copy: models.Copy{BookId: bookId}}, nil)
+		instance.holds.EXPECT().CountWaitingHolds(mock.Anything, bookId).Return(1, nil)
+
+		res := instance.service.RenewLoan(context.Background(), uuid.New(), &common.CustomClaims{Id: userId})
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_HOLDS_WAITING, res.Message)
+	})
+
+	t.Run("error - it should not renew the loans of other members", func(t *testing.T) {
+		instance := newLoanSvcTest(t)
+		instance.repo.EXPECT().GetLoanById(mock.Anything, mock.Anything).Return(&models.Loan{UserId: uuid.New()}, nil)
+
+		res := instance.service.RenewLoan(context.Background(), uuid.New(), &common.CustomClaims{Id: uuid.New(), Role: common.RoleMember})
+		assert.Equal(t, http.StatusForbidden, res.Status)
+	})
+}
+
+func TestLoanSvc_GetUserLoans(t *testing.T) {
+	t.Run("success - it should list the loans of the user", func(t *testing.T) {
+		instance := newLoanSvcTest(t)
+		userId := uuid.New()
+
+		instance.repo.EXPECT().GetLoans(mock.Anything, &repository.LoanFilter{UserId: userId, Status: repository.LoansCurrent}, mock.Anything).
+			Return([]*models.Loan{{Id: uuid.New(), UserId: userId, DueAt: time.Now().Add(-time.Hour)}}, &repository.PageInfo{Total: 1, PageSize: 20}, nil)
+		res := instance.service.GetUserLoans(context.Background(), userId, &params.ListLoans{Status: repository.LoansCurrent})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		loans := res.Payload.([]views.Loan)
+		assert.Len(t, loans, 1)
+		assert.True(t, loans[0].Overdue)
+		assert.Equal(t, int64(1), res.Meta.(*views.Pagination).Total)
+	})
+
+	t.Run("error - it should return 400 for an unknown sort field", func(t *testing.T) {
+		instance := newLoanSvcTest(t)
+		instance.repo.EXPECT().GetLoans(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, repository.ErrInvalidSort)
+
+		res := instance.service.GetUserLoans(context.Background(), uuid.New(), &params.ListLoans{Sort: "user_id"})
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+	})
+}
+
+func TestLoanSvc_GetOverdueLoans(t *testing.T) {
+	t.Run("success - it should only ask for loans past their due date", func(t *testing.T) {
+		instance := newLoanSvcTest(t)
+		instance.repo.EXPECT().GetLoans(mock.Anything, mock.MatchedBy(func(f *repository.LoanFilter) bool {
+			return !f.DueBefore.IsZero() && f.UserId == uuid.Nil
+		}), mock.Anything).Return([]*models.Loan{}, &repository.PageInfo{PageSize: 20}, nil)
+
+		res := instance.service.GetOverdueLoans(context.Background(), &params.ListLoans{})
+		assert.Equal(t, http.StatusOK, res.Status)
+	})
+}
@@ -0,0 +1,123 @@
+package citation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"gorm.io/gorm"
+)
+
+// maxIds bounds the books cited by one request.
+const maxIds = 100
+
+type citationSvc struct {
+	books   repository.BookRepo
+	shelves repository.ShelfRepo
+}
+
+// GetCitation implements service.CitationSvc.
+func (svc *citationSvc) GetCitation(ctx context.Context, id uuid.UUID) *views.Response {
+	book, err := svc.books.GetBookById(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_BOOK_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, []*models.Book{book})
+}
+
+// GetCitations implements service.CitationSvc. The books of a list are cited
+// in its order, a book listed twice once, and those of a shelf in the order
+// of the shelf.
+func (svc *citationSvc) GetCitations(ctx context.Context, query *params.Citations, user *common.CustomClaims) *views.Response {
+	var ids []uuid.UUID
+	if query.ShelfId != "" {
+		shelfIds, resp := svc.shelfBooks(ctx, uuid.MustParse(query.ShelfId), user)
+		if resp != nil {
+			return resp
+		}
+		ids = shelfIds
+	} else {
+		listIds, err := parseIds(query.Ids)
+		if err != nil {
+			return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_QUERY, err)
+		}
+		ids = listIds
+	}
+
+	books, err := svc.books.GetBooksByIds(ctx, ids)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if query.ShelfId == "" && len(books) < len(ids) {
+		return views.ErrorReponse(http.StatusNotFound, views.M_BOOK_NOT_FOUND, gorm.ErrRecordNotFound)
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, books)
+}
+
+// shelfBooks returns the ids of the books of a shelf of the user, or of a
+// public shelf. The private shelves of others are not found.
+func (svc *citationSvc) shelfBooks(ctx context.Context, id uuid.UUID, user *common.CustomClaims) ([]uuid.UUID, *views.Response) {
+	shelf, err := svc.shelves.GetShelfById(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, views.ErrorReponse(http.StatusNotFound, views.M_SHELF_NOT_FOUND, err)
+		}
+		return nil, views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if shelf.UserId != user.Id && !shelf.Public {
+		return nil, views.ErrorReponse(http.StatusNotFound, views.M_SHELF_NOT_FOUND, gorm.ErrRecordNotFound)
+	}
+	items, err := svc.shelves.GetShelfItems(ctx, shelf.Id)
+	if err != nil {
+		return nil, views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.BookId)
+	}
+	return ids, nil
+}
+
+// parseIds parses a comma separated list of book ids, dropping repeats.
+func parseIds(list string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	seen := map[uuid.UUID]bool{}
+	for _, field := range strings.Split(list, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		id, err := uuid.Parse(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid book id %q", field)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no book ids")
+	}
+	if len(ids) > maxIds {
+		return nil, fmt.Errorf("at most %d books can be cited at once", maxIds)
+	}
+	return ids, nil
+}
+
+func NewCitationSvc(books repository.BookRepo, shelves repository.ShelfRepo) service.CitationSvc {
+	return &citationSvc{
+		books:   books,
+		shelves: shelves,
+	}
+}
@@ -0,0 +1,127 @@
+package citation_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/citation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type citationSvcTest struct {
+	books   *repository.MockBookRepo
+	shelves *repository.MockShelfRepo
+	service service.CitationSvc
+}
+
+func newCitationSvcTest(t *testing.T) citationSvcTest {
+	mockBooks := repository.NewMockBookRepo(t)
+	mockShelves := repository.NewMockShelfRepo(t)
+	citationSvc := citation.NewCitationSvc(mockBooks, mockShelves)
+	return citationSvcTest{
+		books:   mockBooks,
+		shelves: mockShelves,
+		service: citationSvc,
+	}
+}
+
+func TestCitationSvc_GetCitation(t *testing.T) {
+	t.Run("success - it should return the book", func(t *testing.T) {
+		instance := newCitationSvcTest(t)
+		book := &models.Book{Id: uuid.New(), Title: "Dune"}
+
+		instance.books.EXPECT().GetBookById(mock.Anything, book.Id).Return(book, nil)
+		res := instance.service.GetCitation(context.Background(), book.Id)
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, []*models.Book{book}, res.Payload)
+	})
+
+	t.Run("error - it should return 404 for an unknown book", func(t *testing.T) {
+		instance := newCitationSvcTest(t)
+		instance.books.EXPECT().GetBookById(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.GetCitation(context.Background(), uuid.New())
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_BOOK_NOT_FOUND, res.Message)
+	})
+}
+
+func TestCitationSvc_GetCitations(t *testing.T) {
+	user := &common.CustomClaims{Id: uuid.New()}
+
+	t.Run("success - it should cite the listed books once, in order", func(t *testing.T) {
+		instance := newCitationSvcTest(t)
+		first := &models.Book{Id: uuid.New(), Title: "Dune"}
+		second := &models.Book{Id: uuid.New(), Title: "Emma"}
+
+		instance.books.EXPECT().GetBooksByIds(mock.Anything, []uuid.UUID{second.Id, first.Id}).Return([]*models.Book{second, first}, nil)
+		res := instance.service.GetCitations(context.Background(), &params.Citations{
+			Ids: second.Id.String() + ", " + first.Id.String() + "," + second.Id.String(),
+		}, user)
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, []*models.Book{second, first}, res.Payload)
+	})
+
+	t.Run("success - it should cite the books of a public shelf", func(t *testing.T) {
+		instance := newCitationSvcTest(t)
+		shelf := &models.Shelf{Id: uuid.New(), UserId: uuid.New(), Public: true}
+		book := &models.Book{Id: uuid.New(), Title: "Dune"}
+
+		instance.shelves.EXPECT().GetShelfById(mock.Anything, shelf.Id).Return(shelf, nil)
+		instance.shelves.EXPECT().GetShelfItems(mock.Anything, shelf.Id).Return([]*models.ShelfItem{{ShelfId: shelf.Id, BookId: book.Id}}, nil)
+		instance.books.EXPECT().GetBooksByIds(mock.Anything, []uuid.UUID{book.Id}).Return([]*models.Book{book}, nil)
+		res := instance.service.GetCitations(context.Background(), &params.Citations{ShelfId: shelf.Id.String()}, user)
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, []*models.Book{book}, res.Payload)
+	})
+
+	t.Run("error - it should not find the private shelf of another user", func(t *testing.T) {
+		instance := newCitationSvcTest(t)
+		shelf := &models.Shelf{Id: uuid.New(), UserId: uuid.New()}
+
+		instance.shelves.EXPECT().GetShelfById(mock.Anything, shelf.Id).Return(shelf, nil)
+		res := instance.service.GetCitations(context.Background(), &params.Citations{ShelfId: shelf.Id.String()}, user)
+
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_SHELF_NOT_FOUND, res.Message)
+	})
+
+	t.Run("error - it should return 404 when a listed book is unknown", func(t *testing.T) {
+		instance := newCitationSvcTest(t)
+		book := &models.Book{Id: uuid.New(), Title: "Dune"}
+
+		instance.books.EXPECT().GetBooksByIds(mock.Anything, mock.Anything).Return([]*models.Book{book}, nil)
+		res := instance.service.GetCitations(context.Background(), &params.Citations{Ids: book.Id.String() + "," + uuid.NewString()}, user)
+
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_BOOK_NOT_FOUND, res.Message)
+	})
+
+	t.Run("error - it should return 400 for an invalid list", func(t *testing.T) {
+		instance := newCitationSvcTest(t)
+		many := make([]string, 101)
+		for i := range many {
+			many[i] = uuid.NewString()
+		}
+
+		for _, ids := range []string{"dune", " , ", strings.Join(many, ",")} {
+			res := instance.service.GetCitations(context.Background(), &params.Citations{Ids: ids}, user)
+			assert.Equal(t, http.StatusBadRequest, res.Status)
+			assert.Equal(t, views.M_INVALID_QUERY, res.Message)
+		}
+	})
+}
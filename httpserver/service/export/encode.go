@@ -0,0 +1,284 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/marc"
+)
+
+const (
+	FormatCsv    = "csv"
+	FormatNdjson = "ndjson"
+	FormatXlsx   = "xlsx"
+	// FormatMarc and FormatMarcxml only hold books.
+	FormatMarc    = "marc"
+	FormatMarcxml = "marcxml"
+)
+
+// encoder writes the rows of an export file. The values of a row are
+// strings, lists of strings, numbers, times or nil for no value, in the
+// order of the columns.
+type encoder interface {
+	// begin writes the header of the file.
+	begin(columns []string) error
+	row(values []interface{}) error
+	// end completes the file and flushes it.
+	end() error
+}
+
+// bookEncoder writes a record per book rather than the columns of a row.
+type bookEncoder interface {
+	encoder
+	book(b *models.Book) error
+}
+
+func newEncoder(format string, w io.Writer, sheet string) (encoder, error) {
+	switch format {
+	case FormatCsv, "":
+		return &csvEncoder{w: csv.NewWriter(w)}, nil
+	case FormatNdjson:
+		return &ndjsonEncoder{w: bufio.NewWriter(w)}, nil
+	case FormatXlsx:
+		return &xlsxEncoder{zw: zip.NewWriter(w), sheet: sheet}, nil
+	case FormatMarc:
+		buf := bufio.NewWriter(w)
+		return &marcEncoder{w: marc.NewWriter(buf), flush: buf.Flush}, nil
+	case FormatMarcxml:
+		xw := marc.NewXMLWriter(w)
+		return &marcEncoder{w: xw, flush: xw.Close}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// text returns a value as written in a CSV or spreadsheet cell. Lists are
+// separated by semicolons, the way imports read them.
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, "; ")
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprint(value)
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) begin(columns []string) error {
+	return e.w.Write(columns)
+}
+
+func (e *csvEncoder) row(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = text(value)
+	}
+	return e.w.Write(record)
+}
+
+func (e *csvEncoder) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// ndjsonEncoder writes a JSON object per row, with the keys in the order of
+// the columns.
+type ndjsonEncoder struct {
+	w       *bufio.Writer
+	columns []string
+}
+
+func (e *ndjsonEncoder) begin(columns []string) error {
+	e.columns = make([]string, len(columns))
+	for i, column := range columns {
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		e.columns[i] = string(key)
+	}
+	return nil
+}
+
+func (e *ndjsonEncoder) row(values []interface{}) error {
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, value := range values {
+		if list, ok := value.([]string); ok && list == nil {
+			value = []string{}
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		line.WriteString(e.columns[i])
+		line.WriteByte(':')
+		line.Write(data)
+	}
+	line.WriteString("}\n")
+	_, err := e.w.Write(line.Bytes())
+	return err
+}
+
+func (e *ndjsonEncoder) end() error {
+	return e.w.Flush()
+}
+
+// xlsxEncoder writes a workbook of one sheet. The parts describing the
+// workbook are written first, so that the sheet can be streamed as the last
+// entry of the zip file. Cells hold numbers or inline strings, which need no
+// shared strings table.
+type xlsxEncoder struct {
+	zw    *zip.Writer
+	sheet string
+	w     *bufio.Writer
+	rows  int
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+func (e *xlsxEncoder) begin(columns []string) error {
+	var name bytes.Buffer
+	if err := xml.EscapeText(&name, []byte(e.sheet)); err != nil {
+		return err
+	}
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, name.String())},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		f, err := e.zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+	f, err := e.zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	e.w = bufio.NewWriter(f)
+	if _, err := e.w.WriteString(xlsxSheetStart); err != nil {
+		return err
+	}
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+	return e.row(header)
+}
+
+func (e *xlsxEncoder) row(values []interface{}) error {
+	e.rows++
+	fmt.Fprintf(e.w, `<row r="%d">`, e.rows)
+	for i, value := range values {
+		ref := cellName(i) + strconv.Itoa(e.rows)
+		switch v := value.(type) {
+		case int64, float64:
+			fmt.Fprintf(e.w, `<c r="%s"><v>%s</v></c>`, ref, text(v))
+		default:
+			fmt.Fprintf(e.w, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			if err := xml.EscapeText(e.w, []byte(text(v))); err != nil {
+				return err
+			}
+			e.w.WriteString(`</t></is></c>`)
+		}
+	}
+	_, err := e.w.WriteString(`</row>`)
+	return err
+}
+
+func (e *xlsxEncoder) end() error {
+	if _, err := e.w.WriteString(xlsxSheetEnd); err != nil {
+		return err
+	}
+	if err := e.w.Flush(); err != nil {
+		return err
+	}
+	return e.zw.Close()
+}
+
+// marcEncoder writes the MARC record of each book.
+type marcEncoder struct {
+	w interface {
+		Write(rec *marc.Record) error
+	}
+	flush func() error
+}
+
+func (e *marcEncoder) begin(columns []string) error {
+	return nil
+}
+
+func (e *marcEncoder) row(values []interface{}) error {
+	return errors.New("MARC exports only hold books")
+}
+
+func (e *marcEncoder) book(b *models.Book) error {
+	return e.w.Write(marc.FromBook(b))
+}
+
+func (e *marcEncoder) end() error {
+	return e.flush()
+}
+
+// cellName returns the letters of the column i, counted from zero: A to Z,
+// then AA, AB and so on.
+func cellName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
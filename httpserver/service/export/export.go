@@ -0,0 +1,183 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/isbn"
+)
+
+// bookColumns name the contributors after the fields of the book imports,
+// so that an export can be imported again.
+var (
+	bookColumns   = []string{"id", "title", "isbn", "authors", "editors", "translators", "illustrators", "rating", "rating_count", "created_at", "updated_at"}
+	authorColumns = []string{"id", "name", "birthdate", "created_at", "updated_at"}
+)
+
+type exportSvc struct {
+	books   repository.BookRepo
+	authors repository.AuthorRepo
+}
+
+// ExportBooks implements service.ExportSvc.
+func (svc *exportSvc) ExportBooks(ctx context.Context, query *params.ExportBooks, w io.Writer) *views.Response {
+	filter := repository.BookFilter{
+		Role:        query.Role,
+		Title:       query.Title,
+		Isbn:        query.Isbn,
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+	}
+	if code, err := isbn.Normalize(query.Isbn); err == nil {
+		filter.Isbn = code
+	}
+	if query.AuthorId != "" {
+		filter.AuthorId = uuid.MustParse(query.AuthorId)
+	}
+	if query.UserId != "" {
+		filter.UserId = uuid.MustParse(query.UserId)
+	}
+	enc, err := newEncoder(query.Format, w, "Books")
+	if err != nil {
+		return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_QUERY, err)
+	}
+
+	file := &exportFile{enc: enc, columns: bookColumns}
+	err = svc.books.EachBook(ctx, &filter, query.Sort, func(books []*models.Book) error {
+		for _, b := range books {
+			if err := file.book(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return file.close(err, "books")
+}
+
+// ExportAuthors implements service.ExportSvc.
+func (svc *exportSvc) ExportAuthors(ctx context.Context, query *params.ExportAuthors, w io.Writer) *views.Response {
+	filter := repository.AuthorFilter{
+		NamePrefix: query.NamePrefix,
+		Name:       query.Name,
+		BornFrom:   query.BornFrom,
+		BornTo:     query.BornTo,
+	}
+	if query.Format == FormatMarc || query.Format == FormatMarcxml {
+		return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_QUERY, errors.New("MARC exports only hold books"))
+	}
+	enc, err := newEncoder(query.Format, w, "Authors")
+	if err != nil {
+		return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_QUERY, err)
+	}
+
+	file := &exportFile{enc: enc, columns: authorColumns}
+	err = svc.authors.EachAuthor(ctx, &filter, query.Sort, func(authors []*models.Author) error {
+		for _, a := range authors {
+			// Authors created by book imports have no birthdate.
+			var birthdate interface{}
+			if !a.Birthdate.IsZero() {
+				birthdate = a.Birthdate.Format(time.DateOnly)
+			}
+			if err := file.row([]interface{}{a.Id.String(), a.Name, birthdate, a.CreatedAt, a.UpdatedAt}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return file.close(err, "authors")
+}
+
+// bookValues returns the columns of a book, its contributors listed by role
+// in the order of the book.
+func bookValues(b *models.Book) []interface{} {
+	names := make(map[string][]string, len(models.ContributorRoles))
+	for _, c := range b.Contributors {
+		names[c.Role] = append(names[c.Role], c.Author.Name)
+	}
+	return []interface{}{
+		b.Id.String(),
+		b.Title,
+		b.Isbn,
+		names[models.ContributorAuthor],
+		names[models.ContributorEditor],
+		names[models.ContributorTranslator],
+		names[models.ContributorIllustrator],
+		math.Round(b.RatingAvg*100) / 100,
+		b.RatingCount,
+		b.CreatedAt,
+		b.UpdatedAt,
+	}
+}
+
+// exportFile begins the file at its first row, so that an invalid query is
+// still answered with an error response rather than an empty file.
+type exportFile struct {
+	enc     encoder
+	columns []string
+	begun   bool
+}
+
+func (f *exportFile) row(values []interface{}) error {
+	if !f.begun {
+		f.begun = true
+		if err := f.enc.begin(f.columns); err != nil {
+			return err
+		}
+	}
+	return f.enc.row(values)
+}
+
+// book writes the record of a book, or its row.
+func (f *exportFile) book(b *models.Book) error {
+	enc, ok := f.enc.(bookEncoder)
+	if !ok {
+		return f.row(bookValues(b))
+	}
+	if !f.begun {
+		f.begun = true
+		if err := enc.begin(f.columns); err != nil {
+			return err
+		}
+	}
+	return enc.book(b)
+}
+
+// close ends the file unless err stopped the export. Once the file has begun
+// the client already has part of it, so the error is only logged.
+func (f *exportFile) close(err error, name string) *views.Response {
+	if err == nil && !f.begun {
+		f.begun = true
+		err = f.enc.begin(f.columns)
+	}
+	if err == nil {
+		err = f.enc.end()
+	}
+	if err == nil {
+		return nil
+	}
+	if err == repository.ErrInvalidSort {
+		return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_QUERY, err)
+	}
+	if f.begun {
+		log.Printf("Failed to export %s : %v", name, err)
+	}
+	return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+}
+
+func NewExportSvc(books repository.BookRepo, authors repository.AuthorRepo) service.ExportSvc {
+	return &exportSvc{
+		books:   books,
+		authors: authors,
+	}
+}
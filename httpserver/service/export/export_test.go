@@ -0,0 +1,224 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/export"
+	"github.com/storyofhis/books-management/marc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type exportSvcTest struct {
+	books   *repository.MockBookRepo
+	authors *repository.MockAuthorRepo
+	service service.ExportSvc
+}
+
+func newExportSvcTest(t *testing.T) exportSvcTest {
+	mockBooks := repository.NewMockBookRepo(t)
+	mockAuthors := repository.NewMockAuthorRepo(t)
+	exportSvc := export.NewExportSvc(mockBooks, mockAuthors)
+	return exportSvcTest{
+		books:   mockBooks,
+		authors: mockAuthors,
+		service: exportSvc,
+	}
+}
+
+func dune() *models.Book {
+	created := time.Date(2024, 9, 18, 10, 0, 0, 0, time.UTC)
+	return &models.Book{
+		Id:    uuid.New(),
+		Title: `Dune, "the" <novel>`,
+		Isbn:  "9780441013593",
+		Contributors: []models.BookContributor{
+			{Role: models.ContributorAuthor, Author: models.Author{Name: "Frank Herbert"}},
+			{Role: models.ContributorEditor, Author: models.Author{Name: "Sterling Lanier"}},
+			{Role: models.ContributorAuthor, Author: models.Author{Name: "Brian Herbert"}},
+		},
+		RatingAvg:   4.256,
+		RatingCount: 3,
+		CreatedAt:   created,
+		UpdatedAt:   created,
+	}
+}
+
+// batches returns an EachBook implementation calling fn with each batch.
+func batches(list ...[]*models.Book) func(context.Context, *repository.BookFilter, string, func([]*models.Book) error) error {
+	return func(_ context.Context, _ *repository.BookFilter, _ string, fn func([]*models.Book) error) error {
+		for _, books := range list {
+			if err := fn(books); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestExportSvc_ExportBooks(t *testing.T) {
+	t.Run("success - it should write the books as CSV", func(t *testing.T) {
+		instance := newExportSvcTest(t)
+		book := dune()
+
+		instance.books.EXPECT().EachBook(mock.Anything, mock.MatchedBy(func(f *repository.BookFilter) bool {
+			return f.Isbn == "9780441013593" && f.Title == "dune"
+		}), "title", mock.Anything).RunAndReturn(batches([]*models.Book{book}, []*models.Book{dune()}))
+		var out bytes.Buffer
+		res := instance.service.ExportBooks(context.Background(), &params.ExportBooks{Format: export.FormatCsv, Sort: "title", Title: "dune", Isbn: "0-441-01359-7"}, &out)
+
+		assert.Nil(t, res)
+		records, err := csv.NewReader(&out).ReadAll()
+		assert.NoError(t, err)
+		assert.Len(t, records, 3)
+		assert.Equal(t, []string{"id", "title", "isbn", "authors", "editors", "translators", "illustrators", "rating", "rating_count", "created_at", "updated_at"}, records[0])
+		assert.Equal(t, []string{book.Id.String(), book.Title, "9780441013593", "Frank Herbert; Brian Herbert", "Sterling Lanier", "", "", "4.26", "3", "2024-09-18T10:00:00Z", "2024-09-18T10:00:00Z"}, records[1])
+	})
+
+	t.Run("success - it should write a JSON object per book", func(t *testing.T) {
+		instance := newExportSvcTest(t)
+		instance.books.EXPECT().EachBook(mock.Anything, mock.Anything, "", mock.Anything).RunAndReturn(batches([]*models.Book{dune()}))
+
+		var out bytes.Buffer
+		res := instance.service.ExportBooks(context.Background(), &params.ExportBooks{Format: export.FormatNdjson}, &out)
+
+		assert.Nil(t, res)
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		assert.Len(t, lines, 1)
+		var row map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &row))
+		assert.Equal(t, []interface{}{"Frank Herbert", "Brian Herbert"}, row["authors"])
+		assert.Equal(t, []interface{}{}, row["translators"])
+		assert.Equal(t, 4.26, row["rating"])
+		assert.True(t, strings.HasPrefix(lines[0], `{"id":`))
+	})
+
+	t.Run("success - it should write a workbook", func(t *testing.T) {
+		instance := newExportSvcTest(t)
+		instance.books.EXPECT().EachBook(mock.Anything, mock.Anything, mock.Anything, mock.Anything).RunAndReturn(batches([]*models.Book{dune()}))
+
+		var out bytes.Buffer
+		res := instance.service.ExportBooks(context.Background(), &params.ExportBooks{Format: export.FormatXlsx}, &out)
+
+		assert.Nil(t, res)
+		zr, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+		assert.NoError(t, err)
+		var names []string
+		var sheet string
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+			if f.Name == "xl/worksheets/sheet1.xml" {
+				r, _ := f.Open()
+				data, _ := io.ReadAll(r)
+				sheet = string(data)
+			}
+		}
+		assert.Contains(t, names, "[Content_Types].xml")
+		assert.Contains(t, names, "xl/workbook.xml")
+		assert.Contains(t, sheet, `<row r="2">`)
+		assert.Contains(t, sheet, `Dune, &#34;the&#34; &lt;novel&gt;`)
+		assert.Contains(t, sheet, `<c r="H2"><v>4.26</v></c>`)
+		assert.True(t, strings.HasSuffix(sheet, `</sheetData></worksheet>`))
+	})
+
+	t.Run("success - it should write a MARC record per book", func(t *testing.T) {
+		instance := newExportSvcTest(t)
+		book := dune()
+		instance.books.EXPECT().EachBook(mock.Anything, mock.Anything, mock.Anything, mock.Anything).RunAndReturn(batches([]*models.Book{book}, []*models.Book{dune()}))
+
+		var out bytes.Buffer
+		res := instance.service.ExportBooks(context.Background(), &params.ExportBooks{Format: export.FormatMarcxml}, &out)
+
+		assert.Nil(t, res)
+		r := marc.NewXMLReader(&out)
+		rec, err := r.Read()
+		assert.NoError(t, err)
+		got := marc.Book(rec)
+		assert.Equal(t, book.Title, got.Title)
+		assert.Equal(t, "Frank Herbert", got.Contributors[0].Author.Name)
+		_, err = r.Read()
+		assert.NoError(t, err)
+		_, err = r.Read()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("success - it should write the header of an empty export", func(t *testing.T) {
+		instance := newExportSvcTest(t)
+		instance.books.EXPECT().EachBook(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		var out bytes.Buffer
+		res := instance.service.ExportBooks(context.Background(), &params.ExportBooks{}, &out)
+
+		assert.Nil(t, res)
+		assert.Equal(t, "id,title,isbn,authors,editors,translators,illustrators,rating,rating_count,created_at,updated_at\n", out.String())
+	})
+
+	t.Run("error - it should return 400 without writing for an invalid sort", func(t *testing.T) {
+		instance := newExportSvcTest(t)
+		instance.books.EXPECT().EachBook(mock.Anything, mock.Anything, "nope", mock.Anything).Return(repository.ErrInvalidSort)
+
+		var out bytes.Buffer
+		res := instance.service.ExportBooks(context.Background(), &params.ExportBooks{Sort: "nope"}, &out)
+
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+		assert.Equal(t, views.M_INVALID_QUERY, res.Message)
+		assert.Zero(t, out.Len())
+	})
+
+	t.Run("error - it should stop at a failing batch", func(t *testing.T) {
+		instance := newExportSvcTest(t)
+		instance.books.EXPECT().EachBook(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, filter *repository.BookFilter, sort string, fn func([]*models.Book) error) error {
+				if err := fn([]*models.Book{dune()}); err != nil {
+					return err
+				}
+				return errors.New("connection lost")
+			})
+
+		var out bytes.Buffer
+		res := instance.service.ExportBooks(context.Background(), &params.ExportBooks{Format: export.FormatNdjson}, &out)
+
+		assert.Equal(t, http.StatusInternalServerError, res.Status)
+	})
+}
+
+func TestExportSvc_ExportAuthors(t *testing.T) {
+	t.Run("success - it should leave unknown birthdates empty", func(t *testing.T) {
+		instance := newExportSvcTest(t)
+		created := time.Date(2024, 9, 18, 10, 0, 0, 0, time.UTC)
+		authors := []*models.Author{
+			{Id: uuid.New(), Name: "Ursula K. Le Guin", Birthdate: time.Date(1929, 10, 21, 0, 0, 0, 0, time.UTC), CreatedAt: created, UpdatedAt: created},
+			{Id: uuid.New(), Name: "Homer", CreatedAt: created, UpdatedAt: created},
+		}
+
+		instance.authors.EXPECT().EachAuthor(mock.Anything, mock.MatchedBy(func(f *repository.AuthorFilter) bool {
+			return f.NamePrefix == "u"
+		}), "-birthdate", mock.Anything).RunAndReturn(func(ctx context.Context, filter *repository.AuthorFilter, sort string, fn func([]*models.Author) error) error {
+			return fn(authors)
+		})
+		var out bytes.Buffer
+		res := instance.service.ExportAuthors(context.Background(), &params.ExportAuthors{Format: export.FormatNdjson, NamePrefix: "u", Sort: "-birthdate"}, &out)
+
+		assert.Nil(t, res)
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		assert.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `"birthdate":"1929-10-21"`)
+		assert.Contains(t, lines[1], `"birthdate":null`)
+	})
+}
@@ -0,0 +1,122 @@
+// Package history builds the history entries recorded by the book and
+// author services and their views.
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+)
+
+// NewEntry returns the history entry of a change from before to after, which
+// are the JSON encodable states of the record. before is nil for a created
+// record. Updates that change nothing return a nil entry.
+func NewEntry(entityType string, entityId uuid.UUID, action string, actorId uuid.UUID, before, after interface{}) (*models.HistoryEntry, error) {
+	changes, err := Diff(before, after)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 && (action == models.HistoryUpdate || action == models.HistoryRevert) {
+		return nil, nil
+	}
+
+	encodedChanges, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := json.Marshal(after)
+	if err != nil {
+		return nil, err
+	}
+	return &models.HistoryEntry{
+		EntityType: entityType,
+		EntityId:   entityId,
+		Action:     action,
+		ActorId:    actorId,
+		Changes:    string(encodedChanges),
+		Snapshot:   string(snapshot),
+	}, nil
+}
+
+// Diff compares the top level fields of the JSON encodings of before and
+// after, in field order.
+func Diff(before, after interface{}) ([]models.FieldChange, error) {
+	from, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(to))
+	for name := range to {
+		names = append(names, name)
+	}
+	for name := range from {
+		if _, ok := to[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := make([]models.FieldChange, 0)
+	for _, name := range names {
+		old, ok := from[name]
+		if !ok {
+			old = json.RawMessage("null")
+		}
+		cur, ok := to[name]
+		if !ok {
+			cur = json.RawMessage("null")
+		}
+		if bytes.Equal(old, cur) {
+			continue
+		}
+		changes = append(changes, models.FieldChange{Field: name, From: old, To: cur})
+	}
+	return changes, nil
+}
+
+func fields(state interface{}) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if state == nil {
+		return fields, nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	return fields, json.Unmarshal(data, &fields)
+}
+
+// Views converts history entries to their views.
+func Views(entries []*models.HistoryEntry) ([]views.HistoryEntry, error) {
+	list := make([]views.HistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		changes := make([]views.FieldChange, 0)
+		if entry.Changes != "" {
+			if err := json.Unmarshal([]byte(entry.Changes), &changes); err != nil {
+				return nil, err
+			}
+		}
+		var snapshot json.RawMessage
+		if entry.Snapshot != "" {
+			snapshot = json.RawMessage(entry.Snapshot)
+		}
+		list = append(list, views.HistoryEntry{
+			Revision:  entry.Version,
+			Action:    entry.Action,
+			ActorId:   entry.ActorId,
+			Changes:   changes,
+			Snapshot:  snapshot,
+			CreatedAt: entry.CreatedAt,
+		})
+	}
+	return list, nil
+}
@@ -0,0 +1,51 @@
+package history_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service/history"
+	"github.com/stretchr/testify/assert"
+)
+
+type state struct {
+	Title string `json:"title"`
+	Isbn  string `json:"isbn"`
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("success - it should list the changed fields in order", func(t *testing.T) {
+		changes, err := history.Diff(&state{Title: "Old", Isbn: "1"}, &state{Title: "New", Isbn: "1"})
+		assert.NoError(t, err)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, "title", changes[0].Field)
+		assert.JSONEq(t, `"Old"`, string(changes[0].From))
+		assert.JSONEq(t, `"New"`, string(changes[0].To))
+	})
+
+	t.Run("success - it should diff a created record against null", func(t *testing.T) {
+		changes, err := history.Diff(nil, &state{Title: "New", Isbn: "1"})
+		assert.NoError(t, err)
+		assert.Len(t, changes, 2)
+		assert.Equal(t, "isbn", changes[0].Field)
+		assert.JSONEq(t, `null`, string(changes[0].From))
+	})
+}
+
+func TestNewEntry(t *testing.T) {
+	t.Run("success - it should skip updates that change nothing", func(t *testing.T) {
+		s := &state{Title: "Same"}
+		entry, err := history.NewEntry(models.HistoryBook, uuid.New(), models.HistoryUpdate, uuid.New(), s, s)
+		assert.NoError(t, err)
+		assert.Nil(t, entry)
+	})
+
+	t.Run("success - it should keep deletes that change nothing", func(t *testing.T) {
+		s := &state{Title: "Same"}
+		entry, err := history.NewEntry(models.HistoryBook, uuid.New(), models.HistoryDelete, uuid.New(), s, s)
+		assert.NoError(t, err)
+		assert.Equal(t, models.HistoryDelete, entry.Action)
+		assert.JSONEq(t, `{"title":"Same","isbn":""}`, entry.Snapshot)
+	})
+}
@@ -0,0 +1,399 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/config"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/author"
+	"github.com/storyofhis/books-management/httpserver/service/book"
+	"github.com/storyofhis/books-management/isbn"
+)
+
+const (
+	rowCreated = "created"
+	// rowValid is a row a dry run would create.
+	rowValid     = "valid"
+	rowDuplicate = "duplicate"
+	rowInvalid   = "invalid"
+)
+
+var (
+	errInvalidRows = errors.New("the import has invalid rows, nothing was imported")
+	errMarcAuthors = errors.New("MARC imports only hold books")
+)
+
+// roleFields are the fields of the book imports listing contributors, by
+// role.
+var roleFields = []struct{ field, role string }{
+	{"authors", models.ContributorAuthor},
+	{"editors", models.ContributorEditor},
+	{"translators", models.ContributorTranslator},
+	{"illustrators", models.ContributorIllustrator},
+}
+
+type importerSvc struct {
+	books   repository.BookRepo
+	authors repository.AuthorRepo
+	history repository.HistoryRepo
+	tx      repository.Transactor
+}
+
+// bookRow is a book to import with the names of its contributors, resolved
+// to authors before the book is created.
+type bookRow struct {
+	report *views.ImportRow
+	book   models.Book
+	names  []string
+}
+
+// ImportBooks implements service.ImportSvc.
+func (svc *importerSvc) ImportBooks(ctx context.Context, file io.Reader, req *params.Import, user *common.CustomClaims) *views.Response {
+	records, err := readRecords(file, req.Format, bookFields, req.Mapping, config.GetImportMaxRows())
+	if err != nil {
+		return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_IMPORT, err)
+	}
+	reports := make([]*views.ImportRow, 0, len(records))
+	rows := make([]*bookRow, 0, len(records))
+	var isbns, names []string
+	for _, rec := range records {
+		row := &bookRow{report: &views.ImportRow{Row: rec.row}}
+		reports = append(reports, row.report)
+		row.book, row.names, row.report.Errors = parseBook(rec)
+		if len(row.report.Errors) > 0 {
+			continue
+		}
+		rows = append(rows, row)
+		isbns = append(isbns, row.book.Isbn)
+		names = append(names, row.names...)
+	}
+
+	existing, err := svc.books.GetBooksByIsbns(ctx, isbns)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	byIsbn := make(map[string]*views.ImportRow, len(existing)+len(rows))
+	for _, b := range existing {
+		id := b.Id
+		byIsbn[b.Isbn] = &views.ImportRow{Id: &id}
+	}
+	authors, err := svc.authorsByName(ctx, names)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	// newAuthors are the authors to create, by lower case name, spelled as
+	// they were first met.
+	newAuthors := make(map[string]*models.Author)
+	var created []*bookRow
+	var authorNames []string
+	for _, row := range rows {
+		if first, ok := byIsbn[row.book.Isbn]; ok {
+			row.report.Status = rowDuplicate
+			row.report.Id = first.Id
+			if first.Row > 0 {
+				row.report.Errors = []string{fmt.Sprintf("same ISBN as row %d", first.Row)}
+			}
+			continue
+		}
+		byIsbn[row.book.Isbn] = row.report
+
+		for _, name := range row.names {
+			key := strings.ToLower(name)
+			if matches := authors[key]; len(matches) > 1 {
+				row.report.Errors = append(row.report.Errors, fmt.Sprintf("%d authors are named %q", len(matches), name))
+			} else if len(matches) == 0 && newAuthors[key] == nil {
+				newAuthors[key] = &models.Author{UserId: user.Id, Name: name}
+				authorNames = append(authorNames, name)
+			}
+		}
+		if len(row.report.Errors) == 0 {
+			created = append(created, row)
+		}
+	}
+	report, resp := newReport(req, reports)
+	if resp != nil {
+		return resp
+	}
+	report.NewAuthors = authorNames
+	if req.DryRun {
+		report.Rows = rowViews(reports)
+		return views.SuccessResponse(http.StatusOK, views.M_OK, report)
+	}
+
+	err = svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		for _, name := range authorNames {
+			if err := svc.createAuthor(ctx, newAuthors[strings.ToLower(name)], user.Id); err != nil {
+				return err
+			}
+		}
+		for _, row := range created {
+			for i := range row.book.Contributors {
+				key := strings.ToLower(row.names[i])
+				if a := newAuthors[key]; a != nil {
+					row.book.Contributors[i].AuthorId = a.Id
+				} else {
+					row.book.Contributors[i].AuthorId = authors[key][0].Id
+				}
+			}
+			row.book.UserId = user.Id
+			if err := svc.books.CreateBook(ctx, &row.book); err != nil {
+				return err
+			}
+			entry, err := book.CreatedEntry(&row.book, user.Id)
+			if err != nil {
+				return err
+			}
+			if err := svc.history.AddHistory(ctx, entry); err != nil {
+				return err
+			}
+			row.report.Id = &row.book.Id
+		}
+		return nil
+	})
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	report.Rows = rowViews(reports)
+	return views.SuccessResponse(http.StatusCreated, views.M_CREATED, report)
+}
+
+// ImportAuthors implements service.ImportSvc. An author is a duplicate of
+// one with the same name, ignoring case, and birthdate.
+func (svc *importerSvc) ImportAuthors(ctx context.Context, file io.Reader, req *params.Import, user *common.CustomClaims) *views.Response {
+	if req.Format == FormatMarc || req.Format == FormatMarcxml {
+		return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_IMPORT, errMarcAuthors)
+	}
+	records, err := readRecords(file, req.Format, authorFields, req.Mapping, config.GetImportMaxRows())
+	if err != nil {
+		return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_IMPORT, err)
+	}
+	reports := make([]*views.ImportRow, 0, len(records))
+	type authorRow struct {
+		report *views.ImportRow
+		author models.Author
+	}
+	rows := make([]*authorRow, 0, len(records))
+	var names []string
+	for _, rec := range records {
+		row := &authorRow{report: &views.ImportRow{Row: rec.row}}
+		reports = append(reports, row.report)
+		row.author, row.report.Errors = parseAuthor(rec)
+		if len(row.report.Errors) > 0 {
+			continue
+		}
+		rows = append(rows, row)
+		names = append(names, row.author.Name)
+	}
+
+	authors, err := svc.authorsByName(ctx, names)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	type identity struct {
+		name      string
+		birthdate time.Time
+	}
+	seen := make(map[identity]*views.ImportRow)
+	for name, list := range authors {
+		for _, a := range list {
+			id := a.Id
+			seen[identity{name, a.Birthdate.UTC()}] = &views.ImportRow{Id: &id}
+		}
+	}
+	var created []*authorRow
+	for _, row := range rows {
+		key := identity{strings.ToLower(row.author.Name), row.author.Birthdate}
+		if first, ok := seen[key]; ok {
+			row.report.Status = rowDuplicate
+			row.report.Id = first.Id
+			if first.Row > 0 {
+				row.report.Errors = []string{fmt.Sprintf("same author as row %d", first.Row)}
+			}
+			continue
+		}
+		seen[key] = row.report
+		created = append(created, row)
+	}
+	report, resp := newReport(req, reports)
+	if resp != nil {
+		return resp
+	}
+	if req.DryRun {
+		report.Rows = rowViews(reports)
+		return views.SuccessResponse(http.StatusOK, views.M_OK, report)
+	}
+
+	err = svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		for _, row := range created {
+			if err := svc.createAuthor(ctx, &row.author, user.Id); err != nil {
+				return err
+			}
+			row.report.Id = &row.author.Id
+		}
+		return nil
+	})
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	report.Rows = rowViews(reports)
+	return views.SuccessResponse(http.StatusCreated, views.M_CREATED, report)
+}
+
+// authorsByName returns the authors with one of the names, by lower case
+// name.
+func (svc *importerSvc) authorsByName(ctx context.Context, names []string) (map[string][]*models.Author, error) {
+	list, err := svc.authors.GetAuthorsByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	authors := make(map[string][]*models.Author, len(list))
+	for _, a := range list {
+		key := strings.ToLower(a.Name)
+		authors[key] = append(authors[key], a)
+	}
+	return authors, nil
+}
+
+func (svc *importerSvc) createAuthor(ctx context.Context, a *models.Author, actorId uuid.UUID) error {
+	a.UserId = actorId
+	if err := svc.authors.CreateAuthor(ctx, a); err != nil {
+		return err
+	}
+	entry, err := author.CreatedEntry(a, actorId)
+	if err != nil {
+		return err
+	}
+	return svc.history.AddHistory(ctx, entry)
+}
+
+// newReport sets the status of the rows left to create and counts them. It
+// returns the error response listing the rows when some are invalid.
+func newReport(req *params.Import, rows []*views.ImportRow) (*views.ImportReport, *views.Response) {
+	report := &views.ImportReport{
+		DryRun: req.DryRun,
+		Total:  len(rows),
+	}
+	for _, row := range rows {
+		switch {
+		case row.Status == rowDuplicate:
+			report.Duplicates++
+		case len(row.Errors) > 0:
+			row.Status = rowInvalid
+			report.Invalid++
+		case req.DryRun:
+			row.Status = rowValid
+		default:
+			row.Status = rowCreated
+			report.Created++
+		}
+	}
+	if report.Invalid > 0 {
+		report.Created = 0
+		for _, row := range rows {
+			if row.Status == rowCreated {
+				row.Status = rowValid
+			}
+		}
+		report.Rows = rowViews(rows)
+		resp := views.ErrorReponse(http.StatusUnprocessableEntity, views.M_INVALID_IMPORT, errInvalidRows)
+		resp.Payload = report
+		return nil, resp
+	}
+	return report, nil
+}
+
+func rowViews(rows []*views.ImportRow) []views.ImportRow {
+	list := make([]views.ImportRow, 0, len(rows))
+	for _, row := range rows {
+		list = append(list, *row)
+	}
+	return list
+}
+
+// parseBook returns the book of the row with its contributors, whose authors
+// are only named, or the errors in the row.
+func parseBook(rec *record) (models.Book, []string, []string) {
+	if rec.err != nil {
+		return models.Book{}, nil, []string{rec.err.Error()}
+	}
+	var errs []string
+	b := models.Book{Title: rec.value("title")}
+	if b.Title == "" {
+		errs = append(errs, "title is required")
+	}
+	if code := rec.value("isbn"); code == "" {
+		errs = append(errs, "isbn is required")
+	} else if normalized, err := isbn.Normalize(code); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		b.Isbn = normalized
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, rf := range roleFields {
+		for _, name := range rec.fields[rf.field] {
+			key := rf.role + "\x00" + strings.ToLower(name)
+			if seen[key] {
+				errs = append(errs, fmt.Sprintf("%q is listed twice as %s", name, rf.role))
+				continue
+			}
+			seen[key] = true
+			b.Contributors = append(b.Contributors, models.BookContributor{
+				Role:     rf.role,
+				Position: len(b.Contributors),
+			})
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		errs = append(errs, "at least one author, editor, translator or illustrator is required")
+	}
+	return b, names, errs
+}
+
+// parseAuthor returns the author of the row, or the errors in the row. The
+// birthdate is a date or an RFC 3339 time.
+func parseAuthor(rec *record) (models.Author, []string) {
+	if rec.err != nil {
+		return models.Author{}, []string{rec.err.Error()}
+	}
+	var errs []string
+	a := models.Author{Name: rec.value("name")}
+	if a.Name == "" {
+		errs = append(errs, "name is required")
+	}
+	birthdate := rec.value("birthdate")
+	if birthdate == "" {
+		errs = append(errs, "birthdate is required")
+	} else if t, err := time.Parse(time.DateOnly, birthdate); err == nil {
+		a.Birthdate = t
+	} else if t, err := time.Parse(time.RFC3339, birthdate); err == nil {
+		a.Birthdate = t.UTC()
+	} else {
+		errs = append(errs, fmt.Sprintf("birthdate %q is not a date", birthdate))
+	}
+	return a, errs
+}
+
+func NewImportSvc(books repository.BookRepo, authors repository.AuthorRepo, history repository.HistoryRepo, tx repository.Transactor) service.ImportSvc {
+	return &importerSvc{
+		books:   books,
+		authors: authors,
+		history: history,
+		tx:      tx,
+	}
+}
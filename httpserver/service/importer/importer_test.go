@@ -0,0 +1,241 @@
+package importer_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/importer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type importSvcTest struct {
+	books   *repository.MockBookRepo
+	authors *repository.MockAuthorRepo
+	history *repository.MockHistoryRepo
+	service service.ImportSvc
+}
+
+func newImportSvcTest(t *testing.T) importSvcTest {
+	mockBooks := repository.NewMockBookRepo(t)
+	mockAuthors := repository.NewMockAuthorRepo(t)
+	mockHistory := repository.NewMockHistoryRepo(t)
+	mockTx := repository.NewMockTransactor(t)
+	mockTx.EXPECT().Transaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).Maybe()
+	importSvc := importer.NewImportSvc(mockBooks, mockAuthors, mockHistory, mockTx)
+	return importSvcTest{
+		books:   mockBooks,
+		authors: mockAuthors,
+		history: mockHistory,
+		service: importSvc,
+	}
+}
+
+const booksCsv = "Book Title,isbn,authors,translators\n" +
+	"Dune,9780441013593,Frank Herbert,\n" +
+	"Dune Messiah,978-0-14-143951-8,frank herbert; Brian Herbert,\n" +
+	"Dune again,9780441013593,Frank Herbert,\n" +
+	"The Name of the Rose,9780156001311,Umberto Eco,William Weaver\n"
+
+func TestImportSvc_ImportBooks(t *testing.T) {
+	t.Run("success - it should create the books and their new authors", func(t *testing.T) {
+		instance := newImportSvcTest(t)
+		userId := uuid.New()
+		frank := &models.Author{Id: uuid.New(), Name: "Frank Herbert"}
+		existing := &models.Book{Id: uuid.New(), Isbn: "9780156001311"}
+
+		instance.books.EXPECT().GetBooksByIsbns(mock.Anything, []string{"9780441013593", "9780141439518", "9780441013593", "9780156001311"}).
+			Return([]*models.Book{existing}, nil)
+		instance.authors.EXPECT().GetAuthorsByNames(mock.Anything, mock.Anything).Return([]*models.Author{frank}, nil)
+		instance.authors.EXPECT().CreateAuthor(mock.Anything, mock.MatchedBy(func(a *models.Author) bool {
+			return a.Name == "Brian Herbert" && a.UserId == userId
+		})).Run(func(_ context.Context, a *models.Author) { a.Id = uuid.New() }).Return(nil).Once()
+		instance.books.EXPECT().CreateBook(mock.Anything, mock.MatchedBy(func(b *models.Book) bool {
+			for _, c := range b.Contributors {
+				if c.AuthorId == uuid.Nil {
+					return false
+				}
+			}
+			return b.UserId == userId && b.Contributors[0].AuthorId == frank.Id
+		})).Return(nil).Twice()
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.Anything).Return(nil).Times(3)
+
+		res := instance.service.ImportBooks(context.Background(), strings.NewReader(booksCsv),
+			&params.Import{Format: importer.FormatCsv, Mapping: map[string]string{"title": "book title"}},
+			&common.CustomClaims{Id: userId})
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+		report := res.Payload.(*views.ImportReport)
+		assert.Equal(t, 4, report.Total)
+		assert.Equal(t, 2, report.Created)
+		assert.Equal(t, 2, report.Duplicates)
+		assert.Equal(t, []string{"Brian Herbert"}, report.NewAuthors)
+		assert.Equal(t, "created", report.Rows[1].Status)
+		assert.Equal(t, []string{"same ISBN as row 1"}, report.Rows[2].Errors)
+		assert.Equal(t, existing.Id, *report.Rows[3].Id)
+	})
+
+	t.Run("success - it should match existing authors ignoring the case of non-ASCII letters", func(t *testing.T) {
+		instance := newImportSvcTest(t)
+		userId := uuid.New()
+		zola := &models.Author{Id: uuid.New(), Name: "Émile Zola"}
+
+		instance.books.EXPECT().GetBooksByIsbns(mock.Anything, []string{"9780140447422"}).Return(nil, nil)
+		instance.authors.EXPECT().GetAuthorsByNames(mock.Anything, []string{"ÉMILE ZOLA"}).Return([]*models.Author{zola}, nil)
+		instance.books.EXPECT().CreateBook(mock.Anything, mock.MatchedBy(func(b *models.Book) bool {
+			return len(b.Contributors) == 1 && b.Contributors[0].AuthorId == zola.Id
+		})).Return(nil).Once()
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.Anything).Return(nil).Once()
+
+		res := instance.service.ImportBooks(context.Background(), strings.NewReader("title,isbn,authors\nGerminal,9780140447422,ÉMILE ZOLA\n"),
+			&params.Import{Format: importer.FormatCsv}, &common.CustomClaims{Id: userId})
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+		report := res.Payload.(*views.ImportReport)
+		assert.Equal(t, 1, report.Created)
+		assert.Empty(t, report.NewAuthors)
+	})
+
+	t.Run("success - it should only report the rows in a dry run", func(t *testing.T) {
+		instance := newImportSvcTest(t)
+		instance.books.EXPECT().GetBooksByIsbns(mock.Anything, mock.Anything).Return(nil, nil)
+		instance.authors.EXPECT().GetAuthorsByNames(mock.Anything, mock.Anything).Return(nil, nil)
+
+		body := `{"title":"The Odyssey","isbn":"9780140449136","authors":["Homer"]}` + "\n\n"
+		res := instance.service.ImportBooks(context.Background(), strings.NewReader(body),
+			&params.Import{Format: importer.FormatNdjson, DryRun: true}, &common.CustomClaims{Id: uuid.New()})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		report := res.Payload.(*views.ImportReport)
+		assert.Equal(t, "valid", report.Rows[0].Status)
+		assert.Equal(t, []string{"Homer"}, report.NewAuthors)
+	})
+
+	t.Run("success - it should read the books of MARC records", func(t *testing.T) {
+		instance := newImportSvcTest(t)
+		file, err := os.Open("../../../marc/testdata/books.mrc")
+		assert.NoError(t, err)
+		defer file.Close()
+
+		instance.books.EXPECT().GetBooksByIsbns(mock.Anything, []string{"9780441013593", "9780156001311", "9780140449136"}).Return(nil, nil)
+		instance.authors.EXPECT().GetAuthorsByNames(mock.Anything, mock.Anything).Return(nil, nil)
+		res := instance.service.ImportBooks(context.Background(), file,
+			&params.Import{Format: importer.FormatMarc, DryRun: true}, &common.CustomClaims{Id: uuid.New()})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		report := res.Payload.(*views.ImportReport)
+		assert.Equal(t, 3, report.Total)
+		assert.Equal(t, []string{"Frank Herbert", "Umberto Eco", "William Weaver", "Homer", "Bernard Knox", "Ursula K. Le Guin", "Robert Fagles"}, report.NewAuthors)
+	})
+
+	t.Run("error - it should not import anything when a row is invalid", func(t *testing.T) {
+		instance := newImportSvcTest(t)
+		instance.books.EXPECT().GetBooksByIsbns(mock.Anything, []string{"9780140449136"}).Return(nil, nil)
+		instance.authors.EXPECT().GetAuthorsByNames(mock.Anything, mock.Anything).Return(nil, nil)
+
+		body := `[{"title":"The Odyssey","isbn":"9780140449136","authors":"Homer"},
+			{"title":"","isbn":"123"},
+			"not an object"]`
+		res := instance.service.ImportBooks(context.Background(), strings.NewReader(body),
+			&params.Import{Format: importer.FormatJson}, &common.CustomClaims{Id: uuid.New()})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+		assert.Equal(t, views.M_INVALID_IMPORT, res.Message)
+		report := res.Payload.(*views.ImportReport)
+		assert.Equal(t, 2, report.Invalid)
+		assert.Equal(t, 0, report.Created)
+		assert.Equal(t, "valid", report.Rows[0].Status)
+		assert.Contains(t, report.Rows[1].Errors, "title is required")
+		assert.Equal(t, []string{"the row is not a JSON object"}, report.Rows[2].Errors)
+	})
+
+	t.Run("error - it should report authors sharing a name", func(t *testing.T) {
+		instance := newImportSvcTest(t)
+		instance.books.EXPECT().GetBooksByIsbns(mock.Anything, mock.Anything).Return(nil, nil)
+		instance.authors.EXPECT().GetAuthorsByNames(mock.Anything, mock.Anything).Return([]*models.Author{
+			{Id: uuid.New(), Name: "Homer"}, {Id: uuid.New(), Name: "homer"},
+		}, nil)
+
+		res := instance.service.ImportBooks(context.Background(), strings.NewReader("title,isbn,authors\nThe Odyssey,9780140449136,Homer\n"),
+			&params.Import{Format: importer.FormatCsv}, &common.CustomClaims{Id: uuid.New()})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+		assert.Equal(t, []string{`2 authors are named "Homer"`}, res.Payload.(*views.ImportReport).Rows[0].Errors)
+	})
+
+	t.Run("error - it should return 400 for an unknown field in the mapping", func(t *testing.T) {
+		instance := newImportSvcTest(t)
+
+		res := instance.service.ImportBooks(context.Background(), strings.NewReader(booksCsv),
+			&params.Import{Format: importer.FormatCsv, Mapping: map[string]string{"subtitle": "x"}}, &common.CustomClaims{Id: uuid.New()})
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+		assert.Equal(t, views.M_INVALID_IMPORT, res.Message)
+	})
+
+	t.Run("error - it should return 400 for too many rows", func(t *testing.T) {
+		instance := newImportSvcTest(t)
+		t.Setenv("IMPORT_MAX_ROWS", "2")
+
+		res := instance.service.ImportBooks(context.Background(), strings.NewReader(booksCsv),
+			&params.Import{Format: importer.FormatCsv}, &common.CustomClaims{Id: uuid.New()})
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+	})
+}
+
+func TestImportSvc_ImportAuthors(t *testing.T) {
+	t.Run("success - it should skip the authors already known", func(t *testing.T) {
+		instance := newImportSvcTest(t)
+		known := &models.Author{Id: uuid.New(), Name: "Ted Chiang", Birthdate: time.Date(1967, 3, 20, 0, 0, 0, 0, time.UTC)}
+
+		instance.authors.EXPECT().GetAuthorsByNames(mock.Anything, mock.Anything).Return([]*models.Author{known}, nil)
+		instance.authors.EXPECT().CreateAuthor(mock.Anything, mock.MatchedBy(func(a *models.Author) bool {
+			return a.Name == "Ursula K. Le Guin" && a.Birthdate.Equal(time.Date(1929, 10, 21, 0, 0, 0, 0, time.UTC))
+		})).Return(nil).Once()
+		instance.history.EXPECT().AddHistory(mock.Anything, mock.Anything).Return(nil).Once()
+
+		body := "name,birthdate\n" +
+			"Ursula K. Le Guin,1929-10-21\n" +
+			"ursula k. le guin,1929-10-21\n" +
+			"ted chiang,1967-03-20T00:00:00Z\n"
+		res := instance.service.ImportAuthors(context.Background(), strings.NewReader(body),
+			&params.Import{Format: importer.FormatCsv}, &common.CustomClaims{Id: uuid.New()})
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+		report := res.Payload.(*views.ImportReport)
+		assert.Equal(t, 1, report.Created)
+		assert.Equal(t, 2, report.Duplicates)
+		assert.Equal(t, []string{"same author as row 1"}, report.Rows[1].Errors)
+		assert.Equal(t, known.Id, *report.Rows[2].Id)
+	})
+
+	t.Run("error - it should not read authors from MARC records", func(t *testing.T) {
+		instance := newImportSvcTest(t)
+
+		res := instance.service.ImportAuthors(context.Background(), strings.NewReader(""),
+			&params.Import{Format: importer.FormatMarcxml}, &common.CustomClaims{Id: uuid.New()})
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+	})
+
+	t.Run("error - it should report a row without a valid birthdate", func(t *testing.T) {
+		instance := newImportSvcTest(t)
+		instance.authors.EXPECT().GetAuthorsByNames(mock.Anything, mock.Anything).Return(nil, nil)
+
+		res := instance.service.ImportAuthors(context.Background(), strings.NewReader(`[{"name":"Ted Chiang","birthdate":"1967"}]`),
+			&params.Import{Format: importer.FormatJson}, &common.CustomClaims{Id: uuid.New()})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Status)
+		assert.Equal(t, []string{`birthdate "1967" is not a date`}, res.Payload.(*views.ImportReport).Rows[0].Errors)
+	})
+}
@@ -0,0 +1,268 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/storyofhis/books-management/marc"
+)
+
+const (
+	FormatCsv    = "csv"
+	FormatJson   = "json"
+	FormatNdjson = "ndjson"
+	// FormatMarc and FormatMarcxml are only read by book imports.
+	FormatMarc    = "marc"
+	FormatMarcxml = "marcxml"
+)
+
+var (
+	bookFields   = []string{"title", "isbn", "authors", "editors", "translators", "illustrators"}
+	authorFields = []string{"name", "birthdate"}
+)
+
+// listFields hold several names. In CSV files and JSON strings the names are
+// separated by semicolons, JSON arrays list them.
+var listFields = map[string]bool{
+	"authors":      true,
+	"editors":      true,
+	"translators":  true,
+	"illustrators": true,
+}
+
+var errNotArray = errors.New("a JSON import must be an array of objects")
+
+// record is a row of an import file, the values of its fields by field name.
+// err is set when the row could not be read.
+type record struct {
+	row    int
+	fields map[string][]string
+	err    error
+}
+
+func (r *record) value(field string) string {
+	if values := r.fields[field]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// readRecords reads the rows of an import file. mapping gives the column or
+// key holding a field when it is not named after the field, matched ignoring
+// case. Errors in a row are kept in the row, a file that cannot be read or has
+// more than maxRows rows is an error.
+func readRecords(r io.Reader, format string, fields []string, mapping map[string]string, maxRows int) ([]*record, error) {
+	columns := make(map[string]string, len(fields))
+	for _, field := range fields {
+		columns[field] = field
+	}
+	for field, column := range mapping {
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("unknown field %q in the mapping", field)
+		}
+		columns[field] = column
+	}
+	byColumn := make(map[string]string, len(columns))
+	for field, column := range columns {
+		byColumn[strings.ToLower(strings.TrimSpace(column))] = field
+	}
+
+	var records []*record
+	add := func(rec *record) error {
+		if len(records) == maxRows {
+			return fmt.Errorf("the import has more than %d rows", maxRows)
+		}
+		rec.row = len(records) + 1
+		records = append(records, rec)
+		return nil
+	}
+
+	switch format {
+	case FormatCsv:
+		return records, readCsv(r, byColumn, add)
+	case FormatJson:
+		return records, readJson(r, byColumn, add)
+	case FormatNdjson:
+		return records, readNdjson(r, byColumn, add)
+	case FormatMarc, FormatMarcxml:
+		if len(mapping) > 0 {
+			return nil, errors.New("the fields of a MARC import cannot be mapped")
+		}
+		if format == FormatMarc {
+			return records, readMarc(marc.NewReader(r).Read, add)
+		}
+		return records, readMarc(marc.NewXMLReader(r).Read, add)
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+func readCsv(r io.Reader, byColumn map[string]string, add func(*record) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fields := make([]string, len(header))
+	for i, column := range header {
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		fields[i] = byColumn[strings.ToLower(strings.TrimSpace(column))]
+	}
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		rec := &record{fields: make(map[string][]string)}
+		for i, value := range row {
+			if i < len(fields) && fields[i] != "" {
+				rec.fields[fields[i]] = split(fields[i], value)
+			}
+		}
+		if err := add(rec); err != nil {
+			return err
+		}
+	}
+}
+
+func readJson(r io.Reader, byColumn map[string]string, add func(*record) error) error {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return errNotArray
+	}
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+		if err := add(jsonRecord(raw, byColumn)); err != nil {
+			return err
+		}
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+func readNdjson(r io.Reader, byColumn map[string]string, add func(*record) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := add(jsonRecord(line, byColumn)); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// readMarc reads the books of MARC records. A malformed record is an error
+// of its row.
+func readMarc(next func() (*marc.Record, error), add func(*record) error) error {
+	for {
+		rec, err := next()
+		if err == io.EOF {
+			return nil
+		}
+		row := &record{fields: make(map[string][]string)}
+		switch {
+		case errors.Is(err, marc.ErrInvalidRecord):
+			row.err = err
+		case err != nil:
+			return err
+		default:
+			b := marc.Book(rec)
+			row.fields["title"] = []string{b.Title}
+			row.fields["isbn"] = []string{b.Isbn}
+			for _, c := range b.Contributors {
+				field := c.Role + "s"
+				row.fields[field] = append(row.fields[field], c.Author.Name)
+			}
+		}
+		if err := add(row); err != nil {
+			return err
+		}
+	}
+}
+
+// jsonRecord reads an object of a JSON or NDJSON import.
+func jsonRecord(raw []byte, byColumn map[string]string) *record {
+	rec := &record{fields: make(map[string][]string)}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		rec.err = errors.New("the row is not a JSON object")
+		return rec
+	}
+	for key, value := range object {
+		field := byColumn[strings.ToLower(strings.TrimSpace(key))]
+		if field == "" {
+			continue
+		}
+		switch v := value.(type) {
+		case nil:
+		case string:
+			rec.fields[field] = split(field, v)
+		case json.Number:
+			rec.fields[field] = []string{v.String()}
+		case []interface{}:
+			if !listFields[field] {
+				rec.err = fmt.Errorf("%s must be a single value", field)
+				return rec
+			}
+			for _, item := range v {
+				name, ok := item.(string)
+				if !ok {
+					rec.err = fmt.Errorf("%s must only hold strings", field)
+					return rec
+				}
+				if name = strings.TrimSpace(name); name != "" {
+					rec.fields[field] = append(rec.fields[field], name)
+				}
+			}
+		default:
+			rec.err = fmt.Errorf("%s has a value of an unsupported type", field)
+			return rec
+		}
+	}
+	return rec
+}
+
+// split returns the values of a field, the names of a list field.
+func split(field, value string) []string {
+	if !listFields[field] {
+		return []string{strings.TrimSpace(value)}
+	}
+	var names []string
+	for _, name := range strings.Split(value, ";") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"io"
 
 	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
 	"github.com/storyofhis/books-management/httpserver/controller/params"
 	"github.com/storyofhis/books-management/httpserver/controller/views"
 )
@@ -11,20 +13,154 @@ import (
 type UserSvc interface {
 	Register(ctx context.Context, user *params.Register) *views.Response
 	Login(ctx context.Context, user *params.Login) *views.Response
+	Refresh(ctx context.Context, token *params.Refresh) *views.Response
+	Logout(ctx context.Context, sessionId uuid.UUID) *views.Response
+	LogoutAll(ctx context.Context, userId uuid.UUID) *views.Response
+	VerifySession(ctx context.Context, claims *common.CustomClaims) error
+	// Authenticate checks the password of a user for the clients that send
+	// it with every request instead of a token, such as OPDS readers. The
+	// claims it returns have no session.
+	Authenticate(ctx context.Context, username, password string) (*common.CustomClaims, error)
+	GetUsers(ctx context.Context) *views.Response
+	UpdateRole(ctx context.Context, role *params.UpdateRole, id uuid.UUID) *views.Response
 }
 
 type AuthorSvc interface {
 	CreateAuthor(ctx context.Context, author *params.CreateAuthors, id uuid.UUID) *views.Response
-	GetAuthors(ctx context.Context) *views.Response
+	GetAuthors(ctx context.Context, query *params.ListAuthors) *views.Response
 	GetAuthorById(ctx context.Context, id uuid.UUID) *views.Response
-	UpdateAuthor(ctx context.Context, author *params.UpdateAuthors, id uuid.UUID) *views.Response
-	DeleteAuthor(ctx context.Context, id uuid.UUID) *views.Response
+	// UpdateAuthor updates the author if it is still at version, the version
+	// the client read from the ETag. Zero skips the check.
+	UpdateAuthor(ctx context.Context, author *params.UpdateAuthors, id uuid.UUID, version int, actorId uuid.UUID) *views.Response
+	DeleteAuthor(ctx context.Context, id uuid.UUID, query *params.DeleteAuthor, version int, user *common.CustomClaims) *views.Response
+	RestoreAuthor(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response
+	GetAuthorHistory(ctx context.Context, id uuid.UUID) *views.Response
+	RevertAuthor(ctx context.Context, id uuid.UUID, revert *params.Revert, version int, actorId uuid.UUID) *views.Response
 }
 
 type BookSvc interface {
 	CreateBook(ctx context.Context, book *params.CreateBook, id uuid.UUID) *views.Response
-	GetBooks(ctx context.Context) *views.Response
+	GetBooks(ctx context.Context, query *params.ListBooks) *views.Response
 	GetBookById(ctx context.Context, id uuid.UUID) *views.Response
-	UpdateBook(ctx context.Context, book *params.UpdateBook, id uuid.UUID) *views.Response
-	DeleteBook(ctx context.Context, id uuid.UUID) *views.Response
+	// UpdateBook updates the book if it is still at version, the version the
+	// client read from the ETag. Zero skips the check.
+	UpdateBook(ctx context.Context, book *params.UpdateBook, id uuid.UUID, version int, actorId uuid.UUID) *views.Response
+	DeleteBook(ctx context.Context, id uuid.UUID, version int, actorId uuid.UUID) *views.Response
+	RestoreBook(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response
+	GetBookHistory(ctx context.Context, id uuid.UUID) *views.Response
+	RevertBook(ctx context.Context, id uuid.UUID, revert *params.Revert, version int, actorId uuid.UUID) *views.Response
+}
+
+type CopySvc interface {
+	CreateCopy(ctx context.Context, bookId uuid.UUID, req *params.CreateCopy) *views.Response
+	GetCopies(ctx context.Context, bookId uuid.UUID) *views.Response
+	GetCopy(ctx context.Context, bookId, id uuid.UUID) *views.Response
+	UpdateCopy(ctx context.Context, bookId, id uuid.UUID, req *params.UpdateCopy) *views.Response
+	DeleteCopy(ctx context.Context, bookId, id uuid.UUID) *views.Response
+	GetCopyByBarcode(ctx context.Context, barcode string) *views.Response
+}
+
+type LoanSvc interface {
+	Checkout(ctx context.Context, req *params.Checkout) *views.Response
+	// GetLoan and RenewLoan are allowed to the borrower and to staff.
+	GetLoan(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response
+	ReturnLoan(ctx context.Context, id uuid.UUID) *views.Response
+	RenewLoan(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response
+	GetUserLoans(ctx context.Context, userId uuid.UUID, query *params.ListLoans) *views.Response
+	GetBookLoans(ctx context.Context, bookId uuid.UUID, query *params.ListLoans) *views.Response
+	GetOverdueLoans(ctx context.Context, query *params.ListLoans) *views.Response
+}
+
+type HoldSvc interface {
+	// PlaceHold places a hold for the caller, or for req.UserId when the
+	// caller is staff.
+	PlaceHold(ctx context.Context, bookId uuid.UUID, req *params.PlaceHold, user *common.CustomClaims) *views.Response
+	GetBookHolds(ctx context.Context, bookId uuid.UUID) *views.Response
+	GetUserHolds(ctx context.Context, userId uuid.UUID) *views.Response
+	// CancelHold is allowed to the holder and to staff.
+	CancelHold(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response
+}
+
+type ReviewSvc interface {
+	CreateReview(ctx context.Context, bookId uuid.UUID, req *params.Review, user *common.CustomClaims) *views.Response
+	GetReviews(ctx context.Context, bookId uuid.UUID, query *params.ListReviews, user *common.CustomClaims) *views.Response
+	// UpdateReview and DeleteReview are only allowed to the author of the
+	// review.
+	UpdateReview(ctx context.Context, bookId, id uuid.UUID, req *params.Review, user *common.CustomClaims) *views.Response
+	DeleteReview(ctx context.Context, bookId, id uuid.UUID, user *common.CustomClaims) *views.Response
+	ModerateReview(ctx context.Context, bookId, id uuid.UUID, req *params.ModerateReview, user *common.CustomClaims) *views.Response
+}
+
+type ShelfSvc interface {
+	// GetShelves lists the shelves of a user, only the public ones unless
+	// they are the caller's, who gets the built-in shelves on the first call.
+	GetShelves(ctx context.Context, userId uuid.UUID, user *common.CustomClaims) *views.Response
+	CreateShelf(ctx context.Context, req *params.Shelf, user *common.CustomClaims) *views.Response
+	// GetShelf returns a shelf of the caller or a public shelf, with its
+	// books.
+	GetShelf(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response
+	// GetSharedShelf returns the public shelf with the share token.
+	GetSharedShelf(ctx context.Context, token string) *views.Response
+	// UpdateShelf and the other changes are only allowed to the owner of the
+	// shelf.
+	UpdateShelf(ctx context.Context, id uuid.UUID, req *params.Shelf, user *common.CustomClaims) *views.Response
+	DeleteShelf(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response
+	AddShelfItem(ctx context.Context, shelfId uuid.UUID, req *params.AddShelfItem, user *common.CustomClaims) *views.Response
+	UpdateShelfItem(ctx context.Context, shelfId, bookId uuid.UUID, req *params.UpdateShelfItem, user *common.CustomClaims) *views.Response
+	RemoveShelfItem(ctx context.Context, shelfId, bookId uuid.UUID, user *common.CustomClaims) *views.Response
+}
+
+// ImportSvc imports books and authors in bulk. Every row is checked before
+// anything is written, and the rows are created in one transaction, or none
+// when a row is invalid.
+type ImportSvc interface {
+	// ImportBooks creates the authors of the books that do not exist yet,
+	// by name, and skips the books whose ISBN is already in the catalog.
+	ImportBooks(ctx context.Context, file io.Reader, req *params.Import, user *common.CustomClaims) *views.Response
+	ImportAuthors(ctx context.Context, file io.Reader, req *params.Import, user *common.CustomClaims) *views.Response
+}
+
+// ExportSvc writes the catalog to a file as it is read, a batch of rows at a
+// time. It returns nil once the file is written, and an error response
+// otherwise, which can only be sent when nothing was written to w yet.
+type ExportSvc interface {
+	ExportBooks(ctx context.Context, query *params.ExportBooks, w io.Writer) *views.Response
+	ExportAuthors(ctx context.Context, query *params.ExportAuthors, w io.Writer) *views.Response
+}
+
+// CatalogSvc builds the feeds of the OPDS catalog. The payload of its
+// responses is an *opds.Feed, which the controller writes in the version of
+// OPDS the client asked for.
+type CatalogSvc interface {
+	GetRoot(ctx context.Context) *views.Response
+	GetNewest(ctx context.Context, query *params.CatalogPage) *views.Response
+	GetAuthors(ctx context.Context, query *params.CatalogPage) *views.Response
+	GetAuthorBooks(ctx context.Context, id uuid.UUID, query *params.CatalogPage) *views.Response
+	Search(ctx context.Context, query *params.CatalogSearch) *views.Response
+}
+
+// CitationSvc gathers the books to cite. The payload of its responses is a
+// []*models.Book, which the controller writes in the format asked for.
+type CitationSvc interface {
+	GetCitation(ctx context.Context, id uuid.UUID) *views.Response
+	// GetCitations returns the books of a list of ids, or of a shelf of the
+	// user or a public one.
+	GetCitations(ctx context.Context, query *params.Citations, user *common.CustomClaims) *views.Response
+}
+
+type LedgerSvc interface {
+	GetBalance(ctx context.Context, userId uuid.UUID) *views.Response
+	GetTransactions(ctx context.Context, userId uuid.UUID, query *params.ListTransactions) *views.Response
+	// RecordPayment and RecordWaiver credit the account of a user, by no
+	// more than their balance.
+	RecordPayment(ctx context.Context, userId uuid.UUID, req *params.Credit, staff *common.CustomClaims) *views.Response
+	RecordWaiver(ctx context.Context, userId uuid.UUID, req *params.Credit, staff *common.CustomClaims) *views.Response
+}
+
+type SearchSvc interface {
+	Search(ctx context.Context, query *params.Search) *views.Response
+}
+
+type TrashSvc interface {
+	GetTrash(ctx context.Context, userId uuid.UUID) *views.Response
 }
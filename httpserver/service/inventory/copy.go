@@ -0,0 +1,239 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/config"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/isbn"
+	"gorm.io/gorm"
+)
+
+var (
+	errCopyOfOtherBook = errors.New("the copy belongs to another book")
+	errCirculation     = errors.New("the on_loan and on_hold statuses are managed by loans and holds")
+	errCopyHasLoans    = errors.New("the copy has been lent or held, withdraw it instead")
+)
+
+type copySvc struct {
+	repo  repository.CopyRepo
+	books repository.BookRepo
+	holds repository.HoldRepo
+	tx    repository.Transactor
+}
+
+// CreateCopy implements service.CopySvc. The new copy goes to the oldest
+// waiting hold on the book, if any.
+func (svc *copySvc) CreateCopy(ctx context.Context, bookId uuid.UUID, req *params.CreateCopy) *views.Response {
+	if resp := svc.checkBook(ctx, bookId); resp != nil {
+		return resp
+	}
+
+	c := models.Copy{
+		BookId:     bookId,
+		Barcode:    req.Barcode,
+		Location:   req.Location,
+		Condition:  req.Condition,
+		Status:     models.CopyAvailable,
+		AcquiredAt: req.AcquiredAt,
+	}
+	if c.Condition == "" {
+		c.Condition = models.ConditionGood
+	}
+	err := svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := svc.repo.CreateCopy(ctx, &c); err != nil {
+			return err
+		}
+		return svc.assignHolds(ctx, &c)
+	})
+	if err != nil {
+		if err == repository.ErrDuplicateBarcode {
+			return views.ErrorReponse(http.StatusConflict, views.M_DUPLICATE_BARCODE, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusCreated, views.M_CREATED, copyView(&c))
+}
+
+// GetCopies implements service.CopySvc.
+func (svc *copySvc) GetCopies(ctx context.Context, bookId uuid.UUID) *views.Response {
+	if resp := svc.checkBook(ctx, bookId); resp != nil {
+		return resp
+	}
+
+	list, err := svc.repo.GetCopies(ctx, bookId)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	copies := make([]views.Copy, 0, len(list))
+	for _, c := range list {
+		copies = append(copies, copyView(c))
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, copies)
+}
+
+// GetCopy implements service.CopySvc.
+func (svc *copySvc) GetCopy(ctx context.Context, bookId, id uuid.UUID) *views.Response {
+	c, resp := svc.getCopy(ctx, bookId, id)
+	if resp != nil {
+		return resp
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, copyView(c))
+}
+
+// UpdateCopy implements service.CopySvc. A copy made available goes to the
+// oldest waiting hold on the book, if any.
+func (svc *copySvc) UpdateCopy(ctx context.Context, bookId, id uuid.UUID, req *params.UpdateCopy) *views.Response {
+	c, resp := svc.getCopy(ctx, bookId, id)
+	if resp != nil {
+		return resp
+	}
+
+	if c.Status != req.Status && (models.Circulating(c.Status) || models.Circulating(req.Status)) {
+		return views.ErrorReponse(http.StatusConflict, views.M_COPY_IN_CIRCULATION, errCirculation)
+	}
+
+	c.Barcode = req.Barcode
+	c.Location = req.Location
+	c.Condition = req.Condition
+	c.Status = req.Status
+	c.AcquiredAt = req.AcquiredAt
+	err := svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := svc.repo.UpdateCopy(ctx, c); err != nil {
+			return err
+		}
+		return svc.assignHolds(ctx, c)
+	})
+	if err != nil {
+		if err == repository.ErrDuplicateBarcode {
+			return views.ErrorReponse(http.StatusConflict, views.M_DUPLICATE_BARCODE, err)
+		}
+		if err == repository.ErrCopyInCirculation {
+			return views.ErrorReponse(http.StatusConflict, views.M_COPY_IN_CIRCULATION, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, copyView(c))
+}
+
+// DeleteCopy implements service.CopySvc.
+func (svc *copySvc) DeleteCopy(ctx context.Context, bookId, id uuid.UUID) *views.Response {
+	c, resp := svc.getCopy(ctx, bookId, id)
+	if resp != nil {
+		return resp
+	}
+	if models.Circulating(c.Status) {
+		return views.ErrorReponse(http.StatusConflict, views.M_COPY_IN_CIRCULATION, repository.ErrCopyInCirculation)
+	}
+
+	err := svc.repo.DeleteCopy(ctx, id)
+	if err != nil {
+		if err == gorm.ErrForeignKeyViolated {
+			return views.ErrorReponse(http.StatusConflict, views.M_COPY_HAS_LOANS, errCopyHasLoans)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusNoContent, views.M_OK, nil)
+}
+
+// GetCopyByBarcode implements service.CopySvc.
+func (svc *copySvc) GetCopyByBarcode(ctx context.Context, barcode string) *views.Response {
+	c, err := svc.repo.GetCopyByBarcode(ctx, barcode)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_COPY_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	view := copyView(c)
+	view.Book = &views.CopyBook{
+		Id:          c.Book.Id,
+		Title:       c.Book.Title,
+		Isbn:        c.Book.Isbn,
+		IsbnDisplay: isbn.Format(c.Book.Isbn),
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, view)
+}
+
+// assignHolds gives c to the oldest waiting hold on its book when it is
+// available, and updates its status when it was assigned.
+func (svc *copySvc) assignHolds(ctx context.Context, c *models.Copy) error {
+	if c.Status != models.CopyAvailable {
+		return nil
+	}
+	assigned, err := svc.holds.AssignHolds(ctx, c.BookId, time.Now().Add(config.GetHoldPickupWindow()))
+	if err != nil {
+		return err
+	}
+	for _, h := range assigned {
+		if h.CopyId != nil && *h.CopyId == c.Id {
+			c.Status = models.CopyOnHold
+		}
+	}
+	return nil
+}
+
+// checkBook returns an error response unless the book exists and is not in
+// the trash.
+func (svc *copySvc) checkBook(ctx context.Context, bookId uuid.UUID) *views.Response {
+	_, err := svc.books.GetBookById(ctx, bookId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_BOOK_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return nil
+}
+
+// getCopy returns the copy with the given id, or an error response when the
+// book or the copy does not exist or the copy belongs to another book.
+func (svc *copySvc) getCopy(ctx context.Context, bookId, id uuid.UUID) (*models.Copy, *views.Response) {
+	if resp := svc.checkBook(ctx, bookId); resp != nil {
+		return nil, resp
+	}
+
+	c, err := svc.repo.GetCopyById(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, views.ErrorReponse(http.StatusNotFound, views.M_COPY_NOT_FOUND, err)
+		}
+		return nil, views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if c.BookId != bookId {
+		return nil, views.ErrorReponse(http.StatusNotFound, views.M_COPY_NOT_FOUND, errCopyOfOtherBook)
+	}
+	return c, nil
+}
+
+func copyView(c *models.Copy) views.Copy {
+	return views.Copy{
+		Id:         c.Id,
+		BookId:     c.BookId,
+		Barcode:    c.Barcode,
+		Location:   c.Location,
+		Condition:  c.Condition,
+		Status:     c.Status,
+		AcquiredAt: c.AcquiredAt,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func NewCopySvc(repo repository.CopyRepo, books repository.BookRepo, holds repository.HoldRepo, tx repository.Transactor) service.CopySvc {
+	return &copySvc{
+		repo:  repo,
+		books: books,
+		holds: holds,
+		tx:    tx,
+	}
+}
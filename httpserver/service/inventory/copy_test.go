@@ -0,0 +1,232 @@
+package inventory_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type copySvcTest struct {
+	repo    *repository.MockCopyRepo
+	books   *repository.MockBookRepo
+	holds   *repository.MockHoldRepo
+	service service.CopySvc
+}
+
+func newCopySvcTest(t *testing.T) copySvcTest {
+	mockRepo := repository.NewMockCopyRepo(t)
+	mockBooks := repository.NewMockBookRepo(t)
+	mockHolds := repository.NewMockHoldRepo(t)
+	mockTx := repository.NewMockTransactor(t)
+	mockTx.EXPECT().Transaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).Maybe()
+	copySvc := inventory.NewCopySvc(mockRepo, mockBooks, mockHolds, mockTx)
+	return copySvcTest{
+		repo:    mockRepo,
+		books:   mockBooks,
+		holds:   mockHolds,
+		service: copySvc,
+	}
+}
+
+func TestCopySvc_CreateCopy(t *testing.T) {
+	t.Run("success - it should add an available copy in good condition", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		bookId := uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().CreateCopy(mock.Anything, mock.MatchedBy(func(c *models.Copy) bool {
+			return c.BookId == bookId && c.Barcode == "B-0001" && c.Status == models.CopyAvailable && c.Condition == models.ConditionGood
+		})).Return(nil)
+		instance.holds.EXPECT().AssignHolds(mock.Anything, bookId, mock.Anything).Return(nil, nil)
+		res := instance.service.CreateCopy(context.Background(), bookId, &params.CreateCopy{Barcode: "B-0001", Location: "A1"})
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+		assert.Equal(t, "A1", res.Payload.(views.Copy).Location)
+		assert.Equal(t, models.CopyAvailable, res.Payload.(views.Copy).Status)
+	})
+
+	t.Run("success - it should give the new copy to the oldest waiting hold", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		bookId, copyId := uuid.New(), uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().CreateCopy(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, c *models.Copy) error {
+			c.Id = copyId
+			return nil
+		})
+		instance.holds.EXPECT().AssignHolds(mock.Anything, bookId, mock.Anything).Return([]*models.Hold{{Id: uuid.New(), CopyId: &copyId}}, nil)
+		res := instance.service.CreateCopy(context.Background(), bookId, &params.CreateCopy{Barcode: "B-0001"})
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+		assert.Equal(t, models.CopyOnHold, res.Payload.(views.Copy).Status)
+	})
+
+	t.Run("error - it should return 404 if the book does not exist", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		instance.books.EXPECT().GetBookById(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.CreateCopy(context.Background(), uuid.New(), &params.CreateCopy{Barcode: "B-0001"})
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_BOOK_NOT_FOUND, res.Message)
+	})
+
+	t.Run("error - it should return 409 for a duplicate barcode", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		instance.books.EXPECT().GetBookById(mock.Anything, mock.Anything).Return(&models.Book{}, nil)
+		instance.repo.EXPECT().CreateCopy(mock.Anything, mock.Anything).Return(repository.ErrDuplicateBarcode)
+
+		res := instance.service.CreateCopy(context.Background(), uuid.New(), &params.CreateCopy{Barcode: "B-0001"})
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_DUPLICATE_BARCODE, res.Message)
+	})
+}
+
+func TestCopySvc_GetCopies(t *testing.T) {
+	t.Run("success - it should list the copies of the book", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		bookId := uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().GetCopies(mock.Anything, bookId).Return([]*models.Copy{
+			{Id: uuid.New(), BookId: bookId, Barcode: "B-0001"},
+			{Id: uuid.New(), BookId: bookId, Barcode: "B-0002"},
+		}, nil)
+		res := instance.service.GetCopies(context.Background(), bookId)
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Len(t, res.Payload.([]views.Copy), 2)
+	})
+
+	t.Run("error - it should return 500 if there is a database error", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		instance.books.EXPECT().GetBookById(mock.Anything, mock.Anything).Return(&models.Book{}, nil)
+		instance.repo.EXPECT().GetCopies(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+		res := instance.service.GetCopies(context.Background(), uuid.New())
+		assert.Equal(t, http.StatusInternalServerError, res.Status)
+	})
+}
+
+func TestCopySvc_UpdateCopy(t *testing.T) {
+	t.Run("success - it should replace the fields of the copy", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		bookId, id := uuid.New(), uuid.New()
+		c := &models.Copy{Id: id, BookId: bookId, Barcode: "B-0001", Location: "A1", Status: models.CopyAvailable}
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().GetCopyById(mock.Anything, id).Return(c, nil)
+		instance.repo.EXPECT().UpdateCopy(mock.Anything, mock.MatchedBy(func(c *models.Copy) bool {
+			return c.Location == "" && c.Status == models.CopyInRepair && c.Condition == models.ConditionPoor
+		})).Return(nil)
+		res := instance.service.UpdateCopy(context.Background(), bookId, id, &params.UpdateCopy{
+			Barcode:   "B-0001",
+			Condition: models.ConditionPoor,
+			Status:    models.CopyInRepair,
+		})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, models.CopyInRepair, res.Payload.(views.Copy).Status)
+	})
+
+	t.Run("error - it should not clear the on_loan status", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		bookId, id := uuid.New(), uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().GetCopyById(mock.Anything, id).Return(&models.Copy{Id: id, BookId: bookId, Status: models.CopyOnLoan}, nil)
+		res := instance.service.UpdateCopy(context.Background(), bookId, id, &params.UpdateCopy{Barcode: "B-0001", Status: models.CopyAvailable})
+
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_COPY_IN_CIRCULATION, res.Message)
+	})
+
+	t.Run("error - it should return 404 for a copy of another book", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		bookId, id := uuid.New(), uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().GetCopyById(mock.Anything, id).Return(&models.Copy{Id: id, BookId: uuid.New()}, nil)
+		res := instance.service.UpdateCopy(context.Background(), bookId, id, &params.UpdateCopy{Barcode: "B-0001"})
+
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_COPY_NOT_FOUND, res.Message)
+	})
+}
+
+func TestCopySvc_DeleteCopy(t *testing.T) {
+	t.Run("success - it should delete the copy", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		bookId, id := uuid.New(), uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().GetCopyById(mock.Anything, id).Return(&models.Copy{Id: id, BookId: bookId}, nil)
+		instance.repo.EXPECT().DeleteCopy(mock.Anything, id).Return(nil)
+		res := instance.service.DeleteCopy(context.Background(), bookId, id)
+
+		assert.Equal(t, http.StatusNoContent, res.Status)
+	})
+
+	t.Run("error - it should return 404 if the copy does not exist", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		instance.books.EXPECT().GetBookById(mock.Anything, mock.Anything).Return(&models.Book{}, nil)
+		instance.repo.EXPECT().GetCopyById(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.DeleteCopy(context.Background(), uuid.New(), uuid.New())
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_COPY_NOT_FOUND, res.Message)
+	})
+
+	t.Run("error - it should return 409 if the copy has been lent", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		bookId, id := uuid.New(), uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().GetCopyById(mock.Anything, id).Return(&models.Copy{Id: id, BookId: bookId, Status: models.CopyAvailable}, nil)
+		instance.repo.EXPECT().DeleteCopy(mock.Anything, id).Return(gorm.ErrForeignKeyViolated)
+		res := instance.service.DeleteCopy(context.Background(), bookId, id)
+
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_COPY_HAS_LOANS, res.Message)
+	})
+}
+
+func TestCopySvc_GetCopyByBarcode(t *testing.T) {
+	t.Run("success - it should return the copy with its book", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		bookId := uuid.New()
+
+		instance.repo.EXPECT().GetCopyByBarcode(mock.Anything, "B-0001").Return(&models.Copy{
+			Id:      uuid.New(),
+			BookId:  bookId,
+			Barcode: "B-0001",
+			Book:    models.Book{Id: bookId, Title: "Dune", Isbn: "9780306406157"},
+		}, nil)
+		res := instance.service.GetCopyByBarcode(context.Background(), "B-0001")
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		payload := res.Payload.(views.Copy)
+		assert.Equal(t, "Dune", payload.Book.Title)
+		assert.Equal(t, "978-0-306-40615-7", payload.Book.IsbnDisplay)
+	})
+
+	t.Run("error - it should return 404 for an unknown barcode", func(t *testing.T) {
+		instance := newCopySvcTest(t)
+		instance.repo.EXPECT().GetCopyByBarcode(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.GetCopyByBarcode(context.Background(), "missing")
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_COPY_NOT_FOUND, res.Message)
+	})
+}
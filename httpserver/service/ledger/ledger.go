@@ -0,0 +1,197 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/config"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"gorm.io/gorm"
+)
+
+var errExceedsBalance = errors.New("the amount is more than the balance")
+
+// accrualPageSize is how many overdue loans are fined at a time.
+const accrualPageSize = 100
+
+type ledgerSvc struct {
+	repo  repository.LedgerRepo
+	users repository.UserRepo
+	tx    repository.Transactor
+}
+
+// GetBalance implements service.LedgerSvc.
+func (svc *ledgerSvc) GetBalance(ctx context.Context, userId uuid.UUID) *views.Response {
+	if resp := svc.checkUser(ctx, userId); resp != nil {
+		return resp
+	}
+	balance, err := svc.repo.GetBalance(ctx, userId)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	limit := config.GetFineBalanceLimit()
+	return views.SuccessResponse(http.StatusOK, views.M_OK, views.Balance{
+		UserId:  userId,
+		Balance: balance,
+		Limit:   limit,
+		Blocked: balance > limit,
+	})
+}
+
+// GetTransactions implements service.LedgerSvc.
+func (svc *ledgerSvc) GetTransactions(ctx context.Context, userId uuid.UUID, query *params.ListTransactions) *views.Response {
+	if resp := svc.checkUser(ctx, userId); resp != nil {
+		return resp
+	}
+	page := repository.Page{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+		Cursor:   query.Cursor,
+		Sort:     query.Sort,
+	}
+	list, info, err := svc.repo.GetEntries(ctx, userId, &page)
+	if err != nil {
+		if err == repository.ErrInvalidCursor || err == repository.ErrInvalidSort {
+			return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_QUERY, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	transactions := make([]views.Transaction, 0, len(list))
+	for _, e := range list {
+		transactions = append(transactions, transactionView(e))
+	}
+	return views.PagedResponse(http.StatusOK, views.M_OK, transactions, &views.Pagination{
+		Total:      info.Total,
+		Page:       query.Page,
+		PageSize:   info.PageSize,
+		NextCursor: info.NextCursor,
+		PrevCursor: info.PrevCursor,
+	})
+}
+
+// RecordPayment implements service.LedgerSvc.
+func (svc *ledgerSvc) RecordPayment(ctx context.Context, userId uuid.UUID, req *params.Credit, staff *common.CustomClaims) *views.Response {
+	return svc.credit(ctx, userId, models.EntryPayment, req, staff)
+}
+
+// RecordWaiver implements service.LedgerSvc.
+func (svc *ledgerSvc) RecordWaiver(ctx context.Context, userId uuid.UUID, req *params.Credit, staff *common.CustomClaims) *views.Response {
+	return svc.credit(ctx, userId, models.EntryWaiver, req, staff)
+}
+
+func (svc *ledgerSvc) credit(ctx context.Context, userId uuid.UUID, kind string, req *params.Credit, staff *common.CustomClaims) *views.Response {
+	if resp := svc.checkUser(ctx, userId); resp != nil {
+		return resp
+	}
+
+	entry := models.LedgerEntry{
+		UserId:    userId,
+		Kind:      kind,
+		Amount:    -req.Amount,
+		Note:      req.Note,
+		CreatedBy: &staff.Id,
+	}
+	err := svc.tx.Transaction(ctx, func(ctx context.Context) error {
+		balance, err := svc.repo.GetBalance(ctx, userId)
+		if err != nil {
+			return err
+		}
+		if req.Amount > balance {
+			return errExceedsBalance
+		}
+		return svc.repo.CreateEntry(ctx, &entry)
+	})
+	if err != nil {
+		if err == errExceedsBalance {
+			return views.ErrorReponse(http.StatusConflict, views.M_AMOUNT_EXCEEDS_BALANCE, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusCreated, views.M_CREATED, transactionView(&entry))
+}
+
+func (svc *ledgerSvc) checkUser(ctx context.Context, userId uuid.UUID) *views.Response {
+	_, err := svc.users.GetUserById(ctx, userId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_USER_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return nil
+}
+
+// StartFineAccrual periodically brings the fines of the overdue loans up to
+// date. Fines are settled for good when a loan is returned.
+func StartFineAccrual(ctx context.Context, loans repository.LoanRepo, repo repository.LedgerRepo) {
+	interval := config.GetFineAccrualInterval()
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := AccrueFines(ctx, loans, repo, time.Now()); err != nil {
+			log.Printf("Failed to accrue fines : %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// AccrueFines sets the fine of every loan overdue at now.
+func AccrueFines(ctx context.Context, loans repository.LoanRepo, repo repository.LedgerRepo, now time.Time) error {
+	rate, limit := config.GetFineDailyRate(), config.GetFineCap()
+	page := repository.Page{PageSize: accrualPageSize}
+	for {
+		list, info, err := loans.GetLoans(ctx, &repository.LoanFilter{DueBefore: now}, &page)
+		if err != nil {
+			return err
+		}
+		for _, l := range list {
+			if fine := l.Fine(now, rate, limit); fine > 0 {
+				if err := repo.SetFine(ctx, l.UserId, l.Id, fine); err != nil {
+					return err
+				}
+			}
+		}
+		if info.NextCursor == "" {
+			return nil
+		}
+		page.Cursor = info.NextCursor
+	}
+}
+
+func transactionView(e *models.LedgerEntry) views.Transaction {
+	return views.Transaction{
+		Id:        e.Id,
+		Kind:      e.Kind,
+		Amount:    e.Amount,
+		LoanId:    e.LoanId,
+		Note:      e.Note,
+		CreatedBy: e.CreatedBy,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+func NewLedgerSvc(repo repository.LedgerRepo, users repository.UserRepo, tx repository.Transactor) service.LedgerSvc {
+	return &ledgerSvc{
+		repo:  repo,
+		users: users,
+		tx:    tx,
+	}
+}
@@ -0,0 +1,159 @@
+package ledger_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/ledger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type ledgerSvcTest struct {
+	repo    *repository.MockLedgerRepo
+	users   *repository.MockUserRepo
+	service service.LedgerSvc
+}
+
+func newLedgerSvcTest(t *testing.T) ledgerSvcTest {
+	mockRepo := repository.NewMockLedgerRepo(t)
+	mockUsers := repository.NewMockUserRepo(t)
+	mockTx := repository.NewMockTransactor(t)
+	mockTx.EXPECT().Transaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).Maybe()
+	ledgerSvc := ledger.NewLedgerSvc(mockRepo, mockUsers, mockTx)
+	return ledgerSvcTest{
+		repo:    mockRepo,
+		users:   mockUsers,
+		service: ledgerSvc,
+	}
+}
+
+func TestLedgerSvc_GetBalance(t *testing.T) {
+	t.Run("success - it should flag balances above the limit", func(t *testing.T) {
+		t.Setenv("FINE_BALANCE_LIMIT", "500")
+		instance := newLedgerSvcTest(t)
+		userId := uuid.New()
+
+		instance.users.EXPECT().GetUserById(mock.Anything, userId).Return(&models.User{Id: userId}, nil)
+		instance.repo.EXPECT().GetBalance(mock.Anything, userId).Return(750, nil)
+		res := instance.service.GetBalance(context.Background(), userId)
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, views.Balance{UserId: userId, Balance: 750, Limit: 500, Blocked: true}, res.Payload)
+	})
+
+	t.Run("error - it should return 404 for an unknown user", func(t *testing.T) {
+		instance := newLedgerSvcTest(t)
+		instance.users.EXPECT().GetUserById(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.GetBalance(context.Background(), uuid.New())
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_USER_NOT_FOUND, res.Message)
+	})
+}
+
+func TestLedgerSvc_GetTransactions(t *testing.T) {
+	t.Run("success - it should list the entries of the user", func(t *testing.T) {
+		instance := newLedgerSvcTest(t)
+		userId, loanId := uuid.New(), uuid.New()
+
+		instance.users.EXPECT().GetUserById(mock.Anything, userId).Return(&models.User{Id: userId}, nil)
+		instance.repo.EXPECT().GetEntries(mock.Anything, userId, mock.Anything).Return([]*models.LedgerEntry{
+			{Id: uuid.New(), UserId: userId, Kind: models.EntryPayment, Amount: -100},
+			{Id: uuid.New(), UserId: userId, Kind: models.EntryFine, Amount: 250, LoanId: &loanId},
+		}, &repository.PageInfo{Total: 2, PageSize: 20}, nil)
+		res := instance.service.GetTransactions(context.Background(), userId, &params.ListTransactions{})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		transactions := res.Payload.([]views.Transaction)
+		assert.Len(t, transactions, 2)
+		assert.Equal(t, &loanId, transactions[1].LoanId)
+		assert.Equal(t, int64(2), res.Meta.(*views.Pagination).Total)
+	})
+
+	t.Run("error - it should return 400 for an unknown sort field", func(t *testing.T) {
+		instance := newLedgerSvcTest(t)
+		instance.users.EXPECT().GetUserById(mock.Anything, mock.Anything).Return(&models.User{}, nil)
+		instance.repo.EXPECT().GetEntries(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, repository.ErrInvalidSort)
+
+		res := instance.service.GetTransactions(context.Background(), uuid.New(), &params.ListTransactions{Sort: "kind"})
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+	})
+}
+
+func TestLedgerSvc_RecordPayment(t *testing.T) {
+	t.Run("success - it should credit the account", func(t *testing.T) {
+		instance := newLedgerSvcTest(t)
+		userId, staffId := uuid.New(), uuid.New()
+
+		instance.users.EXPECT().GetUserById(mock.Anything, userId).Return(&models.User{Id: userId}, nil)
+		instance.repo.EXPECT().GetBalance(mock.Anything, userId).Return(300, nil)
+		instance.repo.EXPECT().CreateEntry(mock.Anything, mock.MatchedBy(func(e *models.LedgerEntry) bool {
+			return e.UserId == userId && e.Kind == models.EntryPayment && e.Amount == -300 && *e.CreatedBy == staffId
+		})).Return(nil)
+		res := instance.service.RecordPayment(context.Background(), userId, &params.Credit{Amount: 300, Note: "cash"}, &common.CustomClaims{Id: staffId})
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+		assert.Equal(t, "cash", res.Payload.(views.Transaction).Note)
+	})
+
+	t.Run("error - it should return 409 when paying more than the balance", func(t *testing.T) {
+		instance := newLedgerSvcTest(t)
+		instance.users.EXPECT().GetUserById(mock.Anything, mock.Anything).Return(&models.User{}, nil)
+		instance.repo.EXPECT().GetBalance(mock.Anything, mock.Anything).Return(100, nil)
+
+		res := instance.service.RecordPayment(context.Background(), uuid.New(), &params.Credit{Amount: 101}, &common.CustomClaims{Id: uuid.New()})
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_AMOUNT_EXCEEDS_BALANCE, res.Message)
+	})
+}
+
+func TestLedgerSvc_RecordWaiver(t *testing.T) {
+	t.Run("success - it should record a waiver", func(t *testing.T) {
+		instance := newLedgerSvcTest(t)
+		userId := uuid.New()
+
+		instance.users.EXPECT().GetUserById(mock.Anything, userId).Return(&models.User{Id: userId}, nil)
+		instance.repo.EXPECT().GetBalance(mock.Anything, userId).Return(300, nil)
+		instance.repo.EXPECT().CreateEntry(mock.Anything, mock.MatchedBy(func(e *models.LedgerEntry) bool {
+			return e.Kind == models.EntryWaiver && e.Amount == -50
+		})).Return(nil)
+		res := instance.service.RecordWaiver(context.Background(), userId, &params.Credit{Amount: 50}, &common.CustomClaims{Id: uuid.New()})
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+	})
+}
+
+func TestAccrueFines(t *testing.T) {
+	t.Run("success - it should charge every started day up to the cap", func(t *testing.T) {
+		t.Setenv("FINE_DAILY_RATE", "25")
+		t.Setenv("FINE_CAP", "100")
+		loans := repository.NewMockLoanRepo(t)
+		repo := repository.NewMockLedgerRepo(t)
+		now := time.Now()
+		recent := &models.Loan{Id: uuid.New(), UserId: uuid.New(), DueAt: now.Add(-25 * time.Hour)}
+		old := &models.Loan{Id: uuid.New(), UserId: uuid.New(), DueAt: now.Add(-30 * 24 * time.Hour)}
+
+		loans.EXPECT().GetLoans(mock.Anything, &repository.LoanFilter{DueBefore: now}, mock.MatchedBy(func(p *repository.Page) bool {
+			return p.Cursor == ""
+		})).Return([]*models.Loan{recent}, &repository.PageInfo{NextCursor: "next"}, nil)
+		loans.EXPECT().GetLoans(mock.Anything, mock.Anything, mock.MatchedBy(func(p *repository.Page) bool {
+			return p.Cursor == "next"
+		})).Return([]*models.Loan{old}, &repository.PageInfo{}, nil)
+		repo.EXPECT().SetFine(mock.Anything, recent.UserId, recent.Id, int64(50)).Return(nil)
+		repo.EXPECT().SetFine(mock.Anything, old.UserId, old.Id, int64(100)).Return(nil)
+
+		assert.NoError(t, ledger.AccrueFines(context.Background(), loans, repo, now))
+	})
+}
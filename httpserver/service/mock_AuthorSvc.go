@@ -5,9 +5,12 @@ package service
 import (
 	context "context"
 
-	params "github.com/storyofhis/books-management/httpserver/controller/params"
+	common "github.com/storyofhis/books-management/common"
+
 	mock "github.com/stretchr/testify/mock"
 
+	params "github.com/storyofhis/books-management/httpserver/controller/params"
+
 	uuid "github.com/google/uuid"
 
 	views "github.com/storyofhis/books-management/httpserver/controller/views"
@@ -76,17 +79,17 @@ func (_c *MockAuthorSvc_CreateAuthor_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
-// DeleteAuthor provides a mock function with given fields: ctx, id
-func (_m *MockAuthorSvc) DeleteAuthor(ctx context.Context, id uuid.UUID) *views.Response {
-	ret := _m.Called(ctx, id)
+// DeleteAuthor provides a mock function with given fields: ctx, id, query, version, user
+func (_m *MockAuthorSvc) DeleteAuthor(ctx context.Context, id uuid.UUID, query *params.DeleteAuthor, version int, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, id, query, version, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteAuthor")
 	}
 
 	var r0 *views.Response
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *views.Response); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *params.DeleteAuthor, int, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, id, query, version, user)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*views.Response)
@@ -104,13 +107,16 @@ type MockAuthorSvc_DeleteAuthor_Call struct {
 // DeleteAuthor is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
-func (_e *MockAuthorSvc_Expecter) DeleteAuthor(ctx interface{}, id interface{}) *MockAuthorSvc_DeleteAuthor_Call {
-	return &MockAuthorSvc_DeleteAuthor_Call{Call: _e.mock.On("DeleteAuthor", ctx, id)}
+//   - query *params.DeleteAuthor
+//   - version int
+//   - user *common.CustomClaims
+func (_e *MockAuthorSvc_Expecter) DeleteAuthor(ctx interface{}, id interface{}, query interface{}, version interface{}, user interface{}) *MockAuthorSvc_DeleteAuthor_Call {
+	return &MockAuthorSvc_DeleteAuthor_Call{Call: _e.mock.On("DeleteAuthor", ctx, id, query, version, user)}
 }
 
-func (_c *MockAuthorSvc_DeleteAuthor_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockAuthorSvc_DeleteAuthor_Call {
+func (_c *MockAuthorSvc_DeleteAuthor_Call) Run(run func(ctx context.Context, id uuid.UUID, query *params.DeleteAuthor, version int, user *common.CustomClaims)) *MockAuthorSvc_DeleteAuthor_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*params.DeleteAuthor), args[3].(int), args[4].(*common.CustomClaims))
 	})
 	return _c
 }
@@ -120,7 +126,7 @@ func (_c *MockAuthorSvc_DeleteAuthor_Call) Return(_a0 *views.Response) *MockAuth
 	return _c
 }
 
-func (_c *MockAuthorSvc_DeleteAuthor_Call) RunAndReturn(run func(context.Context, uuid.UUID) *views.Response) *MockAuthorSvc_DeleteAuthor_Call {
+func (_c *MockAuthorSvc_DeleteAuthor_Call) RunAndReturn(run func(context.Context, uuid.UUID, *params.DeleteAuthor, int, *common.CustomClaims) *views.Response) *MockAuthorSvc_DeleteAuthor_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -174,17 +180,66 @@ func (_c *MockAuthorSvc_GetAuthorById_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
-// GetAuthors provides a mock function with given fields: ctx
-func (_m *MockAuthorSvc) GetAuthors(ctx context.Context) *views.Response {
-	ret := _m.Called(ctx)
+// GetAuthorHistory provides a mock function with given fields: ctx, id
+func (_m *MockAuthorSvc) GetAuthorHistory(ctx context.Context, id uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorHistory")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockAuthorSvc_GetAuthorHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorHistory'
+type MockAuthorSvc_GetAuthorHistory_Call struct {
+	*mock.Call
+}
+
+// GetAuthorHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockAuthorSvc_Expecter) GetAuthorHistory(ctx interface{}, id interface{}) *MockAuthorSvc_GetAuthorHistory_Call {
+	return &MockAuthorSvc_GetAuthorHistory_Call{Call: _e.mock.On("GetAuthorHistory", ctx, id)}
+}
+
+func (_c *MockAuthorSvc_GetAuthorHistory_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockAuthorSvc_GetAuthorHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAuthorSvc_GetAuthorHistory_Call) Return(_a0 *views.Response) *MockAuthorSvc_GetAuthorHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthorSvc_GetAuthorHistory_Call) RunAndReturn(run func(context.Context, uuid.UUID) *views.Response) *MockAuthorSvc_GetAuthorHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthors provides a mock function with given fields: ctx, query
+func (_m *MockAuthorSvc) GetAuthors(ctx context.Context, query *params.ListAuthors) *views.Response {
+	ret := _m.Called(ctx, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAuthors")
 	}
 
 	var r0 *views.Response
-	if rf, ok := ret.Get(0).(func(context.Context) *views.Response); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, *params.ListAuthors) *views.Response); ok {
+		r0 = rf(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*views.Response)
@@ -201,13 +256,14 @@ type MockAuthorSvc_GetAuthors_Call struct {
 
 // GetAuthors is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockAuthorSvc_Expecter) GetAuthors(ctx interface{}) *MockAuthorSvc_GetAuthors_Call {
-	return &MockAuthorSvc_GetAuthors_Call{Call: _e.mock.On("GetAuthors", ctx)}
+//   - query *params.ListAuthors
+func (_e *MockAuthorSvc_Expecter) GetAuthors(ctx interface{}, query interface{}) *MockAuthorSvc_GetAuthors_Call {
+	return &MockAuthorSvc_GetAuthors_Call{Call: _e.mock.On("GetAuthors", ctx, query)}
 }
 
-func (_c *MockAuthorSvc_GetAuthors_Call) Run(run func(ctx context.Context)) *MockAuthorSvc_GetAuthors_Call {
+func (_c *MockAuthorSvc_GetAuthors_Call) Run(run func(ctx context.Context, query *params.ListAuthors)) *MockAuthorSvc_GetAuthors_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(*params.ListAuthors))
 	})
 	return _c
 }
@@ -217,22 +273,124 @@ func (_c *MockAuthorSvc_GetAuthors_Call) Return(_a0 *views.Response) *MockAuthor
 	return _c
 }
 
-func (_c *MockAuthorSvc_GetAuthors_Call) RunAndReturn(run func(context.Context) *views.Response) *MockAuthorSvc_GetAuthors_Call {
+func (_c *MockAuthorSvc_GetAuthors_Call) RunAndReturn(run func(context.Context, *params.ListAuthors) *views.Response) *MockAuthorSvc_GetAuthors_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateAuthor provides a mock function with given fields: ctx, author, id
-func (_m *MockAuthorSvc) UpdateAuthor(ctx context.Context, author *params.UpdateAuthors, id uuid.UUID) *views.Response {
-	ret := _m.Called(ctx, author, id)
+// RestoreAuthor provides a mock function with given fields: ctx, id, user
+func (_m *MockAuthorSvc) RestoreAuthor(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, id, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAuthor")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, id, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockAuthorSvc_RestoreAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreAuthor'
+type MockAuthorSvc_RestoreAuthor_Call struct {
+	*mock.Call
+}
+
+// RestoreAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - user *common.CustomClaims
+func (_e *MockAuthorSvc_Expecter) RestoreAuthor(ctx interface{}, id interface{}, user interface{}) *MockAuthorSvc_RestoreAuthor_Call {
+	return &MockAuthorSvc_RestoreAuthor_Call{Call: _e.mock.On("RestoreAuthor", ctx, id, user)}
+}
+
+func (_c *MockAuthorSvc_RestoreAuthor_Call) Run(run func(ctx context.Context, id uuid.UUID, user *common.CustomClaims)) *MockAuthorSvc_RestoreAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockAuthorSvc_RestoreAuthor_Call) Return(_a0 *views.Response) *MockAuthorSvc_RestoreAuthor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthorSvc_RestoreAuthor_Call) RunAndReturn(run func(context.Context, uuid.UUID, *common.CustomClaims) *views.Response) *MockAuthorSvc_RestoreAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevertAuthor provides a mock function with given fields: ctx, id, revert, version, actorId
+func (_m *MockAuthorSvc) RevertAuthor(ctx context.Context, id uuid.UUID, revert *params.Revert, version int, actorId uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, id, revert, version, actorId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertAuthor")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *params.Revert, int, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, id, revert, version, actorId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockAuthorSvc_RevertAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevertAuthor'
+type MockAuthorSvc_RevertAuthor_Call struct {
+	*mock.Call
+}
+
+// RevertAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - revert *params.Revert
+//   - version int
+//   - actorId uuid.UUID
+func (_e *MockAuthorSvc_Expecter) RevertAuthor(ctx interface{}, id interface{}, revert interface{}, version interface{}, actorId interface{}) *MockAuthorSvc_RevertAuthor_Call {
+	return &MockAuthorSvc_RevertAuthor_Call{Call: _e.mock.On("RevertAuthor", ctx, id, revert, version, actorId)}
+}
+
+func (_c *MockAuthorSvc_RevertAuthor_Call) Run(run func(ctx context.Context, id uuid.UUID, revert *params.Revert, version int, actorId uuid.UUID)) *MockAuthorSvc_RevertAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*params.Revert), args[3].(int), args[4].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAuthorSvc_RevertAuthor_Call) Return(_a0 *views.Response) *MockAuthorSvc_RevertAuthor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthorSvc_RevertAuthor_Call) RunAndReturn(run func(context.Context, uuid.UUID, *params.Revert, int, uuid.UUID) *views.Response) *MockAuthorSvc_RevertAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAuthor provides a mock function with given fields: ctx, author, id, version, actorId
+func (_m *MockAuthorSvc) UpdateAuthor(ctx context.Context, author *params.UpdateAuthors, id uuid.UUID, version int, actorId uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, author, id, version, actorId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateAuthor")
 	}
 
 	var r0 *views.Response
-	if rf, ok := ret.Get(0).(func(context.Context, *params.UpdateAuthors, uuid.UUID) *views.Response); ok {
-		r0 = rf(ctx, author, id)
+	if rf, ok := ret.Get(0).(func(context.Context, *params.UpdateAuthors, uuid.UUID, int, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, author, id, version, actorId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*views.Response)
@@ -251,13 +409,15 @@ type MockAuthorSvc_UpdateAuthor_Call struct {
 //   - ctx context.Context
 //   - author *params.UpdateAuthors
 //   - id uuid.UUID
-func (_e *MockAuthorSvc_Expecter) UpdateAuthor(ctx interface{}, author interface{}, id interface{}) *MockAuthorSvc_UpdateAuthor_Call {
-	return &MockAuthorSvc_UpdateAuthor_Call{Call: _e.mock.On("UpdateAuthor", ctx, author, id)}
+//   - version int
+//   - actorId uuid.UUID
+func (_e *MockAuthorSvc_Expecter) UpdateAuthor(ctx interface{}, author interface{}, id interface{}, version interface{}, actorId interface{}) *MockAuthorSvc_UpdateAuthor_Call {
+	return &MockAuthorSvc_UpdateAuthor_Call{Call: _e.mock.On("UpdateAuthor", ctx, author, id, version, actorId)}
 }
 
-func (_c *MockAuthorSvc_UpdateAuthor_Call) Run(run func(ctx context.Context, author *params.UpdateAuthors, id uuid.UUID)) *MockAuthorSvc_UpdateAuthor_Call {
+func (_c *MockAuthorSvc_UpdateAuthor_Call) Run(run func(ctx context.Context, author *params.UpdateAuthors, id uuid.UUID, version int, actorId uuid.UUID)) *MockAuthorSvc_UpdateAuthor_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*params.UpdateAuthors), args[2].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(*params.UpdateAuthors), args[2].(uuid.UUID), args[3].(int), args[4].(uuid.UUID))
 	})
 	return _c
 }
@@ -267,7 +427,7 @@ func (_c *MockAuthorSvc_UpdateAuthor_Call) Return(_a0 *views.Response) *MockAuth
 	return _c
 }
 
-func (_c *MockAuthorSvc_UpdateAuthor_Call) RunAndReturn(run func(context.Context, *params.UpdateAuthors, uuid.UUID) *views.Response) *MockAuthorSvc_UpdateAuthor_Call {
+func (_c *MockAuthorSvc_UpdateAuthor_Call) RunAndReturn(run func(context.Context, *params.UpdateAuthors, uuid.UUID, int, uuid.UUID) *views.Response) *MockAuthorSvc_UpdateAuthor_Call {
 	_c.Call.Return(run)
 	return _c
 }
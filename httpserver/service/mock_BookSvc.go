@@ -5,9 +5,12 @@ package service
 import (
 	context "context"
 
-	params "github.com/storyofhis/books-management/httpserver/controller/params"
+	common "github.com/storyofhis/books-management/common"
+
 	mock "github.com/stretchr/testify/mock"
 
+	params "github.com/storyofhis/books-management/httpserver/controller/params"
+
 	uuid "github.com/google/uuid"
 
 	views "github.com/storyofhis/books-management/httpserver/controller/views"
@@ -76,17 +79,17 @@ func (_c *MockBookSvc_CreateBook_Call) RunAndReturn(run func(context.Context, *p
 	return _c
 }
 
-// DeleteBook provides a mock function with given fields: ctx, id
-func (_m *MockBookSvc) DeleteBook(ctx context.Context, id uuid.UUID) *views.Response {
-	ret := _m.Called(ctx, id)
+// DeleteBook provides a mock function with given fields: ctx, id, version, actorId
+func (_m *MockBookSvc) DeleteBook(ctx context.Context, id uuid.UUID, version int, actorId uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, id, version, actorId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteBook")
 	}
 
 	var r0 *views.Response
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *views.Response); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, id, version, actorId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*views.Response)
@@ -104,13 +107,15 @@ type MockBookSvc_DeleteBook_Call struct {
 // DeleteBook is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
-func (_e *MockBookSvc_Expecter) DeleteBook(ctx interface{}, id interface{}) *MockBookSvc_DeleteBook_Call {
-	return &MockBookSvc_DeleteBook_Call{Call: _e.mock.On("DeleteBook", ctx, id)}
+//   - version int
+//   - actorId uuid.UUID
+func (_e *MockBookSvc_Expecter) DeleteBook(ctx interface{}, id interface{}, version interface{}, actorId interface{}) *MockBookSvc_DeleteBook_Call {
+	return &MockBookSvc_DeleteBook_Call{Call: _e.mock.On("DeleteBook", ctx, id, version, actorId)}
 }
 
-func (_c *MockBookSvc_DeleteBook_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockBookSvc_DeleteBook_Call {
+func (_c *MockBookSvc_DeleteBook_Call) Run(run func(ctx context.Context, id uuid.UUID, version int, actorId uuid.UUID)) *MockBookSvc_DeleteBook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int), args[3].(uuid.UUID))
 	})
 	return _c
 }
@@ -120,7 +125,7 @@ func (_c *MockBookSvc_DeleteBook_Call) Return(_a0 *views.Response) *MockBookSvc_
 	return _c
 }
 
-func (_c *MockBookSvc_DeleteBook_Call) RunAndReturn(run func(context.Context, uuid.UUID) *views.Response) *MockBookSvc_DeleteBook_Call {
+func (_c *MockBookSvc_DeleteBook_Call) RunAndReturn(run func(context.Context, uuid.UUID, int, uuid.UUID) *views.Response) *MockBookSvc_DeleteBook_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -174,17 +179,66 @@ func (_c *MockBookSvc_GetBookById_Call) RunAndReturn(run func(context.Context, u
 	return _c
 }
 
-// GetBooks provides a mock function with given fields: ctx
-func (_m *MockBookSvc) GetBooks(ctx context.Context) *views.Response {
-	ret := _m.Called(ctx)
+// GetBookHistory provides a mock function with given fields: ctx, id
+func (_m *MockBookSvc) GetBookHistory(ctx context.Context, id uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookHistory")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockBookSvc_GetBookHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookHistory'
+type MockBookSvc_GetBookHistory_Call struct {
+	*mock.Call
+}
+
+// GetBookHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockBookSvc_Expecter) GetBookHistory(ctx interface{}, id interface{}) *MockBookSvc_GetBookHistory_Call {
+	return &MockBookSvc_GetBookHistory_Call{Call: _e.mock.On("GetBookHistory", ctx, id)}
+}
+
+func (_c *MockBookSvc_GetBookHistory_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockBookSvc_GetBookHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBookSvc_GetBookHistory_Call) Return(_a0 *views.Response) *MockBookSvc_GetBookHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBookSvc_GetBookHistory_Call) RunAndReturn(run func(context.Context, uuid.UUID) *views.Response) *MockBookSvc_GetBookHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBooks provides a mock function with given fields: ctx, query
+func (_m *MockBookSvc) GetBooks(ctx context.Context, query *params.ListBooks) *views.Response {
+	ret := _m.Called(ctx, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBooks")
 	}
 
 	var r0 *views.Response
-	if rf, ok := ret.Get(0).(func(context.Context) *views.Response); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, *params.ListBooks) *views.Response); ok {
+		r0 = rf(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*views.Response)
@@ -201,13 +255,14 @@ type MockBookSvc_GetBooks_Call struct {
 
 // GetBooks is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockBookSvc_Expecter) GetBooks(ctx interface{}) *MockBookSvc_GetBooks_Call {
-	return &MockBookSvc_GetBooks_Call{Call: _e.mock.On("GetBooks", ctx)}
+//   - query *params.ListBooks
+func (_e *MockBookSvc_Expecter) GetBooks(ctx interface{}, query interface{}) *MockBookSvc_GetBooks_Call {
+	return &MockBookSvc_GetBooks_Call{Call: _e.mock.On("GetBooks", ctx, query)}
 }
 
-func (_c *MockBookSvc_GetBooks_Call) Run(run func(ctx context.Context)) *MockBookSvc_GetBooks_Call {
+func (_c *MockBookSvc_GetBooks_Call) Run(run func(ctx context.Context, query *params.ListBooks)) *MockBookSvc_GetBooks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(*params.ListBooks))
 	})
 	return _c
 }
@@ -217,22 +272,124 @@ func (_c *MockBookSvc_GetBooks_Call) Return(_a0 *views.Response) *MockBookSvc_Ge
 	return _c
 }
 
-func (_c *MockBookSvc_GetBooks_Call) RunAndReturn(run func(context.Context) *views.Response) *MockBookSvc_GetBooks_Call {
+func (_c *MockBookSvc_GetBooks_Call) RunAndReturn(run func(context.Context, *params.ListBooks) *views.Response) *MockBookSvc_GetBooks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateBook provides a mock function with given fields: ctx, book, id
-func (_m *MockBookSvc) UpdateBook(ctx context.Context, book *params.UpdateBook, id uuid.UUID) *views.Response {
-	ret := _m.Called(ctx, book, id)
+// RestoreBook provides a mock function with given fields: ctx, id, user
+func (_m *MockBookSvc) RestoreBook(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, id, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreBook")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, id, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockBookSvc_RestoreBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreBook'
+type MockBookSvc_RestoreBook_Call struct {
+	*mock.Call
+}
+
+// RestoreBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - user *common.CustomClaims
+func (_e *MockBookSvc_Expecter) RestoreBook(ctx interface{}, id interface{}, user interface{}) *MockBookSvc_RestoreBook_Call {
+	return &MockBookSvc_RestoreBook_Call{Call: _e.mock.On("RestoreBook", ctx, id, user)}
+}
+
+func (_c *MockBookSvc_RestoreBook_Call) Run(run func(ctx context.Context, id uuid.UUID, user *common.CustomClaims)) *MockBookSvc_RestoreBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockBookSvc_RestoreBook_Call) Return(_a0 *views.Response) *MockBookSvc_RestoreBook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBookSvc_RestoreBook_Call) RunAndReturn(run func(context.Context, uuid.UUID, *common.CustomClaims) *views.Response) *MockBookSvc_RestoreBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevertBook provides a mock function with given fields: ctx, id, revert, version, actorId
+func (_m *MockBookSvc) RevertBook(ctx context.Context, id uuid.UUID, revert *params.Revert, version int, actorId uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, id, revert, version, actorId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertBook")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *params.Revert, int, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, id, revert, version, actorId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockBookSvc_RevertBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevertBook'
+type MockBookSvc_RevertBook_Call struct {
+	*mock.Call
+}
+
+// RevertBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - revert *params.Revert
+//   - version int
+//   - actorId uuid.UUID
+func (_e *MockBookSvc_Expecter) RevertBook(ctx interface{}, id interface{}, revert interface{}, version interface{}, actorId interface{}) *MockBookSvc_RevertBook_Call {
+	return &MockBookSvc_RevertBook_Call{Call: _e.mock.On("RevertBook", ctx, id, revert, version, actorId)}
+}
+
+func (_c *MockBookSvc_RevertBook_Call) Run(run func(ctx context.Context, id uuid.UUID, revert *params.Revert, version int, actorId uuid.UUID)) *MockBookSvc_RevertBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*params.Revert), args[3].(int), args[4].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBookSvc_RevertBook_Call) Return(_a0 *views.Response) *MockBookSvc_RevertBook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBookSvc_RevertBook_Call) RunAndReturn(run func(context.Context, uuid.UUID, *params.Revert, int, uuid.UUID) *views.Response) *MockBookSvc_RevertBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBook provides a mock function with given fields: ctx, book, id, version, actorId
+func (_m *MockBookSvc) UpdateBook(ctx context.Context, book *params.UpdateBook, id uuid.UUID, version int, actorId uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, book, id, version, actorId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateBook")
 	}
 
 	var r0 *views.Response
-	if rf, ok := ret.Get(0).(func(context.Context, *params.UpdateBook, uuid.UUID) *views.Response); ok {
-		r0 = rf(ctx, book, id)
+	if rf, ok := ret.Get(0).(func(context.Context, *params.UpdateBook, uuid.UUID, int, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, book, id, version, actorId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*views.Response)
@@ -251,13 +408,15 @@ type MockBookSvc_UpdateBook_Call struct {
 //   - ctx context.Context
 //   - book *params.UpdateBook
 //   - id uuid.UUID
-func (_e *MockBookSvc_Expecter) UpdateBook(ctx interface{}, book interface{}, id interface{}) *MockBookSvc_UpdateBook_Call {
-	return &MockBookSvc_UpdateBook_Call{Call: _e.mock.On("UpdateBook", ctx, book, id)}
+//   - version int
+//   - actorId uuid.UUID
+func (_e *MockBookSvc_Expecter) UpdateBook(ctx interface{}, book interface{}, id interface{}, version interface{}, actorId interface{}) *MockBookSvc_UpdateBook_Call {
+	return &MockBookSvc_UpdateBook_Call{Call: _e.mock.On("UpdateBook", ctx, book, id, version, actorId)}
 }
 
-func (_c *MockBookSvc_UpdateBook_Call) Run(run func(ctx context.Context, book *params.UpdateBook, id uuid.UUID)) *MockBookSvc_UpdateBook_Call {
+func (_c *MockBookSvc_UpdateBook_Call) Run(run func(ctx context.Context, book *params.UpdateBook, id uuid.UUID, version int, actorId uuid.UUID)) *MockBookSvc_UpdateBook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*params.UpdateBook), args[2].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(*params.UpdateBook), args[2].(uuid.UUID), args[3].(int), args[4].(uuid.UUID))
 	})
 	return _c
 }
@@ -267,7 +426,7 @@ func (_c *MockBookSvc_UpdateBook_Call) Return(_a0 *views.Response) *MockBookSvc_
 	return _c
 }
 
-func (_c *MockBookSvc_UpdateBook_Call) RunAndReturn(run func(context.Context, *params.UpdateBook, uuid.UUID) *views.Response) *MockBookSvc_UpdateBook_Call {
+func (_c *MockBookSvc_UpdateBook_Call) RunAndReturn(run func(context.Context, *params.UpdateBook, uuid.UUID, int, uuid.UUID) *views.Response) *MockBookSvc_UpdateBook_Call {
 	_c.Call.Return(run)
 	return _c
 }
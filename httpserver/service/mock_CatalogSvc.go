@@ -0,0 +1,286 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	params "github.com/storyofhis/books-management/httpserver/controller/params"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+
+	views "github.com/storyofhis/books-management/httpserver/controller/views"
+)
+
+// MockCatalogSvc is an autogenerated mock type for the CatalogSvc type
+type MockCatalogSvc struct {
+	mock.Mock
+}
+
+type MockCatalogSvc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCatalogSvc) EXPECT() *MockCatalogSvc_Expecter {
+	return &MockCatalogSvc_Expecter{mock: &_m.Mock}
+}
+
+// GetAuthorBooks provides a mock function with given fields: ctx, id, query
+func (_m *MockCatalogSvc) GetAuthorBooks(ctx context.Context, id uuid.UUID, query *params.CatalogPage) *views.Response {
+	ret := _m.Called(ctx, id, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorBooks")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *params.CatalogPage) *views.Response); ok {
+		r0 = rf(ctx, id, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockCatalogSvc_GetAuthorBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorBooks'
+type MockCatalogSvc_GetAuthorBooks_Call struct {
+	*mock.Call
+}
+
+// GetAuthorBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - query *params.CatalogPage
+func (_e *MockCatalogSvc_Expecter) GetAuthorBooks(ctx interface{}, id interface{}, query interface{}) *MockCatalogSvc_GetAuthorBooks_Call {
+	return &MockCatalogSvc_GetAuthorBooks_Call{Call: _e.mock.On("GetAuthorBooks", ctx, id, query)}
+}
+
+func (_c *MockCatalogSvc_GetAuthorBooks_Call) Run(run func(ctx context.Context, id uuid.UUID, query *params.CatalogPage)) *MockCatalogSvc_GetAuthorBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*params.CatalogPage))
+	})
+	return _c
+}
+
+func (_c *MockCatalogSvc_GetAuthorBooks_Call) Return(_a0 *views.Response) *MockCatalogSvc_GetAuthorBooks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCatalogSvc_GetAuthorBooks_Call) RunAndReturn(run func(context.Context, uuid.UUID, *params.CatalogPage) *views.Response) *MockCatalogSvc_GetAuthorBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthors provides a mock function with given fields: ctx, query
+func (_m *MockCatalogSvc) GetAuthors(ctx context.Context, query *params.CatalogPage) *views.Response {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthors")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, *params.CatalogPage) *views.Response); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockCatalogSvc_GetAuthors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthors'
+type MockCatalogSvc_GetAuthors_Call struct {
+	*mock.Call
+}
+
+// GetAuthors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *params.CatalogPage
+func (_e *MockCatalogSvc_Expecter) GetAuthors(ctx interface{}, query interface{}) *MockCatalogSvc_GetAuthors_Call {
+	return &MockCatalogSvc_GetAuthors_Call{Call: _e.mock.On("GetAuthors", ctx, query)}
+}
+
+func (_c *MockCatalogSvc_GetAuthors_Call) Run(run func(ctx context.Context, query *params.CatalogPage)) *MockCatalogSvc_GetAuthors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*params.CatalogPage))
+	})
+	return _c
+}
+
+func (_c *MockCatalogSvc_GetAuthors_Call) Return(_a0 *views.Response) *MockCatalogSvc_GetAuthors_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCatalogSvc_GetAuthors_Call) RunAndReturn(run func(context.Context, *params.CatalogPage) *views.Response) *MockCatalogSvc_GetAuthors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNewest provides a mock function with given fields: ctx, query
+func (_m *MockCatalogSvc) GetNewest(ctx context.Context, query *params.CatalogPage) *views.Response {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNewest")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, *params.CatalogPage) *views.Response); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockCatalogSvc_GetNewest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNewest'
+type MockCatalogSvc_GetNewest_Call struct {
+	*mock.Call
+}
+
+// GetNewest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *params.CatalogPage
+func (_e *MockCatalogSvc_Expecter) GetNewest(ctx interface{}, query interface{}) *MockCatalogSvc_GetNewest_Call {
+	return &MockCatalogSvc_GetNewest_Call{Call: _e.mock.On("GetNewest", ctx, query)}
+}
+
+func (_c *MockCatalogSvc_GetNewest_Call) Run(run func(ctx context.Context, query *params.CatalogPage)) *MockCatalogSvc_GetNewest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*params.CatalogPage))
+	})
+	return _c
+}
+
+func (_c *MockCatalogSvc_GetNewest_Call) Return(_a0 *views.Response) *MockCatalogSvc_GetNewest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCatalogSvc_GetNewest_Call) RunAndReturn(run func(context.Context, *params.CatalogPage) *views.Response) *MockCatalogSvc_GetNewest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRoot provides a mock function with given fields: ctx
+func (_m *MockCatalogSvc) GetRoot(ctx context.Context) *views.Response {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoot")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context) *views.Response); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockCatalogSvc_GetRoot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoot'
+type MockCatalogSvc_GetRoot_Call struct {
+	*mock.Call
+}
+
+// GetRoot is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCatalogSvc_Expecter) GetRoot(ctx interface{}) *MockCatalogSvc_GetRoot_Call {
+	return &MockCatalogSvc_GetRoot_Call{Call: _e.mock.On("GetRoot", ctx)}
+}
+
+func (_c *MockCatalogSvc_GetRoot_Call) Run(run func(ctx context.Context)) *MockCatalogSvc_GetRoot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCatalogSvc_GetRoot_Call) Return(_a0 *views.Response) *MockCatalogSvc_GetRoot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCatalogSvc_GetRoot_Call) RunAndReturn(run func(context.Context) *views.Response) *MockCatalogSvc_GetRoot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: ctx, query
+func (_m *MockCatalogSvc) Search(ctx context.Context, query *params.CatalogSearch) *views.Response {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, *params.CatalogSearch) *views.Response); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockCatalogSvc_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockCatalogSvc_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *params.CatalogSearch
+func (_e *MockCatalogSvc_Expecter) Search(ctx interface{}, query interface{}) *MockCatalogSvc_Search_Call {
+	return &MockCatalogSvc_Search_Call{Call: _e.mock.On("Search", ctx, query)}
+}
+
+func (_c *MockCatalogSvc_Search_Call) Run(run func(ctx context.Context, query *params.CatalogSearch)) *MockCatalogSvc_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*params.CatalogSearch))
+	})
+	return _c
+}
+
+func (_c *MockCatalogSvc_Search_Call) Return(_a0 *views.Response) *MockCatalogSvc_Search_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCatalogSvc_Search_Call) RunAndReturn(run func(context.Context, *params.CatalogSearch) *views.Response) *MockCatalogSvc_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCatalogSvc creates a new instance of MockCatalogSvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCatalogSvc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCatalogSvc {
+	mock := &MockCatalogSvc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
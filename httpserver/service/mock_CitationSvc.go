@@ -0,0 +1,143 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	common "github.com/storyofhis/books-management/common"
+
+	mock "github.com/stretchr/testify/mock"
+
+	params "github.com/storyofhis/books-management/httpserver/controller/params"
+
+	uuid "github.com/google/uuid"
+
+	views "github.com/storyofhis/books-management/httpserver/controller/views"
+)
+
+// MockCitationSvc is an autogenerated mock type for the CitationSvc type
+type MockCitationSvc struct {
+	mock.Mock
+}
+
+type MockCitationSvc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCitationSvc) EXPECT() *MockCitationSvc_Expecter {
+	return &MockCitationSvc_Expecter{mock: &_m.Mock}
+}
+
+// GetCitation provides a mock function with given fields: ctx, id
+func (_m *MockCitationSvc) GetCitation(ctx context.Context, id uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCitation")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockCitationSvc_GetCitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCitation'
+type MockCitationSvc_GetCitation_Call struct {
+	*mock.Call
+}
+
+// GetCitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockCitationSvc_Expecter) GetCitation(ctx interface{}, id interface{}) *MockCitationSvc_GetCitation_Call {
+	return &MockCitationSvc_GetCitation_Call{Call: _e.mock.On("GetCitation", ctx, id)}
+}
+
+func (_c *MockCitationSvc_GetCitation_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockCitationSvc_GetCitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCitationSvc_GetCitation_Call) Return(_a0 *views.Response) *MockCitationSvc_GetCitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCitationSvc_GetCitation_Call) RunAndReturn(run func(context.Context, uuid.UUID) *views.Response) *MockCitationSvc_GetCitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCitations provides a mock function with given fields: ctx, query, user
+func (_m *MockCitationSvc) GetCitations(ctx context.Context, query *params.Citations, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, query, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCitations")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, *params.Citations, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, query, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockCitationSvc_GetCitations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCitations'
+type MockCitationSvc_GetCitations_Call struct {
+	*mock.Call
+}
+
+// GetCitations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *params.Citations
+//   - user *common.CustomClaims
+func (_e *MockCitationSvc_Expecter) GetCitations(ctx interface{}, query interface{}, user interface{}) *MockCitationSvc_GetCitations_Call {
+	return &MockCitationSvc_GetCitations_Call{Call: _e.mock.On("GetCitations", ctx, query, user)}
+}
+
+func (_c *MockCitationSvc_GetCitations_Call) Run(run func(ctx context.Context, query *params.Citations, user *common.CustomClaims)) *MockCitationSvc_GetCitations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*params.Citations), args[2].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockCitationSvc_GetCitations_Call) Return(_a0 *views.Response) *MockCitationSvc_GetCitations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCitationSvc_GetCitations_Call) RunAndReturn(run func(context.Context, *params.Citations, *common.CustomClaims) *views.Response) *MockCitationSvc_GetCitations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCitationSvc creates a new instance of MockCitationSvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCitationSvc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCitationSvc {
+	mock := &MockCitationSvc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
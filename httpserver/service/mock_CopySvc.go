@@ -0,0 +1,340 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	params "github.com/storyofhis/books-management/httpserver/controller/params"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+
+	views "github.com/storyofhis/books-management/httpserver/controller/views"
+)
+
+// MockCopySvc is an autogenerated mock type for the CopySvc type
+type MockCopySvc struct {
+	mock.Mock
+}
+
+type MockCopySvc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCopySvc) EXPECT() *MockCopySvc_Expecter {
+	return &MockCopySvc_Expecter{mock: &_m.Mock}
+}
+
+// CreateCopy provides a mock function with given fields: ctx, bookId, req
+func (_m *MockCopySvc) CreateCopy(ctx context.Context, bookId uuid.UUID, req *params.CreateCopy) *views.Response {
+	ret := _m.Called(ctx, bookId, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCopy")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *params.CreateCopy) *views.Response); ok {
+		r0 = rf(ctx, bookId, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockCopySvc_CreateCopy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCopy'
+type MockCopySvc_CreateCopy_Call struct {
+	*mock.Call
+}
+
+// CreateCopy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+//   - req *params.CreateCopy
+func (_e *MockCopySvc_Expecter) CreateCopy(ctx interface{}, bookId interface{}, req interface{}) *MockCopySvc_CreateCopy_Call {
+	return &MockCopySvc_CreateCopy_Call{Call: _e.mock.On("CreateCopy", ctx, bookId, req)}
+}
+
+func (_c *MockCopySvc_CreateCopy_Call) Run(run func(ctx context.Context, bookId uuid.UUID, req *params.CreateCopy)) *MockCopySvc_CreateCopy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*params.CreateCopy))
+	})
+	return _c
+}
+
+func (_c *MockCopySvc_CreateCopy_Call) Return(_a0 *views.Response) *MockCopySvc_CreateCopy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCopySvc_CreateCopy_Call) RunAndReturn(run func(context.Context, uuid.UUID, *params.CreateCopy) *views.Response) *MockCopySvc_CreateCopy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCopy provides a mock function with given fields: ctx, bookId, id
+func (_m *MockCopySvc) DeleteCopy(ctx context.Context, bookId uuid.UUID, id uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, bookId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCopy")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, bookId, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockCopySvc_DeleteCopy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCopy'
+type MockCopySvc_DeleteCopy_Call struct {
+	*mock.Call
+}
+
+// DeleteCopy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+//   - id uuid.UUID
+func (_e *MockCopySvc_Expecter) DeleteCopy(ctx interface{}, bookId interface{}, id interface{}) *MockCopySvc_DeleteCopy_Call {
+	return &MockCopySvc_DeleteCopy_Call{Call: _e.mock.On("DeleteCopy", ctx, bookId, id)}
+}
+
+func (_c *MockCopySvc_DeleteCopy_Call) Run(run func(ctx context.Context, bookId uuid.UUID, id uuid.UUID)) *MockCopySvc_DeleteCopy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCopySvc_DeleteCopy_Call) Return(_a0 *views.Response) *MockCopySvc_DeleteCopy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCopySvc_DeleteCopy_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) *views.Response) *MockCopySvc_DeleteCopy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCopies provides a mock function with given fields: ctx, bookId
+func (_m *MockCopySvc) GetCopies(ctx context.Context, bookId uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, bookId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCopies")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, bookId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockCopySvc_GetCopies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCopies'
+type MockCopySvc_GetCopies_Call struct {
+	*mock.Call
+}
+
+// GetCopies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+func (_e *MockCopySvc_Expecter) GetCopies(ctx interface{}, bookId interface{}) *MockCopySvc_GetCopies_Call {
+	return &MockCopySvc_GetCopies_Call{Call: _e.mock.On("GetCopies", ctx, bookId)}
+}
+
+func (_c *MockCopySvc_GetCopies_Call) Run(run func(ctx context.Context, bookId uuid.UUID)) *MockCopySvc_GetCopies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCopySvc_GetCopies_Call) Return(_a0 *views.Response) *MockCopySvc_GetCopies_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCopySvc_GetCopies_Call) RunAndReturn(run func(context.Context, uuid.UUID) *views.Response) *MockCopySvc_GetCopies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCopy provides a mock function with given fields: ctx, bookId, id
+func (_m *MockCopySvc) GetCopy(ctx context.Context, bookId uuid.UUID, id uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, bookId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCopy")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, bookId, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockCopySvc_GetCopy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCopy'
+type MockCopySvc_GetCopy_Call struct {
+	*mock.Call
+}
+
+// GetCopy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+//   - id uuid.UUID
+func (_e *MockCopySvc_Expecter) GetCopy(ctx interface{}, bookId interface{}, id interface{}) *MockCopySvc_GetCopy_Call {
+	return &MockCopySvc_GetCopy_Call{Call: _e.mock.On("GetCopy", ctx, bookId, id)}
+}
+
+func (_c *MockCopySvc_GetCopy_Call) Run(run func(ctx context.Context, bookId uuid.UUID, id uuid.UUID)) *MockCopySvc_GetCopy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCopySvc_GetCopy_Call) Return(_a0 *views.Response) *MockCopySvc_GetCopy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCopySvc_GetCopy_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) *views.Response) *MockCopySvc_GetCopy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCopyByBarcode provides a mock function with given fields: ctx, barcode
+func (_m *MockCopySvc) GetCopyByBarcode(ctx context.Context, barcode string) *views.Response {
+	ret := _m.Called(ctx, barcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCopyByBarcode")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, string) *views.Response); ok {
+		r0 = rf(ctx, barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockCopySvc_GetCopyByBarcode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCopyByBarcode'
+type MockCopySvc_GetCopyByBarcode_Call struct {
+	*mock.Call
+}
+
+// GetCopyByBarcode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - barcode string
+func (_e *MockCopySvc_Expecter) GetCopyByBarcode(ctx interface{}, barcode interface{}) *MockCopySvc_GetCopyByBarcode_Call {
+	return &MockCopySvc_GetCopyByBarcode_Call{Call: _e.mock.On("GetCopyByBarcode", ctx, barcode)}
+}
+
+func (_c *MockCopySvc_GetCopyByBarcode_Call) Run(run func(ctx context.Context, barcode string)) *MockCopySvc_GetCopyByBarcode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCopySvc_GetCopyByBarcode_Call) Return(_a0 *views.Response) *MockCopySvc_GetCopyByBarcode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCopySvc_GetCopyByBarcode_Call) RunAndReturn(run func(context.Context, string) *views.Response) *MockCopySvc_GetCopyByBarcode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCopy provides a mock function with given fields: ctx, bookId, id, req
+func (_m *MockCopySvc) UpdateCopy(ctx context.Context, bookId uuid.UUID, id uuid.UUID, req *params.UpdateCopy) *views.Response {
+	ret := _m.Called(ctx, bookId, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCopy")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *params.UpdateCopy) *views.Response); ok {
+		r0 = rf(ctx, bookId, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockCopySvc_UpdateCopy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCopy'
+type MockCopySvc_UpdateCopy_Call struct {
+	*mock.Call
+}
+
+// UpdateCopy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+//   - id uuid.UUID
+//   - req *params.UpdateCopy
+func (_e *MockCopySvc_Expecter) UpdateCopy(ctx interface{}, bookId interface{}, id interface{}, req interface{}) *MockCopySvc_UpdateCopy_Call {
+	return &MockCopySvc_UpdateCopy_Call{Call: _e.mock.On("UpdateCopy", ctx, bookId, id, req)}
+}
+
+func (_c *MockCopySvc_UpdateCopy_Call) Run(run func(ctx context.Context, bookId uuid.UUID, id uuid.UUID, req *params.UpdateCopy)) *MockCopySvc_UpdateCopy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(*params.UpdateCopy))
+	})
+	return _c
+}
+
+func (_c *MockCopySvc_UpdateCopy_Call) Return(_a0 *views.Response) *MockCopySvc_UpdateCopy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCopySvc_UpdateCopy_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, *params.UpdateCopy) *views.Response) *MockCopySvc_UpdateCopy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCopySvc creates a new instance of MockCopySvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCopySvc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCopySvc {
+	mock := &MockCopySvc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
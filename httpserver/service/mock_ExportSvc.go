@@ -0,0 +1,141 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	params "github.com/storyofhis/books-management/httpserver/controller/params"
+
+	views "github.com/storyofhis/books-management/httpserver/controller/views"
+)
+
+// MockExportSvc is an autogenerated mock type for the ExportSvc type
+type MockExportSvc struct {
+	mock.Mock
+}
+
+type MockExportSvc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportSvc) EXPECT() *MockExportSvc_Expecter {
+	return &MockExportSvc_Expecter{mock: &_m.Mock}
+}
+
+// ExportAuthors provides a mock function with given fields: ctx, query, w
+func (_m *MockExportSvc) ExportAuthors(ctx context.Context, query *params.ExportAuthors, w io.Writer) *views.Response {
+	ret := _m.Called(ctx, query, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportAuthors")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, *params.ExportAuthors, io.Writer) *views.Response); ok {
+		r0 = rf(ctx, query, w)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockExportSvc_ExportAuthors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportAuthors'
+type MockExportSvc_ExportAuthors_Call struct {
+	*mock.Call
+}
+
+// ExportAuthors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *params.ExportAuthors
+//   - w io.Writer
+func (_e *MockExportSvc_Expecter) ExportAuthors(ctx interface{}, query interface{}, w interface{}) *MockExportSvc_ExportAuthors_Call {
+	return &MockExportSvc_ExportAuthors_Call{Call: _e.mock.On("ExportAuthors", ctx, query, w)}
+}
+
+func (_c *MockExportSvc_ExportAuthors_Call) Run(run func(ctx context.Context, query *params.ExportAuthors, w io.Writer)) *MockExportSvc_ExportAuthors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*params.ExportAuthors), args[2].(io.Writer))
+	})
+	return _c
+}
+
+func (_c *MockExportSvc_ExportAuthors_Call) Return(_a0 *views.Response) *MockExportSvc_ExportAuthors_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExportSvc_ExportAuthors_Call) RunAndReturn(run func(context.Context, *params.ExportAuthors, io.Writer) *views.Response) *MockExportSvc_ExportAuthors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportBooks provides a mock function with given fields: ctx, query, w
+func (_m *MockExportSvc) ExportBooks(ctx context.Context, query *params.ExportBooks, w io.Writer) *views.Response {
+	ret := _m.Called(ctx, query, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportBooks")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, *params.ExportBooks, io.Writer) *views.Response); ok {
+		r0 = rf(ctx, query, w)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockExportSvc_ExportBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportBooks'
+type MockExportSvc_ExportBooks_Call struct {
+	*mock.Call
+}
+
+// ExportBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *params.ExportBooks
+//   - w io.Writer
+func (_e *MockExportSvc_Expecter) ExportBooks(ctx interface{}, query interface{}, w interface{}) *MockExportSvc_ExportBooks_Call {
+	return &MockExportSvc_ExportBooks_Call{Call: _e.mock.On("ExportBooks", ctx, query, w)}
+}
+
+func (_c *MockExportSvc_ExportBooks_Call) Run(run func(ctx context.Context, query *params.ExportBooks, w io.Writer)) *MockExportSvc_ExportBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*params.ExportBooks), args[2].(io.Writer))
+	})
+	return _c
+}
+
+func (_c *MockExportSvc_ExportBooks_Call) Return(_a0 *views.Response) *MockExportSvc_ExportBooks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExportSvc_ExportBooks_Call) RunAndReturn(run func(context.Context, *params.ExportBooks, io.Writer) *views.Response) *MockExportSvc_ExportBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExportSvc creates a new instance of MockExportSvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportSvc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportSvc {
+	mock := &MockExportSvc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,243 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	common "github.com/storyofhis/books-management/common"
+
+	mock "github.com/stretchr/testify/mock"
+
+	params "github.com/storyofhis/books-management/httpserver/controller/params"
+
+	uuid "github.com/google/uuid"
+
+	views "github.com/storyofhis/books-management/httpserver/controller/views"
+)
+
+// MockHoldSvc is an autogenerated mock type for the HoldSvc type
+type MockHoldSvc struct {
+	mock.Mock
+}
+
+type MockHoldSvc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHoldSvc) EXPECT() *MockHoldSvc_Expecter {
+	return &MockHoldSvc_Expecter{mock: &_m.Mock}
+}
+
+// CancelHold provides a mock function with given fields: ctx, id, user
+func (_m *MockHoldSvc) CancelHold(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, id, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelHold")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, id, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockHoldSvc_CancelHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelHold'
+type MockHoldSvc_CancelHold_Call struct {
+	*mock.Call
+}
+
+// CancelHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - user *common.CustomClaims
+func (_e *MockHoldSvc_Expecter) CancelHold(ctx interface{}, id interface{}, user interface{}) *MockHoldSvc_CancelHold_Call {
+	return &MockHoldSvc_CancelHold_Call{Call: _e.mock.On("CancelHold", ctx, id, user)}
+}
+
+func (_c *MockHoldSvc_CancelHold_Call) Run(run func(ctx context.Context, id uuid.UUID, user *common.CustomClaims)) *MockHoldSvc_CancelHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockHoldSvc_CancelHold_Call) Return(_a0 *views.Response) *MockHoldSvc_CancelHold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHoldSvc_CancelHold_Call) RunAndReturn(run func(context.Context, uuid.UUID, *common.CustomClaims) *views.Response) *MockHoldSvc_CancelHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBookHolds provides a mock function with given fields: ctx, bookId
+func (_m *MockHoldSvc) GetBookHolds(ctx context.Context, bookId uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, bookId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookHolds")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, bookId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockHoldSvc_GetBookHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookHolds'
+type MockHoldSvc_GetBookHolds_Call struct {
+	*mock.Call
+}
+
+// GetBookHolds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+func (_e *MockHoldSvc_Expecter) GetBookHolds(ctx interface{}, bookId interface{}) *MockHoldSvc_GetBookHolds_Call {
+	return &MockHoldSvc_GetBookHolds_Call{Call: _e.mock.On("GetBookHolds", ctx, bookId)}
+}
+
+func (_c *MockHoldSvc_GetBookHolds_Call) Run(run func(ctx context.Context, bookId uuid.UUID)) *MockHoldSvc_GetBookHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHoldSvc_GetBookHolds_Call) Return(_a0 *views.Response) *MockHoldSvc_GetBookHolds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHoldSvc_GetBookHolds_Call) RunAndReturn(run func(context.Context, uuid.UUID) *views.Response) *MockHoldSvc_GetBookHolds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserHolds provides a mock function with given fields: ctx, userId
+func (_m *MockHoldSvc) GetUserHolds(ctx context.Context, userId uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserHolds")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockHoldSvc_GetUserHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserHolds'
+type MockHoldSvc_GetUserHolds_Call struct {
+	*mock.Call
+}
+
+// GetUserHolds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+func (_e *MockHoldSvc_Expecter) GetUserHolds(ctx interface{}, userId interface{}) *MockHoldSvc_GetUserHolds_Call {
+	return &MockHoldSvc_GetUserHolds_Call{Call: _e.mock.On("GetUserHolds", ctx, userId)}
+}
+
+func (_c *MockHoldSvc_GetUserHolds_Call) Run(run func(ctx context.Context, userId uuid.UUID)) *MockHoldSvc_GetUserHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHoldSvc_GetUserHolds_Call) Return(_a0 *views.Response) *MockHoldSvc_GetUserHolds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHoldSvc_GetUserHolds_Call) RunAndReturn(run func(context.Context, uuid.UUID) *views.Response) *MockHoldSvc_GetUserHolds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlaceHold provides a mock function with given fields: ctx, bookId, req, user
+func (_m *MockHoldSvc) PlaceHold(ctx context.Context, bookId uuid.UUID, req *params.PlaceHold, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, bookId, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlaceHold")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *params.PlaceHold, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, bookId, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockHoldSvc_PlaceHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlaceHold'
+type MockHoldSvc_PlaceHold_Call struct {
+	*mock.Call
+}
+
+// PlaceHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+//   - req *params.PlaceHold
+//   - user *common.CustomClaims
+func (_e *MockHoldSvc_Expecter) PlaceHold(ctx interface{}, bookId interface{}, req interface{}, user interface{}) *MockHoldSvc_PlaceHold_Call {
+	return &MockHoldSvc_PlaceHold_Call{Call: _e.mock.On("PlaceHold", ctx, bookId, req, user)}
+}
+
+func (_c *MockHoldSvc_PlaceHold_Call) Run(run func(ctx context.Context, bookId uuid.UUID, req *params.PlaceHold, user *common.CustomClaims)) *MockHoldSvc_PlaceHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*params.PlaceHold), args[3].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockHoldSvc_PlaceHold_Call) Return(_a0 *views.Response) *MockHoldSvc_PlaceHold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHoldSvc_PlaceHold_Call) RunAndReturn(run func(context.Context, uuid.UUID, *params.PlaceHold, *common.CustomClaims) *views.Response) *MockHoldSvc_PlaceHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHoldSvc creates a new instance of MockHoldSvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHoldSvc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHoldSvc {
+	mock := &MockHoldSvc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
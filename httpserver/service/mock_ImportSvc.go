@@ -0,0 +1,146 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	common "github.com/storyofhis/books-management/common"
+
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	params "github.com/storyofhis/books-management/httpserver/controller/params"
+
+	views "github.com/storyofhis/books-management/httpserver/controller/views"
+)
+
+// MockImportSvc is an autogenerated mock type for the ImportSvc type
+type MockImportSvc struct {
+	mock.Mock
+}
+
+type MockImportSvc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockImportSvc) EXPECT() *MockImportSvc_Expecter {
+	return &MockImportSvc_Expecter{mock: &_m.Mock}
+}
+
+// ImportAuthors provides a mock function with given fields: ctx, file, req, user
+func (_m *MockImportSvc) ImportAuthors(ctx context.Context, file io.Reader, req *params.Import, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, file, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportAuthors")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, *params.Import, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, file, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockImportSvc_ImportAuthors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportAuthors'
+type MockImportSvc_ImportAuthors_Call struct {
+	*mock.Call
+}
+
+// ImportAuthors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - file io.Reader
+//   - req *params.Import
+//   - user *common.CustomClaims
+func (_e *MockImportSvc_Expecter) ImportAuthors(ctx interface{}, file interface{}, req interface{}, user interface{}) *MockImportSvc_ImportAuthors_Call {
+	return &MockImportSvc_ImportAuthors_Call{Call: _e.mock.On("ImportAuthors", ctx, file, req, user)}
+}
+
+func (_c *MockImportSvc_ImportAuthors_Call) Run(run func(ctx context.Context, file io.Reader, req *params.Import, user *common.CustomClaims)) *MockImportSvc_ImportAuthors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(io.Reader), args[2].(*params.Import), args[3].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockImportSvc_ImportAuthors_Call) Return(_a0 *views.Response) *MockImportSvc_ImportAuthors_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockImportSvc_ImportAuthors_Call) RunAndReturn(run func(context.Context, io.Reader, *params.Import, *common.CustomClaims) *views.Response) *MockImportSvc_ImportAuthors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportBooks provides a mock function with given fields: ctx, file, req, user
+func (_m *MockImportSvc) ImportBooks(ctx context.Context, file io.Reader, req *params.Import, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, file, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportBooks")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, *params.Import, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, file, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockImportSvc_ImportBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportBooks'
+type MockImportSvc_ImportBooks_Call struct {
+	*mock.Call
+}
+
+// ImportBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - file io.Reader
+//   - req *params.Import
+//   - user *common.CustomClaims
+func (_e *MockImportSvc_Expecter) ImportBooks(ctx interface{}, file interface{}, req interface{}, user interface{}) *MockImportSvc_ImportBooks_Call {
+	return &MockImportSvc_ImportBooks_Call{Call: _e.mock.On("ImportBooks", ctx, file, req, user)}
+}
+
+func (_c *MockImportSvc_ImportBooks_Call) Run(run func(ctx context.Context, file io.Reader, req *params.Import, user *common.CustomClaims)) *MockImportSvc_ImportBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(io.Reader), args[2].(*params.Import), args[3].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockImportSvc_ImportBooks_Call) Return(_a0 *views.Response) *MockImportSvc_ImportBooks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockImportSvc_ImportBooks_Call) RunAndReturn(run func(context.Context, io.Reader, *params.Import, *common.CustomClaims) *views.Response) *MockImportSvc_ImportBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockImportSvc creates a new instance of MockImportSvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockImportSvc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockImportSvc {
+	mock := &MockImportSvc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,245 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	common "github.com/storyofhis/books-management/common"
+
+	mock "github.com/stretchr/testify/mock"
+
+	params "github.com/storyofhis/books-management/httpserver/controller/params"
+
+	uuid "github.com/google/uuid"
+
+	views "github.com/storyofhis/books-management/httpserver/controller/views"
+)
+
+// MockLedgerSvc is an autogenerated mock type for the LedgerSvc type
+type MockLedgerSvc struct {
+	mock.Mock
+}
+
+type MockLedgerSvc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLedgerSvc) EXPECT() *MockLedgerSvc_Expecter {
+	return &MockLedgerSvc_Expecter{mock: &_m.Mock}
+}
+
+// GetBalance provides a mock function with given fields: ctx, userId
+func (_m *MockLedgerSvc) GetBalance(ctx context.Context, userId uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalance")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockLedgerSvc_GetBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalance'
+type MockLedgerSvc_GetBalance_Call struct {
+	*mock.Call
+}
+
+// GetBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+func (_e *MockLedgerSvc_Expecter) GetBalance(ctx interface{}, userId interface{}) *MockLedgerSvc_GetBalance_Call {
+	return &MockLedgerSvc_GetBalance_Call{Call: _e.mock.On("GetBalance", ctx, userId)}
+}
+
+func (_c *MockLedgerSvc_GetBalance_Call) Run(run func(ctx context.Context, userId uuid.UUID)) *MockLedgerSvc_GetBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockLedgerSvc_GetBalance_Call) Return(_a0 *views.Response) *MockLedgerSvc_GetBalance_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerSvc_GetBalance_Call) RunAndReturn(run func(context.Context, uuid.UUID) *views.Response) *MockLedgerSvc_GetBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactions provides a mock function with given fields: ctx, userId, query
+func (_m *MockLedgerSvc) GetTransactions(ctx context.Context, userId uuid.UUID, query *params.ListTransactions) *views.Response {
+	ret := _m.Called(ctx, userId, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactions")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *params.ListTransactions) *views.Response); ok {
+		r0 = rf(ctx, userId, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockLedgerSvc_GetTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactions'
+type MockLedgerSvc_GetTransactions_Call struct {
+	*mock.Call
+}
+
+// GetTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+//   - query *params.ListTransactions
+func (_e *MockLedgerSvc_Expecter) GetTransactions(ctx interface{}, userId interface{}, query interface{}) *MockLedgerSvc_GetTransactions_Call {
+	return &MockLedgerSvc_GetTransactions_Call{Call: _e.mock.On("GetTransactions", ctx, userId, query)}
+}
+
+func (_c *MockLedgerSvc_GetTransactions_Call) Run(run func(ctx context.Context, userId uuid.UUID, query *params.ListTransactions)) *MockLedgerSvc_GetTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*params.ListTransactions))
+	})
+	return _c
+}
+
+func (_c *MockLedgerSvc_GetTransactions_Call) Return(_a0 *views.Response) *MockLedgerSvc_GetTransactions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerSvc_GetTransactions_Call) RunAndReturn(run func(context.Context, uuid.UUID, *params.ListTransactions) *views.Response) *MockLedgerSvc_GetTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordPayment provides a mock function with given fields: ctx, userId, req, staff
+func (_m *MockLedgerSvc) RecordPayment(ctx context.Context, userId uuid.UUID, req *params.Credit, staff *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, userId, req, staff)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPayment")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *params.Credit, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, userId, req, staff)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockLedgerSvc_RecordPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordPayment'
+type MockLedgerSvc_RecordPayment_Call struct {
+	*mock.Call
+}
+
+// RecordPayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+//   - req *params.Credit
+//   - staff *common.CustomClaims
+func (_e *MockLedgerSvc_Expecter) RecordPayment(ctx interface{}, userId interface{}, req interface{}, staff interface{}) *MockLedgerSvc_RecordPayment_Call {
+	return &MockLedgerSvc_RecordPayment_Call{Call: _e.mock.On("RecordPayment", ctx, userId, req, staff)}
+}
+
+func (_c *MockLedgerSvc_RecordPayment_Call) Run(run func(ctx context.Context, userId uuid.UUID, req *params.Credit, staff *common.CustomClaims)) *MockLedgerSvc_RecordPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*params.Credit), args[3].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockLedgerSvc_RecordPayment_Call) Return(_a0 *views.Response) *MockLedgerSvc_RecordPayment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerSvc_RecordPayment_Call) RunAndReturn(run func(context.Context, uuid.UUID, *params.Credit, *common.CustomClaims) *views.Response) *MockLedgerSvc_RecordPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordWaiver provides a mock function with given fields: ctx, userId, req, staff
+func (_m *MockLedgerSvc) RecordWaiver(ctx context.Context, userId uuid.UUID, req *params.Credit, staff *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, userId, req, staff)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordWaiver")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *params.Credit, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, userId, req, staff)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockLedgerSvc_RecordWaiver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordWaiver'
+type MockLedgerSvc_RecordWaiver_Call struct {
+	*mock.Call
+}
+
+// RecordWaiver is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+//   - req *params.Credit
+//   - staff *common.CustomClaims
+func (_e *MockLedgerSvc_Expecter) RecordWaiver(ctx interface{}, userId interface{}, req interface{}, staff interface{}) *MockLedgerSvc_RecordWaiver_Call {
+	return &MockLedgerSvc_RecordWaiver_Call{Call: _e.mock.On("RecordWaiver", ctx, userId, req, staff)}
+}
+
+func (_c *MockLedgerSvc_RecordWaiver_Call) Run(run func(ctx context.Context, userId uuid.UUID, req *params.Credit, staff *common.CustomClaims)) *MockLedgerSvc_RecordWaiver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*params.Credit), args[3].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockLedgerSvc_RecordWaiver_Call) Return(_a0 *views.Response) *MockLedgerSvc_RecordWaiver_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerSvc_RecordWaiver_Call) RunAndReturn(run func(context.Context, uuid.UUID, *params.Credit, *common.CustomClaims) *views.Response) *MockLedgerSvc_RecordWaiver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLedgerSvc creates a new instance of MockLedgerSvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLedgerSvc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLedgerSvc {
+	mock := &MockLedgerSvc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,391 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	common "github.com/storyofhis/books-management/common"
+
+	mock "github.com/stretchr/testify/mock"
+
+	params "github.com/storyofhis/books-management/httpserver/controller/params"
+
+	uuid "github.com/google/uuid"
+
+	views "github.com/storyofhis/books-management/httpserver/controller/views"
+)
+
+// MockLoanSvc is an autogenerated mock type for the LoanSvc type
+type MockLoanSvc struct {
+	mock.Mock
+}
+
+type MockLoanSvc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLoanSvc) EXPECT() *MockLoanSvc_Expecter {
+	return &MockLoanSvc_Expecter{mock: &_m.Mock}
+}
+
+// Checkout provides a mock function with given fields: ctx, req
+func (_m *MockLoanSvc) Checkout(ctx context.Context, req *params.Checkout) *views.Response {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Checkout")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, *params.Checkout) *views.Response); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockLoanSvc_Checkout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Checkout'
+type MockLoanSvc_Checkout_Call struct {
+	*mock.Call
+}
+
+// Checkout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *params.Checkout
+func (_e *MockLoanSvc_Expecter) Checkout(ctx interface{}, req interface{}) *MockLoanSvc_Checkout_Call {
+	return &MockLoanSvc_Checkout_Call{Call: _e.mock.On("Checkout", ctx, req)}
+}
+
+func (_c *MockLoanSvc_Checkout_Call) Run(run func(ctx context.Context, req *params.Checkout)) *MockLoanSvc_Checkout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*params.Checkout))
+	})
+	return _c
+}
+
+func (_c *MockLoanSvc_Checkout_Call) Return(_a0 *views.Response) *MockLoanSvc_Checkout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLoanSvc_Checkout_Call) RunAndReturn(run func(context.Context, *params.Checkout) *views.Response) *MockLoanSvc_Checkout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBookLoans provides a mock function with given fields: ctx, bookId, query
+func (_m *MockLoanSvc) GetBookLoans(ctx context.Context, bookId uuid.UUID, query *params.ListLoans) *views.Response {
+	ret := _m.Called(ctx, bookId, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookLoans")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *params.ListLoans) *views.Response); ok {
+		r0 = rf(ctx, bookId, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockLoanSvc_GetBookLoans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookLoans'
+type MockLoanSvc_GetBookLoans_Call struct {
+	*mock.Call
+}
+
+// GetBookLoans is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+//   - query *params.ListLoans
+func (_e *MockLoanSvc_Expecter) GetBookLoans(ctx interface{}, bookId interface{}, query interface{}) *MockLoanSvc_GetBookLoans_Call {
+	return &MockLoanSvc_GetBookLoans_Call{Call: _e.mock.On("GetBookLoans", ctx, bookId, query)}
+}
+
+func (_c *MockLoanSvc_GetBookLoans_Call) Run(run func(ctx context.Context, bookId uuid.UUID, query *params.ListLoans)) *MockLoanSvc_GetBookLoans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*params.ListLoans))
+	})
+	return _c
+}
+
+func (_c *MockLoanSvc_GetBookLoans_Call) Return(_a0 *views.Response) *MockLoanSvc_GetBookLoans_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLoanSvc_GetBookLoans_Call) RunAndReturn(run func(context.Context, uuid.UUID, *params.ListLoans) *views.Response) *MockLoanSvc_GetBookLoans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoan provides a mock function with given fields: ctx, id, user
+func (_m *MockLoanSvc) GetLoan(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, id, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoan")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, id, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockLoanSvc_GetLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoan'
+type MockLoanSvc_GetLoan_Call struct {
+	*mock.Call
+}
+
+// GetLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - user *common.CustomClaims
+func (_e *MockLoanSvc_Expecter) GetLoan(ctx interface{}, id interface{}, user interface{}) *MockLoanSvc_GetLoan_Call {
+	return &MockLoanSvc_GetLoan_Call{Call: _e.mock.On("GetLoan", ctx, id, user)}
+}
+
+func (_c *MockLoanSvc_GetLoan_Call) Run(run func(ctx context.Context, id uuid.UUID, user *common.CustomClaims)) *MockLoanSvc_GetLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockLoanSvc_GetLoan_Call) Return(_a0 *views.Response) *MockLoanSvc_GetLoan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLoanSvc_GetLoan_Call) RunAndReturn(run func(context.Context, uuid.UUID, *common.CustomClaims) *views.Response) *MockLoanSvc_GetLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOverdueLoans provides a mock function with given fields: ctx, query
+func (_m *MockLoanSvc) GetOverdueLoans(ctx context.Context, query *params.ListLoans) *views.Response {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverdueLoans")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, *params.ListLoans) *views.Response); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockLoanSvc_GetOverdueLoans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOverdueLoans'
+type MockLoanSvc_GetOverdueLoans_Call struct {
+	*mock.Call
+}
+
+// GetOverdueLoans is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *params.ListLoans
+func (_e *MockLoanSvc_Expecter) GetOverdueLoans(ctx interface{}, query interface{}) *MockLoanSvc_GetOverdueLoans_Call {
+	return &MockLoanSvc_GetOverdueLoans_Call{Call: _e.mock.On("GetOverdueLoans", ctx, query)}
+}
+
+func (_c *MockLoanSvc_GetOverdueLoans_Call) Run(run func(ctx context.Context, query *params.ListLoans)) *MockLoanSvc_GetOverdueLoans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*params.ListLoans))
+	})
+	return _c
+}
+
+func (_c *MockLoanSvc_GetOverdueLoans_Call) Return(_a0 *views.Response) *MockLoanSvc_GetOverdueLoans_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLoanSvc_GetOverdueLoans_Call) RunAndReturn(run func(context.Context, *params.ListLoans) *views.Response) *MockLoanSvc_GetOverdueLoans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserLoans provides a mock function with given fields: ctx, userId, query
+func (_m *MockLoanSvc) GetUserLoans(ctx context.Context, userId uuid.UUID, query *params.ListLoans) *views.Response {
+	ret := _m.Called(ctx, userId, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserLoans")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *params.ListLoans) *views.Response); ok {
+		r0 = rf(ctx, userId, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockLoanSvc_GetUserLoans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserLoans'
+type MockLoanSvc_GetUserLoans_Call struct {
+	*mock.Call
+}
+
+// GetUserLoans is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+//   - query *params.ListLoans
+func (_e *MockLoanSvc_Expecter) GetUserLoans(ctx interface{}, userId interface{}, query interface{}) *MockLoanSvc_GetUserLoans_Call {
+	return &MockLoanSvc_GetUserLoans_Call{Call: _e.mock.On("GetUserLoans", ctx, userId, query)}
+}
+
+func (_c *MockLoanSvc_GetUserLoans_Call) Run(run func(ctx context.Context, userId uuid.UUID, query *params.ListLoans)) *MockLoanSvc_GetUserLoans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*params.ListLoans))
+	})
+	return _c
+}
+
+func (_c *MockLoanSvc_GetUserLoans_Call) Return(_a0 *views.Response) *MockLoanSvc_GetUserLoans_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLoanSvc_GetUserLoans_Call) RunAndReturn(run func(context.Context, uuid.UUID, *params.ListLoans) *views.Response) *MockLoanSvc_GetUserLoans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenewLoan provides a mock function with given fields: ctx, id, user
+func (_m *MockLoanSvc) RenewLoan(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, id, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewLoan")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, id, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockLoanSvc_RenewLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewLoan'
+type MockLoanSvc_RenewLoan_Call struct {
+	*mock.Call
+}
+
+// RenewLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - user *common.CustomClaims
+func (_e *MockLoanSvc_Expecter) RenewLoan(ctx interface{}, id interface{}, user interface{}) *MockLoanSvc_RenewLoan_Call {
+	return &MockLoanSvc_RenewLoan_Call{Call: _e.mock.On("RenewLoan", ctx, id, user)}
+}
+
+func (_c *MockLoanSvc_RenewLoan_Call) Run(run func(ctx context.Context, id uuid.UUID, user *common.CustomClaims)) *MockLoanSvc_RenewLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockLoanSvc_RenewLoan_Call) Return(_a0 *views.Response) *MockLoanSvc_RenewLoan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLoanSvc_RenewLoan_Call) RunAndReturn(run func(context.Context, uuid.UUID, *common.CustomClaims) *views.Response) *MockLoanSvc_RenewLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReturnLoan provides a mock function with given fields: ctx, id
+func (_m *MockLoanSvc) ReturnLoan(ctx context.Context, id uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReturnLoan")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockLoanSvc_ReturnLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReturnLoan'
+type MockLoanSvc_ReturnLoan_Call struct {
+	*mock.Call
+}
+
+// ReturnLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockLoanSvc_Expecter) ReturnLoan(ctx interface{}, id interface{}) *MockLoanSvc_ReturnLoan_Call {
+	return &MockLoanSvc_ReturnLoan_Call{Call: _e.mock.On("ReturnLoan", ctx, id)}
+}
+
+func (_c *MockLoanSvc_ReturnLoan_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockLoanSvc_ReturnLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockLoanSvc_ReturnLoan_Call) Return(_a0 *views.Response) *MockLoanSvc_ReturnLoan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLoanSvc_ReturnLoan_Call) RunAndReturn(run func(context.Context, uuid.UUID) *views.Response) *MockLoanSvc_ReturnLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLoanSvc creates a new instance of MockLoanSvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLoanSvc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLoanSvc {
+	mock := &MockLoanSvc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
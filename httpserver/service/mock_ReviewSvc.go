@@ -0,0 +1,301 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	common "github.com/storyofhis/books-management/common"
+
+	mock "github.com/stretchr/testify/mock"
+
+	params "github.com/storyofhis/books-management/httpserver/controller/params"
+
+	uuid "github.com/google/uuid"
+
+	views "github.com/storyofhis/books-management/httpserver/controller/views"
+)
+
+// MockReviewSvc is an autogenerated mock type for the ReviewSvc type
+type MockReviewSvc struct {
+	mock.Mock
+}
+
+type MockReviewSvc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReviewSvc) EXPECT() *MockReviewSvc_Expecter {
+	return &MockReviewSvc_Expecter{mock: &_m.Mock}
+}
+
+// CreateReview provides a mock function with given fields: ctx, bookId, req, user
+func (_m *MockReviewSvc) CreateReview(ctx context.Context, bookId uuid.UUID, req *params.Review, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, bookId, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReview")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *params.Review, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, bookId, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockReviewSvc_CreateReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReview'
+type MockReviewSvc_CreateReview_Call struct {
+	*mock.Call
+}
+
+// CreateReview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+//   - req *params.Review
+//   - user *common.CustomClaims
+func (_e *MockReviewSvc_Expecter) CreateReview(ctx interface{}, bookId interface{}, req interface{}, user interface{}) *MockReviewSvc_CreateReview_Call {
+	return &MockReviewSvc_CreateReview_Call{Call: _e.mock.On("CreateReview", ctx, bookId, req, user)}
+}
+
+func (_c *MockReviewSvc_CreateReview_Call) Run(run func(ctx context.Context, bookId uuid.UUID, req *params.Review, user *common.CustomClaims)) *MockReviewSvc_CreateReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*params.Review), args[3].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockReviewSvc_CreateReview_Call) Return(_a0 *views.Response) *MockReviewSvc_CreateReview_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReviewSvc_CreateReview_Call) RunAndReturn(run func(context.Context, uuid.UUID, *params.Review, *common.CustomClaims) *views.Response) *MockReviewSvc_CreateReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteReview provides a mock function with given fields: ctx, bookId, id, user
+func (_m *MockReviewSvc) DeleteReview(ctx context.Context, bookId uuid.UUID, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, bookId, id, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReview")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, bookId, id, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockReviewSvc_DeleteReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReview'
+type MockReviewSvc_DeleteReview_Call struct {
+	*mock.Call
+}
+
+// DeleteReview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+//   - id uuid.UUID
+//   - user *common.CustomClaims
+func (_e *MockReviewSvc_Expecter) DeleteReview(ctx interface{}, bookId interface{}, id interface{}, user interface{}) *MockReviewSvc_DeleteReview_Call {
+	return &MockReviewSvc_DeleteReview_Call{Call: _e.mock.On("DeleteReview", ctx, bookId, id, user)}
+}
+
+func (_c *MockReviewSvc_DeleteReview_Call) Run(run func(ctx context.Context, bookId uuid.UUID, id uuid.UUID, user *common.CustomClaims)) *MockReviewSvc_DeleteReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockReviewSvc_DeleteReview_Call) Return(_a0 *views.Response) *MockReviewSvc_DeleteReview_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReviewSvc_DeleteReview_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, *common.CustomClaims) *views.Response) *MockReviewSvc_DeleteReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReviews provides a mock function with given fields: ctx, bookId, query, user
+func (_m *MockReviewSvc) GetReviews(ctx context.Context, bookId uuid.UUID, query *params.ListReviews, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, bookId, query, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviews")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *params.ListReviews, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, bookId, query, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockReviewSvc_GetReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviews'
+type MockReviewSvc_GetReviews_Call struct {
+	*mock.Call
+}
+
+// GetReviews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+//   - query *params.ListReviews
+//   - user *common.CustomClaims
+func (_e *MockReviewSvc_Expecter) GetReviews(ctx interface{}, bookId interface{}, query interface{}, user interface{}) *MockReviewSvc_GetReviews_Call {
+	return &MockReviewSvc_GetReviews_Call{Call: _e.mock.On("GetReviews", ctx, bookId, query, user)}
+}
+
+func (_c *MockReviewSvc_GetReviews_Call) Run(run func(ctx context.Context, bookId uuid.UUID, query *params.ListReviews, user *common.CustomClaims)) *MockReviewSvc_GetReviews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*params.ListReviews), args[3].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockReviewSvc_GetReviews_Call) Return(_a0 *views.Response) *MockReviewSvc_GetReviews_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReviewSvc_GetReviews_Call) RunAndReturn(run func(context.Context, uuid.UUID, *params.ListReviews, *common.CustomClaims) *views.Response) *MockReviewSvc_GetReviews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ModerateReview provides a mock function with given fields: ctx, bookId, id, req, user
+func (_m *MockReviewSvc) ModerateReview(ctx context.Context, bookId uuid.UUID, id uuid.UUID, req *params.ModerateReview, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, bookId, id, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ModerateReview")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *params.ModerateReview, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, bookId, id, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockReviewSvc_ModerateReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ModerateReview'
+type MockReviewSvc_ModerateReview_Call struct {
+	*mock.Call
+}
+
+// ModerateReview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+//   - id uuid.UUID
+//   - req *params.ModerateReview
+//   - user *common.CustomClaims
+func (_e *MockReviewSvc_Expecter) ModerateReview(ctx interface{}, bookId interface{}, id interface{}, req interface{}, user interface{}) *MockReviewSvc_ModerateReview_Call {
+	return &MockReviewSvc_ModerateReview_Call{Call: _e.mock.On("ModerateReview", ctx, bookId, id, req, user)}
+}
+
+func (_c *MockReviewSvc_ModerateReview_Call) Run(run func(ctx context.Context, bookId uuid.UUID, id uuid.UUID, req *params.ModerateReview, user *common.CustomClaims)) *MockReviewSvc_ModerateReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(*params.ModerateReview), args[4].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockReviewSvc_ModerateReview_Call) Return(_a0 *views.Response) *MockReviewSvc_ModerateReview_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReviewSvc_ModerateReview_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, *params.ModerateReview, *common.CustomClaims) *views.Response) *MockReviewSvc_ModerateReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateReview provides a mock function with given fields: ctx, bookId, id, req, user
+func (_m *MockReviewSvc) UpdateReview(ctx context.Context, bookId uuid.UUID, id uuid.UUID, req *params.Review, user *common.CustomClaims) *views.Response {
+	ret := _m.Called(ctx, bookId, id, req, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReview")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *params.Review, *common.CustomClaims) *views.Response); ok {
+		r0 = rf(ctx, bookId, id, req, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockReviewSvc_UpdateReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateReview'
+type MockReviewSvc_UpdateReview_Call struct {
+	*mock.Call
+}
+
+// UpdateReview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookId uuid.UUID
+//   - id uuid.UUID
+//   - req *params.Review
+//   - user *common.CustomClaims
+func (_e *MockReviewSvc_Expecter) UpdateReview(ctx interface{}, bookId interface{}, id interface{}, req interface{}, user interface{}) *MockReviewSvc_UpdateReview_Call {
+	return &MockReviewSvc_UpdateReview_Call{Call: _e.mock.On("UpdateReview", ctx, bookId, id, req, user)}
+}
+
+func (_c *MockReviewSvc_UpdateReview_Call) Run(run func(ctx context.Context, bookId uuid.UUID, id uuid.UUID, req *params.Review, user *common.CustomClaims)) *MockReviewSvc_UpdateReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(*params.Review), args[4].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockReviewSvc_UpdateReview_Call) Return(_a0 *views.Response) *MockReviewSvc_UpdateReview_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReviewSvc_UpdateReview_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, *params.Review, *common.CustomClaims) *views.Response) *MockReviewSvc_UpdateReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReviewSvc creates a new instance of MockReviewSvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReviewSvc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReviewSvc {
+	mock := &MockReviewSvc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,88 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	params "github.com/storyofhis/books-management/httpserver/controller/params"
+	mock "github.com/stretchr/testify/mock"
+
+	views "github.com/storyofhis/books-management/httpserver/controller/views"
+)
+
+// MockSearchSvc is an autogenerated mock type for the SearchSvc type
+type MockSearchSvc struct {
+	mock.Mock
+}
+
+type MockSearchSvc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSearchSvc) EXPECT() *MockSearchSvc_Expecter {
+	return &MockSearchSvc_Expecter{mock: &_m.Mock}
+}
+
+// Search provides a mock function with given fields: ctx, query
+func (_m *MockSearchSvc) Search(ctx context.Context, query *params.Search) *views.Response {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, *params.Search) *views.Response); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockSearchSvc_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockSearchSvc_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *params.Search
+func (_e *MockSearchSvc_Expecter) Search(ctx interface{}, query interface{}) *MockSearchSvc_Search_Call {
+	return &MockSearchSvc_Search_Call{Call: _e.mock.On("Search", ctx, query)}
+}
+
+func (_c *MockSearchSvc_Search_Call) Run(run func(ctx context.Context, query *params.Search)) *MockSearchSvc_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*params.Search))
+	})
+	return _c
+}
+
+func (_c *MockSearchSvc_Search_Call) Return(_a0 *views.Response) *MockSearchSvc_Search_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSearchSvc_Search_Call) RunAndReturn(run func(context.Context, *params.Search) *views.Response) *MockSearchSvc_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSearchSvc creates a new instance of MockSearchSvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSearchSvc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSearchSvc {
+	mock := &MockSearchSvc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,88 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+
+	views "github.com/storyofhis/books-management/httpserver/controller/views"
+)
+
+// MockTrashSvc is an autogenerated mock type for the TrashSvc type
+type MockTrashSvc struct {
+	mock.Mock
+}
+
+type MockTrashSvc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrashSvc) EXPECT() *MockTrashSvc_Expecter {
+	return &MockTrashSvc_Expecter{mock: &_m.Mock}
+}
+
+// GetTrash provides a mock function with given fields: ctx, userId
+func (_m *MockTrashSvc) GetTrash(ctx context.Context, userId uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrash")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockTrashSvc_GetTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrash'
+type MockTrashSvc_GetTrash_Call struct {
+	*mock.Call
+}
+
+// GetTrash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+func (_e *MockTrashSvc_Expecter) GetTrash(ctx interface{}, userId interface{}) *MockTrashSvc_GetTrash_Call {
+	return &MockTrashSvc_GetTrash_Call{Call: _e.mock.On("GetTrash", ctx, userId)}
+}
+
+func (_c *MockTrashSvc_GetTrash_Call) Run(run func(ctx context.Context, userId uuid.UUID)) *MockTrashSvc_GetTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTrashSvc_GetTrash_Call) Return(_a0 *views.Response) *MockTrashSvc_GetTrash_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTrashSvc_GetTrash_Call) RunAndReturn(run func(context.Context, uuid.UUID) *views.Response) *MockTrashSvc_GetTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTrashSvc creates a new instance of MockTrashSvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrashSvc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrashSvc {
+	mock := &MockTrashSvc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,9 +5,14 @@ package service
 import (
 	context "context"
 
-	params "github.com/storyofhis/books-management/httpserver/controller/params"
+	common "github.com/storyofhis/books-management/common"
+
 	mock "github.com/stretchr/testify/mock"
 
+	params "github.com/storyofhis/books-management/httpserver/controller/params"
+
+	uuid "github.com/google/uuid"
+
 	views "github.com/storyofhis/books-management/httpserver/controller/views"
 )
 
@@ -24,6 +29,114 @@ func (_m *MockUserSvc) EXPECT() *MockUserSvc_Expecter {
 	return &MockUserSvc_Expecter{mock: &_m.Mock}
 }
 
+// Authenticate provides a mock function with given fields: ctx, username, password
+func (_m *MockUserSvc) Authenticate(ctx context.Context, username string, password string) (*common.CustomClaims, error) {
+	ret := _m.Called(ctx, username, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 *common.CustomClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*common.CustomClaims, error)); ok {
+		return rf(ctx, username, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *common.CustomClaims); ok {
+		r0 = rf(ctx, username, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*common.CustomClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, username, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserSvc_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type MockUserSvc_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - password string
+func (_e *MockUserSvc_Expecter) Authenticate(ctx interface{}, username interface{}, password interface{}) *MockUserSvc_Authenticate_Call {
+	return &MockUserSvc_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, username, password)}
+}
+
+func (_c *MockUserSvc_Authenticate_Call) Run(run func(ctx context.Context, username string, password string)) *MockUserSvc_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserSvc_Authenticate_Call) Return(_a0 *common.CustomClaims, _a1 error) *MockUserSvc_Authenticate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserSvc_Authenticate_Call) RunAndReturn(run func(context.Context, string, string) (*common.CustomClaims, error)) *MockUserSvc_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsers provides a mock function with given fields: ctx
+func (_m *MockUserSvc) GetUsers(ctx context.Context) *views.Response {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context) *views.Response); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockUserSvc_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
+type MockUserSvc_GetUsers_Call struct {
+	*mock.Call
+}
+
+// GetUsers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUserSvc_Expecter) GetUsers(ctx interface{}) *MockUserSvc_GetUsers_Call {
+	return &MockUserSvc_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx)}
+}
+
+func (_c *MockUserSvc_GetUsers_Call) Run(run func(ctx context.Context)) *MockUserSvc_GetUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockUserSvc_GetUsers_Call) Return(_a0 *views.Response) *MockUserSvc_GetUsers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserSvc_GetUsers_Call) RunAndReturn(run func(context.Context) *views.Response) *MockUserSvc_GetUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Login provides a mock function with given fields: ctx, user
 func (_m *MockUserSvc) Login(ctx context.Context, user *params.Login) *views.Response {
 	ret := _m.Called(ctx, user)
@@ -73,6 +186,153 @@ func (_c *MockUserSvc_Login_Call) RunAndReturn(run func(context.Context, *params
 	return _c
 }
 
+// Logout provides a mock function with given fields: ctx, sessionId
+func (_m *MockUserSvc) Logout(ctx context.Context, sessionId uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, sessionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, sessionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockUserSvc_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockUserSvc_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sessionId uuid.UUID
+func (_e *MockUserSvc_Expecter) Logout(ctx interface{}, sessionId interface{}) *MockUserSvc_Logout_Call {
+	return &MockUserSvc_Logout_Call{Call: _e.mock.On("Logout", ctx, sessionId)}
+}
+
+func (_c *MockUserSvc_Logout_Call) Run(run func(ctx context.Context, sessionId uuid.UUID)) *MockUserSvc_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserSvc_Logout_Call) Return(_a0 *views.Response) *MockUserSvc_Logout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserSvc_Logout_Call) RunAndReturn(run func(context.Context, uuid.UUID) *views.Response) *MockUserSvc_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogoutAll provides a mock function with given fields: ctx, userId
+func (_m *MockUserSvc) LogoutAll(ctx context.Context, userId uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogoutAll")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockUserSvc_LogoutAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogoutAll'
+type MockUserSvc_LogoutAll_Call struct {
+	*mock.Call
+}
+
+// LogoutAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId uuid.UUID
+func (_e *MockUserSvc_Expecter) LogoutAll(ctx interface{}, userId interface{}) *MockUserSvc_LogoutAll_Call {
+	return &MockUserSvc_LogoutAll_Call{Call: _e.mock.On("LogoutAll", ctx, userId)}
+}
+
+func (_c *MockUserSvc_LogoutAll_Call) Run(run func(ctx context.Context, userId uuid.UUID)) *MockUserSvc_LogoutAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserSvc_LogoutAll_Call) Return(_a0 *views.Response) *MockUserSvc_LogoutAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserSvc_LogoutAll_Call) RunAndReturn(run func(context.Context, uuid.UUID) *views.Response) *MockUserSvc_LogoutAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with given fields: ctx, token
+func (_m *MockUserSvc) Refresh(ctx context.Context, token *params.Refresh) *views.Response {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, *params.Refresh) *views.Response); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockUserSvc_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type MockUserSvc_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *params.Refresh
+func (_e *MockUserSvc_Expecter) Refresh(ctx interface{}, token interface{}) *MockUserSvc_Refresh_Call {
+	return &MockUserSvc_Refresh_Call{Call: _e.mock.On("Refresh", ctx, token)}
+}
+
+func (_c *MockUserSvc_Refresh_Call) Run(run func(ctx context.Context, token *params.Refresh)) *MockUserSvc_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*params.Refresh))
+	})
+	return _c
+}
+
+func (_c *MockUserSvc_Refresh_Call) Return(_a0 *views.Response) *MockUserSvc_Refresh_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserSvc_Refresh_Call) RunAndReturn(run func(context.Context, *params.Refresh) *views.Response) *MockUserSvc_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Register provides a mock function with given fields: ctx, user
 func (_m *MockUserSvc) Register(ctx context.Context, user *params.Register) *views.Response {
 	ret := _m.Called(ctx, user)
@@ -122,6 +382,103 @@ func (_c *MockUserSvc_Register_Call) RunAndReturn(run func(context.Context, *par
 	return _c
 }
 
+// UpdateRole provides a mock function with given fields: ctx, role, id
+func (_m *MockUserSvc) UpdateRole(ctx context.Context, role *params.UpdateRole, id uuid.UUID) *views.Response {
+	ret := _m.Called(ctx, role, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 *views.Response
+	if rf, ok := ret.Get(0).(func(context.Context, *params.UpdateRole, uuid.UUID) *views.Response); ok {
+		r0 = rf(ctx, role, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*views.Response)
+		}
+	}
+
+	return r0
+}
+
+// MockUserSvc_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type MockUserSvc_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - role *params.UpdateRole
+//   - id uuid.UUID
+func (_e *MockUserSvc_Expecter) UpdateRole(ctx interface{}, role interface{}, id interface{}) *MockUserSvc_UpdateRole_Call {
+	return &MockUserSvc_UpdateRole_Call{Call: _e.mock.On("UpdateRole", ctx, role, id)}
+}
+
+func (_c *MockUserSvc_UpdateRole_Call) Run(run func(ctx context.Context, role *params.UpdateRole, id uuid.UUID)) *MockUserSvc_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*params.UpdateRole), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserSvc_UpdateRole_Call) Return(_a0 *views.Response) *MockUserSvc_UpdateRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserSvc_UpdateRole_Call) RunAndReturn(run func(context.Context, *params.UpdateRole, uuid.UUID) *views.Response) *MockUserSvc_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifySession provides a mock function with given fields: ctx, claims
+func (_m *MockUserSvc) VerifySession(ctx context.Context, claims *common.CustomClaims) error {
+	ret := _m.Called(ctx, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifySession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *common.CustomClaims) error); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserSvc_VerifySession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifySession'
+type MockUserSvc_VerifySession_Call struct {
+	*mock.Call
+}
+
+// VerifySession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claims *common.CustomClaims
+func (_e *MockUserSvc_Expecter) VerifySession(ctx interface{}, claims interface{}) *MockUserSvc_VerifySession_Call {
+	return &MockUserSvc_VerifySession_Call{Call: _e.mock.On("VerifySession", ctx, claims)}
+}
+
+func (_c *MockUserSvc_VerifySession_Call) Run(run func(ctx context.Context, claims *common.CustomClaims)) *MockUserSvc_VerifySession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*common.CustomClaims))
+	})
+	return _c
+}
+
+func (_c *MockUserSvc_VerifySession_Call) Return(_a0 error) *MockUserSvc_VerifySession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserSvc_VerifySession_Call) RunAndReturn(run func(context.Context, *common.CustomClaims) error) *MockUserSvc_VerifySession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockUserSvc creates a new instance of MockUserSvc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUserSvc(t interface {
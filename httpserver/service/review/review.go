@@ -0,0 +1,217 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"gorm.io/gorm"
+)
+
+var (
+	errNotYourReview    = errors.New("only the author of the review can change it")
+	errReviewOfOther    = errors.New("the review belongs to another book")
+	errStatusNotAllowed = errors.New("only admins can list reviews by status")
+)
+
+type reviewSvc struct {
+	repo  repository.ReviewRepo
+	books repository.BookRepo
+}
+
+// CreateReview implements service.ReviewSvc.
+func (svc *reviewSvc) CreateReview(ctx context.Context, bookId uuid.UUID, req *params.Review, user *common.CustomClaims) *views.Response {
+	if resp := svc.checkBook(ctx, bookId); resp != nil {
+		return resp
+	}
+
+	review := models.Review{
+		BookId: bookId,
+		UserId: user.Id,
+		Rating: req.Rating,
+		Body:   req.Body,
+	}
+	err := svc.repo.CreateReview(ctx, &review)
+	if err != nil {
+		if err == repository.ErrDuplicateReview {
+			return views.ErrorReponse(http.StatusConflict, views.M_DUPLICATE_REVIEW, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	// The token names the user in its subject.
+	review.User = models.User{Id: user.Id, Username: user.Subject}
+	return views.SuccessResponse(http.StatusCreated, views.M_CREATED, reviewView(&review, user))
+}
+
+// GetReviews implements service.ReviewSvc. Hidden reviews are only listed
+// for admins.
+func (svc *reviewSvc) GetReviews(ctx context.Context, bookId uuid.UUID, query *params.ListReviews, user *common.CustomClaims) *views.Response {
+	if resp := svc.checkBook(ctx, bookId); resp != nil {
+		return resp
+	}
+
+	filter := repository.ReviewFilter{BookId: bookId}
+	switch {
+	case user.HasRole(common.RoleAdmin):
+		if query.Status != "" {
+			filter.Statuses = []string{query.Status}
+		}
+	case query.Status != "":
+		return views.ErrorReponse(http.StatusForbidden, views.M_FORBIDDEN, errStatusNotAllowed)
+	default:
+		filter.Statuses = []string{models.ReviewVisible, models.ReviewFlagged}
+	}
+	page := repository.Page{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+		Cursor:   query.Cursor,
+		Sort:     query.Sort,
+	}
+	list, info, err := svc.repo.GetReviews(ctx, &filter, &page)
+	if err != nil {
+		if err == repository.ErrInvalidCursor || err == repository.ErrInvalidSort {
+			return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_QUERY, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	reviews := make([]views.Review, 0, len(list))
+	for _, r := range list {
+		reviews = append(reviews, reviewView(r, user))
+	}
+	return views.PagedResponse(http.StatusOK, views.M_OK, reviews, &views.Pagination{
+		Total:      info.Total,
+		Page:       query.Page,
+		PageSize:   info.PageSize,
+		NextCursor: info.NextCursor,
+		PrevCursor: info.PrevCursor,
+	})
+}
+
+// UpdateReview implements service.ReviewSvc.
+func (svc *reviewSvc) UpdateReview(ctx context.Context, bookId, id uuid.UUID, req *params.Review, user *common.CustomClaims) *views.Response {
+	review, resp := svc.getOwnReview(ctx, bookId, id, user)
+	if resp != nil {
+		return resp
+	}
+
+	review.Rating = req.Rating
+	review.Body = req.Body
+	err := svc.repo.UpdateReview(ctx, review)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, reviewView(review, user))
+}
+
+// DeleteReview implements service.ReviewSvc.
+func (svc *reviewSvc) DeleteReview(ctx context.Context, bookId, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	review, resp := svc.getOwnReview(ctx, bookId, id, user)
+	if resp != nil {
+		return resp
+	}
+
+	err := svc.repo.DeleteReview(ctx, review)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusNoContent, views.M_OK, nil)
+}
+
+// ModerateReview implements service.ReviewSvc.
+func (svc *reviewSvc) ModerateReview(ctx context.Context, bookId, id uuid.UUID, req *params.ModerateReview, user *common.CustomClaims) *views.Response {
+	review, resp := svc.getReview(ctx, bookId, id)
+	if resp != nil {
+		return resp
+	}
+
+	review.Status = req.Status
+	review.ModerationNote = req.Note
+	review.ModeratedBy = &user.Id
+	err := svc.repo.ModerateReview(ctx, review)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, reviewView(review, user))
+}
+
+// checkBook returns an error response unless the book exists and is not in
+// the trash.
+func (svc *reviewSvc) checkBook(ctx context.Context, bookId uuid.UUID) *views.Response {
+	_, err := svc.books.GetBookById(ctx, bookId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_BOOK_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return nil
+}
+
+// getReview returns the review with the given id, or an error response when
+// the book or the review does not exist or the review is of another book.
+func (svc *reviewSvc) getReview(ctx context.Context, bookId, id uuid.UUID) (*models.Review, *views.Response) {
+	if resp := svc.checkBook(ctx, bookId); resp != nil {
+		return nil, resp
+	}
+
+	review, err := svc.repo.GetReviewById(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, views.ErrorReponse(http.StatusNotFound, views.M_REVIEW_NOT_FOUND, err)
+		}
+		return nil, views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if review.BookId != bookId {
+		return nil, views.ErrorReponse(http.StatusNotFound, views.M_REVIEW_NOT_FOUND, errReviewOfOther)
+	}
+	return review, nil
+}
+
+// getOwnReview is getReview restricted to the author of the review.
+func (svc *reviewSvc) getOwnReview(ctx context.Context, bookId, id uuid.UUID, user *common.CustomClaims) (*models.Review, *views.Response) {
+	review, resp := svc.getReview(ctx, bookId, id)
+	if resp != nil {
+		return nil, resp
+	}
+	if review.UserId != user.Id {
+		return nil, views.ErrorReponse(http.StatusForbidden, views.M_FORBIDDEN, errNotYourReview)
+	}
+	return review, nil
+}
+
+// reviewView returns the view of r for user, with the moderation details
+// for admins only.
+func reviewView(r *models.Review, user *common.CustomClaims) views.Review {
+	view := views.Review{
+		Id:        r.Id,
+		BookId:    r.BookId,
+		UserId:    r.UserId,
+		Username:  r.User.Username,
+		Rating:    r.Rating,
+		Body:      r.Body,
+		Status:    r.Status,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+	if user.HasRole(common.RoleAdmin) {
+		view.ModeratedBy = r.ModeratedBy
+		view.ModerationNote = r.ModerationNote
+		view.ModeratedAt = r.ModeratedAt
+	}
+	return view
+}
+
+func NewReviewSvc(repo repository.ReviewRepo, books repository.BookRepo) service.ReviewSvc {
+	return &reviewSvc{
+		repo:  repo,
+		books: books,
+	}
+}
@@ -0,0 +1,189 @@
+package review_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/review"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type reviewSvcTest struct {
+	repo    *repository.MockReviewRepo
+	books   *repository.MockBookRepo
+	service service.ReviewSvc
+}
+
+func newReviewSvcTest(t *testing.T) reviewSvcTest {
+	mockRepo := repository.NewMockReviewRepo(t)
+	mockBooks := repository.NewMockBookRepo(t)
+	reviewSvc := review.NewReviewSvc(mockRepo, mockBooks)
+	return reviewSvcTest{
+		repo:    mockRepo,
+		books:   mockBooks,
+		service: reviewSvc,
+	}
+}
+
+func TestReviewSvc_CreateReview(t *testing.T) {
+	t.Run("success - it should create the review", func(t *testing.T) {
+		instance := newReviewSvcTest(t)
+		bookId, userId := uuid.New(), uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().CreateReview(mock.Anything, mock.MatchedBy(func(r *models.Review) bool {
+			return r.BookId == bookId && r.UserId == userId && r.Rating == 4
+		})).Return(nil)
+		claims := &common.CustomClaims{Id: userId, Role: common.RoleMember}
+		claims.Subject = "alice"
+		res := instance.service.CreateReview(context.Background(), bookId, &params.Review{Rating: 4, Body: "Good"}, claims)
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+		assert.Equal(t, "Good", res.Payload.(views.Review).Body)
+		assert.Equal(t, "alice", res.Payload.(views.Review).Username)
+	})
+
+	t.Run("error - it should return 409 for a second review of the book", func(t *testing.T) {
+		instance := newReviewSvcTest(t)
+		instance.books.EXPECT().GetBookById(mock.Anything, mock.Anything).Return(&models.Book{}, nil)
+		instance.repo.EXPECT().CreateReview(mock.Anything, mock.Anything).Return(repository.ErrDuplicateReview)
+
+		res := instance.service.CreateReview(context.Background(), uuid.New(), &params.Review{Rating: 4}, &common.CustomClaims{Id: uuid.New()})
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_DUPLICATE_REVIEW, res.Message)
+	})
+
+	t.Run("error - it should return 404 for an unknown book", func(t *testing.T) {
+		instance := newReviewSvcTest(t)
+		instance.books.EXPECT().GetBookById(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.CreateReview(context.Background(), uuid.New(), &params.Review{Rating: 4}, &common.CustomClaims{Id: uuid.New()})
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_BOOK_NOT_FOUND, res.Message)
+	})
+}
+
+func TestReviewSvc_GetReviews(t *testing.T) {
+	t.Run("success - it should leave hidden reviews out for members", func(t *testing.T) {
+		instance := newReviewSvcTest(t)
+		bookId := uuid.New()
+		moderator := uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().GetReviews(mock.Anything, &repository.ReviewFilter{
+			BookId:   bookId,
+			Statuses: []string{models.ReviewVisible, models.ReviewFlagged},
+		}, mock.Anything).Return([]*models.Review{
+			{Id: uuid.New(), BookId: bookId, Rating: 5, Status: models.ReviewFlagged, ModeratedBy: &moderator},
+		}, &repository.PageInfo{Total: 1, PageSize: 20}, nil)
+		res := instance.service.GetReviews(context.Background(), bookId, &params.ListReviews{}, &common.CustomClaims{Id: uuid.New(), Role: common.RoleMember})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		reviews := res.Payload.([]views.Review)
+		assert.Len(t, reviews, 1)
+		assert.Nil(t, reviews[0].ModeratedBy)
+	})
+
+	t.Run("success - it should filter by status for admins", func(t *testing.T) {
+		instance := newReviewSvcTest(t)
+		bookId := uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().GetReviews(mock.Anything, &repository.ReviewFilter{
+			BookId:   bookId,
+			Statuses: []string{models.ReviewHidden},
+		}, mock.Anything).Return([]*models.Review{}, &repository.PageInfo{PageSize: 20}, nil)
+		res := instance.service.GetReviews(context.Background(), bookId, &params.ListReviews{Status: models.ReviewHidden}, &common.CustomClaims{Id: uuid.New(), Role: common.RoleAdmin})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+	})
+
+	t.Run("error - it should not filter by status for members", func(t *testing.T) {
+		instance := newReviewSvcTest(t)
+		instance.books.EXPECT().GetBookById(mock.Anything, mock.Anything).Return(&models.Book{}, nil)
+
+		res := instance.service.GetReviews(context.Background(), uuid.New(), &params.ListReviews{Status: models.ReviewHidden}, &common.CustomClaims{Id: uuid.New(), Role: common.RoleMember})
+		assert.Equal(t, http.StatusForbidden, res.Status)
+	})
+}
+
+func TestReviewSvc_UpdateReview(t *testing.T) {
+	t.Run("success - it should update the review of the author", func(t *testing.T) {
+		instance := newReviewSvcTest(t)
+		bookId, userId, id := uuid.New(), uuid.New(), uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().GetReviewById(mock.Anything, id).Return(&models.Review{Id: id, BookId: bookId, UserId: userId, Rating: 2}, nil)
+		instance.repo.EXPECT().UpdateReview(mock.Anything, mock.MatchedBy(func(r *models.Review) bool {
+			return r.Rating == 3 && r.Body == "Better on a reread"
+		})).Return(nil)
+		res := instance.service.UpdateReview(context.Background(), bookId, id, &params.Review{Rating: 3, Body: "Better on a reread"}, &common.CustomClaims{Id: userId, Role: common.RoleMember})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+	})
+
+	t.Run("error - it should return 403 for another user", func(t *testing.T) {
+		instance := newReviewSvcTest(t)
+		bookId, id := uuid.New(), uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().GetReviewById(mock.Anything, id).Return(&models.Review{Id: id, BookId: bookId, UserId: uuid.New()}, nil)
+		res := instance.service.UpdateReview(context.Background(), bookId, id, &params.Review{Rating: 1}, &common.CustomClaims{Id: uuid.New(), Role: common.RoleAdmin})
+
+		assert.Equal(t, http.StatusForbidden, res.Status)
+	})
+
+	t.Run("error - it should return 404 for a review of another book", func(t *testing.T) {
+		instance := newReviewSvcTest(t)
+		bookId, userId, id := uuid.New(), uuid.New(), uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().GetReviewById(mock.Anything, id).Return(&models.Review{Id: id, BookId: uuid.New(), UserId: userId}, nil)
+		res := instance.service.UpdateReview(context.Background(), bookId, id, &params.Review{Rating: 1}, &common.CustomClaims{Id: userId})
+
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_REVIEW_NOT_FOUND, res.Message)
+	})
+}
+
+func TestReviewSvc_DeleteReview(t *testing.T) {
+	t.Run("success - it should delete the review of the author", func(t *testing.T) {
+		instance := newReviewSvcTest(t)
+		bookId, userId, id := uuid.New(), uuid.New(), uuid.New()
+		existing := &models.Review{Id: id, BookId: bookId, UserId: userId}
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().GetReviewById(mock.Anything, id).Return(existing, nil)
+		instance.repo.EXPECT().DeleteReview(mock.Anything, existing).Return(nil)
+		res := instance.service.DeleteReview(context.Background(), bookId, id, &common.CustomClaims{Id: userId})
+
+		assert.Equal(t, http.StatusNoContent, res.Status)
+	})
+}
+
+func TestReviewSvc_ModerateReview(t *testing.T) {
+	t.Run("success - it should record the moderator", func(t *testing.T) {
+		instance := newReviewSvcTest(t)
+		bookId, adminId, id := uuid.New(), uuid.New(), uuid.New()
+
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId}, nil)
+		instance.repo.EXPECT().GetReviewById(mock.Anything, id).Return(&models.Review{Id: id, BookId: bookId, UserId: uuid.New()}, nil)
+		instance.repo.EXPECT().ModerateReview(mock.Anything, mock.MatchedBy(func(r *models.Review) bool {
+			return r.Status == models.ReviewHidden && *r.ModeratedBy == adminId
+		})).Return(nil)
+		res := instance.service.ModerateReview(context.Background(), bookId, id, &params.ModerateReview{Status: models.ReviewHidden, Note: "spoilers"}, &common.CustomClaims{Id: adminId, Role: common.RoleAdmin})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, "spoilers", res.Payload.(views.Review).ModerationNote)
+	})
+}
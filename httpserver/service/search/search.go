@@ -0,0 +1,90 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/service"
+)
+
+const (
+	defaultPageSize = 20
+	typeBooks       = "books"
+	typeAuthors     = "authors"
+)
+
+type searchSvc struct {
+	repo repository.SearchRepo
+}
+
+// Search implements service.SearchSvc.
+func (svc *searchSvc) Search(ctx context.Context, query *params.Search) *views.Response {
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	size := query.PageSize
+	if size < 1 {
+		size = defaultPageSize
+	}
+	offset := (page - 1) * size
+
+	var (
+		payload interface{}
+		total   int64
+		err     error
+	)
+	switch query.Type {
+	case typeAuthors:
+		var hits []*repository.AuthorHit
+		hits, total, err = svc.repo.SearchAuthors(ctx, query.Q, size, offset)
+		authors := make([]views.SearchAuthor, 0, len(hits))
+		for _, hit := range hits {
+			authors = append(authors, views.SearchAuthor{
+				Id:            hit.Id,
+				Name:          hit.Name,
+				NameHighlight: hit.NameHighlight,
+				Rank:          hit.Rank,
+			})
+		}
+		payload = authors
+	default:
+		var hits []*repository.BookHit
+		hits, total, err = svc.repo.SearchBooks(ctx, query.Q, size, offset)
+		books := make([]views.SearchBook, 0, len(hits))
+		for _, hit := range hits {
+			books = append(books, views.SearchBook{
+				Id:             hit.Id,
+				Title:          hit.Title,
+				Isbn:           hit.Isbn,
+				Authors:        hit.Authors,
+				TitleHighlight: hit.TitleHighlight,
+				Snippet:        hit.Snippet,
+				Rank:           hit.Rank,
+			})
+		}
+		payload = books
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidSearch) {
+			return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_QUERY, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	return views.PagedResponse(http.StatusOK, views.M_OK, payload, &views.Pagination{
+		Total:    total,
+		Page:     page,
+		PageSize: size,
+	})
+}
+
+func NewSearchSvc(repo repository.SearchRepo) service.SearchSvc {
+	return &searchSvc{
+		repo: repo,
+	}
+}
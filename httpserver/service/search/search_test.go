@@ -0,0 +1,76 @@
+package search_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type searchSvcTest struct {
+	repo    *repository.MockSearchRepo
+	service service.SearchSvc
+}
+
+func newSearchSvcTest(t *testing.T) searchSvcTest {
+	mockRepo := repository.NewMockSearchRepo(t)
+	searchSvc := search.NewSearchSvc(mockRepo)
+	return searchSvcTest{
+		repo:    mockRepo,
+		service: searchSvc,
+	}
+}
+
+func TestSearchSvc_Search(t *testing.T) {
+	t.Run("success - it should search books by default", func(t *testing.T) {
+		instance := newSearchSvcTest(t)
+		hits := []*repository.BookHit{
+			{Id: uuid.New(), Title: "Dune", TitleHighlight: "<mark>Dune</mark>", Authors: "Frank Herbert"},
+		}
+		instance.repo.EXPECT().SearchBooks(mock.Anything, "dune", 20, 0).Return(hits, 1, nil)
+
+		res := instance.service.Search(context.Background(), &params.Search{Q: "dune"})
+		assert.Equal(t, http.StatusOK, res.Status)
+		books := res.Payload.([]views.SearchBook)
+		assert.Len(t, books, 1)
+		assert.Equal(t, "<mark>Dune</mark>", books[0].TitleHighlight)
+		assert.Equal(t, int64(1), res.Meta.(*views.Pagination).Total)
+	})
+
+	t.Run("success - it should search authors and apply the page", func(t *testing.T) {
+		instance := newSearchSvcTest(t)
+		hits := []*repository.AuthorHit{
+			{Id: uuid.New(), Name: "Frank Herbert", NameHighlight: "<mark>Frank</mark> Herbert"},
+		}
+		instance.repo.EXPECT().SearchAuthors(mock.Anything, "frank", 10, 10).Return(hits, 11, nil)
+
+		res := instance.service.Search(context.Background(), &params.Search{Q: "frank", Type: "authors", Page: 2, PageSize: 10})
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Len(t, res.Payload.([]views.SearchAuthor), 1)
+		assert.Equal(t, 2, res.Meta.(*views.Pagination).Page)
+	})
+
+	t.Run("error - it should return bad request for an invalid query", func(t *testing.T) {
+		instance := newSearchSvcTest(t)
+		instance.repo.EXPECT().SearchBooks(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, 0, repository.ErrInvalidSearch)
+
+		res := instance.service.Search(context.Background(), &params.Search{Q: "*"})
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+	})
+
+	t.Run("error - it should return an error if SearchBooks returns an error", func(t *testing.T) {
+		instance := newSearchSvcTest(t)
+		instance.repo.EXPECT().SearchBooks(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, 0, assert.AnError)
+
+		res := instance.service.Search(context.Background(), &params.Search{Q: "dune"})
+		assert.Equal(t, http.StatusInternalServerError, res.Status)
+	})
+}
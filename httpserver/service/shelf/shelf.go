@@ -0,0 +1,338 @@
+package shelf
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"gorm.io/gorm"
+)
+
+var (
+	errNotYourShelf  = errors.New("only the owner of the shelf can change it")
+	errBuiltInShelf  = errors.New("built-in shelves cannot be deleted")
+	errFinishedEarly = errors.New("finished_at is before started_at")
+)
+
+type shelfSvc struct {
+	repo  repository.ShelfRepo
+	books repository.BookRepo
+	users repository.UserRepo
+}
+
+// GetShelves implements service.ShelfSvc.
+func (svc *shelfSvc) GetShelves(ctx context.Context, userId uuid.UUID, user *common.CustomClaims) *views.Response {
+	own := userId == user.Id
+	if own {
+		if err := svc.repo.CreateDefaultShelves(ctx, userId); err != nil {
+			return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+		}
+	} else {
+		_, err := svc.users.GetUserById(ctx, userId)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return views.ErrorReponse(http.StatusNotFound, views.M_USER_NOT_FOUND, err)
+			}
+			return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+		}
+	}
+
+	list, err := svc.repo.GetShelves(ctx, userId, !own)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	shelves := make([]views.Shelf, 0, len(list))
+	for _, s := range list {
+		shelves = append(shelves, shelfView(s, user))
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, shelves)
+}
+
+// CreateShelf implements service.ShelfSvc.
+func (svc *shelfSvc) CreateShelf(ctx context.Context, req *params.Shelf, user *common.CustomClaims) *views.Response {
+	shelf := models.Shelf{
+		UserId: user.Id,
+		Name:   req.Name,
+		Kind:   models.ShelfCustom,
+	}
+	if err := setPublic(&shelf, req.Public); err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	err := svc.repo.CreateShelf(ctx, &shelf)
+	if err != nil {
+		if err == repository.ErrDuplicateShelf {
+			return views.ErrorReponse(http.StatusConflict, views.M_DUPLICATE_SHELF, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusCreated, views.M_CREATED, shelfView(&shelf, user))
+}
+
+// GetShelf implements service.ShelfSvc.
+func (svc *shelfSvc) GetShelf(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	shelf, resp := svc.getShelf(ctx, id, user, false)
+	if resp != nil {
+		return resp
+	}
+	return svc.shelfWithItems(ctx, shelf, user)
+}
+
+// GetSharedShelf implements service.ShelfSvc.
+func (svc *shelfSvc) GetSharedShelf(ctx context.Context, token string) *views.Response {
+	shelf, err := svc.repo.GetShelfByToken(ctx, token)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_SHELF_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return svc.shelfWithItems(ctx, shelf, nil)
+}
+
+// UpdateShelf implements service.ShelfSvc.
+func (svc *shelfSvc) UpdateShelf(ctx context.Context, id uuid.UUID, req *params.Shelf, user *common.CustomClaims) *views.Response {
+	shelf, resp := svc.getShelf(ctx, id, user, true)
+	if resp != nil {
+		return resp
+	}
+
+	shelf.Name = req.Name
+	if err := setPublic(shelf, req.Public); err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	err := svc.repo.UpdateShelf(ctx, shelf)
+	if err != nil {
+		if err == repository.ErrDuplicateShelf {
+			return views.ErrorReponse(http.StatusConflict, views.M_DUPLICATE_SHELF, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, shelfView(shelf, user))
+}
+
+// DeleteShelf implements service.ShelfSvc.
+func (svc *shelfSvc) DeleteShelf(ctx context.Context, id uuid.UUID, user *common.CustomClaims) *views.Response {
+	shelf, resp := svc.getShelf(ctx, id, user, true)
+	if resp != nil {
+		return resp
+	}
+	if shelf.Kind != models.ShelfCustom {
+		return views.ErrorReponse(http.StatusConflict, views.M_BUILT_IN_SHELF, errBuiltInShelf)
+	}
+
+	if err := svc.repo.DeleteShelf(ctx, id); err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusNoContent, views.M_OK, nil)
+}
+
+// AddShelfItem implements service.ShelfSvc. Books put on the reading shelf
+// are started and books put on the finished shelf are finished today, unless
+// the dates are given.
+func (svc *shelfSvc) AddShelfItem(ctx context.Context, shelfId uuid.UUID, req *params.AddShelfItem, user *common.CustomClaims) *views.Response {
+	shelf, resp := svc.getShelf(ctx, shelfId, user, true)
+	if resp != nil {
+		return resp
+	}
+	book, err := svc.books.GetBookById(ctx, req.BookId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_BOOK_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	item := models.ShelfItem{
+		ShelfId:    shelfId,
+		BookId:     req.BookId,
+		Book:       *book,
+		Position:   req.Position,
+		Note:       req.Note,
+		StartedAt:  req.StartedAt,
+		FinishedAt: req.FinishedAt,
+	}
+	now := time.Now()
+	if shelf.Kind == models.ShelfReading && item.StartedAt == nil {
+		item.StartedAt = &now
+	}
+	if shelf.Kind == models.ShelfFinished && item.FinishedAt == nil {
+		item.FinishedAt = &now
+	}
+	if resp := checkDates(&item); resp != nil {
+		return resp
+	}
+	err = svc.repo.AddShelfItem(ctx, &item)
+	if err != nil {
+		if err == repository.ErrDuplicateShelfItem {
+			return views.ErrorReponse(http.StatusConflict, views.M_DUPLICATE_SHELF_ITEM, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusCreated, views.M_CREATED, itemView(&item))
+}
+
+// UpdateShelfItem implements service.ShelfSvc.
+func (svc *shelfSvc) UpdateShelfItem(ctx context.Context, shelfId, bookId uuid.UUID, req *params.UpdateShelfItem, user *common.CustomClaims) *views.Response {
+	item, resp := svc.getShelfItem(ctx, shelfId, bookId, user)
+	if resp != nil {
+		return resp
+	}
+
+	item.Note = req.Note
+	item.StartedAt = req.StartedAt
+	item.FinishedAt = req.FinishedAt
+	if resp := checkDates(item); resp != nil {
+		return resp
+	}
+	err := svc.repo.UpdateShelfItem(ctx, item, req.Position)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, itemView(item))
+}
+
+// RemoveShelfItem implements service.ShelfSvc.
+func (svc *shelfSvc) RemoveShelfItem(ctx context.Context, shelfId, bookId uuid.UUID, user *common.CustomClaims) *views.Response {
+	item, resp := svc.getShelfItem(ctx, shelfId, bookId, user)
+	if resp != nil {
+		return resp
+	}
+
+	if err := svc.repo.RemoveShelfItem(ctx, item); err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusNoContent, views.M_OK, nil)
+}
+
+// getShelf returns the shelf when the user may read it, or change it when
+// write is set. Private shelves of other users are not found.
+func (svc *shelfSvc) getShelf(ctx context.Context, id uuid.UUID, user *common.CustomClaims, write bool) (*models.Shelf, *views.Response) {
+	shelf, err := svc.repo.GetShelfById(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, views.ErrorReponse(http.StatusNotFound, views.M_SHELF_NOT_FOUND, err)
+		}
+		return nil, views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if shelf.UserId != user.Id {
+		if !shelf.Public {
+			return nil, views.ErrorReponse(http.StatusNotFound, views.M_SHELF_NOT_FOUND, gorm.ErrRecordNotFound)
+		}
+		if write {
+			return nil, views.ErrorReponse(http.StatusForbidden, views.M_FORBIDDEN, errNotYourShelf)
+		}
+	}
+	return shelf, nil
+}
+
+func (svc *shelfSvc) getShelfItem(ctx context.Context, shelfId, bookId uuid.UUID, user *common.CustomClaims) (*models.ShelfItem, *views.Response) {
+	if _, resp := svc.getShelf(ctx, shelfId, user, true); resp != nil {
+		return nil, resp
+	}
+	item, err := svc.repo.GetShelfItem(ctx, shelfId, bookId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, views.ErrorReponse(http.StatusNotFound, views.M_SHELF_ITEM_NOT_FOUND, err)
+		}
+		return nil, views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return item, nil
+}
+
+func (svc *shelfSvc) shelfWithItems(ctx context.Context, shelf *models.Shelf, user *common.CustomClaims) *views.Response {
+	items, err := svc.repo.GetShelfItems(ctx, shelf.Id)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	shelf.ItemCount = int64(len(items))
+	view := shelfView(shelf, user)
+	view.Items = make([]views.ShelfItem, 0, len(items))
+	for _, item := range items {
+		view.Items = append(view.Items, itemView(item))
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, view)
+}
+
+func checkDates(item *models.ShelfItem) *views.Response {
+	if item.StartedAt != nil && item.FinishedAt != nil && item.FinishedAt.Before(*item.StartedAt) {
+		return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_DATES, errFinishedEarly)
+	}
+	return nil
+}
+
+// setPublic gives the shelf a new share token when it is made public and
+// drops it when it is made private, which revokes the links shared so far.
+func setPublic(shelf *models.Shelf, public bool) error {
+	shelf.Public = public
+	if !public {
+		shelf.ShareToken = nil
+		return nil
+	}
+	if shelf.ShareToken != nil {
+		return nil
+	}
+	token, err := newShareToken()
+	if err != nil {
+		return err
+	}
+	shelf.ShareToken = &token
+	return nil
+}
+
+func newShareToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// shelfView returns the view of the shelf, with its share link when user is
+// its owner.
+func shelfView(s *models.Shelf, user *common.CustomClaims) views.Shelf {
+	view := views.Shelf{
+		Id:        s.Id,
+		UserId:    s.UserId,
+		Name:      s.Name,
+		Kind:      s.Kind,
+		Public:    s.Public,
+		ItemCount: s.ItemCount,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+	if user != nil && user.Id == s.UserId && s.ShareToken != nil {
+		view.SharePath = "/shared/shelves/" + *s.ShareToken
+	}
+	return view
+}
+
+func itemView(item *models.ShelfItem) views.ShelfItem {
+	return views.ShelfItem{
+		BookId:     item.BookId,
+		Title:      item.Book.Title,
+		Isbn:       item.Book.Isbn,
+		Position:   item.Position,
+		Note:       item.Note,
+		StartedAt:  item.StartedAt,
+		FinishedAt: item.FinishedAt,
+		AddedAt:    item.CreatedAt,
+	}
+}
+
+func NewShelfSvc(repo repository.ShelfRepo, books repository.BookRepo, users repository.UserRepo) service.ShelfSvc {
+	return &shelfSvc{
+		repo:  repo,
+		books: books,
+		users: users,
+	}
+}
@@ -0,0 +1,244 @@
+package shelf_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/httpserver/controller/params"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/shelf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type shelfSvcTest struct {
+	repo    *repository.MockShelfRepo
+	books   *repository.MockBookRepo
+	users   *repository.MockUserRepo
+	service service.ShelfSvc
+}
+
+func newShelfSvcTest(t *testing.T) shelfSvcTest {
+	mockRepo := repository.NewMockShelfRepo(t)
+	mockBooks := repository.NewMockBookRepo(t)
+	mockUsers := repository.NewMockUserRepo(t)
+	shelfSvc := shelf.NewShelfSvc(mockRepo, mockBooks, mockUsers)
+	return shelfSvcTest{
+		repo:    mockRepo,
+		books:   mockBooks,
+		users:   mockUsers,
+		service: shelfSvc,
+	}
+}
+
+func TestShelfSvc_GetShelves(t *testing.T) {
+	t.Run("success - it should create the built-in shelves of the caller", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		userId := uuid.New()
+
+		instance.repo.EXPECT().CreateDefaultShelves(mock.Anything, userId).Return(nil)
+		instance.repo.EXPECT().GetShelves(mock.Anything, userId, false).Return([]*models.Shelf{
+			{Id: uuid.New(), UserId: userId, Name: "To read", Kind: models.ShelfToRead, ItemCount: 2},
+		}, nil)
+		res := instance.service.GetShelves(context.Background(), userId, &common.CustomClaims{Id: userId})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		shelves := res.Payload.([]views.Shelf)
+		assert.Len(t, shelves, 1)
+		assert.Equal(t, int64(2), shelves[0].ItemCount)
+	})
+
+	t.Run("success - it should only list the public shelves of other users", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		userId := uuid.New()
+		token := "token"
+
+		instance.users.EXPECT().GetUserById(mock.Anything, userId).Return(&models.User{Id: userId}, nil)
+		instance.repo.EXPECT().GetShelves(mock.Anything, userId, true).Return([]*models.Shelf{
+			{Id: uuid.New(), UserId: userId, Name: "Favourites", Kind: models.ShelfCustom, Public: true, ShareToken: &token},
+		}, nil)
+		res := instance.service.GetShelves(context.Background(), userId, &common.CustomClaims{Id: uuid.New()})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Empty(t, res.Payload.([]views.Shelf)[0].SharePath)
+	})
+
+	t.Run("error - it should return 404 for an unknown user", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		instance.users.EXPECT().GetUserById(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.GetShelves(context.Background(), uuid.New(), &common.CustomClaims{Id: uuid.New()})
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_USER_NOT_FOUND, res.Message)
+	})
+}
+
+func TestShelfSvc_CreateShelf(t *testing.T) {
+	t.Run("success - it should give a public shelf a share link", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		userId := uuid.New()
+
+		instance.repo.EXPECT().CreateShelf(mock.Anything, mock.MatchedBy(func(s *models.Shelf) bool {
+			return s.UserId == userId && s.Kind == models.ShelfCustom && s.ShareToken != nil
+		})).Return(nil)
+		res := instance.service.CreateShelf(context.Background(), &params.Shelf{Name: "Summer", Public: true}, &common.CustomClaims{Id: userId})
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+		assert.Contains(t, res.Payload.(views.Shelf).SharePath, "/shared/shelves/")
+	})
+
+	t.Run("error - it should return 409 for a name in use", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		instance.repo.EXPECT().CreateShelf(mock.Anything, mock.Anything).Return(repository.ErrDuplicateShelf)
+
+		res := instance.service.CreateShelf(context.Background(), &params.Shelf{Name: "Summer"}, &common.CustomClaims{Id: uuid.New()})
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_DUPLICATE_SHELF, res.Message)
+	})
+}
+
+func TestShelfSvc_GetShelf(t *testing.T) {
+	t.Run("success - it should return a public shelf of another user", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		id := uuid.New()
+
+		instance.repo.EXPECT().GetShelfById(mock.Anything, id).Return(&models.Shelf{Id: id, UserId: uuid.New(), Public: true}, nil)
+		instance.repo.EXPECT().GetShelfItems(mock.Anything, id).Return([]*models.ShelfItem{
+			{BookId: uuid.New(), Book: models.Book{Title: "Dune"}, Position: 1},
+		}, nil)
+		res := instance.service.GetShelf(context.Background(), id, &common.CustomClaims{Id: uuid.New()})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		view := res.Payload.(views.Shelf)
+		assert.Equal(t, int64(1), view.ItemCount)
+		assert.Equal(t, "Dune", view.Items[0].Title)
+	})
+
+	t.Run("error - it should not find a private shelf of another user", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		id := uuid.New()
+
+		instance.repo.EXPECT().GetShelfById(mock.Anything, id).Return(&models.Shelf{Id: id, UserId: uuid.New()}, nil)
+		res := instance.service.GetShelf(context.Background(), id, &common.CustomClaims{Id: uuid.New(), Role: common.RoleAdmin})
+
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_SHELF_NOT_FOUND, res.Message)
+	})
+}
+
+func TestShelfSvc_UpdateShelf(t *testing.T) {
+	t.Run("success - it should revoke the share link of a shelf made private", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		userId, id := uuid.New(), uuid.New()
+		token := "token"
+
+		instance.repo.EXPECT().GetShelfById(mock.Anything, id).Return(&models.Shelf{Id: id, UserId: userId, Public: true, ShareToken: &token}, nil)
+		instance.repo.EXPECT().UpdateShelf(mock.Anything, mock.MatchedBy(func(s *models.Shelf) bool {
+			return !s.Public && s.ShareToken == nil && s.Name == "Later"
+		})).Return(nil)
+		res := instance.service.UpdateShelf(context.Background(), id, &params.Shelf{Name: "Later"}, &common.CustomClaims{Id: userId})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+	})
+
+	t.Run("error - it should return 403 for a public shelf of another user", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		id := uuid.New()
+
+		instance.repo.EXPECT().GetShelfById(mock.Anything, id).Return(&models.Shelf{Id: id, UserId: uuid.New(), Public: true}, nil)
+		res := instance.service.UpdateShelf(context.Background(), id, &params.Shelf{Name: "Mine"}, &common.CustomClaims{Id: uuid.New()})
+
+		assert.Equal(t, http.StatusForbidden, res.Status)
+	})
+}
+
+func TestShelfSvc_DeleteShelf(t *testing.T) {
+	t.Run("error - it should not delete a built-in shelf", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		userId, id := uuid.New(), uuid.New()
+
+		instance.repo.EXPECT().GetShelfById(mock.Anything, id).Return(&models.Shelf{Id: id, UserId: userId, Kind: models.ShelfReading}, nil)
+		res := instance.service.DeleteShelf(context.Background(), id, &common.CustomClaims{Id: userId})
+
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_BUILT_IN_SHELF, res.Message)
+	})
+}
+
+func TestShelfSvc_AddShelfItem(t *testing.T) {
+	t.Run("success - it should start the books put on the reading shelf", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		userId, id, bookId := uuid.New(), uuid.New(), uuid.New()
+
+		instance.repo.EXPECT().GetShelfById(mock.Anything, id).Return(&models.Shelf{Id: id, UserId: userId, Kind: models.ShelfReading}, nil)
+		instance.books.EXPECT().GetBookById(mock.Anything, bookId).Return(&models.Book{Id: bookId, Title: "Dune"}, nil)
+		instance.repo.EXPECT().AddShelfItem(mock.Anything, mock.MatchedBy(func(item *models.ShelfItem) bool {
+			return item.ShelfId == id && item.BookId == bookId && item.StartedAt != nil && item.FinishedAt == nil
+		})).Return(nil)
+		res := instance.service.AddShelfItem(context.Background(), id, &params.AddShelfItem{BookId: bookId}, &common.CustomClaims{Id: userId})
+
+		assert.Equal(t, http.StatusCreated, res.Status)
+		assert.Equal(t, "Dune", res.Payload.(views.ShelfItem).Title)
+	})
+
+	t.Run("error - it should return 409 for a book already on the shelf", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		userId, id := uuid.New(), uuid.New()
+
+		instance.repo.EXPECT().GetShelfById(mock.Anything, id).Return(&models.Shelf{Id: id, UserId: userId, Kind: models.ShelfCustom}, nil)
+		instance.books.EXPECT().GetBookById(mock.Anything, mock.Anything).Return(&models.Book{}, nil)
+		instance.repo.EXPECT().AddShelfItem(mock.Anything, mock.Anything).Return(repository.ErrDuplicateShelfItem)
+		res := instance.service.AddShelfItem(context.Background(), id, &params.AddShelfItem{BookId: uuid.New()}, &common.CustomClaims{Id: userId})
+
+		assert.Equal(t, http.StatusConflict, res.Status)
+		assert.Equal(t, views.M_DUPLICATE_SHELF_ITEM, res.Message)
+	})
+}
+
+func TestShelfSvc_UpdateShelfItem(t *testing.T) {
+	t.Run("success - it should move the item", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		userId, id, bookId := uuid.New(), uuid.New(), uuid.New()
+
+		instance.repo.EXPECT().GetShelfById(mock.Anything, id).Return(&models.Shelf{Id: id, UserId: userId}, nil)
+		instance.repo.EXPECT().GetShelfItem(mock.Anything, id, bookId).Return(&models.ShelfItem{ShelfId: id, BookId: bookId, Position: 3}, nil)
+		instance.repo.EXPECT().UpdateShelfItem(mock.Anything, mock.MatchedBy(func(item *models.ShelfItem) bool {
+			return item.Note == "next"
+		}), 1).Return(nil)
+		res := instance.service.UpdateShelfItem(context.Background(), id, bookId, &params.UpdateShelfItem{Position: 1, Note: "next"}, &common.CustomClaims{Id: userId})
+
+		assert.Equal(t, http.StatusOK, res.Status)
+	})
+
+	t.Run("error - it should return 400 for a book finished before it was started", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		userId, id, bookId := uuid.New(), uuid.New(), uuid.New()
+		started := time.Now()
+		finished := started.Add(-24 * time.Hour)
+
+		instance.repo.EXPECT().GetShelfById(mock.Anything, id).Return(&models.Shelf{Id: id, UserId: userId}, nil)
+		instance.repo.EXPECT().GetShelfItem(mock.Anything, id, bookId).Return(&models.ShelfItem{ShelfId: id, BookId: bookId}, nil)
+		res := instance.service.UpdateShelfItem(context.Background(), id, bookId, &params.UpdateShelfItem{StartedAt: &started, FinishedAt: &finished}, &common.CustomClaims{Id: userId})
+
+		assert.Equal(t, http.StatusBadRequest, res.Status)
+		assert.Equal(t, views.M_INVALID_DATES, res.Message)
+	})
+}
+
+func TestShelfSvc_GetSharedShelf(t *testing.T) {
+	t.Run("error - it should return 404 for an unknown link", func(t *testing.T) {
+		instance := newShelfSvcTest(t)
+		instance.repo.EXPECT().GetShelfByToken(mock.Anything, "nope").Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.GetSharedShelf(context.Background(), "nope")
+		assert.Equal(t, http.StatusNotFound, res.Status)
+	})
+}
@@ -0,0 +1,101 @@
+package trash
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/config"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/isbn"
+)
+
+type trashSvc struct {
+	repo repository.TrashRepo
+}
+
+// GetTrash implements service.TrashSvc.
+func (svc *trashSvc) GetTrash(ctx context.Context, userId uuid.UUID) *views.Response {
+	books, authors, err := svc.repo.GetTrash(ctx, userId)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	retention := config.GetTrashRetention()
+	trash := views.Trash{
+		Books:   make([]views.TrashBook, 0, len(books)),
+		Authors: make([]views.TrashAuthor, 0, len(authors)),
+	}
+	for _, book := range books {
+		trash.Books = append(trash.Books, views.TrashBook{
+			Id:          book.Id,
+			Title:       book.Title,
+			Isbn:        book.Isbn,
+			IsbnDisplay: isbn.Format(book.Isbn),
+			DeletedAt:   book.DeletedAt.Time,
+			PurgeAt:     purgeAt(book.DeletedAt.Time, retention),
+		})
+	}
+	for _, author := range authors {
+		trash.Authors = append(trash.Authors, views.TrashAuthor{
+			Id:        author.Id,
+			Name:      author.Name,
+			Birthdate: author.Birthdate,
+			DeletedAt: author.DeletedAt.Time,
+			PurgeAt:   purgeAt(author.DeletedAt.Time, retention),
+		})
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, trash)
+}
+
+func purgeAt(deletedAt time.Time, retention time.Duration) *time.Time {
+	if retention <= 0 {
+		return nil
+	}
+	at := deletedAt.Add(retention)
+	return &at
+}
+
+// StartPurge periodically removes the books and authors that have been in
+// the trash for longer than the retention period.
+func StartPurge(ctx context.Context, repo repository.TrashRepo) {
+	interval := config.GetTrashPurgeInterval()
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		purge(ctx, repo)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func purge(ctx context.Context, repo repository.TrashRepo) {
+	retention := config.GetTrashRetention()
+	if retention <= 0 {
+		return
+	}
+	books, authors, err := repo.PurgeTrash(ctx, time.Now().Add(-retention))
+	if err != nil {
+		log.Printf("Failed to purge the trash : %v", err)
+		return
+	}
+	if books > 0 || authors > 0 {
+		log.Printf("Purged %d books and %d authors from the trash", books, authors)
+	}
+}
+
+func NewTrashSvc(repo repository.TrashRepo) service.TrashSvc {
+	return &trashSvc{
+		repo: repo,
+	}
+}
@@ -0,0 +1,75 @@
+package trash_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/controller/views"
+	"github.com/storyofhis/books-management/httpserver/repository"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/httpserver/service"
+	"github.com/storyofhis/books-management/httpserver/service/trash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type trashSvcTest struct {
+	repo    *repository.MockTrashRepo
+	service service.TrashSvc
+}
+
+func newTrashSvcTest(t *testing.T) trashSvcTest {
+	mockRepo := repository.NewMockTrashRepo(t)
+	trashSvc := trash.NewTrashSvc(mockRepo)
+	return trashSvcTest{
+		repo:    mockRepo,
+		service: trashSvc,
+	}
+}
+
+func TestTrashSvc_GetTrash(t *testing.T) {
+	t.Run("success - it should list the trash with the purge date", func(t *testing.T) {
+		t.Setenv("TRASH_RETENTION", "24h")
+		instance := newTrashSvcTest(t)
+		userId := uuid.New()
+		deletedAt := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+		books := []*models.Book{{Id: uuid.New(), Title: "Dune", Isbn: "9780306406157", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}}}
+		authors := []*models.Author{{Id: uuid.New(), Name: "Frank Herbert", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}}}
+
+		instance.repo.EXPECT().GetTrash(mock.Anything, userId).Return(books, authors, nil)
+		res := instance.service.GetTrash(context.Background(), userId)
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		payload := res.Payload.(views.Trash)
+		assert.Len(t, payload.Books, 1)
+		assert.Equal(t, "978-0-306-40615-7", payload.Books[0].IsbnDisplay)
+		assert.Equal(t, deletedAt.Add(24*time.Hour), *payload.Books[0].PurgeAt)
+		assert.Len(t, payload.Authors, 1)
+		assert.Equal(t, deletedAt, payload.Authors[0].DeletedAt)
+	})
+
+	t.Run("success - it should leave out the purge date when the trash is kept", func(t *testing.T) {
+		t.Setenv("TRASH_RETENTION", "0")
+		instance := newTrashSvcTest(t)
+		userId := uuid.New()
+		books := []*models.Book{{Id: uuid.New(), DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}}
+
+		instance.repo.EXPECT().GetTrash(mock.Anything, userId).Return(books, nil, nil)
+		res := instance.service.GetTrash(context.Background(), userId)
+
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Nil(t, res.Payload.(views.Trash).Books[0].PurgeAt)
+	})
+
+	t.Run("error - it should return 500 if the trash cannot be read", func(t *testing.T) {
+		instance := newTrashSvcTest(t)
+		instance.repo.EXPECT().GetTrash(mock.Anything, mock.Anything).Return(nil, nil, assert.AnError)
+
+		res := instance.service.GetTrash(context.Background(), uuid.New())
+		assert.Equal(t, http.StatusInternalServerError, res.Status)
+	})
+}
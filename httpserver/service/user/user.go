@@ -2,11 +2,15 @@ package user
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"net/http"
 	"time"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
 	"github.com/storyofhis/books-management/common"
 	"github.com/storyofhis/books-management/config"
 	"github.com/storyofhis/books-management/httpserver/controller/params"
@@ -19,7 +23,8 @@ import (
 )
 
 type userSvc struct {
-	repo repository.UserRepo
+	repo     repository.UserRepo
+	sessions repository.SessionRepo
 }
 
 // Register implements service.UserSvc.
@@ -38,6 +43,7 @@ func (svc *userSvc) Register(ctx context.Context, user *params.Register) *views.
 	input := models.User{
 		Username: user.Username,
 		Password: string(hashed),
+		Role:     common.RoleMember,
 	}
 
 	err = svc.repo.CreateUser(ctx, &input)
@@ -49,6 +55,7 @@ func (svc *userSvc) Register(ctx context.Context, user *params.Register) *views.
 		Id:        input.Id,
 		Username:  input.Username,
 		Password:  input.Password,
+		Role:      input.Role,
 		CreatedAt: input.CreatedAt,
 	})
 }
@@ -67,23 +74,229 @@ func (svc *userSvc) Login(ctx context.Context, user *params.Login) *views.Respon
 		return views.ErrorReponse(http.StatusBadRequest, views.M_INVALID_CREDENTIALS, err)
 	}
 
+	session := models.Session{
+		UserId: model.Id,
+	}
+	err = svc.sessions.CreateSession(ctx, &session)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return svc.issueTokens(ctx, model, session.Id)
+}
+
+// Refresh implements service.UserSvc. Every refresh token can be used once;
+// presenting a used token again revokes the whole session since it means the
+// token has leaked.
+func (svc *userSvc) Refresh(ctx context.Context, token *params.Refresh) *views.Response {
+	refresh, err := svc.sessions.GetRefreshTokenByHash(ctx, hashToken(token.RefreshToken))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusUnauthorized, views.M_INVALID_REFRESH_TOKEN, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	session, err := svc.sessions.GetSessionById(ctx, refresh.SessionId)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	if session.RevokedAt != nil {
+		return views.ErrorReponse(http.StatusUnauthorized, views.M_INVALID_REFRESH_TOKEN, common.ErrTokenRevoked)
+	}
+
+	unused := refresh.UsedAt == nil
+	if unused {
+		unused, err = svc.sessions.UseRefreshToken(ctx, refresh.Id)
+		if err != nil {
+			return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+		}
+	}
+	if !unused {
+		err = svc.sessions.RevokeSession(ctx, session.Id)
+		if err != nil {
+			return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+		}
+		return views.ErrorReponse(http.StatusUnauthorized, views.M_REFRESH_TOKEN_REUSED, common.ErrTokenRevoked)
+	}
+	if time.Now().After(refresh.ExpiresAt) {
+		return views.ErrorReponse(http.StatusUnauthorized, views.M_INVALID_REFRESH_TOKEN, common.ErrTokenInactive)
+	}
+
+	model, err := svc.repo.GetUserById(ctx, session.UserId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusUnauthorized, views.M_INVALID_REFRESH_TOKEN, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return svc.issueTokens(ctx, model, session.Id)
+}
+
+// Logout implements service.UserSvc.
+func (svc *userSvc) Logout(ctx context.Context, sessionId uuid.UUID) *views.Response {
+	err := svc.sessions.RevokeSession(ctx, sessionId)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_LOGGED_OUT, nil)
+}
+
+// LogoutAll implements service.UserSvc.
+func (svc *userSvc) LogoutAll(ctx context.Context, userId uuid.UUID) *views.Response {
+	err := svc.sessions.RevokeUserSessions(ctx, userId)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_LOGGED_OUT, nil)
+}
+
+// VerifySession implements service.UserSvc.
+func (svc *userSvc) VerifySession(ctx context.Context, claims *common.CustomClaims) error {
+	session, err := svc.sessions.GetSessionById(ctx, claims.SessionId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return common.ErrTokenRevoked
+		}
+		return err
+	}
+	if session.RevokedAt != nil || session.UserId != claims.Id {
+		return common.ErrTokenRevoked
+	}
+	return nil
+}
+
+// Authenticate implements service.UserSvc.
+func (svc *userSvc) Authenticate(ctx context.Context, username, password string) (*common.CustomClaims, error) {
+	model, err := svc.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(model.Password), []byte(password)); err != nil {
+		return nil, common.ErrInvalidCredentials
+	}
+	claims := &common.CustomClaims{Id: model.Id, Role: userRole(model)}
+	claims.Subject = model.Username
+	return claims, nil
+}
+
+// GetUsers implements service.UserSvc.
+func (svc *userSvc) GetUsers(ctx context.Context) *views.Response {
+	user, err := svc.repo.GetUsers(ctx)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	users := make([]views.User, 0)
+	for _, u := range user {
+		users = append(users, views.User{
+			Id:        u.Id,
+			Username:  u.Username,
+			Role:      u.Role,
+			CreatedAt: u.CreatedAt,
+			UpdatedAt: u.UpdatedAt,
+		})
+	}
+	return views.SuccessResponse(http.StatusOK, views.M_OK, users)
+}
+
+// UpdateRole implements service.UserSvc. The sessions of the user are revoked
+// so the new role applies to their next login instead of lingering in tokens
+// that were already issued.
+func (svc *userSvc) UpdateRole(ctx context.Context, role *params.UpdateRole, id uuid.UUID) *views.Response {
+	u, err := svc.repo.GetUserById(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return views.ErrorReponse(http.StatusNotFound, views.M_USER_NOT_FOUND, err)
+		}
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	err = svc.repo.UpdateUserRole(ctx, id, role.Role)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	err = svc.sessions.RevokeUserSessions(ctx, id)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	return views.SuccessResponse(http.StatusOK, views.M_OK, views.User{
+		Id:        u.Id,
+		Username:  u.Username,
+		Role:      role.Role,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	})
+}
+
+func (svc *userSvc) issueTokens(ctx context.Context, model *models.User, sessionId uuid.UUID) *views.Response {
 	claims := &common.CustomClaims{
-		Id: model.Id,
+		Id:        model.Id,
+		Role:      userRole(model),
+		SessionId: sessionId,
 	}
+	claims.StandardClaims.Id = uuid.NewString()
 	claims.ExpiresAt = time.Now().Add(time.Minute * time.Duration(config.GetJwtExpiredTime())).Unix()
 	claims.Subject = model.Username
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	ss, err := token.SignedString(config.GetJwtSignature())
+	ss, err := common.SignToken(claims)
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+	err = svc.sessions.CreateRefreshToken(ctx, &models.RefreshToken{
+		SessionId: sessionId,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: time.Now().Add(time.Minute * time.Duration(config.GetRefreshExpiredTime())),
+	})
+	if err != nil {
+		return views.ErrorReponse(http.StatusInternalServerError, views.M_INTERNAL_SERVER_ERROR, err)
+	}
+
 	return views.SuccessResponse(http.StatusOK, views.M_OK, views.Login{
-		Id:       model.Id,
-		Username: model.Username,
-		Token:    ss,
+		Id:           model.Id,
+		Username:     model.Username,
+		Role:         claims.Role,
+		Token:        ss,
+		ExpiresIn:    config.GetJwtExpiredTime() * 60,
+		RefreshToken: refreshToken,
 	})
 }
 
-func NewUserSvc(repo repository.UserRepo) service.UserSvc {
+func newRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the form refresh tokens are stored in, so a leaked
+// database does not hand out usable tokens.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func NewUserSvc(repo repository.UserRepo, sessions repository.SessionRepo) service.UserSvc {
 	return &userSvc{
-		repo: repo,
+		repo:     repo,
+		sessions: sessions,
+	}
+}
+
+// userRole returns the role of a user, members having none before roles were
+// added.
+func userRole(model *models.User) string {
+	if model.Role == "" {
+		return common.RoleMember
 	}
+	return model.Role
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/common"
+	"github.com/storyofhis/books-management/config"
 	"github.com/storyofhis/books-management/httpserver/controller/params"
 	"github.com/storyofhis/books-management/httpserver/controller/views"
 	"github.com/storyofhis/books-management/httpserver/repository"
@@ -19,16 +22,23 @@ import (
 )
 
 type userSvcTest struct {
-	repo    *repository.MockUserRepo
-	service service.UserSvc
+	repo     *repository.MockUserRepo
+	sessions *repository.MockSessionRepo
+	service  service.UserSvc
 }
 
 func newUserSvcTestTest(t *testing.T) userSvcTest {
+	key, err := config.NewJwtKey()
+	assert.NoError(t, err)
+	config.SetJwtKeys([]*config.JwtKey{key})
+
 	mockRepo := repository.NewMockUserRepo(t)
-	userSvc := user.NewUserSvc(mockRepo)
+	mockSessions := repository.NewMockSessionRepo(t)
+	userSvc := user.NewUserSvc(mockRepo, mockSessions)
 	return userSvcTest{
-		repo:    mockRepo,
-		service: userSvc,
+		repo:     mockRepo,
+		sessions: mockSessions,
+		service:  userSvc,
 	}
 }
 
@@ -77,10 +87,13 @@ func TestUserSvc_Login(t *testing.T) {
 			Id:       uuid.New(),
 			Username: "username",
 			Password: string(hashedPassword),
+			Role:     common.RoleLibrarian,
 		}
 
 		// Mocking the repository responses
 		instance.repo.EXPECT().GetUserByUsername(mock.Anything, mock.Anything).Return(expectedUser, nil)
+		instance.sessions.EXPECT().CreateSession(mock.Anything, mock.Anything).Return(nil)
+		instance.sessions.EXPECT().CreateRefreshToken(mock.Anything, mock.Anything).Return(nil)
 
 		// Calling the login service
 		res := instance.service.Login(context.Background(), &params.Login{
@@ -91,6 +104,8 @@ func TestUserSvc_Login(t *testing.T) {
 		// Asserting the results
 		assert.Equal(t, http.StatusOK, res.Status)
 		assert.NotEmpty(t, res.Payload.(views.Login).Token)
+		assert.NotEmpty(t, res.Payload.(views.Login).RefreshToken)
+		assert.Equal(t, common.RoleLibrarian, res.Payload.(views.Login).Role)
 		assert.Equal(t, expectedUser.Username, res.Payload.(views.Login).Username)
 	})
 
@@ -150,3 +165,232 @@ func TestUserSvc_Login(t *testing.T) {
 		assert.Equal(t, views.M_INTERNAL_SERVER_ERROR, res.Message)
 	})
 }
+
+func TestUserSvc_Refresh(t *testing.T) {
+	newRefreshToken := func() *models.RefreshToken {
+		return &models.RefreshToken{
+			Id:        uuid.New(),
+			SessionId: uuid.New(),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("success - it should rotate the refresh token", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		token := newRefreshToken()
+		userId := uuid.New()
+
+		instance.sessions.EXPECT().GetRefreshTokenByHash(mock.Anything, mock.Anything).Return(token, nil)
+		instance.sessions.EXPECT().GetSessionById(mock.Anything, token.SessionId).Return(&models.Session{Id: token.SessionId, UserId: userId}, nil)
+		instance.sessions.EXPECT().UseRefreshToken(mock.Anything, token.Id).Return(true, nil)
+		instance.repo.EXPECT().GetUserById(mock.Anything, userId).Return(&models.User{Id: userId, Username: "username"}, nil)
+		instance.sessions.EXPECT().CreateRefreshToken(mock.Anything, mock.MatchedBy(func(rt *models.RefreshToken) bool {
+			return rt.SessionId == token.SessionId
+		})).Return(nil)
+
+		res := instance.service.Refresh(context.Background(), &params.Refresh{RefreshToken: "token"})
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.NotEmpty(t, res.Payload.(views.Login).Token)
+		assert.NotEmpty(t, res.Payload.(views.Login).RefreshToken)
+	})
+
+	t.Run("error - it should revoke the session when a used token is presented again", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		token := newRefreshToken()
+		usedAt := time.Now()
+		token.UsedAt = &usedAt
+
+		instance.sessions.EXPECT().GetRefreshTokenByHash(mock.Anything, mock.Anything).Return(token, nil)
+		instance.sessions.EXPECT().GetSessionById(mock.Anything, token.SessionId).Return(&models.Session{Id: token.SessionId}, nil)
+		instance.sessions.EXPECT().RevokeSession(mock.Anything, token.SessionId).Return(nil)
+
+		res := instance.service.Refresh(context.Background(), &params.Refresh{RefreshToken: "token"})
+		assert.Equal(t, http.StatusUnauthorized, res.Status)
+		assert.Equal(t, views.M_REFRESH_TOKEN_REUSED, res.Message)
+	})
+
+	t.Run("error - it should revoke the session when the token was used concurrently", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		token := newRefreshToken()
+
+		instance.sessions.EXPECT().GetRefreshTokenByHash(mock.Anything, mock.Anything).Return(token, nil)
+		instance.sessions.EXPECT().GetSessionById(mock.Anything, token.SessionId).Return(&models.Session{Id: token.SessionId}, nil)
+		instance.sessions.EXPECT().UseRefreshToken(mock.Anything, token.Id).Return(false, nil)
+		instance.sessions.EXPECT().RevokeSession(mock.Anything, token.SessionId).Return(nil)
+
+		res := instance.service.Refresh(context.Background(), &params.Refresh{RefreshToken: "token"})
+		assert.Equal(t, http.StatusUnauthorized, res.Status)
+		assert.Equal(t, views.M_REFRESH_TOKEN_REUSED, res.Message)
+	})
+
+	t.Run("error - it should reject tokens of a revoked session", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		token := newRefreshToken()
+		revokedAt := time.Now()
+
+		instance.sessions.EXPECT().GetRefreshTokenByHash(mock.Anything, mock.Anything).Return(token, nil)
+		instance.sessions.EXPECT().GetSessionById(mock.Anything, token.SessionId).Return(&models.Session{Id: token.SessionId, RevokedAt: &revokedAt}, nil)
+
+		res := instance.service.Refresh(context.Background(), &params.Refresh{RefreshToken: "token"})
+		assert.Equal(t, http.StatusUnauthorized, res.Status)
+		assert.Equal(t, views.M_INVALID_REFRESH_TOKEN, res.Message)
+	})
+
+	t.Run("error - it should reject expired tokens", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		token := newRefreshToken()
+		token.ExpiresAt = time.Now().Add(-time.Minute)
+
+		instance.sessions.EXPECT().GetRefreshTokenByHash(mock.Anything, mock.Anything).Return(token, nil)
+		instance.sessions.EXPECT().GetSessionById(mock.Anything, token.SessionId).Return(&models.Session{Id: token.SessionId}, nil)
+		instance.sessions.EXPECT().UseRefreshToken(mock.Anything, token.Id).Return(true, nil)
+
+		res := instance.service.Refresh(context.Background(), &params.Refresh{RefreshToken: "token"})
+		assert.Equal(t, http.StatusUnauthorized, res.Status)
+		assert.Equal(t, views.M_INVALID_REFRESH_TOKEN, res.Message)
+	})
+
+	t.Run("error - it should reject unknown tokens", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		instance.sessions.EXPECT().GetRefreshTokenByHash(mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.Refresh(context.Background(), &params.Refresh{RefreshToken: "token"})
+		assert.Equal(t, http.StatusUnauthorized, res.Status)
+	})
+}
+
+func TestUserSvc_Logout(t *testing.T) {
+	t.Run("success - it should revoke the current session", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		sessionId := uuid.New()
+		instance.sessions.EXPECT().RevokeSession(mock.Anything, sessionId).Return(nil)
+
+		res := instance.service.Logout(context.Background(), sessionId)
+		assert.Equal(t, http.StatusOK, res.Status)
+	})
+
+	t.Run("success - it should revoke every session of the user", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		userId := uuid.New()
+		instance.sessions.EXPECT().RevokeUserSessions(mock.Anything, userId).Return(nil)
+
+		res := instance.service.LogoutAll(context.Background(), userId)
+		assert.Equal(t, http.StatusOK, res.Status)
+	})
+
+	t.Run("error - it should return 500 if revoking fails", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		instance.sessions.EXPECT().RevokeSession(mock.Anything, mock.Anything).Return(assert.AnError)
+
+		res := instance.service.Logout(context.Background(), uuid.New())
+		assert.Equal(t, http.StatusInternalServerError, res.Status)
+	})
+}
+
+func TestUserSvc_VerifySession(t *testing.T) {
+	t.Run("success - it should accept an active session", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		claims := &common.CustomClaims{Id: uuid.New(), SessionId: uuid.New()}
+		instance.sessions.EXPECT().GetSessionById(mock.Anything, claims.SessionId).Return(&models.Session{Id: claims.SessionId, UserId: claims.Id}, nil)
+
+		assert.NoError(t, instance.service.VerifySession(context.Background(), claims))
+	})
+
+	t.Run("error - it should reject a revoked session", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		claims := &common.CustomClaims{Id: uuid.New(), SessionId: uuid.New()}
+		revokedAt := time.Now()
+		instance.sessions.EXPECT().GetSessionById(mock.Anything, claims.SessionId).Return(&models.Session{Id: claims.SessionId, UserId: claims.Id, RevokedAt: &revokedAt}, nil)
+
+		assert.ErrorIs(t, instance.service.VerifySession(context.Background(), claims), common.ErrTokenRevoked)
+	})
+
+	t.Run("error - it should reject an unknown session", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		claims := &common.CustomClaims{Id: uuid.New(), SessionId: uuid.New()}
+		instance.sessions.EXPECT().GetSessionById(mock.Anything, claims.SessionId).Return(nil, gorm.ErrRecordNotFound)
+
+		assert.ErrorIs(t, instance.service.VerifySession(context.Background(), claims), common.ErrTokenRevoked)
+	})
+}
+
+func TestUserSvc_Authenticate(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	user := &models.User{Id: uuid.New(), Username: "username", Password: string(hashedPassword)}
+
+	t.Run("success - it should return sessionless claims for correct credentials", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		instance.repo.EXPECT().GetUserByUsername(mock.Anything, "username").Return(user, nil)
+
+		claims, err := instance.service.Authenticate(context.Background(), "username", "password")
+		assert.NoError(t, err)
+		assert.Equal(t, user.Id, claims.Id)
+		assert.Equal(t, common.RoleMember, claims.Role)
+		assert.Equal(t, "username", claims.Subject)
+		assert.Equal(t, uuid.Nil, claims.SessionId)
+	})
+
+	t.Run("error - it should reject a wrong password", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		instance.repo.EXPECT().GetUserByUsername(mock.Anything, "username").Return(user, nil)
+
+		_, err := instance.service.Authenticate(context.Background(), "username", "wrong")
+		assert.ErrorIs(t, err, common.ErrInvalidCredentials)
+	})
+
+	t.Run("error - it should reject an unknown user", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		instance.repo.EXPECT().GetUserByUsername(mock.Anything, "nobody").Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := instance.service.Authenticate(context.Background(), "nobody", "password")
+		assert.ErrorIs(t, err, common.ErrInvalidCredentials)
+	})
+}
+
+func TestUserSvc_UpdateRole(t *testing.T) {
+	t.Run("success - it should change the role and revoke the sessions", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		id := uuid.New()
+		instance.repo.EXPECT().GetUserById(mock.Anything, id).Return(&models.User{Id: id, Username: "username", Role: common.RoleMember}, nil)
+		instance.repo.EXPECT().UpdateUserRole(mock.Anything, id, common.RoleLibrarian).Return(nil)
+		instance.sessions.EXPECT().RevokeUserSessions(mock.Anything, id).Return(nil)
+
+		res := instance.service.UpdateRole(context.Background(), &params.UpdateRole{Role: common.RoleLibrarian}, id)
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, common.RoleLibrarian, res.Payload.(views.User).Role)
+	})
+
+	t.Run("error - it should return 404 if the user does not exist", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		id := uuid.New()
+		instance.repo.EXPECT().GetUserById(mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+
+		res := instance.service.UpdateRole(context.Background(), &params.UpdateRole{Role: common.RoleAdmin}, id)
+		assert.Equal(t, http.StatusNotFound, res.Status)
+		assert.Equal(t, views.M_USER_NOT_FOUND, res.Message)
+	})
+}
+
+func TestUserSvc_GetUsers(t *testing.T) {
+	t.Run("success - it should return the users with their roles", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		instance.repo.EXPECT().GetUsers(mock.Anything).Return([]*models.User{
+			{Id: uuid.New(), Username: "admin", Role: common.RoleAdmin},
+			{Id: uuid.New(), Username: "member", Role: common.RoleMember},
+		}, nil)
+
+		res := instance.service.GetUsers(context.Background())
+		assert.Equal(t, http.StatusOK, res.Status)
+		users := res.Payload.([]views.User)
+		assert.Len(t, users, 2)
+		assert.Equal(t, common.RoleAdmin, users[0].Role)
+	})
+
+	t.Run("error - it should return 500 if there is a database error", func(t *testing.T) {
+		instance := newUserSvcTestTest(t)
+		instance.repo.EXPECT().GetUsers(mock.Anything).Return(nil, assert.AnError)
+
+		res := instance.service.GetUsers(context.Background())
+		assert.Equal(t, http.StatusInternalServerError, res.Status)
+	})
+}
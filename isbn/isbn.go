@@ -0,0 +1,151 @@
+// Package isbn validates, normalizes and formats International Standard Book
+// Numbers. Books are stored as unhyphenated ISBN-13 and hyphenated for
+// display.
+package isbn
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	ErrInvalid      = errors.New("invalid isbn")
+	ErrChecksum     = errors.New("invalid isbn check digit")
+	ErrNoIsbn10     = errors.New("isbn has no isbn-10 form")
+	ErrUnknownRange = errors.New("isbn registrant is not in the range table")
+)
+
+// Clean removes hyphens and spaces and upper-cases a trailing x.
+func Clean(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(s))
+	return strings.ToUpper(s)
+}
+
+// Validate checks the length, the characters and the check digit of an
+// ISBN-10 or ISBN-13. Hyphens and spaces are ignored.
+func Validate(s string) error {
+	s = Clean(s)
+	switch len(s) {
+	case 10:
+		if !digits(s[:9]) || !(digits(s[9:]) || s[9] == 'X') {
+			return ErrInvalid
+		}
+		if checkDigit10(s[:9]) != s[9] {
+			return ErrChecksum
+		}
+	case 13:
+		if !digits(s) || !(strings.HasPrefix(s, "978") || strings.HasPrefix(s, "979")) {
+			return ErrInvalid
+		}
+		if checkDigit13(s[:12]) != s[12] {
+			return ErrChecksum
+		}
+	default:
+		return ErrInvalid
+	}
+	return nil
+}
+
+func IsValid(s string) bool {
+	return Validate(s) == nil
+}
+
+// Normalize validates s and returns it as an unhyphenated ISBN-13.
+func Normalize(s string) (string, error) {
+	if err := Validate(s); err != nil {
+		return "", err
+	}
+	s = Clean(s)
+	if len(s) == 10 {
+		s = "978" + s[:9]
+		s += string(checkDigit13(s))
+	}
+	return s, nil
+}
+
+// To10 returns the unhyphenated ISBN-10 form of s. Only 978 ISBNs have one.
+func To10(s string) (string, error) {
+	s, err := Normalize(s)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(s, "978") {
+		return "", ErrNoIsbn10
+	}
+	return s[3:12] + string(checkDigit10(s[3:12])), nil
+}
+
+// Hyphenate returns s as a hyphenated ISBN-13, split into prefix,
+// registration group, registrant, publication and check digit.
+func Hyphenate(s string) (string, error) {
+	s, err := Normalize(s)
+	if err != nil {
+		return "", err
+	}
+	group, registrant, ok := split(s[:3], s[3:12])
+	if !ok {
+		return "", ErrUnknownRange
+	}
+	publication := s[3+group+registrant : 12]
+	return strings.Join([]string{s[:3], s[3 : 3+group], s[3+group : 3+group+registrant], publication, s[12:]}, "-"), nil
+}
+
+// Format returns the hyphenated form of s when its range is known, the
+// unhyphenated ISBN-13 when it is valid, and s unchanged otherwise.
+func Format(s string) string {
+	if h, err := Hyphenate(s); err == nil {
+		return h
+	}
+	if n, err := Normalize(s); err == nil {
+		return n
+	}
+	return s
+}
+
+// RegisterValidation registers the isbn tag, which accepts hyphenated or
+// unhyphenated ISBN-10 and ISBN-13 with a valid check digit.
+func RegisterValidation(v *validator.Validate) error {
+	return v.RegisterValidation("isbn", func(fl validator.FieldLevel) bool {
+		return IsValid(fl.Field().String())
+	})
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func checkDigit10(s string) byte {
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(s[i]-'0') * (10 - i)
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return 'X'
+	}
+	return byte('0' + check)
+}
+
+func checkDigit13(s string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(s[i]-'0') * weight
+	}
+	return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,87 @@
+package isbn_test
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/storyofhis/books-management/isbn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("success - it should accept valid isbn-10 and isbn-13", func(t *testing.T) {
+		for _, s := range []string{"0-306-40615-2", "0306406152", "080442957X", "080442957x", "978-0-306-40615-7", "9791032305690"} {
+			assert.NoError(t, isbn.Validate(s), s)
+		}
+	})
+
+	t.Run("error - it should reject malformed isbns", func(t *testing.T) {
+		for _, s := range []string{"", "123", "123-344443-44-332", "97803064061X7", "X306406152", "1234567890123"} {
+			assert.ErrorIs(t, isbn.Validate(s), isbn.ErrInvalid, s)
+		}
+	})
+
+	t.Run("error - it should reject a wrong check digit", func(t *testing.T) {
+		assert.ErrorIs(t, isbn.Validate("0-306-40615-3"), isbn.ErrChecksum)
+		assert.ErrorIs(t, isbn.Validate("978-0-306-40615-8"), isbn.ErrChecksum)
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	t.Run("success - it should convert to an unhyphenated isbn-13", func(t *testing.T) {
+		for in, want := range map[string]string{
+			"0-306-40615-2":     "9780306406157",
+			"080442957X":        "9780804429573",
+			"978 0 306 40615 7": "9780306406157",
+		} {
+			got, err := isbn.Normalize(in)
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		}
+	})
+
+	t.Run("success - it should convert back to isbn-10", func(t *testing.T) {
+		got, err := isbn.To10("9780804429573")
+		assert.NoError(t, err)
+		assert.Equal(t, "080442957X", got)
+	})
+
+	t.Run("error - it should not convert 979 isbns to isbn-10", func(t *testing.T) {
+		_, err := isbn.To10("9791032305690")
+		assert.ErrorIs(t, err, isbn.ErrNoIsbn10)
+	})
+}
+
+func TestHyphenate(t *testing.T) {
+	t.Run("success - it should hyphenate by registration group and registrant", func(t *testing.T) {
+		for in, want := range map[string]string{
+			"0306406152":    "978-0-306-40615-7",
+			"9781402894626": "978-1-4028-9462-6",
+			"9781566199094": "978-1-56619-909-4",
+			"9782070368228": "978-2-07-036822-8",
+			"9783161484100": "978-3-16-148410-0",
+			"9791032305690": "979-10-323-0569-0",
+		} {
+			got, err := isbn.Hyphenate(in)
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		}
+	})
+
+	t.Run("error - it should report groups without a known range", func(t *testing.T) {
+		_, err := isbn.Hyphenate("9785170900039")
+		assert.ErrorIs(t, err, isbn.ErrUnknownRange)
+		assert.Equal(t, "9785170900039", isbn.Format("978-5-17-090003-9"))
+		assert.Equal(t, "garbage", isbn.Format("garbage"))
+	})
+}
+
+func TestRegisterValidation(t *testing.T) {
+	type book struct {
+		Isbn string `validate:"isbn"`
+	}
+	v := validator.New()
+	assert.NoError(t, isbn.RegisterValidation(v))
+	assert.NoError(t, v.Struct(book{Isbn: "978-0-306-40615-7"}))
+	assert.Error(t, v.Struct(book{Isbn: "123-456"}))
+}
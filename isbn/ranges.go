@@ -0,0 +1,127 @@
+package isbn
+
+// rangeRule gives the length of the element whose next seven digits fall
+// between start and end. A length of zero marks a range that is not in use.
+type rangeRule struct {
+	start, end string
+	length     int
+}
+
+// groups are the registration group ranges of the International ISBN Agency
+// range message, keyed by prefix.
+var groups = map[string][]rangeRule{
+	"978": {
+		{"0000000", "5999999", 1},
+		{"6000000", "6499999", 3},
+		{"6500000", "6599999", 2},
+		{"6600000", "6999999", 0},
+		{"7000000", "7999999", 1},
+		{"8000000", "9499999", 2},
+		{"9500000", "9899999", 3},
+		{"9900000", "9989999", 4},
+		{"9990000", "9999999", 5},
+	},
+	"979": {
+		{"0000000", "0999999", 0},
+		{"1000000", "1299999", 2},
+		{"1300000", "7999999", 0},
+		{"8000000", "8999999", 1},
+		{"9000000", "9999999", 0},
+	},
+}
+
+// registrants are the registrant ranges of the registration groups the
+// catalogue holds most books from, keyed by prefix and group. Books from
+// other groups are shown unhyphenated.
+var registrants = map[string][]rangeRule{
+	// English language
+	"978-0": {
+		{"0000000", "1999999", 2},
+		{"2000000", "6999999", 3},
+		{"7000000", "8499999", 4},
+		{"8500000", "8999999", 5},
+		{"9000000", "9499999", 6},
+		{"9500000", "9999999", 7},
+	},
+	"978-1": {
+		{"0000000", "0999999", 2},
+		{"1000000", "3999999", 3},
+		{"4000000", "5499999", 4},
+		{"5500000", "7319999", 5},
+		{"7320000", "7399999", 7},
+		{"7400000", "7749999", 5},
+		{"7750000", "7753999", 7},
+		{"7754000", "8697999", 5},
+		{"8698000", "9729999", 6},
+		{"9730000", "9877999", 4},
+		{"9878000", "9989999", 6},
+		{"9990000", "9999999", 7},
+	},
+	// French language
+	"978-2": {
+		{"0000000", "1999999", 2},
+		{"2000000", "3499999", 3},
+		{"3500000", "3999999", 5},
+		{"4000000", "6999999", 3},
+		{"7000000", "8399999", 4},
+		{"8400000", "8999999", 5},
+		{"9000000", "9499999", 6},
+		{"9500000", "9999999", 7},
+	},
+	"979-10": {
+		{"0000000", "1999999", 2},
+		{"2000000", "6999999", 3},
+		{"7000000", "8999999", 4},
+		{"9000000", "9759999", 5},
+		{"9760000", "9999999", 6},
+	},
+	// German language
+	"978-3": {
+		{"0000000", "0299999", 2},
+		{"0300000", "0339999", 3},
+		{"0340000", "0369999", 4},
+		{"0370000", "0399999", 5},
+		{"0400000", "1999999", 2},
+		{"2000000", "6999999", 3},
+		{"7000000", "8499999", 4},
+		{"8500000", "8999999", 5},
+		{"9000000", "9499999", 6},
+		{"9500000", "9539999", 7},
+		{"9540000", "9699999", 5},
+		{"9700000", "9849999", 7},
+		{"9850000", "9999999", 5},
+	},
+	// Japan
+	"978-4": {
+		{"0000000", "1999999", 2},
+		{"2000000", "6999999", 3},
+		{"7000000", "8499999", 4},
+		{"8500000", "8999999", 5},
+		{"9000000", "9499999", 6},
+		{"9500000", "9999999", 7},
+	},
+}
+
+// split returns the lengths of the registration group and the registrant of
+// the nine digits following prefix.
+func split(prefix, rest string) (group, registrant int, ok bool) {
+	group = lookup(groups[prefix], rest)
+	if group == 0 {
+		return 0, 0, false
+	}
+	registrant = lookup(registrants[prefix+"-"+rest[:group]], rest[group:])
+	if registrant == 0 || group+registrant >= len(rest) {
+		return 0, 0, false
+	}
+	return group, registrant, true
+}
+
+func lookup(rules []rangeRule, digits string) int {
+	key := (digits + "0000000")[:7]
+	for _, rule := range rules {
+		if key >= rule.start && key <= rule.end {
+			return rule.length
+		}
+	}
+	return 0
+}
@@ -0,0 +1,406 @@
+// Package jsonpatch applies JSON Merge Patches (RFC 7396) and JSON Patches
+// (RFC 6902) to JSON documents.
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPatch is returned for patches that are not well formed.
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrTestFailed is returned when a test operation does not match.
+	ErrTestFailed = errors.New("patch test failed")
+	// ErrPathNotFound is returned when an operation refers to a location
+	// that does not exist in the document.
+	ErrPathNotFound = errors.New("patch path not found")
+)
+
+// MergePatch applies the merge patch to doc. Members set to null in the patch
+// are removed from doc, objects are merged recursively and any other value
+// replaces the one in doc.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{})
+	}
+	for name, value := range p {
+		if value == nil {
+			delete(t, name)
+			continue
+		}
+		t[name] = merge(t[name], value)
+	}
+	return t
+}
+
+type operation struct {
+	op    string
+	path  []string
+	from  []string
+	value interface{}
+}
+
+// Apply applies the operations of the JSON patch to doc in order. The patch
+// is applied entirely or not at all.
+func Apply(doc, patch []byte) ([]byte, error) {
+	ops, err := parse(patch)
+	if err != nil {
+		return nil, err
+	}
+	root, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	for i, op := range ops {
+		root, err = op.apply(root)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+func parse(patch []byte) ([]operation, error) {
+	var raw []map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	ops := make([]operation, 0, len(raw))
+	for i, fields := range raw {
+		var op operation
+		if err := json.Unmarshal(fields["op"], &op.op); err != nil {
+			return nil, fmt.Errorf("%w: operation %d has no op", ErrInvalidPatch, i)
+		}
+		var path string
+		if err := json.Unmarshal(fields["path"], &path); err != nil {
+			return nil, fmt.Errorf("%w: operation %d has no path", ErrInvalidPatch, i)
+		}
+		tokens, err := pointer(path)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+		op.path = tokens
+
+		switch op.op {
+		case "add", "replace", "test":
+			value, ok := fields["value"]
+			if !ok {
+				return nil, fmt.Errorf("%w: operation %d has no value", ErrInvalidPatch, i)
+			}
+			if op.value, err = decode(value); err != nil {
+				return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, i, err)
+			}
+		case "move", "copy":
+			var from string
+			if err := json.Unmarshal(fields["from"], &from); err != nil {
+				return nil, fmt.Errorf("%w: operation %d has no from", ErrInvalidPatch, i)
+			}
+			if op.from, err = pointer(from); err != nil {
+				return nil, fmt.Errorf("operation %d: %w", i, err)
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.op)
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// pointer splits a JSON pointer (RFC 6901) into its unescaped tokens.
+func pointer(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if path[0] != '/' {
+		return nil, fmt.Errorf("%w: pointer %q does not start with /", ErrInvalidPatch, path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func (op operation) apply(root interface{}) (interface{}, error) {
+	switch op.op {
+	case "add":
+		return add(root, op.path, op.value)
+	case "remove":
+		return remove(root, op.path)
+	case "replace":
+		if len(op.path) == 0 {
+			return op.value, nil
+		}
+		return update(root, op.path, func(container interface{}, key string) (interface{}, error) {
+			switch c := container.(type) {
+			case map[string]interface{}:
+				if _, ok := c[key]; !ok {
+					return nil, notFound(op.path)
+				}
+				c[key] = op.value
+				return c, nil
+			case []interface{}:
+				i, err := index(key, len(c)-1)
+				if err != nil {
+					return nil, notFound(op.path)
+				}
+				c[i] = op.value
+				return c, nil
+			}
+			return nil, notFound(op.path)
+		})
+	case "move":
+		if isPrefix(op.from, op.path) && len(op.from) < len(op.path) {
+			return nil, fmt.Errorf("%w: cannot move a value into itself", ErrInvalidPatch)
+		}
+		value, err := get(root, op.from)
+		if err != nil {
+			return nil, err
+		}
+		if root, err = remove(root, op.from); err != nil {
+			return nil, err
+		}
+		return add(root, op.path, value)
+	case "copy":
+		value, err := get(root, op.from)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, op.path, deepCopy(value))
+	case "test":
+		value, err := get(root, op.path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(value, op.value) {
+			return nil, fmt.Errorf("%w: /%s", ErrTestFailed, strings.Join(op.path, "/"))
+		}
+		return root, nil
+	}
+	return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.op)
+}
+
+func add(root interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return update(root, path, func(container interface{}, key string) (interface{}, error) {
+		switch c := container.(type) {
+		case map[string]interface{}:
+			c[key] = value
+			return c, nil
+		case []interface{}:
+			if key == "-" {
+				return append(c, value), nil
+			}
+			i, err := index(key, len(c))
+			if err != nil {
+				return nil, notFound(path)
+			}
+			c = append(c, nil)
+			copy(c[i+1:], c[i:])
+			c[i] = value
+			return c, nil
+		}
+		return nil, notFound(path)
+	})
+}
+
+func remove(root interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+	}
+	return update(root, path, func(container interface{}, key string) (interface{}, error) {
+		switch c := container.(type) {
+		case map[string]interface{}:
+			if _, ok := c[key]; !ok {
+				return nil, notFound(path)
+			}
+			delete(c, key)
+			return c, nil
+		case []interface{}:
+			i, err := index(key, len(c)-1)
+			if err != nil {
+				return nil, notFound(path)
+			}
+			return append(c[:i], c[i+1:]...), nil
+		}
+		return nil, notFound(path)
+	})
+}
+
+// update calls fn with the container holding the last token of path and
+// stores the container it returns in place of the original one.
+func update(node interface{}, path []string, fn func(container interface{}, key string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+	child, err := child(node, path[0])
+	if err != nil {
+		return nil, notFound(path)
+	}
+	child, err = update(child, path[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+	switch c := node.(type) {
+	case map[string]interface{}:
+		c[path[0]] = child
+	case []interface{}:
+		i, _ := index(path[0], len(c)-1)
+		c[i] = child
+	}
+	return node, nil
+}
+
+func get(node interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		var err error
+		if node, err = child(node, token); err != nil {
+			return nil, notFound(path)
+		}
+	}
+	return node, nil
+}
+
+func child(node interface{}, token string) (interface{}, error) {
+	switch c := node.(type) {
+	case map[string]interface{}:
+		value, ok := c[token]
+		if !ok {
+			return nil, ErrPathNotFound
+		}
+		return value, nil
+	case []interface{}:
+		i, err := index(token, len(c)-1)
+		if err != nil {
+			return nil, err
+		}
+		return c[i], nil
+	}
+	return nil, ErrPathNotFound
+}
+
+// index parses an array index no greater than max. Leading zeros are not
+// allowed.
+func index(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, ErrPathNotFound
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max {
+		return 0, ErrPathNotFound
+	}
+	return i, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func notFound(path []string) error {
+	return fmt.Errorf("%w: /%s", ErrPathNotFound, strings.Join(path, "/"))
+}
+
+func decode(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after the document")
+	}
+	return value, nil
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for name, item := range v {
+			c[name] = deepCopy(item)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, item := range v {
+			c[i] = deepCopy(item)
+		}
+		return c
+	}
+	return value
+}
+
+// equal compares two decoded values, numbers by value.
+func equal(a, b interface{}) bool {
+	if x, ok := a.(json.Number); ok {
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		fx, errX := x.Float64()
+		fy, errY := y.Float64()
+		return errX == nil && errY == nil && fx == fy
+	}
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for name, item := range x {
+			other, ok := y[name]
+			if !ok || !equal(item, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,77 @@
+package jsonpatch_test
+
+import (
+	"testing"
+
+	"github.com/storyofhis/books-management/jsonpatch"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	t.Run("success - it should merge objects and remove null members", func(t *testing.T) {
+		doc := `{"title":"Goodbye!","author":{"givenName":"John","familyName":"Doe"},"tags":["example","sample"],"content":"This will be unchanged"}`
+		patch := `{"title":"Hello!","phoneNumber":"+01-123-456-7890","author":{"familyName":null},"tags":["example"]}`
+		out, err := jsonpatch.MergePatch([]byte(doc), []byte(patch))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"title":"Hello!","author":{"givenName":"John"},"tags":["example"],"content":"This will be unchanged","phoneNumber":"+01-123-456-7890"}`, string(out))
+	})
+
+	t.Run("error - it should reject a malformed patch", func(t *testing.T) {
+		_, err := jsonpatch.MergePatch([]byte(`{}`), []byte(`{"title":`))
+		assert.ErrorIs(t, err, jsonpatch.ErrInvalidPatch)
+	})
+}
+
+func TestApply(t *testing.T) {
+	t.Run("success - it should apply the operations in order", func(t *testing.T) {
+		doc := `{"foo":["bar","baz"],"a":{"b":1}}`
+		patch := `[
+			{"op":"add","path":"/foo/1","value":"qux"},
+			{"op":"remove","path":"/foo/0"},
+			{"op":"add","path":"/foo/-","value":"end"},
+			{"op":"replace","path":"/a/b","value":2},
+			{"op":"copy","from":"/a","path":"/c"},
+			{"op":"move","from":"/c/b","path":"/d"},
+			{"op":"test","path":"/d","value":2.0}
+		]`
+		out, err := jsonpatch.Apply([]byte(doc), []byte(patch))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"foo":["qux","baz","end"],"a":{"b":2},"c":{},"d":2}`, string(out))
+	})
+
+	t.Run("success - it should unescape pointer tokens", func(t *testing.T) {
+		out, err := jsonpatch.Apply([]byte(`{"a/b":1,"m~n":2}`), []byte(`[{"op":"remove","path":"/a~1b"},{"op":"replace","path":"/m~0n","value":null}]`))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"m~n":null}`, string(out))
+	})
+
+	t.Run("error - it should fail when a test does not match", func(t *testing.T) {
+		_, err := jsonpatch.Apply([]byte(`{"a":1}`), []byte(`[{"op":"test","path":"/a","value":"1"}]`))
+		assert.ErrorIs(t, err, jsonpatch.ErrTestFailed)
+	})
+
+	t.Run("error - it should fail on missing locations", func(t *testing.T) {
+		for _, patch := range []string{
+			`[{"op":"remove","path":"/b"}]`,
+			`[{"op":"replace","path":"/list/2","value":1}]`,
+			`[{"op":"add","path":"/missing/child","value":1}]`,
+			`[{"op":"add","path":"/list/01","value":1}]`,
+		} {
+			_, err := jsonpatch.Apply([]byte(`{"a":1,"list":[1,2]}`), []byte(patch))
+			assert.ErrorIs(t, err, jsonpatch.ErrPathNotFound, patch)
+		}
+	})
+
+	t.Run("error - it should reject malformed operations", func(t *testing.T) {
+		for _, patch := range []string{
+			`{"op":"add"}`,
+			`[{"op":"jump","path":"/a"}]`,
+			`[{"op":"add","path":"/a"}]`,
+			`[{"op":"add","path":"a","value":1}]`,
+			`[{"op":"move","from":"/a","path":"/a/b"}]`,
+		} {
+			_, err := jsonpatch.Apply([]byte(`{"a":{}}`), []byte(patch))
+			assert.ErrorIs(t, err, jsonpatch.ErrInvalidPatch, patch)
+		}
+	})
+}
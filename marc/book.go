@@ -0,0 +1,192 @@
+package marc
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/isbn"
+	"github.com/storyofhis/books-management/person"
+)
+
+// relators are the roles of the contributors by relator term, subfield $e,
+// and by relator code, subfield $4.
+var relators = map[string]string{
+	"author":      models.ContributorAuthor,
+	"aut":         models.ContributorAuthor,
+	"editor":      models.ContributorEditor,
+	"edt":         models.ContributorEditor,
+	"ed":          models.ContributorEditor,
+	"translator":  models.ContributorTranslator,
+	"trl":         models.ContributorTranslator,
+	"tr":          models.ContributorTranslator,
+	"trans":       models.ContributorTranslator,
+	"illustrator": models.ContributorIllustrator,
+	"ill":         models.ContributorIllustrator,
+}
+
+// Book returns the book described by a record, with its contributors. The
+// contributors are only named, their Author holds the name and the birth
+// year of the heading, and AuthorId is not set. The ISBN is normalized when
+// it is valid, and kept as it is in the record otherwise.
+func Book(rec *Record) *models.Book {
+	b := &models.Book{}
+	if f := first(rec, "245"); f != nil {
+		b.Title = trimPunctuation(f.Subfield('a'))
+		if sub := trimPunctuation(f.Subfield('b')); sub != "" {
+			b.Title += ": " + sub
+		}
+	}
+
+	// 020 $a may be qualified, as in "0306406152 (pbk.)". The first valid
+	// ISBN is the one of the book.
+	for _, f := range rec.FieldsByTag("020") {
+		code := strings.Fields(f.Subfield('a'))
+		if len(code) == 0 {
+			continue
+		}
+		if normalized, err := isbn.Normalize(code[0]); err == nil {
+			b.Isbn = normalized
+			break
+		}
+		if b.Isbn == "" {
+			b.Isbn = code[0]
+		}
+	}
+
+	for _, tag := range []string{"100", "700"} {
+		for _, f := range rec.FieldsByTag(tag) {
+			name := personalName(f)
+			if name == "" {
+				continue
+			}
+			b.Contributors = append(b.Contributors, models.BookContributor{
+				Role:     role(f),
+				Position: len(b.Contributors),
+				Author:   models.Author{Name: name, Birthdate: birthdate(f.Subfield('d'))},
+			})
+		}
+	}
+	return b
+}
+
+// FromBook returns the record of a book. The first author is the main entry,
+// in field 100, and the other contributors are added entries, in 700. The
+// contributors must be loaded with their authors.
+func FromBook(b *models.Book) *Record {
+	rec := &Record{Leader: defaultLeader}
+	rec.Fields = append(rec.Fields, Field{Tag: "001", Value: b.Id.String()})
+	if b.Isbn != "" {
+		rec.Fields = append(rec.Fields, Field{Tag: "020", Ind1: ' ', Ind2: ' ', Subfields: []Subfield{{'a', b.Isbn}}})
+	}
+
+	main := -1
+	for i, c := range b.Contributors {
+		if c.Role == models.ContributorAuthor {
+			main = i
+			break
+		}
+	}
+	if main >= 0 {
+		rec.Fields = append(rec.Fields, nameField("100", &b.Contributors[main]))
+	}
+
+	title := Field{Tag: "245", Ind1: '0', Ind2: '0'}
+	if main >= 0 {
+		title.Ind1 = '1'
+	}
+	if head, sub, ok := strings.Cut(b.Title, ": "); ok {
+		title.Subfields = []Subfield{{'a', head + " :"}, {'b', sub}}
+	} else {
+		title.Subfields = []Subfield{{'a', b.Title}}
+	}
+	rec.Fields = append(rec.Fields, title)
+
+	for i := range b.Contributors {
+		if i != main {
+			rec.Fields = append(rec.Fields, nameField("700", &b.Contributors[i]))
+		}
+	}
+	return rec
+}
+
+func first(rec *Record, tag string) *Field {
+	if fields := rec.FieldsByTag(tag); len(fields) > 0 {
+		return fields[0]
+	}
+	return nil
+}
+
+// nameField returns the heading of a contributor, the name inverted as
+// "Surname, Forenames" when it has forenames.
+func nameField(tag string, c *models.BookContributor) Field {
+	f := Field{Tag: tag, Ind1: '0', Ind2: ' '}
+	name := c.Author.Name
+	if surname, forenames := person.SplitName(name); forenames != "" {
+		f.Ind1 = '1'
+		name = surname + ", " + forenames
+	}
+	f.Subfields = append(f.Subfields, Subfield{'a', name + ","})
+	if !c.Author.Birthdate.IsZero() {
+		f.Subfields = append(f.Subfields, Subfield{'d', strconv.Itoa(c.Author.Birthdate.Year()) + "-"})
+	}
+	role := c.Role
+	if role == "" {
+		role = models.ContributorAuthor
+	}
+	f.Subfields = append(f.Subfields, Subfield{'e', role + "."})
+	return f
+}
+
+// personalName returns the name of a heading in direct order. Surnames,
+// first indicator 1, are inverted in records.
+func personalName(f *Field) string {
+	name := trimPunctuation(f.Subfield('a'))
+	if f.Ind1 == '1' {
+		if surname, forenames, ok := strings.Cut(name, ", "); ok {
+			name = strings.TrimSpace(forenames) + " " + strings.TrimSpace(surname)
+		}
+	}
+	return name
+}
+
+// role returns the role given by the relator code or term of a heading, an
+// author when there is none or it is not one of the roles of a book.
+func role(f *Field) string {
+	for _, code := range f.SubfieldValues('4') {
+		if r, ok := relators[strings.ToLower(trimPunctuation(code))]; ok {
+			return r
+		}
+	}
+	for _, term := range f.SubfieldValues('e') {
+		if r, ok := relators[strings.ToLower(strings.TrimRight(strings.TrimSpace(term), ".,"))]; ok {
+			return r
+		}
+	}
+	return models.ContributorAuthor
+}
+
+// birthdate returns the first of January of the birth year of dates such as
+// "1920-1986.", or the zero time.
+func birthdate(dates string) time.Time {
+	year, _, _ := strings.Cut(strings.TrimSpace(dates), "-")
+	y, err := strconv.Atoi(year)
+	if err != nil || y <= 0 {
+		return time.Time{}
+	}
+	return time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// trimPunctuation removes the ISBD punctuation ending a subfield. A full
+// stop is kept after an initial, as in "Le Guin, Ursula K.".
+func trimPunctuation(s string) string {
+	s = strings.TrimRight(strings.TrimSpace(s), " /:;,=")
+	if strings.HasSuffix(s, ".") {
+		word := s[strings.LastIndexAny(s, " ,")+1:]
+		if len(word) != 2 {
+			s = strings.TrimSuffix(s, ".")
+		}
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,215 @@
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+const (
+	subfieldDelimiter = 0x1f
+	fieldTerminator   = 0x1e
+	recordTerminator  = 0x1d
+
+	leaderLength = 24
+	entryLength  = 12
+)
+
+// Reader reads ISO 2709 records, the binary MARC of .mrc files.
+type Reader struct {
+	r *bufio.Reader
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next record, or io.EOF after the last one. An error
+// wrapping ErrInvalidRecord is only about that record, any other error ends
+// the file.
+func (r *Reader) Read() (*Record, error) {
+	// Some files put a line break after every record.
+	for {
+		b, err := r.r.Peek(1)
+		if err != nil {
+			return nil, err
+		}
+		if b[0] != '\n' && b[0] != '\r' {
+			break
+		}
+		r.r.Discard(1)
+	}
+
+	head, err := r.r.Peek(5)
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	length, ok := number(head)
+	if !ok || length < leaderLength+2 {
+		return nil, fmt.Errorf("marc: invalid record length %q", head)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return Unmarshal(data)
+}
+
+// Unmarshal decodes one ISO 2709 record. Records must be encoded in UTF-8,
+// MARC-8 is only read when it is plain ASCII.
+func Unmarshal(data []byte) (*Record, error) {
+	if len(data) < leaderLength+2 || data[len(data)-1] != recordTerminator {
+		return nil, invalid("the record is not terminated")
+	}
+	if !utf8.Valid(data) {
+		return nil, invalid("the record is not encoded in UTF-8")
+	}
+	rec := &Record{Leader: string(data[:leaderLength])}
+	base, ok := number(data[12:17])
+	if !ok || base <= leaderLength || base > len(data) || data[base-1] != fieldTerminator {
+		return nil, invalid("invalid base address of data %q", data[12:17])
+	}
+	directory := data[leaderLength : base-1]
+	if len(directory)%entryLength != 0 {
+		return nil, invalid("the directory has a partial entry")
+	}
+
+	for i := 0; i < len(directory); i += entryLength {
+		entry := directory[i : i+entryLength]
+		tag := string(entry[:3])
+		length, ok1 := number(entry[3:7])
+		start, ok2 := number(entry[7:12])
+		if !ok1 || !ok2 || length < 1 || base+start+length > len(data)-1 {
+			return nil, invalid("invalid directory entry %q", entry)
+		}
+		raw := data[base+start : base+start+length]
+		if raw[len(raw)-1] != fieldTerminator {
+			return nil, invalid("field %s is not terminated", tag)
+		}
+		raw = raw[:len(raw)-1]
+
+		f := Field{Tag: tag}
+		if f.IsControl() {
+			f.Value = string(raw)
+			rec.Fields = append(rec.Fields, f)
+			continue
+		}
+		if len(raw) < 2 {
+			return nil, invalid("field %s has no indicators", tag)
+		}
+		f.Ind1, f.Ind2 = raw[0], raw[1]
+		parts := bytes.Split(raw[2:], []byte{subfieldDelimiter})
+		if len(parts[0]) > 0 {
+			return nil, invalid("field %s has data outside of subfields", tag)
+		}
+		for _, part := range parts[1:] {
+			if len(part) == 0 {
+				return nil, invalid("field %s has a subfield without code", tag)
+			}
+			f.Subfields = append(f.Subfields, Subfield{Code: part[0], Value: string(part[1:])})
+		}
+		rec.Fields = append(rec.Fields, f)
+	}
+	return rec, nil
+}
+
+// number parses the unsigned decimal numbers of the leader and the
+// directory. Unlike strconv.Atoi it rejects signs, which would make offsets
+// negative.
+func number(b []byte) (int, bool) {
+	n := 0
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, len(b) > 0
+}
+
+// Writer writes ISO 2709 records.
+type Writer struct {
+	w io.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func (w *Writer) Write(rec *Record) error {
+	data, err := Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(data)
+	return err
+}
+
+// Marshal encodes a record. The lengths and the base address of the leader
+// are computed, the rest of the leader is kept but for the character coding,
+// which is always UTF-8.
+func Marshal(rec *Record) ([]byte, error) {
+	if rec.Leader == "" {
+		rec = &Record{Leader: defaultLeader, Fields: rec.Fields}
+	}
+	if err := rec.validate(); err != nil {
+		return nil, err
+	}
+
+	var directory, fields bytes.Buffer
+	for _, f := range rec.Fields {
+		start := fields.Len()
+		if f.IsControl() {
+			fields.WriteString(f.Value)
+		} else {
+			fields.WriteByte(indicator(f.Ind1))
+			fields.WriteByte(indicator(f.Ind2))
+			for _, sf := range f.Subfields {
+				fields.WriteByte(subfieldDelimiter)
+				fields.WriteByte(sf.Code)
+				fields.WriteString(sf.Value)
+			}
+		}
+		fields.WriteByte(fieldTerminator)
+		length := fields.Len() - start
+		if length > 9999 {
+			return nil, invalid("field %s is longer than 9999 bytes", f.Tag)
+		}
+		fmt.Fprintf(&directory, "%s%04d%05d", f.Tag, length, start)
+	}
+	fields.WriteByte(recordTerminator)
+
+	base := leaderLength + directory.Len() + 1
+	length := base + fields.Len()
+	if length > 99999 {
+		return nil, invalid("the record is longer than 99999 bytes")
+	}
+	leader := []byte(rec.Leader)
+	copy(leader[0:5], fmt.Sprintf("%05d", length))
+	leader[9] = 'a'
+	copy(leader[10:12], "22")
+	copy(leader[12:17], fmt.Sprintf("%05d", base))
+	copy(leader[20:24], "4500")
+
+	data := make([]byte, 0, length)
+	data = append(data, leader...)
+	data = append(data, directory.Bytes()...)
+	data = append(data, fieldTerminator)
+	return append(data, fields.Bytes()...), nil
+}
+
+// indicator returns a blank for an unset indicator.
+func indicator(b byte) byte {
+	if b == 0 {
+		return ' '
+	}
+	return b
+}
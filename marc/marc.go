@@ -0,0 +1,93 @@
+// Package marc reads and writes MARC 21 bibliographic records, in ISO 2709
+// transmission format and in MARCXML, and maps them to books: the title in
+// field 245, the ISBN in 020 and the contributors in 100 and 700.
+package marc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidRecord is returned for a record that was read but is malformed.
+// Readers can go on with the next record.
+var ErrInvalidRecord = errors.New("invalid marc record")
+
+// defaultLeader is the leader of a new record: a new language material
+// monograph, encoded in UTF-8, with ISBD punctuation.
+const defaultLeader = "00000nam a2200000 i 4500"
+
+// Record is a MARC record. Control fields come before data fields, each in
+// tag order.
+type Record struct {
+	Leader string
+	Fields []Field
+}
+
+// Field is a control field, with a Value, or a data field, with indicators
+// and subfields. Control fields have the tags 001 to 009.
+type Field struct {
+	Tag       string
+	Ind1      byte
+	Ind2      byte
+	Value     string
+	Subfields []Subfield
+}
+
+type Subfield struct {
+	Code  byte
+	Value string
+}
+
+// IsControl reports whether the field is a control field.
+func (f *Field) IsControl() bool {
+	return len(f.Tag) == 3 && f.Tag[0] == '0' && f.Tag[1] == '0'
+}
+
+// Subfield returns the first value of the subfield with the code, or "".
+func (f *Field) Subfield(code byte) string {
+	for _, sf := range f.Subfields {
+		if sf.Code == code {
+			return sf.Value
+		}
+	}
+	return ""
+}
+
+// SubfieldValues returns all the values of the subfield with the code.
+func (f *Field) SubfieldValues(code byte) []string {
+	var values []string
+	for _, sf := range f.Subfields {
+		if sf.Code == code {
+			values = append(values, sf.Value)
+		}
+	}
+	return values
+}
+
+// FieldsByTag returns the fields of the record with the tag, in record order.
+func (r *Record) FieldsByTag(tag string) []*Field {
+	var fields []*Field
+	for i := range r.Fields {
+		if r.Fields[i].Tag == tag {
+			fields = append(fields, &r.Fields[i])
+		}
+	}
+	return fields
+}
+
+// validate checks what both formats require of a record.
+func (r *Record) validate() error {
+	if len(r.Leader) != 24 {
+		return invalid("the leader is %d characters long", len(r.Leader))
+	}
+	for _, f := range r.Fields {
+		if len(f.Tag) != 3 {
+			return invalid("invalid tag %q", f.Tag)
+		}
+	}
+	return nil
+}
+
+func invalid(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidRecord, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,248 @@
+package marc_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/storyofhis/books-management/httpserver/repository/models"
+	"github.com/storyofhis/books-management/marc"
+	"github.com/stretchr/testify/assert"
+)
+
+func readAll(t *testing.T, next func() (*marc.Record, error)) []*marc.Record {
+	var records []*marc.Record
+	for {
+		rec, err := next()
+		if err == io.EOF {
+			return records
+		}
+		if !assert.NoError(t, err) {
+			return records
+		}
+		records = append(records, rec)
+	}
+}
+
+func fixture(t *testing.T, name string) []byte {
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestReader(t *testing.T) {
+	t.Run("success - it should read the records of a file", func(t *testing.T) {
+		records := readAll(t, marc.NewReader(bytes.NewReader(fixture(t, "books.mrc"))).Read)
+
+		assert.Len(t, records, 3)
+		assert.Equal(t, "2005047328", records[0].Fields[0].Value)
+		title := records[1].FieldsByTag("245")[0]
+		assert.Equal(t, byte('1'), title.Ind1)
+		assert.Equal(t, byte('4'), title.Ind2)
+		assert.Equal(t, "a novel /", title.Subfield('b'))
+		assert.Len(t, records[2].FieldsByTag("700"), 3)
+	})
+
+	t.Run("success - it should write the records back unchanged", func(t *testing.T) {
+		data := fixture(t, "books.mrc")
+		records := readAll(t, marc.NewReader(bytes.NewReader(data)).Read)
+
+		var out bytes.Buffer
+		w := marc.NewWriter(&out)
+		for _, rec := range records {
+			assert.NoError(t, w.Write(rec))
+		}
+		assert.Equal(t, data, out.Bytes())
+	})
+
+	t.Run("success - it should skip line breaks between records", func(t *testing.T) {
+		data := fixture(t, "books.mrc")
+		first, _ := marc.Marshal(readAll(t, marc.NewReader(bytes.NewReader(data)).Read)[0])
+		lines := append(append(append([]byte{}, first...), "\r\n"...), first...)
+
+		records := readAll(t, marc.NewReader(bytes.NewReader(append(lines, '\n'))).Read)
+		assert.Len(t, records, 2)
+	})
+
+	t.Run("error - it should go on after a malformed record", func(t *testing.T) {
+		data := fixture(t, "books.mrc")
+		broken := append([]byte{}, data...)
+		broken[321] = 'x' // the record terminator of the first record
+
+		r := marc.NewReader(bytes.NewReader(broken))
+		_, err := r.Read()
+		assert.ErrorIs(t, err, marc.ErrInvalidRecord)
+		rec, err := r.Read()
+		assert.NoError(t, err)
+		assert.Equal(t, "94012786", rec.Fields[0].Value)
+	})
+
+	t.Run("error - it should reject a directory entry with a negative offset", func(t *testing.T) {
+		leader := "00041nam  2200037   4500"
+		data := []byte(leader + "2450002-9999\x1ea\x1e\x1d")
+
+		assert.NotPanics(t, func() {
+			_, err := marc.Unmarshal(data)
+			assert.ErrorIs(t, err, marc.ErrInvalidRecord)
+		})
+
+		data = []byte(leader + "2450002+0000\x1ea\x1e\x1d")
+		_, err := marc.Unmarshal(data)
+		assert.ErrorIs(t, err, marc.ErrInvalidRecord)
+	})
+
+	t.Run("error - it should stop at an invalid record length", func(t *testing.T) {
+		_, err := marc.NewReader(bytes.NewReader([]byte("hello world"))).Read()
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, marc.ErrInvalidRecord))
+
+		_, err = marc.NewReader(bytes.NewReader(fixture(t, "books.mrc")[:100])).Read()
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
+
+func TestXMLReader(t *testing.T) {
+	t.Run("success - it should read the same records as the binary file", func(t *testing.T) {
+		binary := readAll(t, marc.NewReader(bytes.NewReader(fixture(t, "books.mrc"))).Read)
+		records := readAll(t, marc.NewXMLReader(bytes.NewReader(fixture(t, "books.xml"))).Read)
+
+		assert.Len(t, records, 3)
+		for i := range records {
+			want, _ := marc.Marshal(binary[i])
+			got, err := marc.Marshal(records[i])
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		}
+	})
+
+	t.Run("success - it should write the records back unchanged", func(t *testing.T) {
+		data := fixture(t, "books.xml")
+		records := readAll(t, marc.NewXMLReader(bytes.NewReader(data)).Read)
+
+		var out bytes.Buffer
+		w := marc.NewXMLWriter(&out)
+		for _, rec := range records {
+			assert.NoError(t, w.Write(rec))
+		}
+		assert.NoError(t, w.Close())
+		assert.Equal(t, string(data), out.String())
+	})
+
+	t.Run("success - it should read a single record", func(t *testing.T) {
+		data := `<record xmlns="http://www.loc.gov/MARC21/slim"><leader>00000nam a2200000 i 4500</leader>` +
+			`<datafield tag="245" ind1="0" ind2="0"><subfield code="a">Beowulf.</subfield></datafield></record>`
+		records := readAll(t, marc.NewXMLReader(bytes.NewReader([]byte(data))).Read)
+
+		assert.Len(t, records, 1)
+		assert.Equal(t, "Beowulf", marc.Book(records[0]).Title)
+	})
+
+	t.Run("success - it should write an empty collection", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, marc.NewXMLWriter(&out).Close())
+		assert.Empty(t, readAll(t, marc.NewXMLReader(&out).Read))
+	})
+
+	t.Run("error - it should reject an invalid subfield code", func(t *testing.T) {
+		data := `<collection><record><leader>00000nam a2200000 i 4500</leader>` +
+			`<datafield tag="245" ind1="0" ind2="0"><subfield code="ab">Beowulf</subfield></datafield></record></collection>`
+		_, err := marc.NewXMLReader(bytes.NewReader([]byte(data))).Read()
+		assert.ErrorIs(t, err, marc.ErrInvalidRecord)
+	})
+}
+
+func TestBook(t *testing.T) {
+	t.Run("success - it should map the title, the isbn and the contributors", func(t *testing.T) {
+		records := readAll(t, marc.NewReader(bytes.NewReader(fixture(t, "books.mrc"))).Read)
+
+		dune := marc.Book(records[0])
+		assert.Equal(t, "Dune", dune.Title)
+		assert.Equal(t, "9780441013593", dune.Isbn)
+		assert.Equal(t, "Frank Herbert", dune.Contributors[0].Author.Name)
+		assert.Equal(t, 1920, dune.Contributors[0].Author.Birthdate.Year())
+
+		rose := marc.Book(records[1])
+		assert.Equal(t, "The name of the rose: a novel", rose.Title)
+		assert.Equal(t, "9780156001311", rose.Isbn)
+		assert.Equal(t, models.ContributorAuthor, rose.Contributors[0].Role)
+		assert.Equal(t, "William Weaver", rose.Contributors[1].Author.Name)
+		assert.Equal(t, models.ContributorTranslator, rose.Contributors[1].Role)
+
+		odyssey := marc.Book(records[2])
+		assert.Equal(t, "9780140449136", odyssey.Isbn)
+		var names, roles []string
+		for _, c := range odyssey.Contributors {
+			names = append(names, c.Author.Name)
+			roles = append(roles, c.Role)
+		}
+		assert.Equal(t, []string{"Homer", "Robert Fagles", "Bernard Knox", "Ursula K. Le Guin"}, names)
+		assert.Equal(t, []string{"author", "translator", "author", "editor"}, roles)
+	})
+
+	t.Run("success - it should keep an invalid isbn for the caller to report", func(t *testing.T) {
+		rec := &marc.Record{Fields: []marc.Field{{Tag: "020", Subfields: []marc.Subfield{{Code: 'a', Value: "12345 (hbk.)"}}}}}
+		assert.Equal(t, "12345", marc.Book(rec).Isbn)
+	})
+
+	t.Run("success - it should read back the record of a book", func(t *testing.T) {
+		book := &models.Book{
+			Id:    uuid.New(),
+			Title: "The Left Hand of Darkness: 50th anniversary edition",
+			Isbn:  "9780441478125",
+			Contributors: []models.BookContributor{
+				{Role: models.ContributorEditor, Author: models.Author{Name: "Harold Bloom"}},
+				{Role: models.ContributorAuthor, Author: models.Author{Name: "Ursula K. Le Guin", Birthdate: time.Date(1929, 1, 1, 0, 0, 0, 0, time.UTC)}},
+				{Role: models.ContributorIllustrator, Author: models.Author{Name: "Moebius"}},
+			},
+		}
+		rec := marc.FromBook(book)
+		assert.Equal(t, []string{"001", "020", "100", "245", "700", "700"}, tags(rec))
+		assert.Equal(t, "Le Guin, Ursula K.,", rec.FieldsByTag("100")[0].Subfield('a'))
+
+		data, err := marc.Marshal(rec)
+		assert.NoError(t, err)
+		read, err := marc.Unmarshal(data)
+		assert.NoError(t, err)
+		got := marc.Book(read)
+
+		assert.Equal(t, book.Title, got.Title)
+		assert.Equal(t, book.Isbn, got.Isbn)
+		assert.Len(t, got.Contributors, 3)
+		// The main entry comes first.
+		assert.Equal(t, book.Contributors[1].Author, got.Contributors[0].Author)
+		assert.Equal(t, book.Contributors[0].Author, got.Contributors[1].Author)
+		assert.Equal(t, models.ContributorEditor, got.Contributors[1].Role)
+		assert.Equal(t, book.Contributors[2].Author, got.Contributors[2].Author)
+		assert.Equal(t, models.ContributorIllustrator, got.Contributors[2].Role)
+	})
+
+	t.Run("success - it should invert names as citations split them", func(t *testing.T) {
+		book := &models.Book{
+			Title: "Dune",
+			Contributors: []models.BookContributor{
+				{Role: models.ContributorAuthor, Author: models.Author{Name: "Herbert, Frank"}},
+				{Role: models.ContributorEditor, Author: models.Author{Name: "Smith and Sons"}},
+			},
+		}
+		rec := marc.FromBook(book)
+		author, editor := rec.FieldsByTag("100")[0], rec.FieldsByTag("700")[0]
+		assert.Equal(t, "Herbert, Frank,", author.Subfield('a'))
+		assert.Equal(t, byte('1'), author.Ind1)
+		assert.Equal(t, "Smith and Sons,", editor.Subfield('a'))
+		assert.Equal(t, byte('0'), editor.Ind1)
+	})
+}
+
+func tags(rec *marc.Record) []string {
+	var list []string
+	for _, f := range rec.Fields {
+		list = append(list, f.Tag)
+	}
+	return list
+}
@@ -0,0 +1,161 @@
+package marc
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Namespace is the XML namespace of MARCXML.
+const Namespace = "http://www.loc.gov/MARC21/slim"
+
+type xmlRecord struct {
+	XMLName       xml.Name          `xml:"record"`
+	Leader        string            `xml:"leader"`
+	ControlFields []xmlControlField `xml:"controlfield"`
+	DataFields    []xmlDataField    `xml:"datafield"`
+}
+
+type xmlControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlDataField struct {
+	Tag       string        `xml:"tag,attr"`
+	Ind1      string        `xml:"ind1,attr"`
+	Ind2      string        `xml:"ind2,attr"`
+	Subfields []xmlSubfield `xml:"subfield"`
+}
+
+type xmlSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// XMLReader reads the records of a MARCXML collection, or a single record.
+type XMLReader struct {
+	d *xml.Decoder
+}
+
+func NewXMLReader(r io.Reader) *XMLReader {
+	return &XMLReader{d: xml.NewDecoder(r)}
+}
+
+// Read returns the next record, or io.EOF after the last one. An error
+// wrapping ErrInvalidRecord is only about that record, any other error ends
+// the file.
+func (r *XMLReader) Read() (*Record, error) {
+	for {
+		token, err := r.d.Token()
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+		var xr xmlRecord
+		if err := r.d.DecodeElement(&xr, &start); err != nil {
+			return nil, err
+		}
+		return xr.record()
+	}
+}
+
+func (xr *xmlRecord) record() (*Record, error) {
+	rec := &Record{Leader: xr.Leader}
+	for _, cf := range xr.ControlFields {
+		rec.Fields = append(rec.Fields, Field{Tag: cf.Tag, Value: cf.Value})
+	}
+	for _, df := range xr.DataFields {
+		if len(df.Ind1) > 1 || len(df.Ind2) > 1 {
+			return nil, invalid("field %s has an indicator longer than one character", df.Tag)
+		}
+		f := Field{Tag: df.Tag, Ind1: ' ', Ind2: ' '}
+		if df.Ind1 != "" {
+			f.Ind1 = df.Ind1[0]
+		}
+		if df.Ind2 != "" {
+			f.Ind2 = df.Ind2[0]
+		}
+		for _, sf := range df.Subfields {
+			if len(sf.Code) != 1 {
+				return nil, invalid("field %s has an invalid subfield code %q", df.Tag, sf.Code)
+			}
+			f.Subfields = append(f.Subfields, Subfield{Code: sf.Code[0], Value: sf.Value})
+		}
+		rec.Fields = append(rec.Fields, f)
+	}
+	if err := rec.validate(); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// XMLWriter writes a MARCXML collection. The collection is opened by the
+// first record and must be closed with Close.
+type XMLWriter struct {
+	w      io.Writer
+	e      *xml.Encoder
+	opened bool
+}
+
+func NewXMLWriter(w io.Writer) *XMLWriter {
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	return &XMLWriter{w: w, e: e}
+}
+
+func (w *XMLWriter) Write(rec *Record) error {
+	if rec.Leader == "" {
+		rec = &Record{Leader: defaultLeader, Fields: rec.Fields}
+	}
+	if err := rec.validate(); err != nil {
+		return err
+	}
+	if err := w.open(); err != nil {
+		return err
+	}
+	xr := xmlRecord{Leader: rec.Leader}
+	for _, f := range rec.Fields {
+		if f.IsControl() {
+			xr.ControlFields = append(xr.ControlFields, xmlControlField{Tag: f.Tag, Value: f.Value})
+			continue
+		}
+		df := xmlDataField{Tag: f.Tag, Ind1: string(indicator(f.Ind1)), Ind2: string(indicator(f.Ind2))}
+		for _, sf := range f.Subfields {
+			df.Subfields = append(df.Subfields, xmlSubfield{Code: string(sf.Code), Value: sf.Value})
+		}
+		xr.DataFields = append(xr.DataFields, df)
+	}
+	return w.e.Encode(xr)
+}
+
+// Close ends the collection, writing an empty one when there was no record.
+func (w *XMLWriter) Close() error {
+	if err := w.open(); err != nil {
+		return err
+	}
+	if err := w.e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "collection"}}); err != nil {
+		return err
+	}
+	if err := w.e.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w.w, "\n")
+	return err
+}
+
+func (w *XMLWriter) open() error {
+	if w.opened {
+		return nil
+	}
+	w.opened = true
+	if _, err := io.WriteString(w.w, xml.Header); err != nil {
+		return err
+	}
+	return w.e.EncodeToken(xml.StartElement{
+		Name: xml.Name{Local: "collection"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: Namespace}},
+	})
+}
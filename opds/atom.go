@@ -0,0 +1,207 @@
+package opds
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Namespaces of the OPDS 1.2 feeds.
+const (
+	NamespaceAtom       = "http://www.w3.org/2005/Atom"
+	NamespaceDC         = "http://purl.org/dc/terms/"
+	NamespaceOPDS       = "http://opds-spec.org/2010/catalog"
+	NamespaceOpenSearch = "http://a9.com/-/spec/opensearch/1.1/"
+)
+
+type atomFeed struct {
+	XMLName         xml.Name    `xml:"feed"`
+	Xmlns           string      `xml:"xmlns,attr"`
+	XmlnsDC         string      `xml:"xmlns:dc,attr"`
+	XmlnsOPDS       string      `xml:"xmlns:opds,attr"`
+	XmlnsOpenSearch string      `xml:"xmlns:opensearch,attr"`
+	ID              string      `xml:"id"`
+	Title           string      `xml:"title"`
+	Updated         string      `xml:"updated"`
+	TotalResults    *int64      `xml:"opensearch:totalResults"`
+	ItemsPerPage    int         `xml:"opensearch:itemsPerPage,omitempty"`
+	StartIndex      int         `xml:"opensearch:startIndex,omitempty"`
+	Links           []atomLink  `xml:"link"`
+	Entries         []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel   string `xml:"rel,attr,omitempty"`
+	Href  string `xml:"href,attr"`
+	Type  string `xml:"type,attr,omitempty"`
+	Title string `xml:"title,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title        string       `xml:"title"`
+	ID           string       `xml:"id"`
+	Updated      string       `xml:"updated"`
+	Published    string       `xml:"published,omitempty"`
+	Authors      []atomPerson `xml:"author"`
+	Contributors []atomPerson `xml:"contributor"`
+	Identifier   string       `xml:"dc:identifier,omitempty"`
+	Content      *atomContent `xml:"content"`
+	Links        []atomLink   `xml:"link"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// WriteAtom writes the feed as an OPDS 1.2 Atom feed, resolving its links
+// against root. The search link of the feed is the OpenSearch description
+// written by WriteOpenSearch at root/opensearch.xml, and its authentication
+// link the document at root/authentication.
+func WriteAtom(w io.Writer, root string, f *Feed) error {
+	c, err := newCatalog(root)
+	if err != nil {
+		return err
+	}
+
+	af := atomFeed{
+		Xmlns:           NamespaceAtom,
+		XmlnsDC:         NamespaceDC,
+		XmlnsOPDS:       NamespaceOPDS,
+		XmlnsOpenSearch: NamespaceOpenSearch,
+		ID:              c.id(f.Path),
+		Title:           f.Title,
+		Updated:         timestamp(f.Updated),
+	}
+	if f.Page > 0 {
+		af.TotalResults = &f.Total
+		af.ItemsPerPage = f.PageSize
+		af.StartIndex = (f.Page-1)*f.PageSize + 1
+	}
+
+	af.Links = append(af.Links,
+		c.atomLink(Link{Rel: RelSelf, Href: f.Path, Kind: f.Kind}),
+		c.atomLink(Link{Rel: RelStart, Kind: KindNavigation}),
+		atomLink{Rel: RelSearch, Href: c.resolve("opensearch.xml"), Type: TypeOpenSearch},
+		atomLink{Rel: RelAuthentication, Href: c.resolve(authenticationPath), Type: TypeAuthentication},
+	)
+	for _, l := range append(f.Links, f.pageLinks()...) {
+		af.Links = append(af.Links, c.atomLink(l))
+	}
+
+	for _, n := range f.Navigation {
+		e := atomEntry{
+			Title:   n.Title,
+			ID:      n.ID,
+			Updated: timestamp(n.Updated),
+			Links:   []atomLink{c.atomLink(n.Link)},
+		}
+		if e.ID == "" {
+			e.ID = c.resolve(n.Link.Href)
+		}
+		if n.Summary != "" {
+			e.Content = &atomContent{Type: "text", Text: n.Summary}
+		}
+		af.Entries = append(af.Entries, e)
+	}
+
+	for _, p := range f.Publications {
+		e := atomEntry{
+			Title:      p.Title,
+			ID:         p.ID,
+			Updated:    timestamp(p.Updated),
+			Identifier: p.Identifier,
+		}
+		if !p.Published.IsZero() {
+			e.Published = timestamp(p.Published)
+		}
+		// Atom has no roles, the authors are told from the other
+		// contributors.
+		for _, contributor := range p.Contributors {
+			person := atomPerson{Name: contributor.Name}
+			if contributor.Link != nil {
+				person.URI = c.resolve(contributor.Link.Href)
+			}
+			if contributor.Role == RoleAuthor || contributor.Role == "" {
+				e.Authors = append(e.Authors, person)
+			} else {
+				e.Contributors = append(e.Contributors, person)
+			}
+		}
+		for _, l := range p.Links {
+			e.Links = append(e.Links, c.atomLink(l))
+		}
+		af.Entries = append(af.Entries, e)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	if err := e.Encode(af); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+func (c *catalog) atomLink(l Link) atomLink {
+	al := atomLink{Rel: l.Rel, Href: c.resolve(l.Href), Type: l.Type, Title: l.Title}
+	switch l.Kind {
+	case KindNavigation:
+		al.Type = TypeNavigation
+	case KindAcquisition:
+		al.Type = TypeAcquisition
+	}
+	return al
+}
+
+type openSearchDescription struct {
+	XMLName        xml.Name        `xml:"OpenSearchDescription"`
+	Xmlns          string          `xml:"xmlns,attr"`
+	ShortName      string          `xml:"ShortName"`
+	Description    string          `xml:"Description"`
+	InputEncoding  string          `xml:"InputEncoding"`
+	OutputEncoding string          `xml:"OutputEncoding"`
+	Urls           []openSearchURL `xml:"Url"`
+}
+
+type openSearchURL struct {
+	Type     string `xml:"type,attr"`
+	Template string `xml:"template,attr"`
+}
+
+// WriteOpenSearch writes the OpenSearch description of the catalog at root,
+// whose search feed is root/search and takes the terms in q.
+func WriteOpenSearch(w io.Writer, root, name, description string) error {
+	c, err := newCatalog(root)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	err = e.Encode(openSearchDescription{
+		Xmlns:          NamespaceOpenSearch,
+		ShortName:      name,
+		Description:    description,
+		InputEncoding:  "UTF-8",
+		OutputEncoding: "UTF-8",
+		Urls: []openSearchURL{{
+			Type:     TypeAcquisition,
+			Template: c.resolve("search") + "?q={searchTerms}&page={startPage?}",
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,52 @@
+package opds
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// AuthBasic is the type of HTTP Basic authentication in an authentication
+// document.
+const AuthBasic = "http://opds-spec.org/auth/basic"
+
+// authenticationPath is the path of the authentication document, relative to
+// the root of the catalog.
+const authenticationPath = "authentication"
+
+type authDocument struct {
+	ID             string       `json:"id"`
+	Title          string       `json:"title"`
+	Description    string       `json:"description,omitempty"`
+	Authentication []authMethod `json:"authentication"`
+}
+
+type authMethod struct {
+	Type   string     `json:"type"`
+	Labels authLabels `json:"labels"`
+}
+
+type authLabels struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+// WriteAuthentication writes the OPDS authentication document of the catalog
+// at root, served at root/authentication, which asks readers for the
+// username and password of a user sent with HTTP Basic authentication.
+func WriteAuthentication(w io.Writer, root, title, description string) error {
+	c, err := newCatalog(root)
+	if err != nil {
+		return err
+	}
+	e := json.NewEncoder(w)
+	e.SetEscapeHTML(false)
+	return e.Encode(authDocument{
+		ID:          c.resolve(authenticationPath),
+		Title:       title,
+		Description: description,
+		Authentication: []authMethod{{
+			Type:   AuthBasic,
+			Labels: authLabels{Login: "Username", Password: "Password"},
+		}},
+	})
+}
@@ -0,0 +1,147 @@
+package opds
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type jsonFeed struct {
+	Metadata jsonMetadata `json:"metadata"`
+	Links    []jsonLink   `json:"links"`
+	// Navigation and Publications are left out when nil, and an empty
+	// collection is kept, as a feed must have one.
+	Navigation   interface{} `json:"navigation,omitempty"`
+	Publications interface{} `json:"publications,omitempty"`
+}
+
+type jsonMetadata struct {
+	Title         string `json:"title"`
+	Modified      string `json:"modified,omitempty"`
+	NumberOfItems *int64 `json:"numberOfItems,omitempty"`
+	ItemsPerPage  int    `json:"itemsPerPage,omitempty"`
+	CurrentPage   int    `json:"currentPage,omitempty"`
+}
+
+type jsonLink struct {
+	Rel       string `json:"rel,omitempty"`
+	Href      string `json:"href"`
+	Type      string `json:"type,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Templated bool   `json:"templated,omitempty"`
+}
+
+type jsonPublication struct {
+	Metadata jsonPublicationMetadata `json:"metadata"`
+	Links    []jsonLink              `json:"links"`
+}
+
+type jsonPublicationMetadata struct {
+	Type        string            `json:"@type"`
+	Identifier  string            `json:"identifier,omitempty"`
+	Title       string            `json:"title"`
+	Author      []jsonContributor `json:"author,omitempty"`
+	Editor      []jsonContributor `json:"editor,omitempty"`
+	Translator  []jsonContributor `json:"translator,omitempty"`
+	Illustrator []jsonContributor `json:"illustrator,omitempty"`
+	Published   string            `json:"published,omitempty"`
+	Modified    string            `json:"modified"`
+}
+
+type jsonContributor struct {
+	Name  string     `json:"name"`
+	Links []jsonLink `json:"links,omitempty"`
+}
+
+// WriteJSON writes the feed as an OPDS 2.0 document, resolving its links
+// against root. The search link of the feed is the templated link to
+// root/search, and its authentication link the document at
+// root/authentication.
+func WriteJSON(w io.Writer, root string, f *Feed) error {
+	c, err := newCatalog(root)
+	if err != nil {
+		return err
+	}
+
+	jf := jsonFeed{Metadata: jsonMetadata{Title: f.Title, Modified: timestamp(f.Updated)}}
+	if f.Page > 0 {
+		jf.Metadata.NumberOfItems = &f.Total
+		jf.Metadata.ItemsPerPage = f.PageSize
+		jf.Metadata.CurrentPage = f.Page
+	}
+
+	jf.Links = append(jf.Links,
+		c.jsonLink(Link{Rel: RelSelf, Href: f.Path, Kind: f.Kind}),
+		c.jsonLink(Link{Rel: RelStart, Kind: KindNavigation}),
+		jsonLink{Rel: RelSearch, Href: c.resolve("search") + "{?q}", Type: TypeJSON, Templated: true},
+		jsonLink{Rel: RelAuthentication, Href: c.resolve(authenticationPath), Type: TypeAuthentication},
+	)
+	for _, l := range append(f.Links, f.pageLinks()...) {
+		jf.Links = append(jf.Links, c.jsonLink(l))
+	}
+
+	if f.Kind == KindNavigation {
+		navigation := make([]jsonLink, 0, len(f.Navigation))
+		for _, n := range f.Navigation {
+			l := c.jsonLink(n.Link)
+			l.Title = n.Title
+			navigation = append(navigation, l)
+		}
+		jf.Navigation = navigation
+	} else {
+		publications := make([]jsonPublication, 0, len(f.Publications))
+		for _, p := range f.Publications {
+			publications = append(publications, c.jsonPublication(&p))
+		}
+		jf.Publications = publications
+	}
+
+	e := json.NewEncoder(w)
+	e.SetEscapeHTML(false)
+	return e.Encode(jf)
+}
+
+func (c *catalog) jsonPublication(p *Publication) jsonPublication {
+	jp := jsonPublication{
+		Metadata: jsonPublicationMetadata{
+			Type:       "http://schema.org/Book",
+			Identifier: p.Identifier,
+			Title:      p.Title,
+			Modified:   timestamp(p.Updated),
+		},
+		Links: []jsonLink{},
+	}
+	if jp.Metadata.Identifier == "" {
+		jp.Metadata.Identifier = p.ID
+	}
+	if !p.Published.IsZero() {
+		jp.Metadata.Published = timestamp(p.Published)
+	}
+	for _, contributor := range p.Contributors {
+		jc := jsonContributor{Name: contributor.Name}
+		if contributor.Link != nil {
+			jc.Links = []jsonLink{c.jsonLink(*contributor.Link)}
+		}
+		switch contributor.Role {
+		case RoleEditor:
+			jp.Metadata.Editor = append(jp.Metadata.Editor, jc)
+		case RoleTranslator:
+			jp.Metadata.Translator = append(jp.Metadata.Translator, jc)
+		case RoleIllustrator:
+			jp.Metadata.Illustrator = append(jp.Metadata.Illustrator, jc)
+		default:
+			jp.Metadata.Author = append(jp.Metadata.Author, jc)
+		}
+	}
+	for _, l := range p.Links {
+		jp.Links = append(jp.Links, c.jsonLink(l))
+	}
+	return jp
+}
+
+func (c *catalog) jsonLink(l Link) jsonLink {
+	jl := jsonLink{Rel: l.Rel, Href: c.resolve(l.Href), Type: l.Type, Title: l.Title}
+	if l.Kind != "" {
+		jl.Type = TypeJSON
+	}
+	return jl
+}
@@ -0,0 +1,189 @@
+// Package opds writes OPDS catalogs, the feeds e-reader apps browse. A Feed
+// describes a feed independently of the version of OPDS, and is written as
+// an OPDS 1.2 Atom feed by WriteAtom or as an OPDS 2.0 JSON document by
+// WriteJSON.
+//
+// Every feed links to the authentication document written by
+// WriteAuthentication, which tells readers how to sign in to the catalog.
+//
+// The links of a feed are relative to the root of the catalog, the URL given
+// to the writers, so that the same feed can be served under the root of each
+// version.
+package opds
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The kinds of feeds.
+const (
+	KindNavigation  = "navigation"
+	KindAcquisition = "acquisition"
+)
+
+// Media types.
+const (
+	TypeNavigation     = "application/atom+xml;profile=opds-catalog;kind=navigation"
+	TypeAcquisition    = "application/atom+xml;profile=opds-catalog;kind=acquisition"
+	TypeOpenSearch     = "application/opensearchdescription+xml"
+	TypeJSON           = "application/opds+json"
+	TypeAuthentication = "application/opds-authentication+json"
+)
+
+// Link relations.
+const (
+	RelSelf       = "self"
+	RelStart      = "start"
+	RelSearch     = "search"
+	RelSubsection = "subsection"
+	RelAlternate  = "alternate"
+	RelFirst      = "first"
+	RelPrevious   = "previous"
+	RelNext       = "next"
+	RelLast       = "last"
+	RelNew        = "http://opds-spec.org/sort/new"
+	RelBorrow     = "http://opds-spec.org/acquisition/borrow"
+	// RelAuthentication links a feed to the authentication document of its
+	// catalog.
+	RelAuthentication = "http://opds-spec.org/auth/document"
+)
+
+// Feed is a navigation feed, listing other feeds, or an acquisition feed,
+// listing publications.
+type Feed struct {
+	Kind  string
+	Title string
+	// Path is the path of the feed, relative to the root of the catalog.
+	// The root itself is the empty path.
+	Path    string
+	Updated time.Time
+	// Links are the links of the feed other than self, start, search and
+	// authentication, which every feed has, and the links to the other
+	// pages.
+	Links        []Link
+	Navigation   []Navigation
+	Publications []Publication
+	// Page, PageSize and Total describe the page of a paged feed, whose
+	// Page is 1 or more.
+	Page     int
+	PageSize int
+	Total    int64
+}
+
+// Link is a link to another feed of the catalog when Kind is set, its media
+// type depending on the version, or to any other resource of the type Type.
+type Link struct {
+	Rel   string
+	Href  string
+	Kind  string
+	Type  string
+	Title string
+}
+
+// Navigation is an entry of a navigation feed.
+type Navigation struct {
+	// ID defaults to the URL of the link.
+	ID      string
+	Title   string
+	Summary string
+	Updated time.Time
+	Link    Link
+}
+
+// Publication is an entry of an acquisition feed.
+type Publication struct {
+	ID    string
+	Title string
+	// Identifier is a URN, such as urn:isbn:9780441013593.
+	Identifier   string
+	Contributors []Contributor
+	Published    time.Time
+	Updated      time.Time
+	Links        []Link
+}
+
+// Contributor is a person who took part in a publication, in the role
+// author, editor, translator or illustrator.
+type Contributor struct {
+	Name string
+	Role string
+	// Link is the feed of the publications of the contributor, if any.
+	Link *Link
+}
+
+// The roles of the contributors.
+const (
+	RoleAuthor      = "author"
+	RoleEditor      = "editor"
+	RoleTranslator  = "translator"
+	RoleIllustrator = "illustrator"
+)
+
+// catalog resolves the links of the feeds against the root of the catalog.
+type catalog struct {
+	root *url.URL
+}
+
+func newCatalog(root string) (*catalog, error) {
+	u, err := url.Parse(strings.TrimSuffix(root, "/") + "/")
+	if err != nil {
+		return nil, err
+	}
+	return &catalog{root: u}, nil
+}
+
+// resolve returns the URL of href, the root of the catalog when it is empty.
+func (c *catalog) resolve(href string) string {
+	if href == "" {
+		return strings.TrimSuffix(c.root.String(), "/")
+	}
+	ref, err := url.Parse(href)
+	if err != nil {
+		return href
+	}
+	return c.root.ResolveReference(ref).String()
+}
+
+// id returns the ID of the feed at path, its URL without the query.
+func (c *catalog) id(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	return c.resolve(path)
+}
+
+// pageLinks returns the links to the first, previous, next and last pages
+// of a paged feed.
+func (f *Feed) pageLinks() []Link {
+	if f.Page < 1 || f.PageSize < 1 {
+		return nil
+	}
+	last := int((f.Total + int64(f.PageSize) - 1) / int64(f.PageSize))
+	if last < 1 {
+		last = 1
+	}
+	link := func(rel string, page int) Link {
+		path, query, _ := strings.Cut(f.Path, "?")
+		q, _ := url.ParseQuery(query)
+		q.Set("page", strconv.Itoa(page))
+		return Link{Rel: rel, Href: path + "?" + q.Encode(), Kind: f.Kind}
+	}
+
+	links := []Link{link(RelFirst, 1)}
+	if f.Page > 1 {
+		links = append(links, link(RelPrevious, f.Page-1))
+	}
+	if f.Page < last {
+		links = append(links, link(RelNext, f.Page+1))
+	}
+	return append(links, link(RelLast, last))
+}
+
+// timestamp formats t as RFC 3339, the current time when it is zero.
+func timestamp(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.UTC().Format(time.RFC3339)
+}
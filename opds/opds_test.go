@@ -0,0 +1,201 @@
+package opds_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/storyofhis/books-management/opds"
+	"github.com/stretchr/testify/assert"
+)
+
+const root = "https://books.example.com/opds"
+
+var updated = time.Date(2024, 9, 18, 10, 0, 0, 0, time.UTC)
+
+func newest() *opds.Feed {
+	return &opds.Feed{
+		Kind:     opds.KindAcquisition,
+		Title:    "Newest books",
+		Path:     "new?page=2&page_size=1",
+		Updated:  updated,
+		Page:     2,
+		PageSize: 1,
+		Total:    3,
+		Publications: []opds.Publication{{
+			ID:         "urn:uuid:2f1b7c8e-6b7a-4a8e-9a57-3f5d2c1e0b9a",
+			Title:      "The Left Hand of Darkness",
+			Identifier: "urn:isbn:9780441478125",
+			Contributors: []opds.Contributor{
+				{Name: "Ursula K. Le Guin", Role: opds.RoleAuthor, Link: &opds.Link{Href: "authors/1", Kind: opds.KindAcquisition}},
+				{Name: "Harold Bloom", Role: opds.RoleEditor},
+			},
+			Published: updated,
+			Updated:   updated,
+			Links:     []opds.Link{{Rel: opds.RelBorrow, Href: "/books/1/holds", Type: "application/json"}},
+		}},
+	}
+}
+
+type feed struct {
+	ID           string `xml:"id"`
+	TotalResults int    `xml:"totalResults"`
+	StartIndex   int    `xml:"startIndex"`
+	Links        []struct {
+		Rel  string `xml:"rel,attr"`
+		Href string `xml:"href,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"link"`
+	Entries []struct {
+		Title        string   `xml:"title"`
+		ID           string   `xml:"id"`
+		Updated      string   `xml:"updated"`
+		Authors      []string `xml:"author>name"`
+		AuthorURI    string   `xml:"author>uri"`
+		Contributors []string `xml:"contributor>name"`
+		Identifier   string   `xml:"http://purl.org/dc/terms/ identifier"`
+		Content      string   `xml:"content"`
+		Links        []struct {
+			Rel  string `xml:"rel,attr"`
+			Href string `xml:"href,attr"`
+			Type string `xml:"type,attr"`
+		} `xml:"link"`
+	} `xml:"entry"`
+}
+
+func links(f *feed) map[string]string {
+	list := map[string]string{}
+	for _, l := range f.Links {
+		list[l.Rel] = l.Href
+	}
+	return list
+}
+
+func TestWriteAtom(t *testing.T) {
+	t.Run("success - it should write an acquisition feed", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, opds.WriteAtom(&out, root, newest()))
+
+		var f feed
+		assert.NoError(t, xml.Unmarshal(out.Bytes(), &f))
+		assert.Equal(t, root+"/new", f.ID)
+		assert.Equal(t, 3, f.TotalResults)
+		assert.Equal(t, 2, f.StartIndex)
+		assert.Equal(t, map[string]string{
+			"self":                 root + "/new?page=2&page_size=1",
+			"start":                root,
+			"search":               root + "/opensearch.xml",
+			opds.RelAuthentication: root + "/authentication",
+			"first":                root + "/new?page=1&page_size=1",
+			"previous":             root + "/new?page=1&page_size=1",
+			"next":                 root + "/new?page=3&page_size=1",
+			"last":                 root + "/new?page=3&page_size=1",
+		}, links(&f))
+		assert.Equal(t, opds.TypeAcquisition, f.Links[0].Type)
+
+		assert.Len(t, f.Entries, 1)
+		entry := f.Entries[0]
+		assert.Equal(t, "2024-09-18T10:00:00Z", entry.Updated)
+		assert.Equal(t, []string{"Ursula K. Le Guin"}, entry.Authors)
+		assert.Equal(t, root+"/authors/1", entry.AuthorURI)
+		assert.Equal(t, []string{"Harold Bloom"}, entry.Contributors)
+		assert.Equal(t, "urn:isbn:9780441478125", entry.Identifier)
+		assert.Equal(t, "https://books.example.com/books/1/holds", entry.Links[0].Href)
+		assert.Equal(t, opds.RelBorrow, entry.Links[0].Rel)
+	})
+
+	t.Run("success - it should write a navigation feed", func(t *testing.T) {
+		var out bytes.Buffer
+		err := opds.WriteAtom(&out, root+"/", &opds.Feed{
+			Kind:  opds.KindNavigation,
+			Title: "Catalog",
+			Navigation: []opds.Navigation{
+				{Title: "Newest books", Summary: "The books added last", Link: opds.Link{Rel: opds.RelNew, Href: "new", Kind: opds.KindAcquisition}},
+			},
+		})
+		assert.NoError(t, err)
+
+		var f feed
+		assert.NoError(t, xml.Unmarshal(out.Bytes(), &f))
+		assert.Equal(t, root, f.ID)
+		assert.NotContains(t, links(&f), "next")
+		assert.NotContains(t, out.String(), "totalResults")
+		entry := f.Entries[0]
+		assert.Equal(t, root+"/new", entry.ID)
+		assert.Equal(t, "The books added last", entry.Content)
+		assert.Equal(t, opds.RelNew, entry.Links[0].Rel)
+		assert.Equal(t, opds.TypeAcquisition, entry.Links[0].Type)
+	})
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Run("success - it should write the publications", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, opds.WriteJSON(&out, root, newest()))
+
+		var doc map[string]interface{}
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+		metadata := doc["metadata"].(map[string]interface{})
+		assert.Equal(t, 3.0, metadata["numberOfItems"])
+		assert.Equal(t, 2.0, metadata["currentPage"])
+		assert.Contains(t, doc["links"], map[string]interface{}{
+			"rel": "search", "href": root + "/search{?q}", "type": opds.TypeJSON, "templated": true,
+		})
+		assert.Contains(t, doc["links"], map[string]interface{}{
+			"rel": opds.RelAuthentication, "href": root + "/authentication", "type": opds.TypeAuthentication,
+		})
+		assert.NotContains(t, doc, "navigation")
+
+		publication := doc["publications"].([]interface{})[0].(map[string]interface{})["metadata"].(map[string]interface{})
+		assert.Equal(t, "urn:isbn:9780441478125", publication["identifier"])
+		assert.Equal(t, []interface{}{map[string]interface{}{
+			"name":  "Ursula K. Le Guin",
+			"links": []interface{}{map[string]interface{}{"href": root + "/authors/1", "type": opds.TypeJSON}},
+		}}, publication["author"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"name": "Harold Bloom"}}, publication["editor"])
+	})
+
+	t.Run("success - it should keep an empty collection", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, opds.WriteJSON(&out, root, &opds.Feed{Kind: opds.KindAcquisition, Title: "Search", Path: "search?q=dune", Page: 1, PageSize: 20}))
+
+		var doc map[string]interface{}
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+		assert.Equal(t, []interface{}{}, doc["publications"])
+		assert.Equal(t, 0.0, doc["metadata"].(map[string]interface{})["numberOfItems"])
+	})
+}
+
+func TestWriteOpenSearch(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, opds.WriteOpenSearch(&out, root, "Books", "Search the books"))
+
+	var description struct {
+		ShortName string `xml:"ShortName"`
+		Url       struct {
+			Type     string `xml:"type,attr"`
+			Template string `xml:"template,attr"`
+		} `xml:"Url"`
+	}
+	assert.NoError(t, xml.Unmarshal(out.Bytes(), &description))
+	assert.Equal(t, "Books", description.ShortName)
+	assert.Equal(t, opds.TypeAcquisition, description.Url.Type)
+	assert.Equal(t, root+"/search?q={searchTerms}&page={startPage?}", description.Url.Template)
+}
+
+func TestWriteAuthentication(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, opds.WriteAuthentication(&out, root+"/", "Books", "Sign in with your account"))
+
+	assert.JSONEq(t, `{
+		"id": "`+root+`/authentication",
+		"title": "Books",
+		"description": "Sign in with your account",
+		"authentication": [{
+			"type": "http://opds-spec.org/auth/basic",
+			"labels": {"login": "Username", "password": "Password"}
+		}]
+	}`, out.String())
+}
@@ -0,0 +1,35 @@
+// Package person handles the names of people, as the contributors of books
+// are credited by.
+package person
+
+import "strings"
+
+// particles begin compound surnames, as in "Ursula K. Le Guin".
+var particles = map[string]bool{
+	"da": true, "de": true, "del": true, "della": true, "der": true, "di": true, "du": true,
+	"la": true, "le": true, "ten": true, "ter": true, "van": true, "von": true,
+}
+
+// SplitName returns the surname and the forenames of a name in direct order,
+// or of a name already inverted as "Surname, Forenames". A single word, or a
+// name holding the word "and", such as the name of a firm, is kept whole as
+// the surname.
+func SplitName(name string) (surname, forenames string) {
+	if surname, forenames, ok := strings.Cut(name, ","); ok {
+		return strings.TrimSpace(surname), strings.TrimSpace(forenames)
+	}
+	words := strings.Fields(name)
+	if len(words) < 2 {
+		return strings.TrimSpace(name), ""
+	}
+	for _, word := range words {
+		if strings.EqualFold(word, "and") {
+			return strings.Join(words, " "), ""
+		}
+	}
+	i := len(words) - 1
+	for i > 1 && particles[strings.ToLower(words[i-1])] {
+		i--
+	}
+	return strings.Join(words[i:], " "), strings.Join(words[:i], " ")
+}
@@ -0,0 +1,37 @@
+package person_test
+
+import (
+	"testing"
+
+	"github.com/storyofhis/books-management/person"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitName(t *testing.T) {
+	t.Run("success - it should split a name in direct order", func(t *testing.T) {
+		for name, want := range map[string][2]string{
+			"Frank Herbert":           {"Herbert", "Frank"},
+			"Ursula K. Le Guin":       {"Le Guin", "Ursula K."},
+			"Ludwig van Beethoven":    {"van Beethoven", "Ludwig"},
+			"Maria della Vecchia Ros": {"Ros", "Maria della Vecchia"},
+			"  Umberto   Eco ":        {"Eco", "Umberto"},
+		} {
+			surname, forenames := person.SplitName(name)
+			assert.Equal(t, want, [2]string{surname, forenames}, name)
+		}
+	})
+
+	t.Run("success - it should split an inverted name", func(t *testing.T) {
+		surname, forenames := person.SplitName("Le Guin, Ursula K.")
+		assert.Equal(t, "Le Guin", surname)
+		assert.Equal(t, "Ursula K.", forenames)
+	})
+
+	t.Run("success - it should keep single words and firms whole", func(t *testing.T) {
+		for _, name := range []string{"Homer", "Smith and Sons", "Simon AND Schuster"} {
+			surname, forenames := person.SplitName(name)
+			assert.Equal(t, name, surname)
+			assert.Empty(t, forenames)
+		}
+	})
+}
@@ -1,4 +1,4 @@
-go test -coverprofile='coverage.out' ./...
+go test -tags sqlite_fts5 -coverprofile='coverage.out' ./...
 go tool cover -html='coverage.out' -o 'coverage.html'
 if grep -qi microsoft /proc/version; then
 	explorer.exe coverage.html